@@ -0,0 +1,82 @@
+-- Compares the plans of the ListUsers text filters without and with the trigram indexes of
+-- migration 000017, on a scratch copy of the users columns seeded with :rows users spread over :tenants tenants.
+-- As in the service, the table has the tenant isolation policy and is read by a role subject to it, within a tenant.
+-- Everything runs in a transaction that is rolled back, the users table is not touched:
+--   make bench-filters PG_DSN=postgres://... ROWS=5000000 TENANTS=10
+\set ON_ERROR_STOP on
+\if :{?rows}
+\else
+\set rows 1000000
+\endif
+\if :{?tenants}
+\else
+\set tenants 10
+\endif
+
+BEGIN;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TEMP TABLE bench_users (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   tenant_id UUID NOT NULL,
+   first_name VARCHAR(25) NOT NULL,
+   last_name VARCHAR(25) NOT NULL,
+   nickname VARCHAR(25) NOT NULL,
+   email VARCHAR(320) NOT NULL,
+   merged_into UUID,
+   updated_at TIMESTAMPTZ NOT NULL
+) ON COMMIT DROP;
+
+-- names drawn from a small pool, as real names repeat, nicknames and emails are unique
+INSERT INTO bench_users (tenant_id, first_name, last_name, nickname, email, updated_at)
+SELECT ('00000000-0000-0000-0000-' || lpad((1 + i % :tenants)::text, 12, '0'))::uuid,
+   (ARRAY['john', 'maria', 'joao', 'anne', 'pierre', 'yuki', 'ahmed', 'olga', 'carlos', 'mei'])[1 + i % 10] || (i % 997),
+   (ARRAY['smith', 'silva', 'santos', 'muller', 'dubois', 'tanaka', 'hassan', 'ivanova', 'garcia', 'chen'])[1 + (i / 10) % 10] || (i % 991),
+   'nick' || i,
+   'user' || i || '@example.com',
+   now() - (i || ' seconds')::interval
+FROM generate_series(1, :rows) AS i;
+CREATE INDEX ON bench_users (updated_at DESC, id DESC);
+ANALYZE bench_users;
+
+-- the policy of migration 000007, superusers bypass it so the queries run as a role without privileges
+ALTER TABLE bench_users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE bench_users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON bench_users
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
+CREATE ROLE bench_users_reader NOLOGIN NOSUPERUSER NOBYPASSRLS;
+GRANT SELECT ON bench_users TO bench_users_reader;
+SELECT set_config('app.tenant_id', '00000000-0000-0000-0000-000000000001', true);
+
+\echo '==== before: substring filters without trigram indexes'
+SET LOCAL ROLE bench_users_reader;
+EXPLAIN (ANALYZE, BUFFERS, COSTS OFF)
+SELECT id FROM bench_users
+WHERE merged_into IS NULL AND last_name ILIKE '%tanaka12%' AND email ILIKE '%user4242%'
+ORDER BY updated_at DESC, id DESC LIMIT 20;
+RESET ROLE;
+
+CREATE INDEX ON bench_users USING GIN (first_name gin_trgm_ops);
+CREATE INDEX ON bench_users USING GIN (last_name gin_trgm_ops);
+CREATE INDEX ON bench_users USING GIN (nickname gin_trgm_ops);
+CREATE INDEX ON bench_users USING GIN (email gin_trgm_ops);
+ANALYZE bench_users;
+
+\echo '==== after: substring filters with trigram indexes'
+SET LOCAL ROLE bench_users_reader;
+EXPLAIN (ANALYZE, BUFFERS, COSTS OFF)
+SELECT id FROM bench_users
+WHERE merged_into IS NULL AND last_name ILIKE '%tanaka12%' AND email ILIKE '%user4242%'
+ORDER BY updated_at DESC, id DESC LIMIT 20;
+RESET ROLE;
+
+\echo '==== after: fuzzy filter, the last name with a typo'
+SELECT set_config('pg_trgm.similarity_threshold', '0.3', true);
+SET LOCAL ROLE bench_users_reader;
+EXPLAIN (ANALYZE, BUFFERS, COSTS OFF)
+SELECT id FROM bench_users
+WHERE merged_into IS NULL AND last_name % 'tanka12'
+ORDER BY updated_at DESC, id DESC LIMIT 20;
+
+ROLLBACK;
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"flag"
 	"fmt"
 	"log"
@@ -9,11 +10,15 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // timezones are validated against the IANA database, which is not available in every image
 	"users/config"
 	"users/internal/app"
+	"users/internal/app/user"
 	"users/internal/controller/grpc"
 	"users/internal/controller/http"
 	"users/internal/domain"
+	"users/internal/infra/blob"
+	"users/internal/infra/jobs"
 	"users/internal/infra/notification"
 	"users/internal/infra/outbox"
 	repo "users/internal/infra/postgresql"
@@ -69,7 +74,7 @@ func run(cfg *config.Config, l logger.Interface) error {
 
 	var notificationService domain.NotificationService
 	if cfg.PubSub.Enabled {
-		notificationService = notification.NewPubSubNotifierService(pubsubClient, l, cfg.PubSub.UsersTopic)
+		notificationService = notification.NewPubSubNotifierService(pubsubClient, l, cfg.PubSub.UsersTopic, cfg.PubSub.GroupsTopic)
 	} else {
 		notificationService = notification.NewLoggerNotifierService(l)
 	}
@@ -78,22 +83,115 @@ func run(cfg *config.Config, l logger.Interface) error {
 	interval := time.Duration(cfg.Notifications.Interval) * time.Second
 	go outboxProcessor.StartScheduleProcess(context.Background(), interval, cfg.Notifications.MaxBatchSize)
 
+	// the watchers of the users share a single connection listening to the commits of the outbox
+	outboxListener := outbox.NewListener(l, pg, time.Second)
+	go outboxListener.Start(context.Background())
+
 	// -------------------------------------------------------------------------
 	// Setup Service Layer
 
+	// commands record their audit event in the same transaction as their changes
+	auditCommandsRepo := repo.NewAuditCommandsRepo(pg, l)
+	auditedTx := user.NewAuditedTransaction(txSupplier, auditCommandsRepo)
+
 	healthCheckQueries := app.NewHealthCheckQueries(pg, pubsubClient)
-	userServiceCommands := app.NewUserServiceCommands(l, txSupplier, repo.NewUserCommandsRepo(pg, l), outboxRepoCommands)
-	userServiceQueries := app.NewUserServiceQueries(l, repo.NewUserQueriesRepo(pg, l))
+	userQueriesRepo := repo.NewUserQueriesRepo(pg, l)
+	userCommandsRepo := repo.NewUserCommandsRepo(pg, l)
+	groupCommandsRepo := repo.NewGroupCommandsRepo(pg, l)
+	userServiceCommands := app.NewUserServiceCommands(l, auditedTx, userCommandsRepo, outboxRepoCommands, groupCommandsRepo, user.UserCommandsConfig{
+		MinAge: cfg.Users.MinAge,
+	})
+	cursorKeys := cfg.Pagination.CursorKeys
+	if len(cursorKeys) == 0 {
+		// cursors are only valid on this instance until it restarts
+		l.Warn("no pagination cursor keys configured, generating a random key")
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return fmt.Errorf("pagination cursor key: %w", err)
+		}
+		cursorKeys = []string{string(key)}
+	}
+	userServiceQueries := app.NewUserServiceQueries(l, txSupplier, userQueriesRepo, user.UserQueriesConfig{
+		FuzzyThreshold: cfg.Users.FuzzyThreshold,
+		CursorKeys:     cursorKeys,
+		CursorTTL:      time.Duration(cfg.Pagination.CursorTTL) * time.Second,
+		MaxBatchSize:   cfg.Users.MaxBatchSize,
+		StatsCacheTTL:  time.Duration(cfg.Users.StatsCacheTTL) * time.Second,
+	})
+	phoneVerificationCommands := app.NewPhoneVerificationCommands(l, auditedTx, userQueriesRepo, userCommandsRepo,
+		repo.NewPhoneVerificationCommandsRepo(pg, l), outboxRepoCommands, user.PhoneVerificationConfig{
+			CodeTTL:     time.Duration(cfg.PhoneVerification.CodeTTL) * time.Second,
+			MaxAttempts: cfg.PhoneVerification.MaxAttempts,
+		})
+
+	blobStore := blob.NewLocalStore(cfg.Avatars.StorageDir, cfg.Avatars.BaseURL, l)
+	avatarCommands := app.NewAvatarCommands(l, auditedTx, userCommandsRepo, outboxRepoCommands, blobStore, user.AvatarConfig{
+		MaxSize:        cfg.Avatars.MaxSize,
+		MaxDimension:   cfg.Avatars.MaxDimension,
+		ThumbnailSizes: cfg.Avatars.ThumbnailSizes,
+	})
+
+	preferencesCfg := user.PreferencesConfig{}
+	for _, p := range cfg.Preferences {
+		def, err := user.NewPreferenceDefinition(p.Key, p.Type, p.Values, p.Default)
+		if err != nil {
+			return fmt.Errorf("preferences config: %w", err)
+		}
+		preferencesCfg.Definitions = append(preferencesCfg.Definitions, def)
+	}
+	preferencesService := app.NewPreferencesService(l, auditedTx, repo.NewPreferencesQueriesRepo(pg, l),
+		repo.NewPreferencesCommandsRepo(pg, l), outboxRepoCommands, preferencesCfg)
+
+	groupService := app.NewGroupService(l, auditedTx, repo.NewGroupQueriesRepo(pg, l), groupCommandsRepo, outboxRepoCommands)
+
+	relationshipService := app.NewRelationshipService(l, auditedTx, userQueriesRepo, repo.NewRelationshipQueriesRepo(pg, l),
+		repo.NewRelationshipCommandsRepo(pg, l), outboxRepoCommands)
+
+	invitationService := app.NewInvitationService(l, auditedTx, repo.NewInvitationQueriesRepo(pg, l), repo.NewInvitationCommandsRepo(pg, l),
+		userCommandsRepo, outboxRepoCommands, user.InvitationConfig{
+			TokenTTL: time.Duration(cfg.Invitations.TokenTTL) * time.Second,
+		})
+
+	mergeCommands := app.NewMergeCommands(l, auditedTx, repo.NewUserMergeCommandsRepo(pg, l), outboxRepoCommands, blobStore)
+
+	tagCommands := app.NewTagCommands(l, auditedTx, repo.NewTagCommandsRepo(pg, l), outboxRepoCommands)
+
+	historyService := app.NewHistoryService(l, txSupplier, repo.NewUserHistoryQueriesRepo(pg, l), repo.NewUserHistoryCommandsRepo(pg, l),
+		user.HistoryConfig{
+			Retention: time.Duration(cfg.History.Retention) * time.Second,
+		})
+	historyPurger := jobs.NewScheduler(l, "history-purge", historyService.PurgeExpiredHistory)
+	go historyPurger.Start(context.Background(), time.Duration(cfg.History.PurgeInterval)*time.Second)
+
+	// activity is tracking rather than a command, it is not audited
+	activityCommands := app.NewActivityCommands(l, txSupplier, repo.NewActivityCommandsRepo(pg, l), outboxRepoCommands,
+		user.ActivityConfig{
+			WriteInterval:     time.Duration(cfg.Activity.WriteInterval) * time.Second,
+			DormantAfter:      time.Duration(cfg.Activity.DormantAfter) * time.Second,
+			DeactivateDormant: cfg.Activity.DeactivateDormant,
+		})
+	dormantUsersChecker := jobs.NewScheduler(l, "dormant-users", activityCommands.FlagDormantUsers)
+	go dormantUsersChecker.Start(context.Background(), time.Duration(cfg.Activity.CheckInterval)*time.Second)
+
+	auditService := app.NewAuditService(l, txSupplier, repo.NewAuditQueriesRepo(pg, l), auditCommandsRepo)
+
+	watchQueries := app.NewWatchQueries(l, txSupplier, repo.NewOutboxQueriesRepo(pg, l), outboxListener, user.WatchConfig{
+		HeartbeatInterval: time.Duration(cfg.Users.WatchHeartbeat) * time.Second,
+	})
+
+	tenantQueries := app.NewTenantQueries(l, repo.NewTenantQueriesRepo(pg, l), time.Duration(cfg.Tenants.CacheTTL)*time.Second)
 
 	// -------------------------------------------------------------------------
 	// Setup Controller Layer
 
-	httpEngine, err := http.Setup(l, cfg.GRPC.Port, healthCheckQueries)
+	httpEngine, err := http.Setup(l, cfg.GRPC.Port, healthCheckQueries, cfg.Avatars.StorageDir)
 	if err != nil {
 		return fmt.Errorf("httpServer.Setup: %w", err)
 	}
 
-	settedUpServer, err := grpc.Setup(l, userServiceCommands, userServiceQueries)
+	settedUpServer, err := grpc.Setup(l, userServiceCommands, userServiceQueries, phoneVerificationCommands, avatarCommands, preferencesService,
+		groupService, relationshipService, invitationService, mergeCommands, historyService, auditService, tagCommands, activityCommands, watchQueries, tenantQueries,
+		cfg.Tenants.Default)
 	if err != nil {
 		return fmt.Errorf("grpcServer.Setup: %w", err)
 	}
@@ -123,7 +221,11 @@ func run(cfg *config.Config, l logger.Interface) error {
 	if err != nil {
 		return fmt.Errorf("httpServer.Shutdown: %w", err)
 	}
+	// the watch streams only end once the listener is stopped
+	outboxListener.GracefulStop()
 	grpcServer.GracefulStop()
 	outboxProcessor.GracefulStop()
+	historyPurger.GracefulStop()
+	dormantUsersChecker.GracefulStop()
 	return nil
 }
@@ -8,12 +8,21 @@ import (
 
 type (
 	Config struct {
-		App           `yaml:"app"`
-		HTTP          `yaml:"http"`
-		GRPC          `yaml:"grpc"`
-		PG            `yaml:"postgres"`
-		PubSub        `yaml:"pubsub"`
-		Notifications `yaml:"notifications"`
+		App               `yaml:"app"`
+		HTTP              `yaml:"http"`
+		GRPC              `yaml:"grpc"`
+		PG                `yaml:"postgres"`
+		PubSub            `yaml:"pubsub"`
+		Notifications     `yaml:"notifications"`
+		PhoneVerification `yaml:"phone_verification"`
+		Users             `yaml:"users"`
+		Avatars           `yaml:"avatars"`
+		Tenants           `yaml:"tenants"`
+		Invitations       `yaml:"invitations"`
+		History           `yaml:"history"`
+		Activity          `yaml:"activity"`
+		Pagination        `yaml:"pagination"`
+		Preferences       []Preference `yaml:"preferences"`
 	}
 
 	App struct {
@@ -40,10 +49,78 @@ type (
 		Interval     int   `env-required:"true" yaml:"interval" env:"NOTIFICATIONS_INTERVAL"`
 	}
 
+	PhoneVerification struct {
+		CodeTTL     int   `env-default:"300" yaml:"code_ttl" env:"PHONE_VERIFICATION_CODE_TTL"`
+		MaxAttempts int32 `env-default:"5" yaml:"max_attempts" env:"PHONE_VERIFICATION_MAX_ATTEMPTS"`
+	}
+
+	Users struct {
+		MinAge         int     `env-default:"0" yaml:"min_age" env:"USERS_MIN_AGE"`
+		FuzzyThreshold float64 `env-default:"0.3" yaml:"fuzzy_threshold" env:"USERS_FUZZY_THRESHOLD"`
+		MaxBatchSize   int     `env-default:"100" yaml:"max_batch_size" env:"USERS_MAX_BATCH_SIZE"`
+		StatsCacheTTL  int     `env-default:"0" yaml:"stats_cache_ttl" env:"USERS_STATS_CACHE_TTL"`
+		WatchHeartbeat int     `env-default:"30" yaml:"watch_heartbeat" env:"USERS_WATCH_HEARTBEAT"`
+	}
+
+	Avatars struct {
+		StorageDir     string `env-default:"./data/avatars" yaml:"storage_dir" env:"AVATARS_STORAGE_DIR"`
+		BaseURL        string `env-default:"/avatars" yaml:"base_url" env:"AVATARS_BASE_URL"`
+		MaxSize        int64  `env-default:"5242880" yaml:"max_size" env:"AVATARS_MAX_SIZE"`
+		MaxDimension   int    `env-default:"4096" yaml:"max_dimension" env:"AVATARS_MAX_DIMENSION"`
+		ThumbnailSizes []int  `env-default:"64,128,256" yaml:"thumbnail_sizes" env:"AVATARS_THUMBNAIL_SIZES"`
+	}
+
+	// Tenants configures the tenant resolution. Default is the ID or slug used when a request has no tenant,
+	// leave it empty to require the tenant in every request.
+	Tenants struct {
+		Default  string `env-default:"default" yaml:"default" env:"TENANTS_DEFAULT"`
+		CacheTTL int    `env-default:"60" yaml:"cache_ttl" env:"TENANTS_CACHE_TTL"`
+	}
+
+	// Invitations configures the lifetime, in seconds, of the invitations to create a user
+	Invitations struct {
+		TokenTTL int `env-default:"604800" yaml:"token_ttl" env:"INVITATIONS_TOKEN_TTL"`
+	}
+
+	// History configures for how long, in seconds, the history of deleted users is kept (0 keeps it forever)
+	// and the interval, in seconds, of the job purging the expired history
+	History struct {
+		Retention     int `env-default:"7776000" yaml:"retention" env:"HISTORY_RETENTION"`
+		PurgeInterval int `env-default:"3600" yaml:"purge_interval" env:"HISTORY_PURGE_INTERVAL"`
+	}
+
+	// Activity configures, in seconds, how often the last activity of a user is written, after how long without activity
+	// a user is dormant (0 disables the dormant users job) and the interval of the job. Dormant users are deactivated
+	// instead if DeactivateDormant is set.
+	Activity struct {
+		WriteInterval     int  `env-default:"300" yaml:"write_interval" env:"ACTIVITY_WRITE_INTERVAL"`
+		DormantAfter      int  `env-default:"15552000" yaml:"dormant_after" env:"ACTIVITY_DORMANT_AFTER"`
+		DeactivateDormant bool `env-default:"false" yaml:"deactivate_dormant" env:"ACTIVITY_DEACTIVATE_DORMANT"`
+		CheckInterval     int  `env-default:"3600" yaml:"check_interval" env:"ACTIVITY_CHECK_INTERVAL"`
+	}
+
+	// Pagination configures the ListUsers cursors: the comma separated HMAC keys signing them, the first one signs and
+	// all of them verify so that keys are rotated without breaking the pagination in flight, and for how long, in seconds,
+	// a cursor is accepted. Without keys, a random key is generated on startup.
+	Pagination struct {
+		CursorKeys []string `yaml:"cursor_keys" env:"PAGINATION_CURSOR_KEYS"`
+		CursorTTL  int      `env-default:"86400" yaml:"cursor_ttl" env:"PAGINATION_CURSOR_TTL"`
+	}
+
+	// Preference declares a user preference. Type is one of bool, enum, int or string,
+	// Values lists the accepted values of enum preferences.
+	Preference struct {
+		Key     string   `yaml:"key"`
+		Type    string   `yaml:"type"`
+		Values  []string `yaml:"values"`
+		Default string   `yaml:"default"`
+	}
+
 	PubSub struct {
-		Enabled    bool   `env-required:"true" yaml:"enabled" env:"PUBSUB_ENABLED"`
-		ProjectID  string `env-required:"true" yaml:"project_id" env:"PUBSUB_PROJECT_ID"`
-		UsersTopic string `env-required:"true" yaml:"users_topic" env:"PUBSUB_USERS_TOPIC"`
+		Enabled     bool   `env-required:"true" yaml:"enabled" env:"PUBSUB_ENABLED"`
+		ProjectID   string `env-required:"true" yaml:"project_id" env:"PUBSUB_PROJECT_ID"`
+		UsersTopic  string `env-required:"true" yaml:"users_topic" env:"PUBSUB_USERS_TOPIC"`
+		GroupsTopic string `env-default:"groups" yaml:"groups_topic" env:"PUBSUB_GROUPS_TOPIC"`
 	}
 )
 
@@ -33,10 +33,20 @@ pubsub:
   enabled: true
   project_id: users-project
   users_topic: users
+  groups_topic: user-groups
 
 notifications:
   interval: 30
   batch_size_max: 50
+
+preferences:
+  - key: marketing_emails
+    type: bool
+    default: false
+  - key: digest_frequency
+    type: enum
+    values: [never, weekly]
+    default: weekly
   `)
 	invalidTmpFile, err := os.CreateTemp("", "invalid_config.yaml")
 	assert.NoError(t, err)
@@ -81,12 +91,30 @@ notifications:
 				path: validTmpFile.Name(),
 			},
 			want: &Config{
-				App:           App{Name: "users", Version: "1.0.0", LogLevel: "debug"},
-				HTTP:          HTTP{Port: 8080},
-				GRPC:          GRPC{Port: 8081},
-				PG:            PG{PoolMax: 2, DSN: "something"},
-				PubSub:        PubSub{Enabled: true, ProjectID: "users-project", UsersTopic: "users"},
-				Notifications: Notifications{MaxBatchSize: 50, Interval: 30},
+				App:               App{Name: "users", Version: "1.0.0", LogLevel: "debug"},
+				HTTP:              HTTP{Port: 8080},
+				GRPC:              GRPC{Port: 8081},
+				PG:                PG{PoolMax: 2, DSN: "something"},
+				PubSub:            PubSub{Enabled: true, ProjectID: "users-project", UsersTopic: "users", GroupsTopic: "user-groups"},
+				Notifications:     Notifications{MaxBatchSize: 50, Interval: 30},
+				PhoneVerification: PhoneVerification{CodeTTL: 300, MaxAttempts: 5},
+				Users:             Users{MinAge: 0, FuzzyThreshold: 0.3, MaxBatchSize: 100, WatchHeartbeat: 30},
+				Avatars: Avatars{
+					StorageDir:     "./data/avatars",
+					BaseURL:        "/avatars",
+					MaxSize:        5242880,
+					MaxDimension:   4096,
+					ThumbnailSizes: []int{64, 128, 256},
+				},
+				Tenants:     Tenants{Default: "default", CacheTTL: 60},
+				Invitations: Invitations{TokenTTL: 604800},
+				History:     History{Retention: 7776000, PurgeInterval: 3600},
+				Activity:    Activity{WriteInterval: 300, DormantAfter: 15552000, CheckInterval: 3600},
+				Pagination:  Pagination{CursorTTL: 86400},
+				Preferences: []Preference{
+					{Key: "marketing_emails", Type: "bool", Default: "false"},
+					{Key: "digest_frequency", Type: "enum", Values: []string{"never", "weekly"}, Default: "weekly"},
+				},
 			},
 			wantErr: nil,
 		},
@@ -0,0 +1,129 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ActivityCommands is an autogenerated mock type for the ActivityCommands type
+type ActivityCommands struct {
+	mock.Mock
+}
+
+type ActivityCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ActivityCommands) EXPECT() *ActivityCommands_Expecter {
+	return &ActivityCommands_Expecter{mock: &_m.Mock}
+}
+
+// FlagDormantUsers provides a mock function with given fields: ctx
+func (_m *ActivityCommands) FlagDormantUsers(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagDormantUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ActivityCommands_FlagDormantUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlagDormantUsers'
+type ActivityCommands_FlagDormantUsers_Call struct {
+	*mock.Call
+}
+
+// FlagDormantUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ActivityCommands_Expecter) FlagDormantUsers(ctx interface{}) *ActivityCommands_FlagDormantUsers_Call {
+	return &ActivityCommands_FlagDormantUsers_Call{Call: _e.mock.On("FlagDormantUsers", ctx)}
+}
+
+func (_c *ActivityCommands_FlagDormantUsers_Call) Run(run func(ctx context.Context)) *ActivityCommands_FlagDormantUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ActivityCommands_FlagDormantUsers_Call) Return(_a0 error) *ActivityCommands_FlagDormantUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ActivityCommands_FlagDormantUsers_Call) RunAndReturn(run func(context.Context) error) *ActivityCommands_FlagDormantUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordActivity provides a mock function with given fields: ctx, userID
+func (_m *ActivityCommands) RecordActivity(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordActivity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ActivityCommands_RecordActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordActivity'
+type ActivityCommands_RecordActivity_Call struct {
+	*mock.Call
+}
+
+// RecordActivity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ActivityCommands_Expecter) RecordActivity(ctx interface{}, userID interface{}) *ActivityCommands_RecordActivity_Call {
+	return &ActivityCommands_RecordActivity_Call{Call: _e.mock.On("RecordActivity", ctx, userID)}
+}
+
+func (_c *ActivityCommands_RecordActivity_Call) Run(run func(ctx context.Context, userID string)) *ActivityCommands_RecordActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ActivityCommands_RecordActivity_Call) Return(_a0 error) *ActivityCommands_RecordActivity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ActivityCommands_RecordActivity_Call) RunAndReturn(run func(context.Context, string) error) *ActivityCommands_RecordActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewActivityCommands creates a new instance of ActivityCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewActivityCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ActivityCommands {
+	mock := &ActivityCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
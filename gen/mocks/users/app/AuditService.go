@@ -0,0 +1,139 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "users/internal/app/user"
+)
+
+// AuditService is an autogenerated mock type for the AuditService type
+type AuditService struct {
+	mock.Mock
+}
+
+type AuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditService) EXPECT() *AuditService_Expecter {
+	return &AuditService_Expecter{mock: &_m.Mock}
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, req
+func (_m *AuditService) ListAuditEvents(ctx context.Context, req user.ListAuditEventsRequest) ([]*domain.AuditEvent, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 []*domain.AuditEvent
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListAuditEventsRequest) ([]*domain.AuditEvent, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListAuditEventsRequest) []*domain.AuditEvent); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListAuditEventsRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.ListAuditEventsRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AuditService_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type AuditService_ListAuditEvents_Call struct {
+	*mock.Call
+}
+
+// ListAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ListAuditEventsRequest
+func (_e *AuditService_Expecter) ListAuditEvents(ctx interface{}, req interface{}) *AuditService_ListAuditEvents_Call {
+	return &AuditService_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", ctx, req)}
+}
+
+func (_c *AuditService_ListAuditEvents_Call) Run(run func(ctx context.Context, req user.ListAuditEventsRequest)) *AuditService_ListAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ListAuditEventsRequest))
+	})
+	return _c
+}
+
+func (_c *AuditService_ListAuditEvents_Call) Return(events []*domain.AuditEvent, nextCursor string, err error) *AuditService_ListAuditEvents_Call {
+	_c.Call.Return(events, nextCursor, err)
+	return _c
+}
+
+func (_c *AuditService_ListAuditEvents_Call) RunAndReturn(run func(context.Context, user.ListAuditEventsRequest) ([]*domain.AuditEvent, string, error)) *AuditService_ListAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFailure provides a mock function with given fields: ctx, cause
+func (_m *AuditService) RecordFailure(ctx context.Context, cause error) {
+	_m.Called(ctx, cause)
+}
+
+// AuditService_RecordFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFailure'
+type AuditService_RecordFailure_Call struct {
+	*mock.Call
+}
+
+// RecordFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cause error
+func (_e *AuditService_Expecter) RecordFailure(ctx interface{}, cause interface{}) *AuditService_RecordFailure_Call {
+	return &AuditService_RecordFailure_Call{Call: _e.mock.On("RecordFailure", ctx, cause)}
+}
+
+func (_c *AuditService_RecordFailure_Call) Run(run func(ctx context.Context, cause error)) *AuditService_RecordFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(error))
+	})
+	return _c
+}
+
+func (_c *AuditService_RecordFailure_Call) Return() *AuditService_RecordFailure_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *AuditService_RecordFailure_Call) RunAndReturn(run func(context.Context, error)) *AuditService_RecordFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditService creates a new instance of AuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditService {
+	mock := &AuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AvatarCommands is an autogenerated mock type for the AvatarCommands type
+type AvatarCommands struct {
+	mock.Mock
+}
+
+type AvatarCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AvatarCommands) EXPECT() *AvatarCommands_Expecter {
+	return &AvatarCommands_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAvatar provides a mock function with given fields: ctx, userID
+func (_m *AvatarCommands) DeleteAvatar(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAvatar")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AvatarCommands_DeleteAvatar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAvatar'
+type AvatarCommands_DeleteAvatar_Call struct {
+	*mock.Call
+}
+
+// DeleteAvatar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *AvatarCommands_Expecter) DeleteAvatar(ctx interface{}, userID interface{}) *AvatarCommands_DeleteAvatar_Call {
+	return &AvatarCommands_DeleteAvatar_Call{Call: _e.mock.On("DeleteAvatar", ctx, userID)}
+}
+
+func (_c *AvatarCommands_DeleteAvatar_Call) Run(run func(ctx context.Context, userID string)) *AvatarCommands_DeleteAvatar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AvatarCommands_DeleteAvatar_Call) Return(_a0 error) *AvatarCommands_DeleteAvatar_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AvatarCommands_DeleteAvatar_Call) RunAndReturn(run func(context.Context, string) error) *AvatarCommands_DeleteAvatar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadAvatar provides a mock function with given fields: ctx, userID, contentType, data
+func (_m *AvatarCommands) UploadAvatar(ctx context.Context, userID string, contentType string, data io.Reader) (string, error) {
+	ret := _m.Called(ctx, userID, contentType, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadAvatar")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) (string, error)); ok {
+		return rf(ctx, userID, contentType, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) string); ok {
+		r0 = rf(ctx, userID, contentType, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, io.Reader) error); ok {
+		r1 = rf(ctx, userID, contentType, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AvatarCommands_UploadAvatar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadAvatar'
+type AvatarCommands_UploadAvatar_Call struct {
+	*mock.Call
+}
+
+// UploadAvatar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - contentType string
+//   - data io.Reader
+func (_e *AvatarCommands_Expecter) UploadAvatar(ctx interface{}, userID interface{}, contentType interface{}, data interface{}) *AvatarCommands_UploadAvatar_Call {
+	return &AvatarCommands_UploadAvatar_Call{Call: _e.mock.On("UploadAvatar", ctx, userID, contentType, data)}
+}
+
+func (_c *AvatarCommands_UploadAvatar_Call) Run(run func(ctx context.Context, userID string, contentType string, data io.Reader)) *AvatarCommands_UploadAvatar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *AvatarCommands_UploadAvatar_Call) Return(avatarURL string, err error) *AvatarCommands_UploadAvatar_Call {
+	_c.Call.Return(avatarURL, err)
+	return _c
+}
+
+func (_c *AvatarCommands_UploadAvatar_Call) RunAndReturn(run func(context.Context, string, string, io.Reader) (string, error)) *AvatarCommands_UploadAvatar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAvatarCommands creates a new instance of AvatarCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAvatarCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AvatarCommands {
+	mock := &AvatarCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
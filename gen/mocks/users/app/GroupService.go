@@ -0,0 +1,364 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "users/internal/app/user"
+)
+
+// GroupService is an autogenerated mock type for the GroupService type
+type GroupService struct {
+	mock.Mock
+}
+
+type GroupService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GroupService) EXPECT() *GroupService_Expecter {
+	return &GroupService_Expecter{mock: &_m.Mock}
+}
+
+// AddGroupMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *GroupService) AddGroupMember(ctx context.Context, groupID string, userID string) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddGroupMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GroupService_AddGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddGroupMember'
+type GroupService_AddGroupMember_Call struct {
+	*mock.Call
+}
+
+// AddGroupMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - userID string
+func (_e *GroupService_Expecter) AddGroupMember(ctx interface{}, groupID interface{}, userID interface{}) *GroupService_AddGroupMember_Call {
+	return &GroupService_AddGroupMember_Call{Call: _e.mock.On("AddGroupMember", ctx, groupID, userID)}
+}
+
+func (_c *GroupService_AddGroupMember_Call) Run(run func(ctx context.Context, groupID string, userID string)) *GroupService_AddGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *GroupService_AddGroupMember_Call) Return(_a0 error) *GroupService_AddGroupMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GroupService_AddGroupMember_Call) RunAndReturn(run func(context.Context, string, string) error) *GroupService_AddGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGroup provides a mock function with given fields: ctx, req
+func (_m *GroupService) CreateGroup(ctx context.Context, req user.CreateGroupRequest) (string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroup")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.CreateGroupRequest) (string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.CreateGroupRequest) string); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.CreateGroupRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupService_CreateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGroup'
+type GroupService_CreateGroup_Call struct {
+	*mock.Call
+}
+
+// CreateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.CreateGroupRequest
+func (_e *GroupService_Expecter) CreateGroup(ctx interface{}, req interface{}) *GroupService_CreateGroup_Call {
+	return &GroupService_CreateGroup_Call{Call: _e.mock.On("CreateGroup", ctx, req)}
+}
+
+func (_c *GroupService_CreateGroup_Call) Run(run func(ctx context.Context, req user.CreateGroupRequest)) *GroupService_CreateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.CreateGroupRequest))
+	})
+	return _c
+}
+
+func (_c *GroupService_CreateGroup_Call) Return(groupID string, err error) *GroupService_CreateGroup_Call {
+	_c.Call.Return(groupID, err)
+	return _c
+}
+
+func (_c *GroupService_CreateGroup_Call) RunAndReturn(run func(context.Context, user.CreateGroupRequest) (string, error)) *GroupService_CreateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, groupID
+func (_m *GroupService) DeleteGroup(ctx context.Context, groupID string) error {
+	ret := _m.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GroupService_DeleteGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroup'
+type GroupService_DeleteGroup_Call struct {
+	*mock.Call
+}
+
+// DeleteGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *GroupService_Expecter) DeleteGroup(ctx interface{}, groupID interface{}) *GroupService_DeleteGroup_Call {
+	return &GroupService_DeleteGroup_Call{Call: _e.mock.On("DeleteGroup", ctx, groupID)}
+}
+
+func (_c *GroupService_DeleteGroup_Call) Run(run func(ctx context.Context, groupID string)) *GroupService_DeleteGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *GroupService_DeleteGroup_Call) Return(_a0 error) *GroupService_DeleteGroup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GroupService_DeleteGroup_Call) RunAndReturn(run func(context.Context, string) error) *GroupService_DeleteGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroupMembers provides a mock function with given fields: ctx, req
+func (_m *GroupService) ListGroupMembers(ctx context.Context, req user.ListGroupMembersRequest) ([]*domain.GroupMember, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroupMembers")
+	}
+
+	var r0 []*domain.GroupMember
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListGroupMembersRequest) ([]*domain.GroupMember, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListGroupMembersRequest) []*domain.GroupMember); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.GroupMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListGroupMembersRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.ListGroupMembersRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GroupService_ListGroupMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroupMembers'
+type GroupService_ListGroupMembers_Call struct {
+	*mock.Call
+}
+
+// ListGroupMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ListGroupMembersRequest
+func (_e *GroupService_Expecter) ListGroupMembers(ctx interface{}, req interface{}) *GroupService_ListGroupMembers_Call {
+	return &GroupService_ListGroupMembers_Call{Call: _e.mock.On("ListGroupMembers", ctx, req)}
+}
+
+func (_c *GroupService_ListGroupMembers_Call) Run(run func(ctx context.Context, req user.ListGroupMembersRequest)) *GroupService_ListGroupMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ListGroupMembersRequest))
+	})
+	return _c
+}
+
+func (_c *GroupService_ListGroupMembers_Call) Return(members []*domain.GroupMember, nextCursor string, err error) *GroupService_ListGroupMembers_Call {
+	_c.Call.Return(members, nextCursor, err)
+	return _c
+}
+
+func (_c *GroupService_ListGroupMembers_Call) RunAndReturn(run func(context.Context, user.ListGroupMembersRequest) ([]*domain.GroupMember, string, error)) *GroupService_ListGroupMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserGroups provides a mock function with given fields: ctx, userID
+func (_m *GroupService) ListUserGroups(ctx context.Context, userID string) ([]*domain.Group, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserGroups")
+	}
+
+	var r0 []*domain.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Group, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Group); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupService_ListUserGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserGroups'
+type GroupService_ListUserGroups_Call struct {
+	*mock.Call
+}
+
+// ListUserGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *GroupService_Expecter) ListUserGroups(ctx interface{}, userID interface{}) *GroupService_ListUserGroups_Call {
+	return &GroupService_ListUserGroups_Call{Call: _e.mock.On("ListUserGroups", ctx, userID)}
+}
+
+func (_c *GroupService_ListUserGroups_Call) Run(run func(ctx context.Context, userID string)) *GroupService_ListUserGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *GroupService_ListUserGroups_Call) Return(_a0 []*domain.Group, _a1 error) *GroupService_ListUserGroups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GroupService_ListUserGroups_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Group, error)) *GroupService_ListUserGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveGroupMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *GroupService) RemoveGroupMember(ctx context.Context, groupID string, userID string) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveGroupMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GroupService_RemoveGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveGroupMember'
+type GroupService_RemoveGroupMember_Call struct {
+	*mock.Call
+}
+
+// RemoveGroupMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - userID string
+func (_e *GroupService_Expecter) RemoveGroupMember(ctx interface{}, groupID interface{}, userID interface{}) *GroupService_RemoveGroupMember_Call {
+	return &GroupService_RemoveGroupMember_Call{Call: _e.mock.On("RemoveGroupMember", ctx, groupID, userID)}
+}
+
+func (_c *GroupService_RemoveGroupMember_Call) Run(run func(ctx context.Context, groupID string, userID string)) *GroupService_RemoveGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *GroupService_RemoveGroupMember_Call) Return(_a0 error) *GroupService_RemoveGroupMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GroupService_RemoveGroupMember_Call) RunAndReturn(run func(context.Context, string, string) error) *GroupService_RemoveGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGroupService creates a new instance of GroupService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGroupService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GroupService {
+	mock := &GroupService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,213 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	user "users/internal/app/user"
+)
+
+// HistoryService is an autogenerated mock type for the HistoryService type
+type HistoryService struct {
+	mock.Mock
+}
+
+type HistoryService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HistoryService) EXPECT() *HistoryService_Expecter {
+	return &HistoryService_Expecter{mock: &_m.Mock}
+}
+
+// GetUserAsOf provides a mock function with given fields: ctx, userID, at
+func (_m *HistoryService) GetUserAsOf(ctx context.Context, userID string, at time.Time) (*domain.UserVersion, error) {
+	ret := _m.Called(ctx, userID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAsOf")
+	}
+
+	var r0 *domain.UserVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*domain.UserVersion, error)); ok {
+		return rf(ctx, userID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *domain.UserVersion); ok {
+		r0 = rf(ctx, userID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HistoryService_GetUserAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAsOf'
+type HistoryService_GetUserAsOf_Call struct {
+	*mock.Call
+}
+
+// GetUserAsOf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - at time.Time
+func (_e *HistoryService_Expecter) GetUserAsOf(ctx interface{}, userID interface{}, at interface{}) *HistoryService_GetUserAsOf_Call {
+	return &HistoryService_GetUserAsOf_Call{Call: _e.mock.On("GetUserAsOf", ctx, userID, at)}
+}
+
+func (_c *HistoryService_GetUserAsOf_Call) Run(run func(ctx context.Context, userID string, at time.Time)) *HistoryService_GetUserAsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *HistoryService_GetUserAsOf_Call) Return(_a0 *domain.UserVersion, _a1 error) *HistoryService_GetUserAsOf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HistoryService_GetUserAsOf_Call) RunAndReturn(run func(context.Context, string, time.Time) (*domain.UserVersion, error)) *HistoryService_GetUserAsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserVersions provides a mock function with given fields: ctx, req
+func (_m *HistoryService) ListUserVersions(ctx context.Context, req user.ListUserVersionsRequest) ([]*domain.UserVersion, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserVersions")
+	}
+
+	var r0 []*domain.UserVersion
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListUserVersionsRequest) ([]*domain.UserVersion, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListUserVersionsRequest) []*domain.UserVersion); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.UserVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListUserVersionsRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.ListUserVersionsRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// HistoryService_ListUserVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserVersions'
+type HistoryService_ListUserVersions_Call struct {
+	*mock.Call
+}
+
+// ListUserVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ListUserVersionsRequest
+func (_e *HistoryService_Expecter) ListUserVersions(ctx interface{}, req interface{}) *HistoryService_ListUserVersions_Call {
+	return &HistoryService_ListUserVersions_Call{Call: _e.mock.On("ListUserVersions", ctx, req)}
+}
+
+func (_c *HistoryService_ListUserVersions_Call) Run(run func(ctx context.Context, req user.ListUserVersionsRequest)) *HistoryService_ListUserVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ListUserVersionsRequest))
+	})
+	return _c
+}
+
+func (_c *HistoryService_ListUserVersions_Call) Return(versions []*domain.UserVersion, nextCursor string, err error) *HistoryService_ListUserVersions_Call {
+	_c.Call.Return(versions, nextCursor, err)
+	return _c
+}
+
+func (_c *HistoryService_ListUserVersions_Call) RunAndReturn(run func(context.Context, user.ListUserVersionsRequest) ([]*domain.UserVersion, string, error)) *HistoryService_ListUserVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpiredHistory provides a mock function with given fields: ctx
+func (_m *HistoryService) PurgeExpiredHistory(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HistoryService_PurgeExpiredHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredHistory'
+type HistoryService_PurgeExpiredHistory_Call struct {
+	*mock.Call
+}
+
+// PurgeExpiredHistory is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HistoryService_Expecter) PurgeExpiredHistory(ctx interface{}) *HistoryService_PurgeExpiredHistory_Call {
+	return &HistoryService_PurgeExpiredHistory_Call{Call: _e.mock.On("PurgeExpiredHistory", ctx)}
+}
+
+func (_c *HistoryService_PurgeExpiredHistory_Call) Run(run func(ctx context.Context)) *HistoryService_PurgeExpiredHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HistoryService_PurgeExpiredHistory_Call) Return(_a0 error) *HistoryService_PurgeExpiredHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HistoryService_PurgeExpiredHistory_Call) RunAndReturn(run func(context.Context) error) *HistoryService_PurgeExpiredHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHistoryService creates a new instance of HistoryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHistoryService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HistoryService {
+	mock := &HistoryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
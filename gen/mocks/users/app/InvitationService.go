@@ -0,0 +1,268 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "users/internal/app/user"
+)
+
+// InvitationService is an autogenerated mock type for the InvitationService type
+type InvitationService struct {
+	mock.Mock
+}
+
+type InvitationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvitationService) EXPECT() *InvitationService_Expecter {
+	return &InvitationService_Expecter{mock: &_m.Mock}
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, req
+func (_m *InvitationService) AcceptInvitation(ctx context.Context, req user.AcceptInvitationRequest) (string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.AcceptInvitationRequest) (string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.AcceptInvitationRequest) string); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.AcceptInvitationRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationService_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type InvitationService_AcceptInvitation_Call struct {
+	*mock.Call
+}
+
+// AcceptInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.AcceptInvitationRequest
+func (_e *InvitationService_Expecter) AcceptInvitation(ctx interface{}, req interface{}) *InvitationService_AcceptInvitation_Call {
+	return &InvitationService_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, req)}
+}
+
+func (_c *InvitationService_AcceptInvitation_Call) Run(run func(ctx context.Context, req user.AcceptInvitationRequest)) *InvitationService_AcceptInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.AcceptInvitationRequest))
+	})
+	return _c
+}
+
+func (_c *InvitationService_AcceptInvitation_Call) Return(userID string, err error) *InvitationService_AcceptInvitation_Call {
+	_c.Call.Return(userID, err)
+	return _c
+}
+
+func (_c *InvitationService_AcceptInvitation_Call) RunAndReturn(run func(context.Context, user.AcceptInvitationRequest) (string, error)) *InvitationService_AcceptInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InviteUser provides a mock function with given fields: ctx, req
+func (_m *InvitationService) InviteUser(ctx context.Context, req user.InviteUserRequest) (*domain.Invitation, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InviteUser")
+	}
+
+	var r0 *domain.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.InviteUserRequest) (*domain.Invitation, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.InviteUserRequest) *domain.Invitation); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.InviteUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationService_InviteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InviteUser'
+type InvitationService_InviteUser_Call struct {
+	*mock.Call
+}
+
+// InviteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.InviteUserRequest
+func (_e *InvitationService_Expecter) InviteUser(ctx interface{}, req interface{}) *InvitationService_InviteUser_Call {
+	return &InvitationService_InviteUser_Call{Call: _e.mock.On("InviteUser", ctx, req)}
+}
+
+func (_c *InvitationService_InviteUser_Call) Run(run func(ctx context.Context, req user.InviteUserRequest)) *InvitationService_InviteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.InviteUserRequest))
+	})
+	return _c
+}
+
+func (_c *InvitationService_InviteUser_Call) Return(_a0 *domain.Invitation, _a1 error) *InvitationService_InviteUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationService_InviteUser_Call) RunAndReturn(run func(context.Context, user.InviteUserRequest) (*domain.Invitation, error)) *InvitationService_InviteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInvitations provides a mock function with given fields: ctx, req
+func (_m *InvitationService) ListInvitations(ctx context.Context, req user.ListInvitationsRequest) ([]*domain.Invitation, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 []*domain.Invitation
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListInvitationsRequest) ([]*domain.Invitation, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListInvitationsRequest) []*domain.Invitation); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListInvitationsRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.ListInvitationsRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// InvitationService_ListInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvitations'
+type InvitationService_ListInvitations_Call struct {
+	*mock.Call
+}
+
+// ListInvitations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ListInvitationsRequest
+func (_e *InvitationService_Expecter) ListInvitations(ctx interface{}, req interface{}) *InvitationService_ListInvitations_Call {
+	return &InvitationService_ListInvitations_Call{Call: _e.mock.On("ListInvitations", ctx, req)}
+}
+
+func (_c *InvitationService_ListInvitations_Call) Run(run func(ctx context.Context, req user.ListInvitationsRequest)) *InvitationService_ListInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ListInvitationsRequest))
+	})
+	return _c
+}
+
+func (_c *InvitationService_ListInvitations_Call) Return(invitations []*domain.Invitation, nextCursor string, err error) *InvitationService_ListInvitations_Call {
+	_c.Call.Return(invitations, nextCursor, err)
+	return _c
+}
+
+func (_c *InvitationService_ListInvitations_Call) RunAndReturn(run func(context.Context, user.ListInvitationsRequest) ([]*domain.Invitation, string, error)) *InvitationService_ListInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, invitationID
+func (_m *InvitationService) RevokeInvitation(ctx context.Context, invitationID string) error {
+	ret := _m.Called(ctx, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationService_RevokeInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeInvitation'
+type InvitationService_RevokeInvitation_Call struct {
+	*mock.Call
+}
+
+// RevokeInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitationID string
+func (_e *InvitationService_Expecter) RevokeInvitation(ctx interface{}, invitationID interface{}) *InvitationService_RevokeInvitation_Call {
+	return &InvitationService_RevokeInvitation_Call{Call: _e.mock.On("RevokeInvitation", ctx, invitationID)}
+}
+
+func (_c *InvitationService_RevokeInvitation_Call) Run(run func(ctx context.Context, invitationID string)) *InvitationService_RevokeInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationService_RevokeInvitation_Call) Return(_a0 error) *InvitationService_RevokeInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationService_RevokeInvitation_Call) RunAndReturn(run func(context.Context, string) error) *InvitationService_RevokeInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvitationService creates a new instance of InvitationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationService {
+	mock := &InvitationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
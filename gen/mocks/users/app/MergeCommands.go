@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	user "users/internal/app/user"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MergeCommands is an autogenerated mock type for the MergeCommands type
+type MergeCommands struct {
+	mock.Mock
+}
+
+type MergeCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MergeCommands) EXPECT() *MergeCommands_Expecter {
+	return &MergeCommands_Expecter{mock: &_m.Mock}
+}
+
+// MergeUsers provides a mock function with given fields: ctx, req
+func (_m *MergeCommands) MergeUsers(ctx context.Context, req user.MergeUsersRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.MergeUsersRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeCommands_MergeUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeUsers'
+type MergeCommands_MergeUsers_Call struct {
+	*mock.Call
+}
+
+// MergeUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.MergeUsersRequest
+func (_e *MergeCommands_Expecter) MergeUsers(ctx interface{}, req interface{}) *MergeCommands_MergeUsers_Call {
+	return &MergeCommands_MergeUsers_Call{Call: _e.mock.On("MergeUsers", ctx, req)}
+}
+
+func (_c *MergeCommands_MergeUsers_Call) Run(run func(ctx context.Context, req user.MergeUsersRequest)) *MergeCommands_MergeUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.MergeUsersRequest))
+	})
+	return _c
+}
+
+func (_c *MergeCommands_MergeUsers_Call) Return(_a0 error) *MergeCommands_MergeUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MergeCommands_MergeUsers_Call) RunAndReturn(run func(context.Context, user.MergeUsersRequest) error) *MergeCommands_MergeUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMergeCommands creates a new instance of MergeCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMergeCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MergeCommands {
+	mock := &MergeCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
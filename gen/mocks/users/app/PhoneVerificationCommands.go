@@ -0,0 +1,142 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PhoneVerificationCommands is an autogenerated mock type for the PhoneVerificationCommands type
+type PhoneVerificationCommands struct {
+	mock.Mock
+}
+
+type PhoneVerificationCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PhoneVerificationCommands) EXPECT() *PhoneVerificationCommands_Expecter {
+	return &PhoneVerificationCommands_Expecter{mock: &_m.Mock}
+}
+
+// ConfirmPhoneVerification provides a mock function with given fields: ctx, userID, code
+func (_m *PhoneVerificationCommands) ConfirmPhoneVerification(ctx context.Context, userID string, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmPhoneVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PhoneVerificationCommands_ConfirmPhoneVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmPhoneVerification'
+type PhoneVerificationCommands_ConfirmPhoneVerification_Call struct {
+	*mock.Call
+}
+
+// ConfirmPhoneVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *PhoneVerificationCommands_Expecter) ConfirmPhoneVerification(ctx interface{}, userID interface{}, code interface{}) *PhoneVerificationCommands_ConfirmPhoneVerification_Call {
+	return &PhoneVerificationCommands_ConfirmPhoneVerification_Call{Call: _e.mock.On("ConfirmPhoneVerification", ctx, userID, code)}
+}
+
+func (_c *PhoneVerificationCommands_ConfirmPhoneVerification_Call) Run(run func(ctx context.Context, userID string, code string)) *PhoneVerificationCommands_ConfirmPhoneVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PhoneVerificationCommands_ConfirmPhoneVerification_Call) Return(_a0 error) *PhoneVerificationCommands_ConfirmPhoneVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PhoneVerificationCommands_ConfirmPhoneVerification_Call) RunAndReturn(run func(context.Context, string, string) error) *PhoneVerificationCommands_ConfirmPhoneVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartPhoneVerification provides a mock function with given fields: ctx, userID
+func (_m *PhoneVerificationCommands) StartPhoneVerification(ctx context.Context, userID string) (time.Time, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartPhoneVerification")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PhoneVerificationCommands_StartPhoneVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartPhoneVerification'
+type PhoneVerificationCommands_StartPhoneVerification_Call struct {
+	*mock.Call
+}
+
+// StartPhoneVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PhoneVerificationCommands_Expecter) StartPhoneVerification(ctx interface{}, userID interface{}) *PhoneVerificationCommands_StartPhoneVerification_Call {
+	return &PhoneVerificationCommands_StartPhoneVerification_Call{Call: _e.mock.On("StartPhoneVerification", ctx, userID)}
+}
+
+func (_c *PhoneVerificationCommands_StartPhoneVerification_Call) Run(run func(ctx context.Context, userID string)) *PhoneVerificationCommands_StartPhoneVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PhoneVerificationCommands_StartPhoneVerification_Call) Return(expiresAt time.Time, err error) *PhoneVerificationCommands_StartPhoneVerification_Call {
+	_c.Call.Return(expiresAt, err)
+	return _c
+}
+
+func (_c *PhoneVerificationCommands_StartPhoneVerification_Call) RunAndReturn(run func(context.Context, string) (time.Time, error)) *PhoneVerificationCommands_StartPhoneVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPhoneVerificationCommands creates a new instance of PhoneVerificationCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPhoneVerificationCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PhoneVerificationCommands {
+	mock := &PhoneVerificationCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
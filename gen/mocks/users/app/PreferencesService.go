@@ -0,0 +1,215 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PreferencesService is an autogenerated mock type for the PreferencesService type
+type PreferencesService struct {
+	mock.Mock
+}
+
+type PreferencesService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PreferencesService) EXPECT() *PreferencesService_Expecter {
+	return &PreferencesService_Expecter{mock: &_m.Mock}
+}
+
+// GetPreferences provides a mock function with given fields: ctx, userID
+func (_m *PreferencesService) GetPreferences(ctx context.Context, userID string) (map[string]interface{}, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreferences")
+	}
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]interface{}, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]interface{}); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreferencesService_GetPreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreferences'
+type PreferencesService_GetPreferences_Call struct {
+	*mock.Call
+}
+
+// GetPreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PreferencesService_Expecter) GetPreferences(ctx interface{}, userID interface{}) *PreferencesService_GetPreferences_Call {
+	return &PreferencesService_GetPreferences_Call{Call: _e.mock.On("GetPreferences", ctx, userID)}
+}
+
+func (_c *PreferencesService_GetPreferences_Call) Run(run func(ctx context.Context, userID string)) *PreferencesService_GetPreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PreferencesService_GetPreferences_Call) Return(_a0 map[string]interface{}, _a1 error) *PreferencesService_GetPreferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PreferencesService_GetPreferences_Call) RunAndReturn(run func(context.Context, string) (map[string]interface{}, error)) *PreferencesService_GetPreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPreferences provides a mock function with given fields: ctx, userID, keys
+func (_m *PreferencesService) ResetPreferences(ctx context.Context, userID string, keys []string) (map[string]interface{}, error) {
+	ret := _m.Called(ctx, userID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPreferences")
+	}
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string]interface{}, error)); ok {
+		return rf(ctx, userID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string]interface{}); ok {
+		r0 = rf(ctx, userID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreferencesService_ResetPreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPreferences'
+type PreferencesService_ResetPreferences_Call struct {
+	*mock.Call
+}
+
+// ResetPreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - keys []string
+func (_e *PreferencesService_Expecter) ResetPreferences(ctx interface{}, userID interface{}, keys interface{}) *PreferencesService_ResetPreferences_Call {
+	return &PreferencesService_ResetPreferences_Call{Call: _e.mock.On("ResetPreferences", ctx, userID, keys)}
+}
+
+func (_c *PreferencesService_ResetPreferences_Call) Run(run func(ctx context.Context, userID string, keys []string)) *PreferencesService_ResetPreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *PreferencesService_ResetPreferences_Call) Return(_a0 map[string]interface{}, _a1 error) *PreferencesService_ResetPreferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PreferencesService_ResetPreferences_Call) RunAndReturn(run func(context.Context, string, []string) (map[string]interface{}, error)) *PreferencesService_ResetPreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePreferences provides a mock function with given fields: ctx, userID, preferences
+func (_m *PreferencesService) UpdatePreferences(ctx context.Context, userID string, preferences map[string]interface{}) (map[string]interface{}, error) {
+	ret := _m.Called(ctx, userID, preferences)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePreferences")
+	}
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) (map[string]interface{}, error)); ok {
+		return rf(ctx, userID, preferences)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) map[string]interface{}); ok {
+		r0 = rf(ctx, userID, preferences)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]interface{}) error); ok {
+		r1 = rf(ctx, userID, preferences)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreferencesService_UpdatePreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePreferences'
+type PreferencesService_UpdatePreferences_Call struct {
+	*mock.Call
+}
+
+// UpdatePreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - preferences map[string]interface{}
+func (_e *PreferencesService_Expecter) UpdatePreferences(ctx interface{}, userID interface{}, preferences interface{}) *PreferencesService_UpdatePreferences_Call {
+	return &PreferencesService_UpdatePreferences_Call{Call: _e.mock.On("UpdatePreferences", ctx, userID, preferences)}
+}
+
+func (_c *PreferencesService_UpdatePreferences_Call) Run(run func(ctx context.Context, userID string, preferences map[string]interface{})) *PreferencesService_UpdatePreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *PreferencesService_UpdatePreferences_Call) Return(_a0 map[string]interface{}, _a1 error) *PreferencesService_UpdatePreferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PreferencesService_UpdatePreferences_Call) RunAndReturn(run func(context.Context, string, map[string]interface{}) (map[string]interface{}, error)) *PreferencesService_UpdatePreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPreferencesService creates a new instance of PreferencesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPreferencesService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PreferencesService {
+	mock := &PreferencesService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
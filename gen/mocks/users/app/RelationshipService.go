@@ -0,0 +1,429 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "users/internal/app/user"
+)
+
+// RelationshipService is an autogenerated mock type for the RelationshipService type
+type RelationshipService struct {
+	mock.Mock
+}
+
+type RelationshipService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RelationshipService) EXPECT() *RelationshipService_Expecter {
+	return &RelationshipService_Expecter{mock: &_m.Mock}
+}
+
+// BlockUser provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipService) BlockUser(ctx context.Context, userID string, targetID string) error {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelationshipService_BlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUser'
+type RelationshipService_BlockUser_Call struct {
+	*mock.Call
+}
+
+// BlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipService_Expecter) BlockUser(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipService_BlockUser_Call {
+	return &RelationshipService_BlockUser_Call{Call: _e.mock.On("BlockUser", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipService_BlockUser_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipService_BlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipService_BlockUser_Call) Return(_a0 error) *RelationshipService_BlockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RelationshipService_BlockUser_Call) RunAndReturn(run func(context.Context, string, string) error) *RelationshipService_BlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FollowUser provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipService) FollowUser(ctx context.Context, userID string, targetID string) error {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelationshipService_FollowUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FollowUser'
+type RelationshipService_FollowUser_Call struct {
+	*mock.Call
+}
+
+// FollowUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipService_Expecter) FollowUser(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipService_FollowUser_Call {
+	return &RelationshipService_FollowUser_Call{Call: _e.mock.On("FollowUser", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipService_FollowUser_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipService_FollowUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipService_FollowUser_Call) Return(_a0 error) *RelationshipService_FollowUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RelationshipService_FollowUser_Call) RunAndReturn(run func(context.Context, string, string) error) *RelationshipService_FollowUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBlocked provides a mock function with given fields: ctx, req
+func (_m *RelationshipService) ListBlocked(ctx context.Context, req user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBlocked")
+	}
+
+	var r0 []*domain.RelatedUser
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListRelationshipsRequest) []*domain.RelatedUser); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListRelationshipsRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.ListRelationshipsRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RelationshipService_ListBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBlocked'
+type RelationshipService_ListBlocked_Call struct {
+	*mock.Call
+}
+
+// ListBlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ListRelationshipsRequest
+func (_e *RelationshipService_Expecter) ListBlocked(ctx interface{}, req interface{}) *RelationshipService_ListBlocked_Call {
+	return &RelationshipService_ListBlocked_Call{Call: _e.mock.On("ListBlocked", ctx, req)}
+}
+
+func (_c *RelationshipService_ListBlocked_Call) Run(run func(ctx context.Context, req user.ListRelationshipsRequest)) *RelationshipService_ListBlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ListRelationshipsRequest))
+	})
+	return _c
+}
+
+func (_c *RelationshipService_ListBlocked_Call) Return(users []*domain.RelatedUser, nextCursor string, err error) *RelationshipService_ListBlocked_Call {
+	_c.Call.Return(users, nextCursor, err)
+	return _c
+}
+
+func (_c *RelationshipService_ListBlocked_Call) RunAndReturn(run func(context.Context, user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error)) *RelationshipService_ListBlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFollowers provides a mock function with given fields: ctx, req
+func (_m *RelationshipService) ListFollowers(ctx context.Context, req user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFollowers")
+	}
+
+	var r0 []*domain.RelatedUser
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListRelationshipsRequest) []*domain.RelatedUser); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListRelationshipsRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.ListRelationshipsRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RelationshipService_ListFollowers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFollowers'
+type RelationshipService_ListFollowers_Call struct {
+	*mock.Call
+}
+
+// ListFollowers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ListRelationshipsRequest
+func (_e *RelationshipService_Expecter) ListFollowers(ctx interface{}, req interface{}) *RelationshipService_ListFollowers_Call {
+	return &RelationshipService_ListFollowers_Call{Call: _e.mock.On("ListFollowers", ctx, req)}
+}
+
+func (_c *RelationshipService_ListFollowers_Call) Run(run func(ctx context.Context, req user.ListRelationshipsRequest)) *RelationshipService_ListFollowers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ListRelationshipsRequest))
+	})
+	return _c
+}
+
+func (_c *RelationshipService_ListFollowers_Call) Return(users []*domain.RelatedUser, nextCursor string, err error) *RelationshipService_ListFollowers_Call {
+	_c.Call.Return(users, nextCursor, err)
+	return _c
+}
+
+func (_c *RelationshipService_ListFollowers_Call) RunAndReturn(run func(context.Context, user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error)) *RelationshipService_ListFollowers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFollowing provides a mock function with given fields: ctx, req
+func (_m *RelationshipService) ListFollowing(ctx context.Context, req user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFollowing")
+	}
+
+	var r0 []*domain.RelatedUser
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListRelationshipsRequest) []*domain.RelatedUser); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListRelationshipsRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.ListRelationshipsRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RelationshipService_ListFollowing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFollowing'
+type RelationshipService_ListFollowing_Call struct {
+	*mock.Call
+}
+
+// ListFollowing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ListRelationshipsRequest
+func (_e *RelationshipService_Expecter) ListFollowing(ctx interface{}, req interface{}) *RelationshipService_ListFollowing_Call {
+	return &RelationshipService_ListFollowing_Call{Call: _e.mock.On("ListFollowing", ctx, req)}
+}
+
+func (_c *RelationshipService_ListFollowing_Call) Run(run func(ctx context.Context, req user.ListRelationshipsRequest)) *RelationshipService_ListFollowing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ListRelationshipsRequest))
+	})
+	return _c
+}
+
+func (_c *RelationshipService_ListFollowing_Call) Return(users []*domain.RelatedUser, nextCursor string, err error) *RelationshipService_ListFollowing_Call {
+	_c.Call.Return(users, nextCursor, err)
+	return _c
+}
+
+func (_c *RelationshipService_ListFollowing_Call) RunAndReturn(run func(context.Context, user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error)) *RelationshipService_ListFollowing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUser provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipService) UnblockUser(ctx context.Context, userID string, targetID string) error {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelationshipService_UnblockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUser'
+type RelationshipService_UnblockUser_Call struct {
+	*mock.Call
+}
+
+// UnblockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipService_Expecter) UnblockUser(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipService_UnblockUser_Call {
+	return &RelationshipService_UnblockUser_Call{Call: _e.mock.On("UnblockUser", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipService_UnblockUser_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipService_UnblockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipService_UnblockUser_Call) Return(_a0 error) *RelationshipService_UnblockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RelationshipService_UnblockUser_Call) RunAndReturn(run func(context.Context, string, string) error) *RelationshipService_UnblockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnfollowUser provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipService) UnfollowUser(ctx context.Context, userID string, targetID string) error {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnfollowUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelationshipService_UnfollowUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnfollowUser'
+type RelationshipService_UnfollowUser_Call struct {
+	*mock.Call
+}
+
+// UnfollowUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipService_Expecter) UnfollowUser(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipService_UnfollowUser_Call {
+	return &RelationshipService_UnfollowUser_Call{Call: _e.mock.On("UnfollowUser", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipService_UnfollowUser_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipService_UnfollowUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipService_UnfollowUser_Call) Return(_a0 error) *RelationshipService_UnfollowUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RelationshipService_UnfollowUser_Call) RunAndReturn(run func(context.Context, string, string) error) *RelationshipService_UnfollowUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRelationshipService creates a new instance of RelationshipService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRelationshipService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RelationshipService {
+	mock := &RelationshipService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,132 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TagCommands is an autogenerated mock type for the TagCommands type
+type TagCommands struct {
+	mock.Mock
+}
+
+type TagCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TagCommands) EXPECT() *TagCommands_Expecter {
+	return &TagCommands_Expecter{mock: &_m.Mock}
+}
+
+// TagUsers provides a mock function with given fields: ctx, userIDs, tags
+func (_m *TagCommands) TagUsers(ctx context.Context, userIDs []string, tags []string) error {
+	ret := _m.Called(ctx, userIDs, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) error); ok {
+		r0 = rf(ctx, userIDs, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TagCommands_TagUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagUsers'
+type TagCommands_TagUsers_Call struct {
+	*mock.Call
+}
+
+// TagUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+//   - tags []string
+func (_e *TagCommands_Expecter) TagUsers(ctx interface{}, userIDs interface{}, tags interface{}) *TagCommands_TagUsers_Call {
+	return &TagCommands_TagUsers_Call{Call: _e.mock.On("TagUsers", ctx, userIDs, tags)}
+}
+
+func (_c *TagCommands_TagUsers_Call) Run(run func(ctx context.Context, userIDs []string, tags []string)) *TagCommands_TagUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *TagCommands_TagUsers_Call) Return(_a0 error) *TagCommands_TagUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TagCommands_TagUsers_Call) RunAndReturn(run func(context.Context, []string, []string) error) *TagCommands_TagUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UntagUsers provides a mock function with given fields: ctx, userIDs, tags
+func (_m *TagCommands) UntagUsers(ctx context.Context, userIDs []string, tags []string) error {
+	ret := _m.Called(ctx, userIDs, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UntagUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) error); ok {
+		r0 = rf(ctx, userIDs, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TagCommands_UntagUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UntagUsers'
+type TagCommands_UntagUsers_Call struct {
+	*mock.Call
+}
+
+// UntagUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+//   - tags []string
+func (_e *TagCommands_Expecter) UntagUsers(ctx interface{}, userIDs interface{}, tags interface{}) *TagCommands_UntagUsers_Call {
+	return &TagCommands_UntagUsers_Call{Call: _e.mock.On("UntagUsers", ctx, userIDs, tags)}
+}
+
+func (_c *TagCommands_UntagUsers_Call) Run(run func(ctx context.Context, userIDs []string, tags []string)) *TagCommands_UntagUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *TagCommands_UntagUsers_Call) Return(_a0 error) *TagCommands_UntagUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TagCommands_UntagUsers_Call) RunAndReturn(run func(context.Context, []string, []string) error) *TagCommands_UntagUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTagCommands creates a new instance of TagCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagCommands {
+	mock := &TagCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
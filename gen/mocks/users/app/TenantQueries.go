@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TenantQueries is an autogenerated mock type for the TenantQueries type
+type TenantQueries struct {
+	mock.Mock
+}
+
+type TenantQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TenantQueries) EXPECT() *TenantQueries_Expecter {
+	return &TenantQueries_Expecter{mock: &_m.Mock}
+}
+
+// ResolveTenant provides a mock function with given fields: ctx, ref
+func (_m *TenantQueries) ResolveTenant(ctx context.Context, ref string) (*domain.Tenant, error) {
+	ret := _m.Called(ctx, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveTenant")
+	}
+
+	var r0 *domain.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Tenant, error)); ok {
+		return rf(ctx, ref)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Tenant); ok {
+		r0 = rf(ctx, ref)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantQueries_ResolveTenant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveTenant'
+type TenantQueries_ResolveTenant_Call struct {
+	*mock.Call
+}
+
+// ResolveTenant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ref string
+func (_e *TenantQueries_Expecter) ResolveTenant(ctx interface{}, ref interface{}) *TenantQueries_ResolveTenant_Call {
+	return &TenantQueries_ResolveTenant_Call{Call: _e.mock.On("ResolveTenant", ctx, ref)}
+}
+
+func (_c *TenantQueries_ResolveTenant_Call) Run(run func(ctx context.Context, ref string)) *TenantQueries_ResolveTenant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantQueries_ResolveTenant_Call) Return(_a0 *domain.Tenant, _a1 error) *TenantQueries_ResolveTenant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TenantQueries_ResolveTenant_Call) RunAndReturn(run func(context.Context, string) (*domain.Tenant, error)) *TenantQueries_ResolveTenant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTenantQueries creates a new instance of TenantQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTenantQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TenantQueries {
+	mock := &TenantQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
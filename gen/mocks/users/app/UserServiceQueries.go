@@ -24,9 +24,135 @@ func (_m *UserServiceQueries) EXPECT() *UserServiceQueries_Expecter {
 	return &UserServiceQueries_Expecter{mock: &_m.Mock}
 }
 
-// GetUser provides a mock function with given fields: ctx, userID
-func (_m *UserServiceQueries) GetUser(ctx context.Context, userID string) (*domain.User, error) {
-	ret := _m.Called(ctx, userID)
+// BatchGetUsers provides a mock function with given fields: ctx, userIDs
+func (_m *UserServiceQueries) BatchGetUsers(ctx context.Context, userIDs []string) ([]*domain.User, []string, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchGetUsers")
+	}
+
+	var r0 []*domain.User
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*domain.User, []string, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*domain.User); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) []string); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string) error); ok {
+		r2 = rf(ctx, userIDs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UserServiceQueries_BatchGetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGetUsers'
+type UserServiceQueries_BatchGetUsers_Call struct {
+	*mock.Call
+}
+
+// BatchGetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *UserServiceQueries_Expecter) BatchGetUsers(ctx interface{}, userIDs interface{}) *UserServiceQueries_BatchGetUsers_Call {
+	return &UserServiceQueries_BatchGetUsers_Call{Call: _e.mock.On("BatchGetUsers", ctx, userIDs)}
+}
+
+func (_c *UserServiceQueries_BatchGetUsers_Call) Run(run func(ctx context.Context, userIDs []string)) *UserServiceQueries_BatchGetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *UserServiceQueries_BatchGetUsers_Call) Return(users []*domain.User, missingIDs []string, err error) *UserServiceQueries_BatchGetUsers_Call {
+	_c.Call.Return(users, missingIDs, err)
+	return _c
+}
+
+func (_c *UserServiceQueries_BatchGetUsers_Call) RunAndReturn(run func(context.Context, []string) ([]*domain.User, []string, error)) *UserServiceQueries_BatchGetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportUsers provides a mock function with given fields: ctx, req, fn
+func (_m *UserServiceQueries) ExportUsers(ctx context.Context, req user.ExportUsersRequest, fn func(*domain.User) error) (int64, error) {
+	ret := _m.Called(ctx, req, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ExportUsersRequest, func(*domain.User) error) (int64, error)); ok {
+		return rf(ctx, req, fn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ExportUsersRequest, func(*domain.User) error) int64); ok {
+		r0 = rf(ctx, req, fn)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.ExportUsersRequest, func(*domain.User) error) error); ok {
+		r1 = rf(ctx, req, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserServiceQueries_ExportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUsers'
+type UserServiceQueries_ExportUsers_Call struct {
+	*mock.Call
+}
+
+// ExportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.ExportUsersRequest
+//   - fn func(*domain.User) error
+func (_e *UserServiceQueries_Expecter) ExportUsers(ctx interface{}, req interface{}, fn interface{}) *UserServiceQueries_ExportUsers_Call {
+	return &UserServiceQueries_ExportUsers_Call{Call: _e.mock.On("ExportUsers", ctx, req, fn)}
+}
+
+func (_c *UserServiceQueries_ExportUsers_Call) Run(run func(ctx context.Context, req user.ExportUsersRequest, fn func(*domain.User) error)) *UserServiceQueries_ExportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.ExportUsersRequest), args[2].(func(*domain.User) error))
+	})
+	return _c
+}
+
+func (_c *UserServiceQueries_ExportUsers_Call) Return(exported int64, err error) *UserServiceQueries_ExportUsers_Call {
+	_c.Call.Return(exported, err)
+	return _c
+}
+
+func (_c *UserServiceQueries_ExportUsers_Call) RunAndReturn(run func(context.Context, user.ExportUsersRequest, func(*domain.User) error) (int64, error)) *UserServiceQueries_ExportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID, fields
+func (_m *UserServiceQueries) GetUser(ctx context.Context, userID string, fields []string) (*domain.User, error) {
+	ret := _m.Called(ctx, userID, fields)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUser")
@@ -34,19 +160,19 @@ func (_m *UserServiceQueries) GetUser(ctx context.Context, userID string) (*doma
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*domain.User, error)); ok {
+		return rf(ctx, userID, fields)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *domain.User); ok {
+		r0 = rf(ctx, userID, fields)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +188,14 @@ type UserServiceQueries_GetUser_Call struct {
 // GetUser is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *UserServiceQueries_Expecter) GetUser(ctx interface{}, userID interface{}) *UserServiceQueries_GetUser_Call {
-	return &UserServiceQueries_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+//   - fields []string
+func (_e *UserServiceQueries_Expecter) GetUser(ctx interface{}, userID interface{}, fields interface{}) *UserServiceQueries_GetUser_Call {
+	return &UserServiceQueries_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID, fields)}
 }
 
-func (_c *UserServiceQueries_GetUser_Call) Run(run func(ctx context.Context, userID string)) *UserServiceQueries_GetUser_Call {
+func (_c *UserServiceQueries_GetUser_Call) Run(run func(ctx context.Context, userID string, fields []string)) *UserServiceQueries_GetUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
 	})
 	return _c
 }
@@ -78,46 +205,96 @@ func (_c *UserServiceQueries_GetUser_Call) Return(_a0 *domain.User, err error) *
 	return _c
 }
 
-func (_c *UserServiceQueries_GetUser_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *UserServiceQueries_GetUser_Call {
+func (_c *UserServiceQueries_GetUser_Call) RunAndReturn(run func(context.Context, string, []string) (*domain.User, error)) *UserServiceQueries_GetUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListUsers provides a mock function with given fields: ctx, req
-func (_m *UserServiceQueries) ListUsers(ctx context.Context, req user.ListUsersRequest) ([]*domain.User, string, error) {
+// GetUserStats provides a mock function with given fields: ctx, req
+func (_m *UserServiceQueries) GetUserStats(ctx context.Context, req user.UserStatsRequest) (*domain.UserStats, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUsers")
+		panic("no return value specified for GetUserStats")
 	}
 
-	var r0 []*domain.User
-	var r1 string
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, user.ListUsersRequest) ([]*domain.User, string, error)); ok {
+	var r0 *domain.UserStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserStatsRequest) (*domain.UserStats, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, user.ListUsersRequest) []*domain.User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserStatsRequest) *domain.UserStats); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.User)
+			r0 = ret.Get(0).(*domain.UserStats)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, user.ListUsersRequest) string); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, user.UserStatsRequest) error); ok {
 		r1 = rf(ctx, req)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, user.ListUsersRequest) error); ok {
-		r2 = rf(ctx, req)
+	return r0, r1
+}
+
+// UserServiceQueries_GetUserStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserStats'
+type UserServiceQueries_GetUserStats_Call struct {
+	*mock.Call
+}
+
+// GetUserStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.UserStatsRequest
+func (_e *UserServiceQueries_Expecter) GetUserStats(ctx interface{}, req interface{}) *UserServiceQueries_GetUserStats_Call {
+	return &UserServiceQueries_GetUserStats_Call{Call: _e.mock.On("GetUserStats", ctx, req)}
+}
+
+func (_c *UserServiceQueries_GetUserStats_Call) Run(run func(ctx context.Context, req user.UserStatsRequest)) *UserServiceQueries_GetUserStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.UserStatsRequest))
+	})
+	return _c
+}
+
+func (_c *UserServiceQueries_GetUserStats_Call) Return(stats *domain.UserStats, err error) *UserServiceQueries_GetUserStats_Call {
+	_c.Call.Return(stats, err)
+	return _c
+}
+
+func (_c *UserServiceQueries_GetUserStats_Call) RunAndReturn(run func(context.Context, user.UserStatsRequest) (*domain.UserStats, error)) *UserServiceQueries_GetUserStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx, req
+func (_m *UserServiceQueries) ListUsers(ctx context.Context, req user.ListUsersRequest) (user.ListUsersResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 user.ListUsersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListUsersRequest) (user.ListUsersResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.ListUsersRequest) user.ListUsersResponse); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Get(0).(user.ListUsersResponse)
 	}
 
-	return r0, r1, r2
+	if rf, ok := ret.Get(1).(func(context.Context, user.ListUsersRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UserServiceQueries_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
@@ -139,12 +316,137 @@ func (_c *UserServiceQueries_ListUsers_Call) Run(run func(ctx context.Context, r
 	return _c
 }
 
-func (_c *UserServiceQueries_ListUsers_Call) Return(users []*domain.User, nextCursor string, err error) *UserServiceQueries_ListUsers_Call {
-	_c.Call.Return(users, nextCursor, err)
+func (_c *UserServiceQueries_ListUsers_Call) Return(page user.ListUsersResponse, err error) *UserServiceQueries_ListUsers_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *UserServiceQueries_ListUsers_Call) RunAndReturn(run func(context.Context, user.ListUsersRequest) (user.ListUsersResponse, error)) *UserServiceQueries_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupUser provides a mock function with given fields: ctx, req
+func (_m *UserServiceQueries) LookupUser(ctx context.Context, req user.LookupUserRequest) (*domain.User, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.LookupUserRequest) (*domain.User, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.LookupUserRequest) *domain.User); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.LookupUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserServiceQueries_LookupUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupUser'
+type UserServiceQueries_LookupUser_Call struct {
+	*mock.Call
+}
+
+// LookupUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.LookupUserRequest
+func (_e *UserServiceQueries_Expecter) LookupUser(ctx interface{}, req interface{}) *UserServiceQueries_LookupUser_Call {
+	return &UserServiceQueries_LookupUser_Call{Call: _e.mock.On("LookupUser", ctx, req)}
+}
+
+func (_c *UserServiceQueries_LookupUser_Call) Run(run func(ctx context.Context, req user.LookupUserRequest)) *UserServiceQueries_LookupUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.LookupUserRequest))
+	})
+	return _c
+}
+
+func (_c *UserServiceQueries_LookupUser_Call) Return(_a0 *domain.User, err error) *UserServiceQueries_LookupUser_Call {
+	_c.Call.Return(_a0, err)
+	return _c
+}
+
+func (_c *UserServiceQueries_LookupUser_Call) RunAndReturn(run func(context.Context, user.LookupUserRequest) (*domain.User, error)) *UserServiceQueries_LookupUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchUsers provides a mock function with given fields: ctx, req
+func (_m *UserServiceQueries) SearchUsers(ctx context.Context, req user.SearchUsersRequest) ([]*domain.UserSearchResult, string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []*domain.UserSearchResult
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.SearchUsersRequest) ([]*domain.UserSearchResult, string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.SearchUsersRequest) []*domain.UserSearchResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.UserSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.SearchUsersRequest) string); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, user.SearchUsersRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UserServiceQueries_SearchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchUsers'
+type UserServiceQueries_SearchUsers_Call struct {
+	*mock.Call
+}
+
+// SearchUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.SearchUsersRequest
+func (_e *UserServiceQueries_Expecter) SearchUsers(ctx interface{}, req interface{}) *UserServiceQueries_SearchUsers_Call {
+	return &UserServiceQueries_SearchUsers_Call{Call: _e.mock.On("SearchUsers", ctx, req)}
+}
+
+func (_c *UserServiceQueries_SearchUsers_Call) Run(run func(ctx context.Context, req user.SearchUsersRequest)) *UserServiceQueries_SearchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.SearchUsersRequest))
+	})
+	return _c
+}
+
+func (_c *UserServiceQueries_SearchUsers_Call) Return(results []*domain.UserSearchResult, nextPageToken string, err error) *UserServiceQueries_SearchUsers_Call {
+	_c.Call.Return(results, nextPageToken, err)
 	return _c
 }
 
-func (_c *UserServiceQueries_ListUsers_Call) RunAndReturn(run func(context.Context, user.ListUsersRequest) ([]*domain.User, string, error)) *UserServiceQueries_ListUsers_Call {
+func (_c *UserServiceQueries_SearchUsers_Call) RunAndReturn(run func(context.Context, user.SearchUsersRequest) ([]*domain.UserSearchResult, string, error)) *UserServiceQueries_SearchUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
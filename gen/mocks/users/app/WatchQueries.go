@@ -0,0 +1,87 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	user "users/internal/app/user"
+)
+
+// WatchQueries is an autogenerated mock type for the WatchQueries type
+type WatchQueries struct {
+	mock.Mock
+}
+
+type WatchQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WatchQueries) EXPECT() *WatchQueries_Expecter {
+	return &WatchQueries_Expecter{mock: &_m.Mock}
+}
+
+// WatchUsers provides a mock function with given fields: ctx, req, fn
+func (_m *WatchQueries) WatchUsers(ctx context.Context, req user.WatchUsersRequest, fn func(*domain.UserChange) error) error {
+	ret := _m.Called(ctx, req, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.WatchUsersRequest, func(*domain.UserChange) error) error); ok {
+		r0 = rf(ctx, req, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WatchQueries_WatchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchUsers'
+type WatchQueries_WatchUsers_Call struct {
+	*mock.Call
+}
+
+// WatchUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req user.WatchUsersRequest
+//   - fn func(*domain.UserChange) error
+func (_e *WatchQueries_Expecter) WatchUsers(ctx interface{}, req interface{}, fn interface{}) *WatchQueries_WatchUsers_Call {
+	return &WatchQueries_WatchUsers_Call{Call: _e.mock.On("WatchUsers", ctx, req, fn)}
+}
+
+func (_c *WatchQueries_WatchUsers_Call) Run(run func(ctx context.Context, req user.WatchUsersRequest, fn func(*domain.UserChange) error)) *WatchQueries_WatchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.WatchUsersRequest), args[2].(func(*domain.UserChange) error))
+	})
+	return _c
+}
+
+func (_c *WatchQueries_WatchUsers_Call) Return(_a0 error) *WatchQueries_WatchUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WatchQueries_WatchUsers_Call) RunAndReturn(run func(context.Context, user.WatchUsersRequest, func(*domain.UserChange) error) error) *WatchQueries_WatchUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWatchQueries creates a new instance of WatchQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWatchQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WatchQueries {
+	mock := &WatchQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,159 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ActivityRepoCommands is an autogenerated mock type for the ActivityRepoCommands type
+type ActivityRepoCommands struct {
+	mock.Mock
+}
+
+type ActivityRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ActivityRepoCommands) EXPECT() *ActivityRepoCommands_Expecter {
+	return &ActivityRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// FlagInactiveUsers provides a mock function with given fields: ctx, inactiveSince, status, limit
+func (_m *ActivityRepoCommands) FlagInactiveUsers(ctx context.Context, inactiveSince time.Time, status string, limit int32) ([]*domain.InactiveUser, error) {
+	ret := _m.Called(ctx, inactiveSince, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FlagInactiveUsers")
+	}
+
+	var r0 []*domain.InactiveUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string, int32) ([]*domain.InactiveUser, error)); ok {
+		return rf(ctx, inactiveSince, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string, int32) []*domain.InactiveUser); ok {
+		r0 = rf(ctx, inactiveSince, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.InactiveUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, string, int32) error); ok {
+		r1 = rf(ctx, inactiveSince, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ActivityRepoCommands_FlagInactiveUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FlagInactiveUsers'
+type ActivityRepoCommands_FlagInactiveUsers_Call struct {
+	*mock.Call
+}
+
+// FlagInactiveUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - inactiveSince time.Time
+//   - status string
+//   - limit int32
+func (_e *ActivityRepoCommands_Expecter) FlagInactiveUsers(ctx interface{}, inactiveSince interface{}, status interface{}, limit interface{}) *ActivityRepoCommands_FlagInactiveUsers_Call {
+	return &ActivityRepoCommands_FlagInactiveUsers_Call{Call: _e.mock.On("FlagInactiveUsers", ctx, inactiveSince, status, limit)}
+}
+
+func (_c *ActivityRepoCommands_FlagInactiveUsers_Call) Run(run func(ctx context.Context, inactiveSince time.Time, status string, limit int32)) *ActivityRepoCommands_FlagInactiveUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(string), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *ActivityRepoCommands_FlagInactiveUsers_Call) Return(_a0 []*domain.InactiveUser, _a1 error) *ActivityRepoCommands_FlagInactiveUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ActivityRepoCommands_FlagInactiveUsers_Call) RunAndReturn(run func(context.Context, time.Time, string, int32) ([]*domain.InactiveUser, error)) *ActivityRepoCommands_FlagInactiveUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchUser provides a mock function with given fields: ctx, userID, seenAt, notBefore
+func (_m *ActivityRepoCommands) TouchUser(ctx context.Context, userID string, seenAt time.Time, notBefore time.Time) (bool, error) {
+	ret := _m.Called(ctx, userID, seenAt, notBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchUser")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, userID, seenAt, notBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, userID, seenAt, notBefore)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, seenAt, notBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ActivityRepoCommands_TouchUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchUser'
+type ActivityRepoCommands_TouchUser_Call struct {
+	*mock.Call
+}
+
+// TouchUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - seenAt time.Time
+//   - notBefore time.Time
+func (_e *ActivityRepoCommands_Expecter) TouchUser(ctx interface{}, userID interface{}, seenAt interface{}, notBefore interface{}) *ActivityRepoCommands_TouchUser_Call {
+	return &ActivityRepoCommands_TouchUser_Call{Call: _e.mock.On("TouchUser", ctx, userID, seenAt, notBefore)}
+}
+
+func (_c *ActivityRepoCommands_TouchUser_Call) Run(run func(ctx context.Context, userID string, seenAt time.Time, notBefore time.Time)) *ActivityRepoCommands_TouchUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ActivityRepoCommands_TouchUser_Call) Return(reactivated bool, err error) *ActivityRepoCommands_TouchUser_Call {
+	_c.Call.Return(reactivated, err)
+	return _c
+}
+
+func (_c *ActivityRepoCommands_TouchUser_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (bool, error)) *ActivityRepoCommands_TouchUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewActivityRepoCommands creates a new instance of ActivityRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewActivityRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ActivityRepoCommands {
+	mock := &ActivityRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
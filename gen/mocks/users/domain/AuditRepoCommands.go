@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditRepoCommands is an autogenerated mock type for the AuditRepoCommands type
+type AuditRepoCommands struct {
+	mock.Mock
+}
+
+type AuditRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRepoCommands) EXPECT() *AuditRepoCommands_Expecter {
+	return &AuditRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// AddAuditEvent provides a mock function with given fields: ctx, event
+func (_m *AuditRepoCommands) AddAuditEvent(ctx context.Context, event *domain.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAuditEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditRepoCommands_AddAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEvent'
+type AuditRepoCommands_AddAuditEvent_Call struct {
+	*mock.Call
+}
+
+// AddAuditEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *domain.AuditEvent
+func (_e *AuditRepoCommands_Expecter) AddAuditEvent(ctx interface{}, event interface{}) *AuditRepoCommands_AddAuditEvent_Call {
+	return &AuditRepoCommands_AddAuditEvent_Call{Call: _e.mock.On("AddAuditEvent", ctx, event)}
+}
+
+func (_c *AuditRepoCommands_AddAuditEvent_Call) Run(run func(ctx context.Context, event *domain.AuditEvent)) *AuditRepoCommands_AddAuditEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.AuditEvent))
+	})
+	return _c
+}
+
+func (_c *AuditRepoCommands_AddAuditEvent_Call) Return(_a0 error) *AuditRepoCommands_AddAuditEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditRepoCommands_AddAuditEvent_Call) RunAndReturn(run func(context.Context, *domain.AuditEvent) error) *AuditRepoCommands_AddAuditEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRepoCommands creates a new instance of AuditRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepoCommands {
+	mock := &AuditRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AuditRepoQueries is an autogenerated mock type for the AuditRepoQueries type
+type AuditRepoQueries struct {
+	mock.Mock
+}
+
+type AuditRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRepoQueries) EXPECT() *AuditRepoQueries_Expecter {
+	return &AuditRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, cursorID, cursorCreatedAt, limit, filters
+func (_m *AuditRepoQueries) ListAuditEvents(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, filters domain.AuditEventFilters) ([]*domain.AuditEvent, error) {
+	ret := _m.Called(ctx, cursorID, cursorCreatedAt, limit, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 []*domain.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, int32, domain.AuditEventFilters) ([]*domain.AuditEvent, error)); ok {
+		return rf(ctx, cursorID, cursorCreatedAt, limit, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, int32, domain.AuditEventFilters) []*domain.AuditEvent); ok {
+		r0 = rf(ctx, cursorID, cursorCreatedAt, limit, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time, int32, domain.AuditEventFilters) error); ok {
+		r1 = rf(ctx, cursorID, cursorCreatedAt, limit, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRepoQueries_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type AuditRepoQueries_ListAuditEvents_Call struct {
+	*mock.Call
+}
+
+// ListAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cursorID string
+//   - cursorCreatedAt *time.Time
+//   - limit int32
+//   - filters domain.AuditEventFilters
+func (_e *AuditRepoQueries_Expecter) ListAuditEvents(ctx interface{}, cursorID interface{}, cursorCreatedAt interface{}, limit interface{}, filters interface{}) *AuditRepoQueries_ListAuditEvents_Call {
+	return &AuditRepoQueries_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", ctx, cursorID, cursorCreatedAt, limit, filters)}
+}
+
+func (_c *AuditRepoQueries_ListAuditEvents_Call) Run(run func(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, filters domain.AuditEventFilters)) *AuditRepoQueries_ListAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*time.Time), args[3].(int32), args[4].(domain.AuditEventFilters))
+	})
+	return _c
+}
+
+func (_c *AuditRepoQueries_ListAuditEvents_Call) Return(_a0 []*domain.AuditEvent, _a1 error) *AuditRepoQueries_ListAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRepoQueries_ListAuditEvents_Call) RunAndReturn(run func(context.Context, string, *time.Time, int32, domain.AuditEventFilters) ([]*domain.AuditEvent, error)) *AuditRepoQueries_ListAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRepoQueries creates a new instance of AuditRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepoQueries {
+	mock := &AuditRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobStore is an autogenerated mock type for the BlobStore type
+type BlobStore struct {
+	mock.Mock
+}
+
+type BlobStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlobStore) EXPECT() *BlobStore_Expecter {
+	return &BlobStore_Expecter{mock: &_m.Mock}
+}
+
+// DeletePrefix provides a mock function with given fields: ctx, prefix
+func (_m *BlobStore) DeletePrefix(ctx context.Context, prefix string) error {
+	ret := _m.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePrefix")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobStore_DeletePrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePrefix'
+type BlobStore_DeletePrefix_Call struct {
+	*mock.Call
+}
+
+// DeletePrefix is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+func (_e *BlobStore_Expecter) DeletePrefix(ctx interface{}, prefix interface{}) *BlobStore_DeletePrefix_Call {
+	return &BlobStore_DeletePrefix_Call{Call: _e.mock.On("DeletePrefix", ctx, prefix)}
+}
+
+func (_c *BlobStore_DeletePrefix_Call) Run(run func(ctx context.Context, prefix string)) *BlobStore_DeletePrefix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlobStore_DeletePrefix_Call) Return(_a0 error) *BlobStore_DeletePrefix_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobStore_DeletePrefix_Call) RunAndReturn(run func(context.Context, string) error) *BlobStore_DeletePrefix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, key, contentType, data
+func (_m *BlobStore) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	ret := _m.Called(ctx, key, contentType, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) (string, error)); ok {
+		return rf(ctx, key, contentType, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) string); ok {
+		r0 = rf(ctx, key, contentType, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, key, contentType, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlobStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type BlobStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - contentType string
+//   - data []byte
+func (_e *BlobStore_Expecter) Put(ctx interface{}, key interface{}, contentType interface{}, data interface{}) *BlobStore_Put_Call {
+	return &BlobStore_Put_Call{Call: _e.mock.On("Put", ctx, key, contentType, data)}
+}
+
+func (_c *BlobStore_Put_Call) Run(run func(ctx context.Context, key string, contentType string, data []byte)) *BlobStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *BlobStore_Put_Call) Return(url string, err error) *BlobStore_Put_Call {
+	_c.Call.Return(url, err)
+	return _c
+}
+
+func (_c *BlobStore_Put_Call) RunAndReturn(run func(context.Context, string, string, []byte) (string, error)) *BlobStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlobStore creates a new instance of BlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlobStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlobStore {
+	mock := &BlobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,296 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupRepoCommands is an autogenerated mock type for the GroupRepoCommands type
+type GroupRepoCommands struct {
+	mock.Mock
+}
+
+type GroupRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GroupRepoCommands) EXPECT() *GroupRepoCommands_Expecter {
+	return &GroupRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *GroupRepoCommands) AddMember(ctx context.Context, groupID string, userID string) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GroupRepoCommands_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type GroupRepoCommands_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - userID string
+func (_e *GroupRepoCommands_Expecter) AddMember(ctx interface{}, groupID interface{}, userID interface{}) *GroupRepoCommands_AddMember_Call {
+	return &GroupRepoCommands_AddMember_Call{Call: _e.mock.On("AddMember", ctx, groupID, userID)}
+}
+
+func (_c *GroupRepoCommands_AddMember_Call) Run(run func(ctx context.Context, groupID string, userID string)) *GroupRepoCommands_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *GroupRepoCommands_AddMember_Call) Return(_a0 error) *GroupRepoCommands_AddMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GroupRepoCommands_AddMember_Call) RunAndReturn(run func(context.Context, string, string) error) *GroupRepoCommands_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, groupID
+func (_m *GroupRepoCommands) DeleteGroup(ctx context.Context, groupID string) error {
+	ret := _m.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GroupRepoCommands_DeleteGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroup'
+type GroupRepoCommands_DeleteGroup_Call struct {
+	*mock.Call
+}
+
+// DeleteGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *GroupRepoCommands_Expecter) DeleteGroup(ctx interface{}, groupID interface{}) *GroupRepoCommands_DeleteGroup_Call {
+	return &GroupRepoCommands_DeleteGroup_Call{Call: _e.mock.On("DeleteGroup", ctx, groupID)}
+}
+
+func (_c *GroupRepoCommands_DeleteGroup_Call) Run(run func(ctx context.Context, groupID string)) *GroupRepoCommands_DeleteGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *GroupRepoCommands_DeleteGroup_Call) Return(_a0 error) *GroupRepoCommands_DeleteGroup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GroupRepoCommands_DeleteGroup_Call) RunAndReturn(run func(context.Context, string) error) *GroupRepoCommands_DeleteGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *GroupRepoCommands) RemoveMember(ctx context.Context, groupID string, userID string) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GroupRepoCommands_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type GroupRepoCommands_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - userID string
+func (_e *GroupRepoCommands_Expecter) RemoveMember(ctx interface{}, groupID interface{}, userID interface{}) *GroupRepoCommands_RemoveMember_Call {
+	return &GroupRepoCommands_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, groupID, userID)}
+}
+
+func (_c *GroupRepoCommands_RemoveMember_Call) Run(run func(ctx context.Context, groupID string, userID string)) *GroupRepoCommands_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *GroupRepoCommands_RemoveMember_Call) Return(_a0 error) *GroupRepoCommands_RemoveMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GroupRepoCommands_RemoveMember_Call) RunAndReturn(run func(context.Context, string, string) error) *GroupRepoCommands_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveUserMemberships provides a mock function with given fields: ctx, userID
+func (_m *GroupRepoCommands) RemoveUserMemberships(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUserMemberships")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupRepoCommands_RemoveUserMemberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUserMemberships'
+type GroupRepoCommands_RemoveUserMemberships_Call struct {
+	*mock.Call
+}
+
+// RemoveUserMemberships is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *GroupRepoCommands_Expecter) RemoveUserMemberships(ctx interface{}, userID interface{}) *GroupRepoCommands_RemoveUserMemberships_Call {
+	return &GroupRepoCommands_RemoveUserMemberships_Call{Call: _e.mock.On("RemoveUserMemberships", ctx, userID)}
+}
+
+func (_c *GroupRepoCommands_RemoveUserMemberships_Call) Run(run func(ctx context.Context, userID string)) *GroupRepoCommands_RemoveUserMemberships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *GroupRepoCommands_RemoveUserMemberships_Call) Return(groupIDs []string, err error) *GroupRepoCommands_RemoveUserMemberships_Call {
+	_c.Call.Return(groupIDs, err)
+	return _c
+}
+
+func (_c *GroupRepoCommands_RemoveUserMemberships_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *GroupRepoCommands_RemoveUserMemberships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveGroup provides a mock function with given fields: ctx, group
+func (_m *GroupRepoCommands) SaveGroup(ctx context.Context, group *domain.Group) (string, error) {
+	ret := _m.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGroup")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Group) (string, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Group) string); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Group) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupRepoCommands_SaveGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveGroup'
+type GroupRepoCommands_SaveGroup_Call struct {
+	*mock.Call
+}
+
+// SaveGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group *domain.Group
+func (_e *GroupRepoCommands_Expecter) SaveGroup(ctx interface{}, group interface{}) *GroupRepoCommands_SaveGroup_Call {
+	return &GroupRepoCommands_SaveGroup_Call{Call: _e.mock.On("SaveGroup", ctx, group)}
+}
+
+func (_c *GroupRepoCommands_SaveGroup_Call) Run(run func(ctx context.Context, group *domain.Group)) *GroupRepoCommands_SaveGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Group))
+	})
+	return _c
+}
+
+func (_c *GroupRepoCommands_SaveGroup_Call) Return(_a0 string, _a1 error) *GroupRepoCommands_SaveGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GroupRepoCommands_SaveGroup_Call) RunAndReturn(run func(context.Context, *domain.Group) (string, error)) *GroupRepoCommands_SaveGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGroupRepoCommands creates a new instance of GroupRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGroupRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GroupRepoCommands {
+	mock := &GroupRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,219 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// GroupRepoQueries is an autogenerated mock type for the GroupRepoQueries type
+type GroupRepoQueries struct {
+	mock.Mock
+}
+
+type GroupRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GroupRepoQueries) EXPECT() *GroupRepoQueries_Expecter {
+	return &GroupRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// GetGroup provides a mock function with given fields: ctx, groupID
+func (_m *GroupRepoQueries) GetGroup(ctx context.Context, groupID string) (*domain.Group, error) {
+	ret := _m.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroup")
+	}
+
+	var r0 *domain.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Group, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Group); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupRepoQueries_GetGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroup'
+type GroupRepoQueries_GetGroup_Call struct {
+	*mock.Call
+}
+
+// GetGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *GroupRepoQueries_Expecter) GetGroup(ctx interface{}, groupID interface{}) *GroupRepoQueries_GetGroup_Call {
+	return &GroupRepoQueries_GetGroup_Call{Call: _e.mock.On("GetGroup", ctx, groupID)}
+}
+
+func (_c *GroupRepoQueries_GetGroup_Call) Run(run func(ctx context.Context, groupID string)) *GroupRepoQueries_GetGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *GroupRepoQueries_GetGroup_Call) Return(_a0 *domain.Group, _a1 error) *GroupRepoQueries_GetGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GroupRepoQueries_GetGroup_Call) RunAndReturn(run func(context.Context, string) (*domain.Group, error)) *GroupRepoQueries_GetGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroupMembers provides a mock function with given fields: ctx, groupID, cursorUserID, cursorAddedAt, limit
+func (_m *GroupRepoQueries) ListGroupMembers(ctx context.Context, groupID string, cursorUserID string, cursorAddedAt *time.Time, limit int32) ([]*domain.GroupMember, error) {
+	ret := _m.Called(ctx, groupID, cursorUserID, cursorAddedAt, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroupMembers")
+	}
+
+	var r0 []*domain.GroupMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) ([]*domain.GroupMember, error)); ok {
+		return rf(ctx, groupID, cursorUserID, cursorAddedAt, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) []*domain.GroupMember); ok {
+		r0 = rf(ctx, groupID, cursorUserID, cursorAddedAt, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.GroupMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *time.Time, int32) error); ok {
+		r1 = rf(ctx, groupID, cursorUserID, cursorAddedAt, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupRepoQueries_ListGroupMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroupMembers'
+type GroupRepoQueries_ListGroupMembers_Call struct {
+	*mock.Call
+}
+
+// ListGroupMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+//   - cursorUserID string
+//   - cursorAddedAt *time.Time
+//   - limit int32
+func (_e *GroupRepoQueries_Expecter) ListGroupMembers(ctx interface{}, groupID interface{}, cursorUserID interface{}, cursorAddedAt interface{}, limit interface{}) *GroupRepoQueries_ListGroupMembers_Call {
+	return &GroupRepoQueries_ListGroupMembers_Call{Call: _e.mock.On("ListGroupMembers", ctx, groupID, cursorUserID, cursorAddedAt, limit)}
+}
+
+func (_c *GroupRepoQueries_ListGroupMembers_Call) Run(run func(ctx context.Context, groupID string, cursorUserID string, cursorAddedAt *time.Time, limit int32)) *GroupRepoQueries_ListGroupMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*time.Time), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *GroupRepoQueries_ListGroupMembers_Call) Return(_a0 []*domain.GroupMember, _a1 error) *GroupRepoQueries_ListGroupMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GroupRepoQueries_ListGroupMembers_Call) RunAndReturn(run func(context.Context, string, string, *time.Time, int32) ([]*domain.GroupMember, error)) *GroupRepoQueries_ListGroupMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserGroups provides a mock function with given fields: ctx, userID
+func (_m *GroupRepoQueries) ListUserGroups(ctx context.Context, userID string) ([]*domain.Group, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserGroups")
+	}
+
+	var r0 []*domain.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Group, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Group); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupRepoQueries_ListUserGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserGroups'
+type GroupRepoQueries_ListUserGroups_Call struct {
+	*mock.Call
+}
+
+// ListUserGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *GroupRepoQueries_Expecter) ListUserGroups(ctx interface{}, userID interface{}) *GroupRepoQueries_ListUserGroups_Call {
+	return &GroupRepoQueries_ListUserGroups_Call{Call: _e.mock.On("ListUserGroups", ctx, userID)}
+}
+
+func (_c *GroupRepoQueries_ListUserGroups_Call) Run(run func(ctx context.Context, userID string)) *GroupRepoQueries_ListUserGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *GroupRepoQueries_ListUserGroups_Call) Return(_a0 []*domain.Group, _a1 error) *GroupRepoQueries_ListUserGroups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GroupRepoQueries_ListUserGroups_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Group, error)) *GroupRepoQueries_ListUserGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGroupRepoQueries creates a new instance of GroupRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGroupRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GroupRepoQueries {
+	mock := &GroupRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
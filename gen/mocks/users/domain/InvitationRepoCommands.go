@@ -0,0 +1,248 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InvitationRepoCommands is an autogenerated mock type for the InvitationRepoCommands type
+type InvitationRepoCommands struct {
+	mock.Mock
+}
+
+type InvitationRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvitationRepoCommands) EXPECT() *InvitationRepoCommands_Expecter {
+	return &InvitationRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// GetPendingInvitation provides a mock function with given fields: ctx, tokenHash
+func (_m *InvitationRepoCommands) GetPendingInvitation(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingInvitation")
+	}
+
+	var r0 *domain.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Invitation, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Invitation); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationRepoCommands_GetPendingInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingInvitation'
+type InvitationRepoCommands_GetPendingInvitation_Call struct {
+	*mock.Call
+}
+
+// GetPendingInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *InvitationRepoCommands_Expecter) GetPendingInvitation(ctx interface{}, tokenHash interface{}) *InvitationRepoCommands_GetPendingInvitation_Call {
+	return &InvitationRepoCommands_GetPendingInvitation_Call{Call: _e.mock.On("GetPendingInvitation", ctx, tokenHash)}
+}
+
+func (_c *InvitationRepoCommands_GetPendingInvitation_Call) Run(run func(ctx context.Context, tokenHash string)) *InvitationRepoCommands_GetPendingInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepoCommands_GetPendingInvitation_Call) Return(_a0 *domain.Invitation, _a1 error) *InvitationRepoCommands_GetPendingInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationRepoCommands_GetPendingInvitation_Call) RunAndReturn(run func(context.Context, string) (*domain.Invitation, error)) *InvitationRepoCommands_GetPendingInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkInvitationAccepted provides a mock function with given fields: ctx, invitationID, userID
+func (_m *InvitationRepoCommands) MarkInvitationAccepted(ctx context.Context, invitationID string, userID string) error {
+	ret := _m.Called(ctx, invitationID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkInvitationAccepted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, invitationID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationRepoCommands_MarkInvitationAccepted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkInvitationAccepted'
+type InvitationRepoCommands_MarkInvitationAccepted_Call struct {
+	*mock.Call
+}
+
+// MarkInvitationAccepted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitationID string
+//   - userID string
+func (_e *InvitationRepoCommands_Expecter) MarkInvitationAccepted(ctx interface{}, invitationID interface{}, userID interface{}) *InvitationRepoCommands_MarkInvitationAccepted_Call {
+	return &InvitationRepoCommands_MarkInvitationAccepted_Call{Call: _e.mock.On("MarkInvitationAccepted", ctx, invitationID, userID)}
+}
+
+func (_c *InvitationRepoCommands_MarkInvitationAccepted_Call) Run(run func(ctx context.Context, invitationID string, userID string)) *InvitationRepoCommands_MarkInvitationAccepted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepoCommands_MarkInvitationAccepted_Call) Return(_a0 error) *InvitationRepoCommands_MarkInvitationAccepted_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationRepoCommands_MarkInvitationAccepted_Call) RunAndReturn(run func(context.Context, string, string) error) *InvitationRepoCommands_MarkInvitationAccepted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, invitationID
+func (_m *InvitationRepoCommands) RevokeInvitation(ctx context.Context, invitationID string) error {
+	ret := _m.Called(ctx, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationRepoCommands_RevokeInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeInvitation'
+type InvitationRepoCommands_RevokeInvitation_Call struct {
+	*mock.Call
+}
+
+// RevokeInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitationID string
+func (_e *InvitationRepoCommands_Expecter) RevokeInvitation(ctx interface{}, invitationID interface{}) *InvitationRepoCommands_RevokeInvitation_Call {
+	return &InvitationRepoCommands_RevokeInvitation_Call{Call: _e.mock.On("RevokeInvitation", ctx, invitationID)}
+}
+
+func (_c *InvitationRepoCommands_RevokeInvitation_Call) Run(run func(ctx context.Context, invitationID string)) *InvitationRepoCommands_RevokeInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepoCommands_RevokeInvitation_Call) Return(_a0 error) *InvitationRepoCommands_RevokeInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InvitationRepoCommands_RevokeInvitation_Call) RunAndReturn(run func(context.Context, string) error) *InvitationRepoCommands_RevokeInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveInvitation provides a mock function with given fields: ctx, invitation
+func (_m *InvitationRepoCommands) SaveInvitation(ctx context.Context, invitation *domain.Invitation) (string, error) {
+	ret := _m.Called(ctx, invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveInvitation")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Invitation) (string, error)); ok {
+		return rf(ctx, invitation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Invitation) string); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Invitation) error); ok {
+		r1 = rf(ctx, invitation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationRepoCommands_SaveInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveInvitation'
+type InvitationRepoCommands_SaveInvitation_Call struct {
+	*mock.Call
+}
+
+// SaveInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitation *domain.Invitation
+func (_e *InvitationRepoCommands_Expecter) SaveInvitation(ctx interface{}, invitation interface{}) *InvitationRepoCommands_SaveInvitation_Call {
+	return &InvitationRepoCommands_SaveInvitation_Call{Call: _e.mock.On("SaveInvitation", ctx, invitation)}
+}
+
+func (_c *InvitationRepoCommands_SaveInvitation_Call) Run(run func(ctx context.Context, invitation *domain.Invitation)) *InvitationRepoCommands_SaveInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Invitation))
+	})
+	return _c
+}
+
+func (_c *InvitationRepoCommands_SaveInvitation_Call) Return(_a0 string, _a1 error) *InvitationRepoCommands_SaveInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationRepoCommands_SaveInvitation_Call) RunAndReturn(run func(context.Context, *domain.Invitation) (string, error)) *InvitationRepoCommands_SaveInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvitationRepoCommands creates a new instance of InvitationRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationRepoCommands {
+	mock := &InvitationRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
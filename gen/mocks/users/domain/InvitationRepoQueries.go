@@ -0,0 +1,101 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// InvitationRepoQueries is an autogenerated mock type for the InvitationRepoQueries type
+type InvitationRepoQueries struct {
+	mock.Mock
+}
+
+type InvitationRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InvitationRepoQueries) EXPECT() *InvitationRepoQueries_Expecter {
+	return &InvitationRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// ListInvitations provides a mock function with given fields: ctx, cursorID, cursorCreatedAt, limit, status
+func (_m *InvitationRepoQueries) ListInvitations(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, status string) ([]*domain.Invitation, error) {
+	ret := _m.Called(ctx, cursorID, cursorCreatedAt, limit, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 []*domain.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, int32, string) ([]*domain.Invitation, error)); ok {
+		return rf(ctx, cursorID, cursorCreatedAt, limit, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, int32, string) []*domain.Invitation); ok {
+		r0 = rf(ctx, cursorID, cursorCreatedAt, limit, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time, int32, string) error); ok {
+		r1 = rf(ctx, cursorID, cursorCreatedAt, limit, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationRepoQueries_ListInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvitations'
+type InvitationRepoQueries_ListInvitations_Call struct {
+	*mock.Call
+}
+
+// ListInvitations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cursorID string
+//   - cursorCreatedAt *time.Time
+//   - limit int32
+//   - status string
+func (_e *InvitationRepoQueries_Expecter) ListInvitations(ctx interface{}, cursorID interface{}, cursorCreatedAt interface{}, limit interface{}, status interface{}) *InvitationRepoQueries_ListInvitations_Call {
+	return &InvitationRepoQueries_ListInvitations_Call{Call: _e.mock.On("ListInvitations", ctx, cursorID, cursorCreatedAt, limit, status)}
+}
+
+func (_c *InvitationRepoQueries_ListInvitations_Call) Run(run func(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, status string)) *InvitationRepoQueries_ListInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*time.Time), args[3].(int32), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *InvitationRepoQueries_ListInvitations_Call) Return(_a0 []*domain.Invitation, _a1 error) *InvitationRepoQueries_ListInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InvitationRepoQueries_ListInvitations_Call) RunAndReturn(run func(context.Context, string, *time.Time, int32, string) ([]*domain.Invitation, error)) *InvitationRepoQueries_ListInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewInvitationRepoQueries creates a new instance of InvitationRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationRepoQueries {
+	mock := &InvitationRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
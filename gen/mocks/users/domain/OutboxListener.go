@@ -0,0 +1,92 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// OutboxListener is an autogenerated mock type for the OutboxListener type
+type OutboxListener struct {
+	mock.Mock
+}
+
+type OutboxListener_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxListener) EXPECT() *OutboxListener_Expecter {
+	return &OutboxListener_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function with given fields: tenantID
+func (_m *OutboxListener) Subscribe(tenantID string) (<-chan struct{}, func()) {
+	ret := _m.Called(tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan struct{}
+	var r1 func()
+	if rf, ok := ret.Get(0).(func(string) (<-chan struct{}, func())); ok {
+		return rf(tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(string) <-chan struct{}); ok {
+		r0 = rf(tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) func()); ok {
+		r1 = rf(tenantID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	return r0, r1
+}
+
+// OutboxListener_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type OutboxListener_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - tenantID string
+func (_e *OutboxListener_Expecter) Subscribe(tenantID interface{}) *OutboxListener_Subscribe_Call {
+	return &OutboxListener_Subscribe_Call{Call: _e.mock.On("Subscribe", tenantID)}
+}
+
+func (_c *OutboxListener_Subscribe_Call) Run(run func(tenantID string)) *OutboxListener_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *OutboxListener_Subscribe_Call) Return(wake <-chan struct{}, unsubscribe func()) *OutboxListener_Subscribe_Call {
+	_c.Call.Return(wake, unsubscribe)
+	return _c
+}
+
+func (_c *OutboxListener_Subscribe_Call) RunAndReturn(run func(string) (<-chan struct{}, func())) *OutboxListener_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxListener creates a new instance of OutboxListener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxListener(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxListener {
+	mock := &OutboxListener{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
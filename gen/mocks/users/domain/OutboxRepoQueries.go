@@ -0,0 +1,154 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OutboxRepoQueries is an autogenerated mock type for the OutboxRepoQueries type
+type OutboxRepoQueries struct {
+	mock.Mock
+}
+
+type OutboxRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepoQueries) EXPECT() *OutboxRepoQueries_Expecter {
+	return &OutboxRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// GetCommittedEvents provides a mock function with given fields: ctx, after, types, limit
+func (_m *OutboxRepoQueries) GetCommittedEvents(ctx context.Context, after int64, types []string, limit int32) ([]*domain.Event, error) {
+	ret := _m.Called(ctx, after, types, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommittedEvents")
+	}
+
+	var r0 []*domain.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, int32) ([]*domain.Event, error)); ok {
+		return rf(ctx, after, types, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, int32) []*domain.Event); ok {
+		r0 = rf(ctx, after, types, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []string, int32) error); ok {
+		r1 = rf(ctx, after, types, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepoQueries_GetCommittedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommittedEvents'
+type OutboxRepoQueries_GetCommittedEvents_Call struct {
+	*mock.Call
+}
+
+// GetCommittedEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after int64
+//   - types []string
+//   - limit int32
+func (_e *OutboxRepoQueries_Expecter) GetCommittedEvents(ctx interface{}, after interface{}, types interface{}, limit interface{}) *OutboxRepoQueries_GetCommittedEvents_Call {
+	return &OutboxRepoQueries_GetCommittedEvents_Call{Call: _e.mock.On("GetCommittedEvents", ctx, after, types, limit)}
+}
+
+func (_c *OutboxRepoQueries_GetCommittedEvents_Call) Run(run func(ctx context.Context, after int64, types []string, limit int32)) *OutboxRepoQueries_GetCommittedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *OutboxRepoQueries_GetCommittedEvents_Call) Return(_a0 []*domain.Event, _a1 error) *OutboxRepoQueries_GetCommittedEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepoQueries_GetCommittedEvents_Call) RunAndReturn(run func(context.Context, int64, []string, int32) ([]*domain.Event, error)) *OutboxRepoQueries_GetCommittedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastCommittedPosition provides a mock function with given fields: ctx
+func (_m *OutboxRepoQueries) GetLastCommittedPosition(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastCommittedPosition")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepoQueries_GetLastCommittedPosition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastCommittedPosition'
+type OutboxRepoQueries_GetLastCommittedPosition_Call struct {
+	*mock.Call
+}
+
+// GetLastCommittedPosition is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *OutboxRepoQueries_Expecter) GetLastCommittedPosition(ctx interface{}) *OutboxRepoQueries_GetLastCommittedPosition_Call {
+	return &OutboxRepoQueries_GetLastCommittedPosition_Call{Call: _e.mock.On("GetLastCommittedPosition", ctx)}
+}
+
+func (_c *OutboxRepoQueries_GetLastCommittedPosition_Call) Run(run func(ctx context.Context)) *OutboxRepoQueries_GetLastCommittedPosition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OutboxRepoQueries_GetLastCommittedPosition_Call) Return(_a0 int64, _a1 error) *OutboxRepoQueries_GetLastCommittedPosition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepoQueries_GetLastCommittedPosition_Call) RunAndReturn(run func(context.Context) (int64, error)) *OutboxRepoQueries_GetLastCommittedPosition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepoQueries creates a new instance of OutboxRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepoQueries {
+	mock := &OutboxRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,237 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PhoneVerificationRepoCommands is an autogenerated mock type for the PhoneVerificationRepoCommands type
+type PhoneVerificationRepoCommands struct {
+	mock.Mock
+}
+
+type PhoneVerificationRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PhoneVerificationRepoCommands) EXPECT() *PhoneVerificationRepoCommands_Expecter {
+	return &PhoneVerificationRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// DeleteVerification provides a mock function with given fields: ctx, userID
+func (_m *PhoneVerificationRepoCommands) DeleteVerification(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PhoneVerificationRepoCommands_DeleteVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVerification'
+type PhoneVerificationRepoCommands_DeleteVerification_Call struct {
+	*mock.Call
+}
+
+// DeleteVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PhoneVerificationRepoCommands_Expecter) DeleteVerification(ctx interface{}, userID interface{}) *PhoneVerificationRepoCommands_DeleteVerification_Call {
+	return &PhoneVerificationRepoCommands_DeleteVerification_Call{Call: _e.mock.On("DeleteVerification", ctx, userID)}
+}
+
+func (_c *PhoneVerificationRepoCommands_DeleteVerification_Call) Run(run func(ctx context.Context, userID string)) *PhoneVerificationRepoCommands_DeleteVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_DeleteVerification_Call) Return(_a0 error) *PhoneVerificationRepoCommands_DeleteVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_DeleteVerification_Call) RunAndReturn(run func(context.Context, string) error) *PhoneVerificationRepoCommands_DeleteVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVerification provides a mock function with given fields: ctx, userID
+func (_m *PhoneVerificationRepoCommands) GetVerification(ctx context.Context, userID string) (*domain.PhoneVerification, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerification")
+	}
+
+	var r0 *domain.PhoneVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.PhoneVerification, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PhoneVerification); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PhoneVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PhoneVerificationRepoCommands_GetVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerification'
+type PhoneVerificationRepoCommands_GetVerification_Call struct {
+	*mock.Call
+}
+
+// GetVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PhoneVerificationRepoCommands_Expecter) GetVerification(ctx interface{}, userID interface{}) *PhoneVerificationRepoCommands_GetVerification_Call {
+	return &PhoneVerificationRepoCommands_GetVerification_Call{Call: _e.mock.On("GetVerification", ctx, userID)}
+}
+
+func (_c *PhoneVerificationRepoCommands_GetVerification_Call) Run(run func(ctx context.Context, userID string)) *PhoneVerificationRepoCommands_GetVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_GetVerification_Call) Return(_a0 *domain.PhoneVerification, _a1 error) *PhoneVerificationRepoCommands_GetVerification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_GetVerification_Call) RunAndReturn(run func(context.Context, string) (*domain.PhoneVerification, error)) *PhoneVerificationRepoCommands_GetVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementAttempts provides a mock function with given fields: ctx, userID
+func (_m *PhoneVerificationRepoCommands) IncrementAttempts(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PhoneVerificationRepoCommands_IncrementAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementAttempts'
+type PhoneVerificationRepoCommands_IncrementAttempts_Call struct {
+	*mock.Call
+}
+
+// IncrementAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PhoneVerificationRepoCommands_Expecter) IncrementAttempts(ctx interface{}, userID interface{}) *PhoneVerificationRepoCommands_IncrementAttempts_Call {
+	return &PhoneVerificationRepoCommands_IncrementAttempts_Call{Call: _e.mock.On("IncrementAttempts", ctx, userID)}
+}
+
+func (_c *PhoneVerificationRepoCommands_IncrementAttempts_Call) Run(run func(ctx context.Context, userID string)) *PhoneVerificationRepoCommands_IncrementAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_IncrementAttempts_Call) Return(_a0 error) *PhoneVerificationRepoCommands_IncrementAttempts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_IncrementAttempts_Call) RunAndReturn(run func(context.Context, string) error) *PhoneVerificationRepoCommands_IncrementAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveVerification provides a mock function with given fields: ctx, verification
+func (_m *PhoneVerificationRepoCommands) SaveVerification(ctx context.Context, verification *domain.PhoneVerification) error {
+	ret := _m.Called(ctx, verification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.PhoneVerification) error); ok {
+		r0 = rf(ctx, verification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PhoneVerificationRepoCommands_SaveVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveVerification'
+type PhoneVerificationRepoCommands_SaveVerification_Call struct {
+	*mock.Call
+}
+
+// SaveVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - verification *domain.PhoneVerification
+func (_e *PhoneVerificationRepoCommands_Expecter) SaveVerification(ctx interface{}, verification interface{}) *PhoneVerificationRepoCommands_SaveVerification_Call {
+	return &PhoneVerificationRepoCommands_SaveVerification_Call{Call: _e.mock.On("SaveVerification", ctx, verification)}
+}
+
+func (_c *PhoneVerificationRepoCommands_SaveVerification_Call) Run(run func(ctx context.Context, verification *domain.PhoneVerification)) *PhoneVerificationRepoCommands_SaveVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.PhoneVerification))
+	})
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_SaveVerification_Call) Return(_a0 error) *PhoneVerificationRepoCommands_SaveVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PhoneVerificationRepoCommands_SaveVerification_Call) RunAndReturn(run func(context.Context, *domain.PhoneVerification) error) *PhoneVerificationRepoCommands_SaveVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPhoneVerificationRepoCommands creates a new instance of PhoneVerificationRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPhoneVerificationRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PhoneVerificationRepoCommands {
+	mock := &PhoneVerificationRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
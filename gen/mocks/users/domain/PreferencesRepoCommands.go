@@ -0,0 +1,134 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	jsontext "encoding/json/jsontext"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PreferencesRepoCommands is an autogenerated mock type for the PreferencesRepoCommands type
+type PreferencesRepoCommands struct {
+	mock.Mock
+}
+
+type PreferencesRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PreferencesRepoCommands) EXPECT() *PreferencesRepoCommands_Expecter {
+	return &PreferencesRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// DeletePreferences provides a mock function with given fields: ctx, userID, keys
+func (_m *PreferencesRepoCommands) DeletePreferences(ctx context.Context, userID string, keys []string) error {
+	ret := _m.Called(ctx, userID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PreferencesRepoCommands_DeletePreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePreferences'
+type PreferencesRepoCommands_DeletePreferences_Call struct {
+	*mock.Call
+}
+
+// DeletePreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - keys []string
+func (_e *PreferencesRepoCommands_Expecter) DeletePreferences(ctx interface{}, userID interface{}, keys interface{}) *PreferencesRepoCommands_DeletePreferences_Call {
+	return &PreferencesRepoCommands_DeletePreferences_Call{Call: _e.mock.On("DeletePreferences", ctx, userID, keys)}
+}
+
+func (_c *PreferencesRepoCommands_DeletePreferences_Call) Run(run func(ctx context.Context, userID string, keys []string)) *PreferencesRepoCommands_DeletePreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *PreferencesRepoCommands_DeletePreferences_Call) Return(_a0 error) *PreferencesRepoCommands_DeletePreferences_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PreferencesRepoCommands_DeletePreferences_Call) RunAndReturn(run func(context.Context, string, []string) error) *PreferencesRepoCommands_DeletePreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePreferences provides a mock function with given fields: ctx, userID, preferences
+func (_m *PreferencesRepoCommands) SavePreferences(ctx context.Context, userID string, preferences map[string]jsontext.Value) error {
+	ret := _m.Called(ctx, userID, preferences)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]jsontext.Value) error); ok {
+		r0 = rf(ctx, userID, preferences)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PreferencesRepoCommands_SavePreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePreferences'
+type PreferencesRepoCommands_SavePreferences_Call struct {
+	*mock.Call
+}
+
+// SavePreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - preferences map[string]jsontext.Value
+func (_e *PreferencesRepoCommands_Expecter) SavePreferences(ctx interface{}, userID interface{}, preferences interface{}) *PreferencesRepoCommands_SavePreferences_Call {
+	return &PreferencesRepoCommands_SavePreferences_Call{Call: _e.mock.On("SavePreferences", ctx, userID, preferences)}
+}
+
+func (_c *PreferencesRepoCommands_SavePreferences_Call) Run(run func(ctx context.Context, userID string, preferences map[string]jsontext.Value)) *PreferencesRepoCommands_SavePreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(map[string]jsontext.Value))
+	})
+	return _c
+}
+
+func (_c *PreferencesRepoCommands_SavePreferences_Call) Return(_a0 error) *PreferencesRepoCommands_SavePreferences_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PreferencesRepoCommands_SavePreferences_Call) RunAndReturn(run func(context.Context, string, map[string]jsontext.Value) error) *PreferencesRepoCommands_SavePreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPreferencesRepoCommands creates a new instance of PreferencesRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPreferencesRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PreferencesRepoCommands {
+	mock := &PreferencesRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
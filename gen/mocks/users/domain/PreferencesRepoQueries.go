@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	jsontext "encoding/json/jsontext"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PreferencesRepoQueries is an autogenerated mock type for the PreferencesRepoQueries type
+type PreferencesRepoQueries struct {
+	mock.Mock
+}
+
+type PreferencesRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PreferencesRepoQueries) EXPECT() *PreferencesRepoQueries_Expecter {
+	return &PreferencesRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// GetPreferences provides a mock function with given fields: ctx, userID
+func (_m *PreferencesRepoQueries) GetPreferences(ctx context.Context, userID string) (map[string]jsontext.Value, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreferences")
+	}
+
+	var r0 map[string]jsontext.Value
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]jsontext.Value, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]jsontext.Value); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]jsontext.Value)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreferencesRepoQueries_GetPreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreferences'
+type PreferencesRepoQueries_GetPreferences_Call struct {
+	*mock.Call
+}
+
+// GetPreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PreferencesRepoQueries_Expecter) GetPreferences(ctx interface{}, userID interface{}) *PreferencesRepoQueries_GetPreferences_Call {
+	return &PreferencesRepoQueries_GetPreferences_Call{Call: _e.mock.On("GetPreferences", ctx, userID)}
+}
+
+func (_c *PreferencesRepoQueries_GetPreferences_Call) Run(run func(ctx context.Context, userID string)) *PreferencesRepoQueries_GetPreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PreferencesRepoQueries_GetPreferences_Call) Return(_a0 map[string]jsontext.Value, _a1 error) *PreferencesRepoQueries_GetPreferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PreferencesRepoQueries_GetPreferences_Call) RunAndReturn(run func(context.Context, string) (map[string]jsontext.Value, error)) *PreferencesRepoQueries_GetPreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPreferencesRepoQueries creates a new instance of PreferencesRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPreferencesRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PreferencesRepoQueries {
+	mock := &PreferencesRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,245 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RelationshipRepoCommands is an autogenerated mock type for the RelationshipRepoCommands type
+type RelationshipRepoCommands struct {
+	mock.Mock
+}
+
+type RelationshipRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RelationshipRepoCommands) EXPECT() *RelationshipRepoCommands_Expecter {
+	return &RelationshipRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// Block provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipRepoCommands) Block(ctx context.Context, userID string, targetID string) (bool, bool, error) {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 bool
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, bool, error)); ok {
+		return rf(ctx, userID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, userID, targetID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, userID, targetID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RelationshipRepoCommands_Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Block'
+type RelationshipRepoCommands_Block_Call struct {
+	*mock.Call
+}
+
+// Block is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipRepoCommands_Expecter) Block(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipRepoCommands_Block_Call {
+	return &RelationshipRepoCommands_Block_Call{Call: _e.mock.On("Block", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipRepoCommands_Block_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipRepoCommands_Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Block_Call) Return(wasFollowing bool, wasFollowed bool, err error) *RelationshipRepoCommands_Block_Call {
+	_c.Call.Return(wasFollowing, wasFollowed, err)
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Block_Call) RunAndReturn(run func(context.Context, string, string) (bool, bool, error)) *RelationshipRepoCommands_Block_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Follow provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipRepoCommands) Follow(ctx context.Context, userID string, targetID string) error {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Follow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelationshipRepoCommands_Follow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Follow'
+type RelationshipRepoCommands_Follow_Call struct {
+	*mock.Call
+}
+
+// Follow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipRepoCommands_Expecter) Follow(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipRepoCommands_Follow_Call {
+	return &RelationshipRepoCommands_Follow_Call{Call: _e.mock.On("Follow", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipRepoCommands_Follow_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipRepoCommands_Follow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Follow_Call) Return(_a0 error) *RelationshipRepoCommands_Follow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Follow_Call) RunAndReturn(run func(context.Context, string, string) error) *RelationshipRepoCommands_Follow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unblock provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipRepoCommands) Unblock(ctx context.Context, userID string, targetID string) error {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unblock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelationshipRepoCommands_Unblock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unblock'
+type RelationshipRepoCommands_Unblock_Call struct {
+	*mock.Call
+}
+
+// Unblock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipRepoCommands_Expecter) Unblock(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipRepoCommands_Unblock_Call {
+	return &RelationshipRepoCommands_Unblock_Call{Call: _e.mock.On("Unblock", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipRepoCommands_Unblock_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipRepoCommands_Unblock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Unblock_Call) Return(_a0 error) *RelationshipRepoCommands_Unblock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Unblock_Call) RunAndReturn(run func(context.Context, string, string) error) *RelationshipRepoCommands_Unblock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unfollow provides a mock function with given fields: ctx, userID, targetID
+func (_m *RelationshipRepoCommands) Unfollow(ctx context.Context, userID string, targetID string) error {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unfollow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RelationshipRepoCommands_Unfollow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unfollow'
+type RelationshipRepoCommands_Unfollow_Call struct {
+	*mock.Call
+}
+
+// Unfollow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - targetID string
+func (_e *RelationshipRepoCommands_Expecter) Unfollow(ctx interface{}, userID interface{}, targetID interface{}) *RelationshipRepoCommands_Unfollow_Call {
+	return &RelationshipRepoCommands_Unfollow_Call{Call: _e.mock.On("Unfollow", ctx, userID, targetID)}
+}
+
+func (_c *RelationshipRepoCommands_Unfollow_Call) Run(run func(ctx context.Context, userID string, targetID string)) *RelationshipRepoCommands_Unfollow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Unfollow_Call) Return(_a0 error) *RelationshipRepoCommands_Unfollow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RelationshipRepoCommands_Unfollow_Call) RunAndReturn(run func(context.Context, string, string) error) *RelationshipRepoCommands_Unfollow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRelationshipRepoCommands creates a new instance of RelationshipRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRelationshipRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RelationshipRepoCommands {
+	mock := &RelationshipRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,225 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RelationshipRepoQueries is an autogenerated mock type for the RelationshipRepoQueries type
+type RelationshipRepoQueries struct {
+	mock.Mock
+}
+
+type RelationshipRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RelationshipRepoQueries) EXPECT() *RelationshipRepoQueries_Expecter {
+	return &RelationshipRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// ListBlocked provides a mock function with given fields: ctx, userID, cursorUserID, cursorSince, limit
+func (_m *RelationshipRepoQueries) ListBlocked(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error) {
+	ret := _m.Called(ctx, userID, cursorUserID, cursorSince, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBlocked")
+	}
+
+	var r0 []*domain.RelatedUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) ([]*domain.RelatedUser, error)); ok {
+		return rf(ctx, userID, cursorUserID, cursorSince, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) []*domain.RelatedUser); ok {
+		r0 = rf(ctx, userID, cursorUserID, cursorSince, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *time.Time, int32) error); ok {
+		r1 = rf(ctx, userID, cursorUserID, cursorSince, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RelationshipRepoQueries_ListBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBlocked'
+type RelationshipRepoQueries_ListBlocked_Call struct {
+	*mock.Call
+}
+
+// ListBlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - cursorUserID string
+//   - cursorSince *time.Time
+//   - limit int32
+func (_e *RelationshipRepoQueries_Expecter) ListBlocked(ctx interface{}, userID interface{}, cursorUserID interface{}, cursorSince interface{}, limit interface{}) *RelationshipRepoQueries_ListBlocked_Call {
+	return &RelationshipRepoQueries_ListBlocked_Call{Call: _e.mock.On("ListBlocked", ctx, userID, cursorUserID, cursorSince, limit)}
+}
+
+func (_c *RelationshipRepoQueries_ListBlocked_Call) Run(run func(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32)) *RelationshipRepoQueries_ListBlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*time.Time), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *RelationshipRepoQueries_ListBlocked_Call) Return(_a0 []*domain.RelatedUser, _a1 error) *RelationshipRepoQueries_ListBlocked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RelationshipRepoQueries_ListBlocked_Call) RunAndReturn(run func(context.Context, string, string, *time.Time, int32) ([]*domain.RelatedUser, error)) *RelationshipRepoQueries_ListBlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFollowers provides a mock function with given fields: ctx, userID, cursorUserID, cursorSince, limit
+func (_m *RelationshipRepoQueries) ListFollowers(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error) {
+	ret := _m.Called(ctx, userID, cursorUserID, cursorSince, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFollowers")
+	}
+
+	var r0 []*domain.RelatedUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) ([]*domain.RelatedUser, error)); ok {
+		return rf(ctx, userID, cursorUserID, cursorSince, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) []*domain.RelatedUser); ok {
+		r0 = rf(ctx, userID, cursorUserID, cursorSince, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *time.Time, int32) error); ok {
+		r1 = rf(ctx, userID, cursorUserID, cursorSince, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RelationshipRepoQueries_ListFollowers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFollowers'
+type RelationshipRepoQueries_ListFollowers_Call struct {
+	*mock.Call
+}
+
+// ListFollowers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - cursorUserID string
+//   - cursorSince *time.Time
+//   - limit int32
+func (_e *RelationshipRepoQueries_Expecter) ListFollowers(ctx interface{}, userID interface{}, cursorUserID interface{}, cursorSince interface{}, limit interface{}) *RelationshipRepoQueries_ListFollowers_Call {
+	return &RelationshipRepoQueries_ListFollowers_Call{Call: _e.mock.On("ListFollowers", ctx, userID, cursorUserID, cursorSince, limit)}
+}
+
+func (_c *RelationshipRepoQueries_ListFollowers_Call) Run(run func(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32)) *RelationshipRepoQueries_ListFollowers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*time.Time), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *RelationshipRepoQueries_ListFollowers_Call) Return(_a0 []*domain.RelatedUser, _a1 error) *RelationshipRepoQueries_ListFollowers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RelationshipRepoQueries_ListFollowers_Call) RunAndReturn(run func(context.Context, string, string, *time.Time, int32) ([]*domain.RelatedUser, error)) *RelationshipRepoQueries_ListFollowers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFollowing provides a mock function with given fields: ctx, userID, cursorUserID, cursorSince, limit
+func (_m *RelationshipRepoQueries) ListFollowing(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error) {
+	ret := _m.Called(ctx, userID, cursorUserID, cursorSince, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFollowing")
+	}
+
+	var r0 []*domain.RelatedUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) ([]*domain.RelatedUser, error)); ok {
+		return rf(ctx, userID, cursorUserID, cursorSince, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time, int32) []*domain.RelatedUser); ok {
+		r0 = rf(ctx, userID, cursorUserID, cursorSince, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *time.Time, int32) error); ok {
+		r1 = rf(ctx, userID, cursorUserID, cursorSince, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RelationshipRepoQueries_ListFollowing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFollowing'
+type RelationshipRepoQueries_ListFollowing_Call struct {
+	*mock.Call
+}
+
+// ListFollowing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - cursorUserID string
+//   - cursorSince *time.Time
+//   - limit int32
+func (_e *RelationshipRepoQueries_Expecter) ListFollowing(ctx interface{}, userID interface{}, cursorUserID interface{}, cursorSince interface{}, limit interface{}) *RelationshipRepoQueries_ListFollowing_Call {
+	return &RelationshipRepoQueries_ListFollowing_Call{Call: _e.mock.On("ListFollowing", ctx, userID, cursorUserID, cursorSince, limit)}
+}
+
+func (_c *RelationshipRepoQueries_ListFollowing_Call) Run(run func(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32)) *RelationshipRepoQueries_ListFollowing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*time.Time), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *RelationshipRepoQueries_ListFollowing_Call) Return(_a0 []*domain.RelatedUser, _a1 error) *RelationshipRepoQueries_ListFollowing_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RelationshipRepoQueries_ListFollowing_Call) RunAndReturn(run func(context.Context, string, string, *time.Time, int32) ([]*domain.RelatedUser, error)) *RelationshipRepoQueries_ListFollowing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRelationshipRepoQueries creates a new instance of RelationshipRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRelationshipRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RelationshipRepoQueries {
+	mock := &RelationshipRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
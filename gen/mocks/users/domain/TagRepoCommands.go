@@ -0,0 +1,157 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TagRepoCommands is an autogenerated mock type for the TagRepoCommands type
+type TagRepoCommands struct {
+	mock.Mock
+}
+
+type TagRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TagRepoCommands) EXPECT() *TagRepoCommands_Expecter {
+	return &TagRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// TagUsers provides a mock function with given fields: ctx, userIDs, tags
+func (_m *TagRepoCommands) TagUsers(ctx context.Context, userIDs []string, tags []string) ([]domain.UserTag, error) {
+	ret := _m.Called(ctx, userIDs, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagUsers")
+	}
+
+	var r0 []domain.UserTag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) ([]domain.UserTag, error)); ok {
+		return rf(ctx, userIDs, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) []domain.UserTag); ok {
+		r0 = rf(ctx, userIDs, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserTag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []string) error); ok {
+		r1 = rf(ctx, userIDs, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepoCommands_TagUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagUsers'
+type TagRepoCommands_TagUsers_Call struct {
+	*mock.Call
+}
+
+// TagUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+//   - tags []string
+func (_e *TagRepoCommands_Expecter) TagUsers(ctx interface{}, userIDs interface{}, tags interface{}) *TagRepoCommands_TagUsers_Call {
+	return &TagRepoCommands_TagUsers_Call{Call: _e.mock.On("TagUsers", ctx, userIDs, tags)}
+}
+
+func (_c *TagRepoCommands_TagUsers_Call) Run(run func(ctx context.Context, userIDs []string, tags []string)) *TagRepoCommands_TagUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *TagRepoCommands_TagUsers_Call) Return(_a0 []domain.UserTag, _a1 error) *TagRepoCommands_TagUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepoCommands_TagUsers_Call) RunAndReturn(run func(context.Context, []string, []string) ([]domain.UserTag, error)) *TagRepoCommands_TagUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UntagUsers provides a mock function with given fields: ctx, userIDs, tags
+func (_m *TagRepoCommands) UntagUsers(ctx context.Context, userIDs []string, tags []string) ([]domain.UserTag, error) {
+	ret := _m.Called(ctx, userIDs, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UntagUsers")
+	}
+
+	var r0 []domain.UserTag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) ([]domain.UserTag, error)); ok {
+		return rf(ctx, userIDs, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) []domain.UserTag); ok {
+		r0 = rf(ctx, userIDs, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserTag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []string) error); ok {
+		r1 = rf(ctx, userIDs, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepoCommands_UntagUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UntagUsers'
+type TagRepoCommands_UntagUsers_Call struct {
+	*mock.Call
+}
+
+// UntagUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+//   - tags []string
+func (_e *TagRepoCommands_Expecter) UntagUsers(ctx interface{}, userIDs interface{}, tags interface{}) *TagRepoCommands_UntagUsers_Call {
+	return &TagRepoCommands_UntagUsers_Call{Call: _e.mock.On("UntagUsers", ctx, userIDs, tags)}
+}
+
+func (_c *TagRepoCommands_UntagUsers_Call) Run(run func(ctx context.Context, userIDs []string, tags []string)) *TagRepoCommands_UntagUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *TagRepoCommands_UntagUsers_Call) Return(_a0 []domain.UserTag, _a1 error) *TagRepoCommands_UntagUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepoCommands_UntagUsers_Call) RunAndReturn(run func(context.Context, []string, []string) ([]domain.UserTag, error)) *TagRepoCommands_UntagUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTagRepoCommands creates a new instance of TagRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagRepoCommands {
+	mock := &TagRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TenantRepoQueries is an autogenerated mock type for the TenantRepoQueries type
+type TenantRepoQueries struct {
+	mock.Mock
+}
+
+type TenantRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TenantRepoQueries) EXPECT() *TenantRepoQueries_Expecter {
+	return &TenantRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// GetTenantByID provides a mock function with given fields: ctx, tenantID
+func (_m *TenantRepoQueries) GetTenantByID(ctx context.Context, tenantID string) (*domain.Tenant, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantByID")
+	}
+
+	var r0 *domain.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Tenant, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Tenant); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantRepoQueries_GetTenantByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantByID'
+type TenantRepoQueries_GetTenantByID_Call struct {
+	*mock.Call
+}
+
+// GetTenantByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID string
+func (_e *TenantRepoQueries_Expecter) GetTenantByID(ctx interface{}, tenantID interface{}) *TenantRepoQueries_GetTenantByID_Call {
+	return &TenantRepoQueries_GetTenantByID_Call{Call: _e.mock.On("GetTenantByID", ctx, tenantID)}
+}
+
+func (_c *TenantRepoQueries_GetTenantByID_Call) Run(run func(ctx context.Context, tenantID string)) *TenantRepoQueries_GetTenantByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantRepoQueries_GetTenantByID_Call) Return(_a0 *domain.Tenant, _a1 error) *TenantRepoQueries_GetTenantByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TenantRepoQueries_GetTenantByID_Call) RunAndReturn(run func(context.Context, string) (*domain.Tenant, error)) *TenantRepoQueries_GetTenantByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantBySlug provides a mock function with given fields: ctx, slug
+func (_m *TenantRepoQueries) GetTenantBySlug(ctx context.Context, slug string) (*domain.Tenant, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantBySlug")
+	}
+
+	var r0 *domain.Tenant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Tenant, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Tenant); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Tenant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TenantRepoQueries_GetTenantBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantBySlug'
+type TenantRepoQueries_GetTenantBySlug_Call struct {
+	*mock.Call
+}
+
+// GetTenantBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *TenantRepoQueries_Expecter) GetTenantBySlug(ctx interface{}, slug interface{}) *TenantRepoQueries_GetTenantBySlug_Call {
+	return &TenantRepoQueries_GetTenantBySlug_Call{Call: _e.mock.On("GetTenantBySlug", ctx, slug)}
+}
+
+func (_c *TenantRepoQueries_GetTenantBySlug_Call) Run(run func(ctx context.Context, slug string)) *TenantRepoQueries_GetTenantBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TenantRepoQueries_GetTenantBySlug_Call) Return(_a0 *domain.Tenant, _a1 error) *TenantRepoQueries_GetTenantBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TenantRepoQueries_GetTenantBySlug_Call) RunAndReturn(run func(context.Context, string) (*domain.Tenant, error)) *TenantRepoQueries_GetTenantBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTenantRepoQueries creates a new instance of TenantRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTenantRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TenantRepoQueries {
+	mock := &TenantRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
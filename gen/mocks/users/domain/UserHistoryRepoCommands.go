@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UserHistoryRepoCommands is an autogenerated mock type for the UserHistoryRepoCommands type
+type UserHistoryRepoCommands struct {
+	mock.Mock
+}
+
+type UserHistoryRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserHistoryRepoCommands) EXPECT() *UserHistoryRepoCommands_Expecter {
+	return &UserHistoryRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// PurgeDeletedUsersHistory provides a mock function with given fields: ctx, deletedBefore
+func (_m *UserHistoryRepoCommands) PurgeDeletedUsersHistory(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedUsersHistory")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedUsersHistory'
+type UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedUsersHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+func (_e *UserHistoryRepoCommands_Expecter) PurgeDeletedUsersHistory(ctx interface{}, deletedBefore interface{}) *UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call {
+	return &UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call{Call: _e.mock.On("PurgeDeletedUsersHistory", ctx, deletedBefore)}
+}
+
+func (_c *UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call) Run(run func(ctx context.Context, deletedBefore time.Time)) *UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call) Return(_a0 int64, _a1 error) *UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *UserHistoryRepoCommands_PurgeDeletedUsersHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserHistoryRepoCommands creates a new instance of UserHistoryRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserHistoryRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserHistoryRepoCommands {
+	mock := &UserHistoryRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
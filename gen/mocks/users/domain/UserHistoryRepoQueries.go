@@ -0,0 +1,161 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UserHistoryRepoQueries is an autogenerated mock type for the UserHistoryRepoQueries type
+type UserHistoryRepoQueries struct {
+	mock.Mock
+}
+
+type UserHistoryRepoQueries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserHistoryRepoQueries) EXPECT() *UserHistoryRepoQueries_Expecter {
+	return &UserHistoryRepoQueries_Expecter{mock: &_m.Mock}
+}
+
+// GetUserVersionAt provides a mock function with given fields: ctx, userID, at
+func (_m *UserHistoryRepoQueries) GetUserVersionAt(ctx context.Context, userID string, at time.Time) (*domain.UserVersion, error) {
+	ret := _m.Called(ctx, userID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserVersionAt")
+	}
+
+	var r0 *domain.UserVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*domain.UserVersion, error)); ok {
+		return rf(ctx, userID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *domain.UserVersion); ok {
+		r0 = rf(ctx, userID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserHistoryRepoQueries_GetUserVersionAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserVersionAt'
+type UserHistoryRepoQueries_GetUserVersionAt_Call struct {
+	*mock.Call
+}
+
+// GetUserVersionAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - at time.Time
+func (_e *UserHistoryRepoQueries_Expecter) GetUserVersionAt(ctx interface{}, userID interface{}, at interface{}) *UserHistoryRepoQueries_GetUserVersionAt_Call {
+	return &UserHistoryRepoQueries_GetUserVersionAt_Call{Call: _e.mock.On("GetUserVersionAt", ctx, userID, at)}
+}
+
+func (_c *UserHistoryRepoQueries_GetUserVersionAt_Call) Run(run func(ctx context.Context, userID string, at time.Time)) *UserHistoryRepoQueries_GetUserVersionAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserHistoryRepoQueries_GetUserVersionAt_Call) Return(_a0 *domain.UserVersion, _a1 error) *UserHistoryRepoQueries_GetUserVersionAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserHistoryRepoQueries_GetUserVersionAt_Call) RunAndReturn(run func(context.Context, string, time.Time) (*domain.UserVersion, error)) *UserHistoryRepoQueries_GetUserVersionAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserVersions provides a mock function with given fields: ctx, userID, cursorVersion, cursorRecordedAt, limit
+func (_m *UserHistoryRepoQueries) ListUserVersions(ctx context.Context, userID string, cursorVersion int64, cursorRecordedAt *time.Time, limit int32) ([]*domain.UserVersion, error) {
+	ret := _m.Called(ctx, userID, cursorVersion, cursorRecordedAt, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserVersions")
+	}
+
+	var r0 []*domain.UserVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, *time.Time, int32) ([]*domain.UserVersion, error)); ok {
+		return rf(ctx, userID, cursorVersion, cursorRecordedAt, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, *time.Time, int32) []*domain.UserVersion); ok {
+		r0 = rf(ctx, userID, cursorVersion, cursorRecordedAt, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.UserVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, *time.Time, int32) error); ok {
+		r1 = rf(ctx, userID, cursorVersion, cursorRecordedAt, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserHistoryRepoQueries_ListUserVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserVersions'
+type UserHistoryRepoQueries_ListUserVersions_Call struct {
+	*mock.Call
+}
+
+// ListUserVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - cursorVersion int64
+//   - cursorRecordedAt *time.Time
+//   - limit int32
+func (_e *UserHistoryRepoQueries_Expecter) ListUserVersions(ctx interface{}, userID interface{}, cursorVersion interface{}, cursorRecordedAt interface{}, limit interface{}) *UserHistoryRepoQueries_ListUserVersions_Call {
+	return &UserHistoryRepoQueries_ListUserVersions_Call{Call: _e.mock.On("ListUserVersions", ctx, userID, cursorVersion, cursorRecordedAt, limit)}
+}
+
+func (_c *UserHistoryRepoQueries_ListUserVersions_Call) Run(run func(ctx context.Context, userID string, cursorVersion int64, cursorRecordedAt *time.Time, limit int32)) *UserHistoryRepoQueries_ListUserVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(*time.Time), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *UserHistoryRepoQueries_ListUserVersions_Call) Return(_a0 []*domain.UserVersion, _a1 error) *UserHistoryRepoQueries_ListUserVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserHistoryRepoQueries_ListUserVersions_Call) RunAndReturn(run func(context.Context, string, int64, *time.Time, int32) ([]*domain.UserVersion, error)) *UserHistoryRepoQueries_ListUserVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserHistoryRepoQueries creates a new instance of UserHistoryRepoQueries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserHistoryRepoQueries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserHistoryRepoQueries {
+	mock := &UserHistoryRepoQueries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
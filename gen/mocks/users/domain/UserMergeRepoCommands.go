@@ -0,0 +1,154 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "users/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserMergeRepoCommands is an autogenerated mock type for the UserMergeRepoCommands type
+type UserMergeRepoCommands struct {
+	mock.Mock
+}
+
+type UserMergeRepoCommands_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserMergeRepoCommands) EXPECT() *UserMergeRepoCommands_Expecter {
+	return &UserMergeRepoCommands_Expecter{mock: &_m.Mock}
+}
+
+// LockUsersForMerge provides a mock function with given fields: ctx, sourceID, targetID
+func (_m *UserMergeRepoCommands) LockUsersForMerge(ctx context.Context, sourceID string, targetID string) (*domain.User, *domain.User, error) {
+	ret := _m.Called(ctx, sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockUsersForMerge")
+	}
+
+	var r0 *domain.User
+	var r1 *domain.User
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, *domain.User, error)); ok {
+		return rf(ctx, sourceID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = rf(ctx, sourceID, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *domain.User); ok {
+		r1 = rf(ctx, sourceID, targetID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, sourceID, targetID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UserMergeRepoCommands_LockUsersForMerge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockUsersForMerge'
+type UserMergeRepoCommands_LockUsersForMerge_Call struct {
+	*mock.Call
+}
+
+// LockUsersForMerge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceID string
+//   - targetID string
+func (_e *UserMergeRepoCommands_Expecter) LockUsersForMerge(ctx interface{}, sourceID interface{}, targetID interface{}) *UserMergeRepoCommands_LockUsersForMerge_Call {
+	return &UserMergeRepoCommands_LockUsersForMerge_Call{Call: _e.mock.On("LockUsersForMerge", ctx, sourceID, targetID)}
+}
+
+func (_c *UserMergeRepoCommands_LockUsersForMerge_Call) Run(run func(ctx context.Context, sourceID string, targetID string)) *UserMergeRepoCommands_LockUsersForMerge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserMergeRepoCommands_LockUsersForMerge_Call) Return(source *domain.User, target *domain.User, err error) *UserMergeRepoCommands_LockUsersForMerge_Call {
+	_c.Call.Return(source, target, err)
+	return _c
+}
+
+func (_c *UserMergeRepoCommands_LockUsersForMerge_Call) RunAndReturn(run func(context.Context, string, string) (*domain.User, *domain.User, error)) *UserMergeRepoCommands_LockUsersForMerge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeUsers provides a mock function with given fields: ctx, sourceID, target
+func (_m *UserMergeRepoCommands) MergeUsers(ctx context.Context, sourceID string, target *domain.User) error {
+	ret := _m.Called(ctx, sourceID, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.User) error); ok {
+		r0 = rf(ctx, sourceID, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserMergeRepoCommands_MergeUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeUsers'
+type UserMergeRepoCommands_MergeUsers_Call struct {
+	*mock.Call
+}
+
+// MergeUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceID string
+//   - target *domain.User
+func (_e *UserMergeRepoCommands_Expecter) MergeUsers(ctx interface{}, sourceID interface{}, target interface{}) *UserMergeRepoCommands_MergeUsers_Call {
+	return &UserMergeRepoCommands_MergeUsers_Call{Call: _e.mock.On("MergeUsers", ctx, sourceID, target)}
+}
+
+func (_c *UserMergeRepoCommands_MergeUsers_Call) Run(run func(ctx context.Context, sourceID string, target *domain.User)) *UserMergeRepoCommands_MergeUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*domain.User))
+	})
+	return _c
+}
+
+func (_c *UserMergeRepoCommands_MergeUsers_Call) Return(_a0 error) *UserMergeRepoCommands_MergeUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserMergeRepoCommands_MergeUsers_Call) RunAndReturn(run func(context.Context, string, *domain.User) error) *UserMergeRepoCommands_MergeUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserMergeRepoCommands creates a new instance of UserMergeRepoCommands. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserMergeRepoCommands(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserMergeRepoCommands {
+	mock := &UserMergeRepoCommands{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
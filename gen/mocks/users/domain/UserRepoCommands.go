@@ -69,6 +69,54 @@ func (_c *UserRepoCommands_DeleteUser_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// MarkPhoneVerified provides a mock function with given fields: ctx, userID, phone
+func (_m *UserRepoCommands) MarkPhoneVerified(ctx context.Context, userID string, phone string) error {
+	ret := _m.Called(ctx, userID, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPhoneVerified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, phone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepoCommands_MarkPhoneVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkPhoneVerified'
+type UserRepoCommands_MarkPhoneVerified_Call struct {
+	*mock.Call
+}
+
+// MarkPhoneVerified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - phone string
+func (_e *UserRepoCommands_Expecter) MarkPhoneVerified(ctx interface{}, userID interface{}, phone interface{}) *UserRepoCommands_MarkPhoneVerified_Call {
+	return &UserRepoCommands_MarkPhoneVerified_Call{Call: _e.mock.On("MarkPhoneVerified", ctx, userID, phone)}
+}
+
+func (_c *UserRepoCommands_MarkPhoneVerified_Call) Run(run func(ctx context.Context, userID string, phone string)) *UserRepoCommands_MarkPhoneVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepoCommands_MarkPhoneVerified_Call) Return(_a0 error) *UserRepoCommands_MarkPhoneVerified_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepoCommands_MarkPhoneVerified_Call) RunAndReturn(run func(context.Context, string, string) error) *UserRepoCommands_MarkPhoneVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveUser provides a mock function with given fields: ctx, user
 func (_m *UserRepoCommands) SaveUser(ctx context.Context, user *domain.User) (string, error) {
 	ret := _m.Called(ctx, user)
@@ -126,22 +174,93 @@ func (_c *UserRepoCommands_SaveUser_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpdateAvatar provides a mock function with given fields: ctx, userID, key, url
+func (_m *UserRepoCommands) UpdateAvatar(ctx context.Context, userID string, key string, url string) (string, error) {
+	ret := _m.Called(ctx, userID, key, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAvatar")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, userID, key, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, userID, key, url)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, key, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepoCommands_UpdateAvatar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAvatar'
+type UserRepoCommands_UpdateAvatar_Call struct {
+	*mock.Call
+}
+
+// UpdateAvatar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - key string
+//   - url string
+func (_e *UserRepoCommands_Expecter) UpdateAvatar(ctx interface{}, userID interface{}, key interface{}, url interface{}) *UserRepoCommands_UpdateAvatar_Call {
+	return &UserRepoCommands_UpdateAvatar_Call{Call: _e.mock.On("UpdateAvatar", ctx, userID, key, url)}
+}
+
+func (_c *UserRepoCommands_UpdateAvatar_Call) Run(run func(ctx context.Context, userID string, key string, url string)) *UserRepoCommands_UpdateAvatar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepoCommands_UpdateAvatar_Call) Return(previousKey string, err error) *UserRepoCommands_UpdateAvatar_Call {
+	_c.Call.Return(previousKey, err)
+	return _c
+}
+
+func (_c *UserRepoCommands_UpdateAvatar_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *UserRepoCommands_UpdateAvatar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUser provides a mock function with given fields: ctx, user
-func (_m *UserRepoCommands) UpdateUser(ctx context.Context, user *domain.User) error {
+func (_m *UserRepoCommands) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) (*domain.User, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) *domain.User); ok {
 		r0 = rf(ctx, user)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UserRepoCommands_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
@@ -163,12 +282,12 @@ func (_c *UserRepoCommands_UpdateUser_Call) Run(run func(ctx context.Context, us
 	return _c
 }
 
-func (_c *UserRepoCommands_UpdateUser_Call) Return(_a0 error) *UserRepoCommands_UpdateUser_Call {
-	_c.Call.Return(_a0)
+func (_c *UserRepoCommands_UpdateUser_Call) Return(previous *domain.User, err error) *UserRepoCommands_UpdateUser_Call {
+	_c.Call.Return(previous, err)
 	return _c
 }
 
-func (_c *UserRepoCommands_UpdateUser_Call) RunAndReturn(run func(context.Context, *domain.User) error) *UserRepoCommands_UpdateUser_Call {
+func (_c *UserRepoCommands_UpdateUser_Call) RunAndReturn(run func(context.Context, *domain.User) (*domain.User, error)) *UserRepoCommands_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
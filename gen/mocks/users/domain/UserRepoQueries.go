@@ -24,9 +24,175 @@ func (_m *UserRepoQueries) EXPECT() *UserRepoQueries_Expecter {
 	return &UserRepoQueries_Expecter{mock: &_m.Mock}
 }
 
-// GetUser provides a mock function with given fields: ctx, userID
-func (_m *UserRepoQueries) GetUser(ctx context.Context, userID string) (*domain.User, error) {
-	ret := _m.Called(ctx, userID)
+// BatchGetUsers provides a mock function with given fields: ctx, userIDs
+func (_m *UserRepoQueries) BatchGetUsers(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchGetUsers")
+	}
+
+	var r0 []*domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*domain.User, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*domain.User); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepoQueries_BatchGetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGetUsers'
+type UserRepoQueries_BatchGetUsers_Call struct {
+	*mock.Call
+}
+
+// BatchGetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *UserRepoQueries_Expecter) BatchGetUsers(ctx interface{}, userIDs interface{}) *UserRepoQueries_BatchGetUsers_Call {
+	return &UserRepoQueries_BatchGetUsers_Call{Call: _e.mock.On("BatchGetUsers", ctx, userIDs)}
+}
+
+func (_c *UserRepoQueries_BatchGetUsers_Call) Run(run func(ctx context.Context, userIDs []string)) *UserRepoQueries_BatchGetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *UserRepoQueries_BatchGetUsers_Call) Return(_a0 []*domain.User, _a1 error) *UserRepoQueries_BatchGetUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepoQueries_BatchGetUsers_Call) RunAndReturn(run func(context.Context, []string) ([]*domain.User, error)) *UserRepoQueries_BatchGetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUsers provides a mock function with given fields: ctx, filters, estimate
+func (_m *UserRepoQueries) CountUsers(ctx context.Context, filters domain.UserSearchFilters, estimate bool) (int64, error) {
+	ret := _m.Called(ctx, filters, estimate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserSearchFilters, bool) (int64, error)); ok {
+		return rf(ctx, filters, estimate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserSearchFilters, bool) int64); ok {
+		r0 = rf(ctx, filters, estimate)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserSearchFilters, bool) error); ok {
+		r1 = rf(ctx, filters, estimate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepoQueries_CountUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsers'
+type UserRepoQueries_CountUsers_Call struct {
+	*mock.Call
+}
+
+// CountUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters domain.UserSearchFilters
+//   - estimate bool
+func (_e *UserRepoQueries_Expecter) CountUsers(ctx interface{}, filters interface{}, estimate interface{}) *UserRepoQueries_CountUsers_Call {
+	return &UserRepoQueries_CountUsers_Call{Call: _e.mock.On("CountUsers", ctx, filters, estimate)}
+}
+
+func (_c *UserRepoQueries_CountUsers_Call) Run(run func(ctx context.Context, filters domain.UserSearchFilters, estimate bool)) *UserRepoQueries_CountUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserSearchFilters), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *UserRepoQueries_CountUsers_Call) Return(_a0 int64, _a1 error) *UserRepoQueries_CountUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepoQueries_CountUsers_Call) RunAndReturn(run func(context.Context, domain.UserSearchFilters, bool) (int64, error)) *UserRepoQueries_CountUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportUsers provides a mock function with given fields: ctx, filters, batchSize, fn
+func (_m *UserRepoQueries) ExportUsers(ctx context.Context, filters domain.UserSearchFilters, batchSize int, fn func(*domain.User) error) error {
+	ret := _m.Called(ctx, filters, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserSearchFilters, int, func(*domain.User) error) error); ok {
+		r0 = rf(ctx, filters, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepoQueries_ExportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUsers'
+type UserRepoQueries_ExportUsers_Call struct {
+	*mock.Call
+}
+
+// ExportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters domain.UserSearchFilters
+//   - batchSize int
+//   - fn func(*domain.User) error
+func (_e *UserRepoQueries_Expecter) ExportUsers(ctx interface{}, filters interface{}, batchSize interface{}, fn interface{}) *UserRepoQueries_ExportUsers_Call {
+	return &UserRepoQueries_ExportUsers_Call{Call: _e.mock.On("ExportUsers", ctx, filters, batchSize, fn)}
+}
+
+func (_c *UserRepoQueries_ExportUsers_Call) Run(run func(ctx context.Context, filters domain.UserSearchFilters, batchSize int, fn func(*domain.User) error)) *UserRepoQueries_ExportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserSearchFilters), args[2].(int), args[3].(func(*domain.User) error))
+	})
+	return _c
+}
+
+func (_c *UserRepoQueries_ExportUsers_Call) Return(_a0 error) *UserRepoQueries_ExportUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepoQueries_ExportUsers_Call) RunAndReturn(run func(context.Context, domain.UserSearchFilters, int, func(*domain.User) error) error) *UserRepoQueries_ExportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID, fields
+func (_m *UserRepoQueries) GetUser(ctx context.Context, userID string, fields []string) (*domain.User, error) {
+	ret := _m.Called(ctx, userID, fields)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUser")
@@ -34,19 +200,19 @@ func (_m *UserRepoQueries) GetUser(ctx context.Context, userID string) (*domain.
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*domain.User, error)); ok {
+		return rf(ctx, userID, fields)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *domain.User); ok {
+		r0 = rf(ctx, userID, fields)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +228,14 @@ type UserRepoQueries_GetUser_Call struct {
 // GetUser is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *UserRepoQueries_Expecter) GetUser(ctx interface{}, userID interface{}) *UserRepoQueries_GetUser_Call {
-	return &UserRepoQueries_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+//   - fields []string
+func (_e *UserRepoQueries_Expecter) GetUser(ctx interface{}, userID interface{}, fields interface{}) *UserRepoQueries_GetUser_Call {
+	return &UserRepoQueries_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID, fields)}
 }
 
-func (_c *UserRepoQueries_GetUser_Call) Run(run func(ctx context.Context, userID string)) *UserRepoQueries_GetUser_Call {
+func (_c *UserRepoQueries_GetUser_Call) Run(run func(ctx context.Context, userID string, fields []string)) *UserRepoQueries_GetUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
 	})
 	return _c
 }
@@ -78,14 +245,132 @@ func (_c *UserRepoQueries_GetUser_Call) Return(_a0 *domain.User, _a1 error) *Use
 	return _c
 }
 
-func (_c *UserRepoQueries_GetUser_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *UserRepoQueries_GetUser_Call {
+func (_c *UserRepoQueries_GetUser_Call) RunAndReturn(run func(context.Context, string, []string) (*domain.User, error)) *UserRepoQueries_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserStats provides a mock function with given fields: ctx, period, from, to
+func (_m *UserRepoQueries) GetUserStats(ctx context.Context, period string, from time.Time, to time.Time) (*domain.UserStats, error) {
+	ret := _m.Called(ctx, period, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStats")
+	}
+
+	var r0 *domain.UserStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (*domain.UserStats, error)); ok {
+		return rf(ctx, period, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *domain.UserStats); ok {
+		r0 = rf(ctx, period, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, period, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepoQueries_GetUserStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserStats'
+type UserRepoQueries_GetUserStats_Call struct {
+	*mock.Call
+}
+
+// GetUserStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - period string
+//   - from time.Time
+//   - to time.Time
+func (_e *UserRepoQueries_Expecter) GetUserStats(ctx interface{}, period interface{}, from interface{}, to interface{}) *UserRepoQueries_GetUserStats_Call {
+	return &UserRepoQueries_GetUserStats_Call{Call: _e.mock.On("GetUserStats", ctx, period, from, to)}
+}
+
+func (_c *UserRepoQueries_GetUserStats_Call) Run(run func(ctx context.Context, period string, from time.Time, to time.Time)) *UserRepoQueries_GetUserStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepoQueries_GetUserStats_Call) Return(_a0 *domain.UserStats, _a1 error) *UserRepoQueries_GetUserStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepoQueries_GetUserStats_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (*domain.UserStats, error)) *UserRepoQueries_GetUserStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListUsers provides a mock function with given fields: ctx, cursorUserID, cursorUpdatedAt, limit, filters
-func (_m *UserRepoQueries) ListUsers(ctx context.Context, cursorUserID string, cursorUpdatedAt *time.Time, limit int32, filters domain.UserSearchFilters) ([]*domain.User, error) {
-	ret := _m.Called(ctx, cursorUserID, cursorUpdatedAt, limit, filters)
+// IsPhoneVerified provides a mock function with given fields: ctx, phone
+func (_m *UserRepoQueries) IsPhoneVerified(ctx context.Context, phone string) (bool, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsPhoneVerified")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepoQueries_IsPhoneVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsPhoneVerified'
+type UserRepoQueries_IsPhoneVerified_Call struct {
+	*mock.Call
+}
+
+// IsPhoneVerified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *UserRepoQueries_Expecter) IsPhoneVerified(ctx interface{}, phone interface{}) *UserRepoQueries_IsPhoneVerified_Call {
+	return &UserRepoQueries_IsPhoneVerified_Call{Call: _e.mock.On("IsPhoneVerified", ctx, phone)}
+}
+
+func (_c *UserRepoQueries_IsPhoneVerified_Call) Run(run func(ctx context.Context, phone string)) *UserRepoQueries_IsPhoneVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepoQueries_IsPhoneVerified_Call) Return(_a0 bool, _a1 error) *UserRepoQueries_IsPhoneVerified_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepoQueries_IsPhoneVerified_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *UserRepoQueries_IsPhoneVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx, order, cursor, limit, filters, fields
+func (_m *UserRepoQueries) ListUsers(ctx context.Context, order domain.UserOrder, cursor *domain.UserCursor, limit int32, filters domain.UserSearchFilters, fields []string) ([]*domain.User, error) {
+	ret := _m.Called(ctx, order, cursor, limit, filters, fields)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListUsers")
@@ -93,19 +378,19 @@ func (_m *UserRepoQueries) ListUsers(ctx context.Context, cursorUserID string, c
 
 	var r0 []*domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, int32, domain.UserSearchFilters) ([]*domain.User, error)); ok {
-		return rf(ctx, cursorUserID, cursorUpdatedAt, limit, filters)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserOrder, *domain.UserCursor, int32, domain.UserSearchFilters, []string) ([]*domain.User, error)); ok {
+		return rf(ctx, order, cursor, limit, filters, fields)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, int32, domain.UserSearchFilters) []*domain.User); ok {
-		r0 = rf(ctx, cursorUserID, cursorUpdatedAt, limit, filters)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserOrder, *domain.UserCursor, int32, domain.UserSearchFilters, []string) []*domain.User); ok {
+		r0 = rf(ctx, order, cursor, limit, filters, fields)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time, int32, domain.UserSearchFilters) error); ok {
-		r1 = rf(ctx, cursorUserID, cursorUpdatedAt, limit, filters)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserOrder, *domain.UserCursor, int32, domain.UserSearchFilters, []string) error); ok {
+		r1 = rf(ctx, order, cursor, limit, filters, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -120,17 +405,18 @@ type UserRepoQueries_ListUsers_Call struct {
 
 // ListUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - cursorUserID string
-//   - cursorUpdatedAt *time.Time
+//   - order domain.UserOrder
+//   - cursor *domain.UserCursor
 //   - limit int32
 //   - filters domain.UserSearchFilters
-func (_e *UserRepoQueries_Expecter) ListUsers(ctx interface{}, cursorUserID interface{}, cursorUpdatedAt interface{}, limit interface{}, filters interface{}) *UserRepoQueries_ListUsers_Call {
-	return &UserRepoQueries_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, cursorUserID, cursorUpdatedAt, limit, filters)}
+//   - fields []string
+func (_e *UserRepoQueries_Expecter) ListUsers(ctx interface{}, order interface{}, cursor interface{}, limit interface{}, filters interface{}, fields interface{}) *UserRepoQueries_ListUsers_Call {
+	return &UserRepoQueries_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, order, cursor, limit, filters, fields)}
 }
 
-func (_c *UserRepoQueries_ListUsers_Call) Run(run func(ctx context.Context, cursorUserID string, cursorUpdatedAt *time.Time, limit int32, filters domain.UserSearchFilters)) *UserRepoQueries_ListUsers_Call {
+func (_c *UserRepoQueries_ListUsers_Call) Run(run func(ctx context.Context, order domain.UserOrder, cursor *domain.UserCursor, limit int32, filters domain.UserSearchFilters, fields []string)) *UserRepoQueries_ListUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(*time.Time), args[3].(int32), args[4].(domain.UserSearchFilters))
+		run(args[0].(context.Context), args[1].(domain.UserOrder), args[2].(*domain.UserCursor), args[3].(int32), args[4].(domain.UserSearchFilters), args[5].([]string))
 	})
 	return _c
 }
@@ -140,7 +426,129 @@ func (_c *UserRepoQueries_ListUsers_Call) Return(_a0 []*domain.User, _a1 error)
 	return _c
 }
 
-func (_c *UserRepoQueries_ListUsers_Call) RunAndReturn(run func(context.Context, string, *time.Time, int32, domain.UserSearchFilters) ([]*domain.User, error)) *UserRepoQueries_ListUsers_Call {
+func (_c *UserRepoQueries_ListUsers_Call) RunAndReturn(run func(context.Context, domain.UserOrder, *domain.UserCursor, int32, domain.UserSearchFilters, []string) ([]*domain.User, error)) *UserRepoQueries_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupUser provides a mock function with given fields: ctx, field, value
+func (_m *UserRepoQueries) LookupUser(ctx context.Context, field string, value string) (*domain.User, error) {
+	ret := _m.Called(ctx, field, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return rf(ctx, field, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = rf(ctx, field, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, field, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepoQueries_LookupUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupUser'
+type UserRepoQueries_LookupUser_Call struct {
+	*mock.Call
+}
+
+// LookupUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - field string
+//   - value string
+func (_e *UserRepoQueries_Expecter) LookupUser(ctx interface{}, field interface{}, value interface{}) *UserRepoQueries_LookupUser_Call {
+	return &UserRepoQueries_LookupUser_Call{Call: _e.mock.On("LookupUser", ctx, field, value)}
+}
+
+func (_c *UserRepoQueries_LookupUser_Call) Run(run func(ctx context.Context, field string, value string)) *UserRepoQueries_LookupUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepoQueries_LookupUser_Call) Return(_a0 *domain.User, _a1 error) *UserRepoQueries_LookupUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepoQueries_LookupUser_Call) RunAndReturn(run func(context.Context, string, string) (*domain.User, error)) *UserRepoQueries_LookupUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchUsers provides a mock function with given fields: ctx, query, cursorRank, cursorUserID, limit
+func (_m *UserRepoQueries) SearchUsers(ctx context.Context, query string, cursorRank *float32, cursorUserID string, limit int32) ([]*domain.UserSearchResult, error) {
+	ret := _m.Called(ctx, query, cursorRank, cursorUserID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []*domain.UserSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *float32, string, int32) ([]*domain.UserSearchResult, error)); ok {
+		return rf(ctx, query, cursorRank, cursorUserID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *float32, string, int32) []*domain.UserSearchResult); ok {
+		r0 = rf(ctx, query, cursorRank, cursorUserID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.UserSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *float32, string, int32) error); ok {
+		r1 = rf(ctx, query, cursorRank, cursorUserID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepoQueries_SearchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchUsers'
+type UserRepoQueries_SearchUsers_Call struct {
+	*mock.Call
+}
+
+// SearchUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - cursorRank *float32
+//   - cursorUserID string
+//   - limit int32
+func (_e *UserRepoQueries_Expecter) SearchUsers(ctx interface{}, query interface{}, cursorRank interface{}, cursorUserID interface{}, limit interface{}) *UserRepoQueries_SearchUsers_Call {
+	return &UserRepoQueries_SearchUsers_Call{Call: _e.mock.On("SearchUsers", ctx, query, cursorRank, cursorUserID, limit)}
+}
+
+func (_c *UserRepoQueries_SearchUsers_Call) Run(run func(ctx context.Context, query string, cursorRank *float32, cursorUserID string, limit int32)) *UserRepoQueries_SearchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*float32), args[3].(string), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *UserRepoQueries_SearchUsers_Call) Return(_a0 []*domain.UserSearchResult, _a1 error) *UserRepoQueries_SearchUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepoQueries_SearchUsers_Call) RunAndReturn(run func(context.Context, string, *float32, string, int32) ([]*domain.UserSearchResult, error)) *UserRepoQueries_SearchUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -146,6 +146,54 @@ func (_c *Interface_IsEnabled_Call) RunAndReturn(run func() bool) *Interface_IsE
 	return _c
 }
 
+// Listen provides a mock function with given fields: ctx, channel, fn
+func (_m *Interface) Listen(ctx context.Context, channel string, fn func(string)) error {
+	ret := _m.Called(ctx, channel, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Listen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string)) error); ok {
+		r0 = rf(ctx, channel, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Interface_Listen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Listen'
+type Interface_Listen_Call struct {
+	*mock.Call
+}
+
+// Listen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - fn func(string)
+func (_e *Interface_Expecter) Listen(ctx interface{}, channel interface{}, fn interface{}) *Interface_Listen_Call {
+	return &Interface_Listen_Call{Call: _e.mock.On("Listen", ctx, channel, fn)}
+}
+
+func (_c *Interface_Listen_Call) Run(run func(ctx context.Context, channel string, fn func(string))) *Interface_Listen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(string)))
+	})
+	return _c
+}
+
+func (_c *Interface_Listen_Call) Return(_a0 error) *Interface_Listen_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Interface_Listen_Call) RunAndReturn(run func(context.Context, string, func(string)) error) *Interface_Listen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ping provides a mock function with given fields: ctx
 func (_m *Interface) Ping(ctx context.Context) bool {
 	ret := _m.Called(ctx)
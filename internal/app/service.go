@@ -13,15 +13,133 @@ type UserServiceCommands interface {
 type UserServiceQueries interface {
 	user.UserQueries
 }
+type PhoneVerificationCommands interface {
+	user.PhoneVerificationCommands
+}
+type AvatarCommands interface {
+	user.AvatarCommands
+}
+type PreferencesService interface {
+	user.PreferencesQueries
+	user.PreferencesCommands
+}
+type GroupService interface {
+	user.GroupQueries
+	user.GroupCommands
+}
+type InvitationService interface {
+	user.InvitationQueries
+	user.InvitationCommands
+}
+type RelationshipService interface {
+	user.RelationshipQueries
+	user.RelationshipCommands
+}
+type MergeCommands interface {
+	user.MergeCommands
+}
+type HistoryService interface {
+	user.HistoryQueries
+	user.HistoryCommands
+}
+type AuditService interface {
+	user.AuditQueries
+	user.AuditCommands
+}
+type TagCommands interface {
+	user.TagCommands
+}
+type ActivityCommands interface {
+	user.ActivityCommands
+}
+type WatchQueries interface {
+	user.WatchQueries
+}
 
 // NewUserServiceQueries creates an instance of User Queries that satisfies UserServiceQueries interface
-func NewUserServiceQueries(logger logger.Interface, queries domain.UserRepoQueries) UserServiceQueries {
-	return user.NewUserUseCaseQueries(logger, queries)
+func NewUserServiceQueries(logger logger.Interface, transaction domain.Transaction, queries domain.UserRepoQueries,
+	cfg user.UserQueriesConfig) UserServiceQueries {
+	return user.NewUserUseCaseQueries(logger, queries, transaction, cfg)
 }
 
 // NewUserServiceCommands creates an instance of User Commands that satisfies UserServiceCommands interface
-func NewUserServiceCommands(logger logger.Interface, transaction domain.Transaction, commands domain.UserRepoCommands, outboxCommands domain.OutboxRepoCommands) UserServiceCommands {
-	return user.NewUserUseCaseCommands(logger, commands, transaction, outboxCommands)
+func NewUserServiceCommands(logger logger.Interface, transaction domain.Transaction, commands domain.UserRepoCommands, outboxCommands domain.OutboxRepoCommands,
+	groupCommands domain.GroupRepoCommands, cfg user.UserCommandsConfig) UserServiceCommands {
+	return user.NewUserUseCaseCommands(logger, commands, transaction, outboxCommands, groupCommands, cfg)
+}
+
+// NewPhoneVerificationCommands creates an instance of Phone Verification Commands that satisfies PhoneVerificationCommands interface
+func NewPhoneVerificationCommands(logger logger.Interface, transaction domain.Transaction, queries domain.UserRepoQueries, commands domain.UserRepoCommands,
+	verifications domain.PhoneVerificationRepoCommands, outboxCommands domain.OutboxRepoCommands, cfg user.PhoneVerificationConfig) PhoneVerificationCommands {
+	return user.NewPhoneVerificationUseCase(logger, transaction, queries, commands, verifications, outboxCommands, cfg)
+}
+
+// NewAvatarCommands creates an instance of Avatar Commands that satisfies AvatarCommands interface
+func NewAvatarCommands(logger logger.Interface, transaction domain.Transaction, commands domain.UserRepoCommands,
+	outboxCommands domain.OutboxRepoCommands, blobStore domain.BlobStore, cfg user.AvatarConfig) AvatarCommands {
+	return user.NewAvatarUseCase(logger, transaction, commands, outboxCommands, blobStore, cfg)
+}
+
+// NewPreferencesService creates an instance of Preferences Queries and Commands that satisfies PreferencesService interface
+func NewPreferencesService(logger logger.Interface, transaction domain.Transaction, queries domain.PreferencesRepoQueries,
+	commands domain.PreferencesRepoCommands, outboxCommands domain.OutboxRepoCommands, cfg user.PreferencesConfig) PreferencesService {
+	return user.NewPreferencesUseCase(logger, transaction, queries, commands, outboxCommands, cfg)
+}
+
+// NewGroupService creates an instance of Group Queries and Commands that satisfies GroupService interface
+func NewGroupService(logger logger.Interface, transaction domain.Transaction, queries domain.GroupRepoQueries,
+	commands domain.GroupRepoCommands, outboxCommands domain.OutboxRepoCommands) GroupService {
+	return user.NewGroupUseCase(logger, transaction, queries, commands, outboxCommands)
+}
+
+// NewInvitationService creates an instance of Invitation Queries and Commands that satisfies InvitationService interface
+func NewInvitationService(logger logger.Interface, transaction domain.Transaction, queries domain.InvitationRepoQueries,
+	commands domain.InvitationRepoCommands, userCommands domain.UserRepoCommands, outboxCommands domain.OutboxRepoCommands,
+	cfg user.InvitationConfig) InvitationService {
+	return user.NewInvitationUseCase(logger, transaction, queries, commands, userCommands, outboxCommands, cfg)
+}
+
+// NewRelationshipService creates an instance of Relationship Queries and Commands that satisfies RelationshipService interface
+func NewRelationshipService(logger logger.Interface, transaction domain.Transaction, userQueries domain.UserRepoQueries,
+	queries domain.RelationshipRepoQueries, commands domain.RelationshipRepoCommands, outboxCommands domain.OutboxRepoCommands) RelationshipService {
+	return user.NewRelationshipUseCase(logger, transaction, userQueries, queries, commands, outboxCommands)
+}
+
+// NewMergeCommands creates an instance of Merge Commands that satisfies MergeCommands interface
+func NewMergeCommands(logger logger.Interface, transaction domain.Transaction, commands domain.UserMergeRepoCommands,
+	outboxCommands domain.OutboxRepoCommands, blobStore domain.BlobStore) MergeCommands {
+	return user.NewMergeUseCase(logger, transaction, commands, outboxCommands, blobStore)
+}
+
+// NewHistoryService creates an instance of History Queries and Commands that satisfies HistoryService interface
+func NewHistoryService(logger logger.Interface, transaction domain.Transaction, queries domain.UserHistoryRepoQueries,
+	commands domain.UserHistoryRepoCommands, cfg user.HistoryConfig) HistoryService {
+	return user.NewHistoryUseCase(logger, transaction, queries, commands, cfg)
+}
+
+// NewAuditService creates an instance of Audit Queries and Commands that satisfies AuditService interface.
+// The transaction must not be audited, since failures are recorded in their own transaction.
+func NewAuditService(logger logger.Interface, transaction domain.Transaction, queries domain.AuditRepoQueries,
+	commands domain.AuditRepoCommands) AuditService {
+	return user.NewAuditUseCase(logger, transaction, queries, commands)
+}
+
+// NewTagCommands creates an instance of Tag Commands that satisfies TagCommands interface
+func NewTagCommands(logger logger.Interface, transaction domain.Transaction, commands domain.TagRepoCommands,
+	outboxCommands domain.OutboxRepoCommands) TagCommands {
+	return user.NewTagUseCase(logger, transaction, commands, outboxCommands)
+}
+
+// NewActivityCommands creates an instance of Activity Commands that satisfies ActivityCommands interface
+func NewActivityCommands(logger logger.Interface, transaction domain.Transaction, commands domain.ActivityRepoCommands,
+	outboxCommands domain.OutboxRepoCommands, cfg user.ActivityConfig) ActivityCommands {
+	return user.NewActivityUseCase(logger, transaction, commands, outboxCommands, cfg)
+}
+
+// NewWatchQueries creates an instance of Watch Queries that satisfies WatchQueries interface
+func NewWatchQueries(logger logger.Interface, transaction domain.Transaction, outboxQueries domain.OutboxRepoQueries,
+	listener domain.OutboxListener, cfg user.WatchConfig) WatchQueries {
+	return user.NewWatchUseCase(logger, transaction, outboxQueries, listener, cfg)
 }
 
 // HealthCheckQueries is an interface for checking the health of application dependencies
@@ -3,6 +3,7 @@ package app
 import (
 	"reflect"
 	"testing"
+	"time"
 	mocks "users/gen/mocks/users/domain"
 	loggermocks "users/gen/mocks/users/pkg/logger"
 	"users/internal/app/user"
@@ -15,11 +16,13 @@ func TestNewUserServiceCommands(t *testing.T) {
 	transactionMock := mocks.NewTransaction(t)
 	commandsMock := mocks.NewUserRepoCommands(t)
 	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+	groupCommandsMock := mocks.NewGroupRepoCommands(t)
 	type args struct {
 		logger         logger.Interface
 		transaction    domain.Transaction
 		commands       domain.UserRepoCommands
 		outboxCommands domain.OutboxRepoCommands
+		groupCommands  domain.GroupRepoCommands
 	}
 	tests := []struct {
 		name string
@@ -33,13 +36,14 @@ func TestNewUserServiceCommands(t *testing.T) {
 				transaction:    transactionMock,
 				commands:       commandsMock,
 				outboxCommands: outboxCommandsMock,
+				groupCommands:  groupCommandsMock,
 			},
-			want: user.NewUserUseCaseCommands(mockLogger, commandsMock, transactionMock, outboxCommandsMock),
+			want: user.NewUserUseCaseCommands(mockLogger, commandsMock, transactionMock, outboxCommandsMock, groupCommandsMock, user.UserCommandsConfig{MinAge: 16}),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewUserServiceCommands(tt.args.logger, tt.args.transaction, tt.args.commands, tt.args.outboxCommands); !reflect.DeepEqual(got, tt.want) {
+			if got := NewUserServiceCommands(tt.args.logger, tt.args.transaction, tt.args.commands, tt.args.outboxCommands, tt.args.groupCommands, user.UserCommandsConfig{MinAge: 16}); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewUserServiceCommands() = %v, want %v", got, tt.want)
 			}
 		})
@@ -48,10 +52,12 @@ func TestNewUserServiceCommands(t *testing.T) {
 
 func TestNewUserServiceQueries(t *testing.T) {
 	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
 	queriesMock := mocks.NewUserRepoQueries(t)
 	type args struct {
-		logger  logger.Interface
-		queries domain.UserRepoQueries
+		logger      logger.Interface
+		transaction domain.Transaction
+		queries     domain.UserRepoQueries
 	}
 	tests := []struct {
 		name string
@@ -61,17 +67,191 @@ func TestNewUserServiceQueries(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				logger:  mockLogger,
-				queries: queriesMock,
+				logger:      mockLogger,
+				transaction: transactionMock,
+				queries:     queriesMock,
 			},
-			want: user.NewUserUseCaseQueries(mockLogger, queriesMock),
+			want: user.NewUserUseCaseQueries(mockLogger, queriesMock, transactionMock, user.UserQueriesConfig{FuzzyThreshold: 0.3}),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewUserServiceQueries(tt.args.logger, tt.args.queries); !reflect.DeepEqual(got, tt.want) {
+			if got := NewUserServiceQueries(tt.args.logger, tt.args.transaction, tt.args.queries, user.UserQueriesConfig{FuzzyThreshold: 0.3}); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewUserServiceQueries() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestNewPhoneVerificationCommands(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	queriesMock := mocks.NewUserRepoQueries(t)
+	commandsMock := mocks.NewUserRepoCommands(t)
+	verificationsMock := mocks.NewPhoneVerificationRepoCommands(t)
+	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+	cfg := user.PhoneVerificationConfig{CodeTTL: time.Minute, MaxAttempts: 3}
+
+	want := user.NewPhoneVerificationUseCase(mockLogger, transactionMock, queriesMock, commandsMock, verificationsMock, outboxCommandsMock, cfg)
+	got := NewPhoneVerificationCommands(mockLogger, transactionMock, queriesMock, commandsMock, verificationsMock, outboxCommandsMock, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPhoneVerificationCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestNewAvatarCommands(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	commandsMock := mocks.NewUserRepoCommands(t)
+	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+	blobStoreMock := mocks.NewBlobStore(t)
+	cfg := user.AvatarConfig{MaxSize: 1024, MaxDimension: 512, ThumbnailSizes: []int{128, 64}}
+
+	want := user.NewAvatarUseCase(mockLogger, transactionMock, commandsMock, outboxCommandsMock, blobStoreMock, cfg)
+	got := NewAvatarCommands(mockLogger, transactionMock, commandsMock, outboxCommandsMock, blobStoreMock, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAvatarCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestNewPreferencesService(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	queriesMock := mocks.NewPreferencesRepoQueries(t)
+	commandsMock := mocks.NewPreferencesRepoCommands(t)
+	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+	cfg := user.PreferencesConfig{Definitions: []user.PreferenceDefinition{{Key: "newsletter", Type: user.PreferenceBool, Default: true}}}
+
+	want := user.NewPreferencesUseCase(mockLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock, cfg)
+	got := NewPreferencesService(mockLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPreferencesService() = %v, want %v", got, want)
+	}
+}
+
+func TestNewGroupService(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	queriesMock := mocks.NewGroupRepoQueries(t)
+	commandsMock := mocks.NewGroupRepoCommands(t)
+	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+
+	want := user.NewGroupUseCase(mockLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+	got := NewGroupService(mockLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewGroupService() = %v, want %v", got, want)
+	}
+}
+
+func TestNewRelationshipService(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	userQueriesMock := mocks.NewUserRepoQueries(t)
+	queriesMock := mocks.NewRelationshipRepoQueries(t)
+	commandsMock := mocks.NewRelationshipRepoCommands(t)
+	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+
+	want := user.NewRelationshipUseCase(mockLogger, transactionMock, userQueriesMock, queriesMock, commandsMock, outboxCommandsMock)
+	got := NewRelationshipService(mockLogger, transactionMock, userQueriesMock, queriesMock, commandsMock, outboxCommandsMock)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewRelationshipService() = %v, want %v", got, want)
+	}
+}
+
+func TestNewInvitationService(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	queriesMock := mocks.NewInvitationRepoQueries(t)
+	commandsMock := mocks.NewInvitationRepoCommands(t)
+	userCommandsMock := mocks.NewUserRepoCommands(t)
+	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+	cfg := user.InvitationConfig{TokenTTL: time.Hour}
+
+	want := user.NewInvitationUseCase(mockLogger, transactionMock, queriesMock, commandsMock, userCommandsMock, outboxCommandsMock, cfg)
+	got := NewInvitationService(mockLogger, transactionMock, queriesMock, commandsMock, userCommandsMock, outboxCommandsMock, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewInvitationService() = %v, want %v", got, want)
+	}
+}
+
+func TestNewMergeCommands(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	commandsMock := mocks.NewUserMergeRepoCommands(t)
+	outboxCommandsMock := mocks.NewOutboxRepoCommands(t)
+	blobStoreMock := mocks.NewBlobStore(t)
+
+	want := user.NewMergeUseCase(mockLogger, transactionMock, commandsMock, outboxCommandsMock, blobStoreMock)
+	got := NewMergeCommands(mockLogger, transactionMock, commandsMock, outboxCommandsMock, blobStoreMock)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMergeCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestNewHistoryService(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	queriesMock := mocks.NewUserHistoryRepoQueries(t)
+	commandsMock := mocks.NewUserHistoryRepoCommands(t)
+	cfg := user.HistoryConfig{Retention: time.Hour}
+
+	want := user.NewHistoryUseCase(mockLogger, transactionMock, queriesMock, commandsMock, cfg)
+	got := NewHistoryService(mockLogger, transactionMock, queriesMock, commandsMock, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewHistoryService() = %v, want %v", got, want)
+	}
+}
+
+func TestNewAuditService(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	queriesMock := mocks.NewAuditRepoQueries(t)
+	commandsMock := mocks.NewAuditRepoCommands(t)
+
+	want := user.NewAuditUseCase(mockLogger, transactionMock, queriesMock, commandsMock)
+	got := NewAuditService(mockLogger, transactionMock, queriesMock, commandsMock)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAuditService() = %v, want %v", got, want)
+	}
+}
+
+func TestNewTagCommands(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	commandsMock := mocks.NewTagRepoCommands(t)
+	outboxMock := mocks.NewOutboxRepoCommands(t)
+
+	want := user.NewTagUseCase(mockLogger, transactionMock, commandsMock, outboxMock)
+	got := NewTagCommands(mockLogger, transactionMock, commandsMock, outboxMock)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTagCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestNewActivityCommands(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	commandsMock := mocks.NewActivityRepoCommands(t)
+	outboxMock := mocks.NewOutboxRepoCommands(t)
+	cfg := user.ActivityConfig{WriteInterval: time.Minute, DormantAfter: time.Hour}
+
+	want := user.NewActivityUseCase(mockLogger, transactionMock, commandsMock, outboxMock, cfg)
+	got := NewActivityCommands(mockLogger, transactionMock, commandsMock, outboxMock, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewActivityCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestNewWatchQueries(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	transactionMock := mocks.NewTransaction(t)
+	queriesMock := mocks.NewOutboxRepoQueries(t)
+	listenerMock := mocks.NewOutboxListener(t)
+	cfg := user.WatchConfig{HeartbeatInterval: time.Minute}
+
+	want := user.NewWatchUseCase(mockLogger, transactionMock, queriesMock, listenerMock, cfg)
+	got := NewWatchQueries(mockLogger, transactionMock, queriesMock, listenerMock, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewWatchQueries() = %v, want %v", got, want)
+	}
+}
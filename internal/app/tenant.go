@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// TenantQueries is an interface for resolving the tenant of a request
+type TenantQueries interface {
+	// ResolveTenant fetches a tenant by its ID or slug.
+	// It returns domain.ErrTenantNotFound if the tenant does not exist.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ResolveTenant(ctx context.Context, ref string) (*domain.Tenant, error)
+}
+
+type cachedTenant struct {
+	tenant    *domain.Tenant
+	expiresAt time.Time
+}
+
+type tenantQueries struct {
+	l        logger.Interface
+	repo     domain.TenantRepoQueries
+	cacheTTL time.Duration
+	mu       sync.RWMutex
+	cache    map[string]cachedTenant
+}
+
+// NewTenantQueries creates a service that satisfies the interface TenantQueries.
+// Resolved tenants are cached for cacheTTL, since every request needs one. A zero cacheTTL disables the cache.
+func NewTenantQueries(logger logger.Interface, repo domain.TenantRepoQueries, cacheTTL time.Duration) TenantQueries {
+	return &tenantQueries{l: logger, repo: repo, cacheTTL: cacheTTL, cache: make(map[string]cachedTenant)}
+}
+
+// ResolveTenant fetches a tenant by its ID or slug, going through the cache first.
+func (q *tenantQueries) ResolveTenant(ctx context.Context, ref string) (*domain.Tenant, error) {
+	q.mu.RLock()
+	cached, ok := q.cache[ref]
+	q.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.tenant, nil
+	}
+
+	var tenant *domain.Tenant
+	var err error
+	if _, parseErr := uuid.Parse(ref); parseErr == nil {
+		tenant, err = q.repo.GetTenantByID(ctx, ref)
+	} else {
+		tenant, err = q.repo.GetTenantBySlug(ctx, ref)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrTenantNotFound) {
+			return nil, err
+		}
+		q.l.Warn("app-tenant error resolving tenant %s: %v", ref, err)
+		return nil, domain.ErrInternal
+	}
+
+	if q.cacheTTL > 0 {
+		q.mu.Lock()
+		q.cache[ref] = cachedTenant{tenant: tenant, expiresAt: time.Now().Add(q.cacheTTL)}
+		q.mu.Unlock()
+	}
+	return tenant, nil
+}
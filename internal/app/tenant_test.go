@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+	mocks "users/gen/mocks/users/domain"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_tenantQueries_ResolveTenant(t *testing.T) {
+	mockLogger := loggermocks.NewInterface(t)
+	repoMock := mocks.NewTenantRepoQueries(t)
+	tenant := &domain.Tenant{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Slug: "default", Name: "Default"}
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	q := NewTenantQueries(mockLogger, repoMock, time.Minute)
+
+	tests := []struct {
+		name          string
+		ref           string
+		expectedMocks func()
+		want          *domain.Tenant
+		wantErr       error
+	}{
+		{
+			name: "resolved by id",
+			ref:  tenant.ID.String(),
+			expectedMocks: func() {
+				repoMock.On("GetTenantByID", mock.Anything, tenant.ID.String()).Return(tenant, nil).Once()
+			},
+			want: tenant,
+		},
+		{
+			name: "resolved by slug",
+			ref:  "default",
+			expectedMocks: func() {
+				repoMock.On("GetTenantBySlug", mock.Anything, "default").Return(tenant, nil).Once()
+			},
+			want: tenant,
+		},
+		{
+			name: "cached",
+			ref:  "default",
+			want: tenant,
+		},
+		{
+			name: "not found",
+			ref:  "unknown",
+			expectedMocks: func() {
+				repoMock.On("GetTenantBySlug", mock.Anything, "unknown").Return(nil, domain.ErrTenantNotFound).Once()
+			},
+			wantErr: domain.ErrTenantNotFound,
+		},
+		{
+			name: "repository error",
+			ref:  "unknown",
+			expectedMocks: func() {
+				repoMock.On("GetTenantBySlug", mock.Anything, "unknown").Return(nil, domain.ErrFailedToProcessData).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := q.ResolveTenant(context.Background(), tt.ref)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
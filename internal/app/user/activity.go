@@ -0,0 +1,183 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// _inactiveUsersBatchSize is the amount of users flagged per transaction by the dormant users job
+const _inactiveUsersBatchSize = 100
+
+type ActivityCommands interface {
+	// RecordActivity sets the last time the user was seen, at most once per configured interval.
+	// Dormant users become active again, writing a UserReactivated event.
+	// Only the users of the tenant of the context are tracked, unknown users and contexts without a tenant are ignored.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInternal if it fails to record the activity.
+	RecordActivity(ctx context.Context, userID string) error
+
+	// FlagDormantUsers flags the active users not seen for longer than the configured threshold, across every tenant,
+	// writing a UserDormant event for each of them. They are deactivated instead of dormant if configured.
+	// It does nothing if the threshold is not positive.
+	// It returns domain.ErrInternal if it fails to flag the users.
+	FlagDormantUsers(ctx context.Context) error
+}
+
+// ActivityConfig defines how often the activity of a user is written, after how long without activity
+// a user is dormant and whether dormant users are deactivated
+type ActivityConfig struct {
+	WriteInterval     time.Duration
+	DormantAfter      time.Duration
+	DeactivateDormant bool
+}
+
+type userReactivated struct {
+	UserID string `json:"user_id"`
+}
+
+type userDormant struct {
+	UserID     string     `json:"user_id"`
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+}
+
+type activityUseCase struct {
+	l                logger.Interface
+	transaction      domain.Transaction
+	activityCommands domain.ActivityRepoCommands
+	outboxRepo       domain.OutboxRepoCommands
+	cfg              ActivityConfig
+	throttle         *activityThrottle
+}
+
+func NewActivityUseCase(logger logger.Interface, transaction domain.Transaction, activityCommands domain.ActivityRepoCommands,
+	outboxRepo domain.OutboxRepoCommands, cfg ActivityConfig) *activityUseCase {
+	return &activityUseCase{logger, transaction, activityCommands, outboxRepo, cfg, newActivityThrottle(cfg.WriteInterval)}
+}
+
+// RecordActivity sets the last time the user was seen.
+// It implements the RecordActivity method of ActivityCommands interface
+func (uc activityUseCase) RecordActivity(ctx context.Context, userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return domain.ErrInvalidUserID
+	}
+	if _, ok := domain.TenantFromContext(ctx); !ok {
+		return nil
+	}
+	now := time.Now()
+	if !uc.throttle.allow(userID, now) {
+		return nil
+	}
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		// other instances may have written it meanwhile
+		reactivated, err := uc.activityCommands.TouchUser(txCtx, userID, now, now.Add(-uc.cfg.WriteInterval))
+		if err != nil || !reactivated {
+			return err
+		}
+		return addEvent(txCtx, uc.outboxRepo, "UserReactivated", userReactivated{UserID: userID})
+	}); err != nil {
+		// the activity was not written, the next one is
+		uc.throttle.forget(userID, now)
+		uc.l.Warn("app-user-activity-record error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// FlagDormantUsers flags the users not seen for longer than the configured threshold, in batches.
+// It implements the FlagDormantUsers method of ActivityCommands interface
+func (uc activityUseCase) FlagDormantUsers(ctx context.Context) error {
+	if uc.cfg.DormantAfter <= 0 {
+		return nil
+	}
+	status := domain.UserDormant
+	if uc.cfg.DeactivateDormant {
+		status = domain.UserDeactivated
+	}
+	inactiveSince := time.Now().Add(-uc.cfg.DormantAfter)
+	var flagged int
+	for {
+		var batch []*domain.InactiveUser
+		if err := uc.transaction.BeginTx(domain.WithAllTenants(ctx), func(txCtx context.Context) (err error) {
+			batch, err = uc.activityCommands.FlagInactiveUsers(txCtx, inactiveSince, status, _inactiveUsersBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, u := range batch {
+				if err := addTenantEvent(txCtx, uc.outboxRepo, u.TenantID, "UserDormant",
+					userDormant{UserID: u.ID.String(), Status: status, LastSeenAt: u.LastSeenAt}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			uc.l.Warn("app-user-activity-dormant error: %v", err)
+			return domain.ErrInternal
+		}
+		flagged += len(batch)
+		if len(batch) < _inactiveUsersBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+	uc.l.Debug("app-user-activity-dormant flagged %d users", flagged)
+	return nil
+}
+
+// addTenantEvent writes an event owned by the provided tenant, for jobs running across every tenant
+func addTenantEvent(ctx context.Context, outboxRepo domain.OutboxRepoCommands, tenantID string, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = outboxRepo.AddEvent(ctx, &domain.Event{TenantID: tenantID, Type: eventType, Payload: data})
+	return err
+}
+
+// activityThrottle remembers when the activity of each user was last written by this instance,
+// so that most requests skip the database
+type activityThrottle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	written  map[string]time.Time
+	pruned   time.Time
+}
+
+func newActivityThrottle(interval time.Duration) *activityThrottle {
+	return &activityThrottle{interval: interval, written: map[string]time.Time{}}
+}
+
+// allow reports whether the activity of the user should be written, recording it as written if so.
+// Entries older than the interval are pruned once per interval.
+func (t *activityThrottle) allow(userID string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if last, ok := t.written[userID]; ok && now.Sub(last) < t.interval {
+		return false
+	}
+	if now.Sub(t.pruned) >= t.interval {
+		for id, last := range t.written {
+			if now.Sub(last) >= t.interval {
+				delete(t.written, id)
+			}
+		}
+		t.pruned = now
+	}
+	t.written[userID] = now
+	return true
+}
+
+// forget drops the write of the user recorded at the time by allow, so that the next activity is written again.
+// A later write recorded meanwhile is kept.
+func (t *activityThrottle) forget(userID string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if last, ok := t.written[userID]; ok && last.Equal(at) {
+		delete(t.written, userID)
+	}
+}
@@ -0,0 +1,133 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_activityUseCase_RecordActivity(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewActivityRepoCommands(t)
+	outboxMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	otherID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewActivityUseCase(mockedLogger, transactionMock, commandsMock, outboxMock, ActivityConfig{WriteInterval: time.Hour})
+	notBefore := mock.MatchedBy(func(ts time.Time) bool {
+		return ts.Before(time.Now().Add(-59*time.Minute)) && ts.After(time.Now().Add(-61*time.Minute))
+	})
+
+	tenantCtx := domain.WithTenant(context.Background(), "00000000-0000-0000-0000-000000000001")
+
+	t.Run("invalid user id", func(t *testing.T) {
+		assert.EqualError(t, uc.RecordActivity(context.Background(), "invalid"), domain.ErrInvalidUserID.Error())
+	})
+
+	t.Run("without tenant", func(t *testing.T) {
+		assert.NoError(t, uc.RecordActivity(context.Background(), userID))
+	})
+
+	t.Run("writes the activity once per interval", func(t *testing.T) {
+		commandsMock.On("TouchUser", mock.Anything, userID, mock.AnythingOfType("time.Time"), notBefore).Return(false, nil).Once()
+		assert.NoError(t, uc.RecordActivity(tenantCtx, userID))
+		assert.NoError(t, uc.RecordActivity(tenantCtx, userID))
+	})
+
+	t.Run("reactivates dormant users", func(t *testing.T) {
+		payload, _ := json.Marshal(userReactivated{UserID: otherID})
+		commandsMock.On("TouchUser", mock.Anything, otherID, mock.AnythingOfType("time.Time"), notBefore).Return(true, nil).Once()
+		outboxMock.On("AddEvent", mock.Anything, &domain.Event{Type: "UserReactivated", Payload: payload}).Return("", nil).Once()
+		assert.NoError(t, uc.RecordActivity(tenantCtx, otherID))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		uc := NewActivityUseCase(mockedLogger, transactionMock, commandsMock, outboxMock, ActivityConfig{WriteInterval: time.Hour})
+		commandsMock.On("TouchUser", mock.Anything, userID, mock.Anything, mock.Anything).Return(false, domain.ErrInternal).Once()
+		assert.EqualError(t, uc.RecordActivity(tenantCtx, userID), domain.ErrInternal.Error())
+
+		// the failed write does not hold back the next activity
+		commandsMock.On("TouchUser", mock.Anything, userID, mock.Anything, mock.Anything).Return(false, nil).Once()
+		assert.NoError(t, uc.RecordActivity(tenantCtx, userID))
+	})
+}
+
+func Test_activityThrottle_allow(t *testing.T) {
+	throttle := newActivityThrottle(time.Minute)
+	now := time.Now()
+
+	assert.True(t, throttle.allow("a", now))
+	assert.False(t, throttle.allow("a", now.Add(30*time.Second)))
+	assert.True(t, throttle.allow("b", now.Add(30*time.Second)))
+	assert.True(t, throttle.allow("a", now.Add(time.Minute)))
+	// the entries of the previous interval are pruned
+	assert.True(t, throttle.allow("c", now.Add(2*time.Minute)))
+	assert.Len(t, throttle.written, 1)
+
+	// forgetting a write allows the next activity, unless it was written again since
+	throttle.forget("c", now.Add(2*time.Minute))
+	assert.True(t, throttle.allow("c", now.Add(2*time.Minute+time.Second)))
+	throttle.forget("c", now.Add(2*time.Minute))
+	assert.False(t, throttle.allow("c", now.Add(2*time.Minute+2*time.Second)))
+}
+
+func Test_activityUseCase_FlagDormantUsers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewActivityRepoCommands(t)
+	outboxMock := domainMocks.NewOutboxRepoCommands(t)
+	tenantID := "3f1b2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+	userID := uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25")
+	lastSeen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.MatchedBy(domain.IsAllTenants), mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+	inactiveSince := mock.MatchedBy(func(ts time.Time) bool {
+		return ts.Before(time.Now().Add(-23*time.Hour)) && ts.After(time.Now().Add(-25*time.Hour))
+	})
+
+	t.Run("disabled threshold", func(t *testing.T) {
+		uc := NewActivityUseCase(mockedLogger, transactionMock, commandsMock, outboxMock, ActivityConfig{})
+		assert.NoError(t, uc.FlagDormantUsers(context.Background()))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		uc := NewActivityUseCase(mockedLogger, transactionMock, commandsMock, outboxMock, ActivityConfig{DormantAfter: 24 * time.Hour})
+		commandsMock.On("FlagInactiveUsers", mock.Anything, inactiveSince, domain.UserDormant, int32(_inactiveUsersBatchSize)).Return(nil, domain.ErrInternal).Once()
+		assert.EqualError(t, uc.FlagDormantUsers(context.Background()), domain.ErrInternal.Error())
+	})
+
+	t.Run("flags dormant users", func(t *testing.T) {
+		uc := NewActivityUseCase(mockedLogger, transactionMock, commandsMock, outboxMock, ActivityConfig{DormantAfter: 24 * time.Hour})
+		payload, _ := json.Marshal(userDormant{UserID: userID.String(), Status: domain.UserDormant, LastSeenAt: &lastSeen})
+		commandsMock.On("FlagInactiveUsers", mock.Anything, inactiveSince, domain.UserDormant, int32(_inactiveUsersBatchSize)).
+			Return([]*domain.InactiveUser{{ID: userID, TenantID: tenantID, LastSeenAt: &lastSeen}}, nil).Once()
+		outboxMock.On("AddEvent", mock.Anything, &domain.Event{TenantID: tenantID, Type: "UserDormant", Payload: payload}).Return("", nil).Once()
+		assert.NoError(t, uc.FlagDormantUsers(context.Background()))
+	})
+
+	t.Run("deactivates in batches", func(t *testing.T) {
+		uc := NewActivityUseCase(mockedLogger, transactionMock, commandsMock, outboxMock, ActivityConfig{DormantAfter: 24 * time.Hour, DeactivateDormant: true})
+		batch := make([]*domain.InactiveUser, _inactiveUsersBatchSize)
+		for i := range batch {
+			batch[i] = &domain.InactiveUser{ID: uuid.New(), TenantID: tenantID}
+		}
+		commandsMock.On("FlagInactiveUsers", mock.Anything, inactiveSince, domain.UserDeactivated, int32(_inactiveUsersBatchSize)).Return(batch, nil).Once()
+		commandsMock.On("FlagInactiveUsers", mock.Anything, inactiveSince, domain.UserDeactivated, int32(_inactiveUsersBatchSize)).Return(nil, nil).Once()
+		outboxMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == "UserDormant" && e.TenantID == tenantID
+		})).Return("", nil).Times(_inactiveUsersBatchSize)
+		assert.NoError(t, uc.FlagDormantUsers(context.Background()))
+	})
+}
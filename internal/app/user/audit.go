@@ -0,0 +1,172 @@
+package user
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type AuditQueries interface {
+	// ListAuditEvents retrieves a paginated list of the audit events matching the filters, the most recent first.
+	// It returns domain.ErrInvalidUserID if an invalid target user id is provided.
+	// It returns domain.ErrInvalidPaginationCursor if an invalid cursor is provided.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ListAuditEvents(ctx context.Context, req ListAuditEventsRequest) (events []*domain.AuditEvent, nextCursor string, err error)
+}
+
+type AuditCommands interface {
+	// RecordFailure records the failure of the command of the context in its own transaction,
+	// unless the command is not audited or its event was already recorded.
+	// Failing to record is only logged, so the cause is still returned to the caller.
+	RecordFailure(ctx context.Context, cause error)
+}
+
+type ListAuditEventsRequest struct {
+	Cursor  string
+	Limit   int32
+	Filters domain.AuditEventFilters
+}
+
+type auditUseCase struct {
+	l            logger.Interface
+	transaction  domain.Transaction
+	auditQueries domain.AuditRepoQueries
+	auditRepo    domain.AuditRepoCommands
+}
+
+func NewAuditUseCase(logger logger.Interface, transaction domain.Transaction, auditQueries domain.AuditRepoQueries,
+	auditCommands domain.AuditRepoCommands) *auditUseCase {
+	return &auditUseCase{logger, transaction, auditQueries, auditCommands}
+}
+
+// ListAuditEvents retrieves a paginated list of the audit events matching the filters.
+// It implements the ListAuditEvents method of AuditQueries interface
+func (uc auditUseCase) ListAuditEvents(ctx context.Context, req ListAuditEventsRequest) (events []*domain.AuditEvent, nextCur string, err error) {
+	if req.Filters.TargetUserID != nil {
+		if _, err := uuid.Parse(*req.Filters.TargetUserID); err != nil {
+			return []*domain.AuditEvent{}, "", domain.ErrInvalidUserID
+		}
+	}
+	var createdAtCur *time.Time
+	var idCur string
+	if len(req.Cursor) > 0 {
+		var cCur time.Time
+		cCur, idCur, err = decodeCursor(req.Cursor)
+		if err == nil {
+			_, err = uuid.Parse(idCur)
+		}
+		if err != nil {
+			uc.l.Debug("app-user-audit error decoding cursor: %v", err)
+			return []*domain.AuditEvent{}, "", domain.ErrInvalidPaginationCursor
+		}
+		createdAtCur = &cCur
+	}
+
+	if err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		events, err = uc.auditQueries.ListAuditEvents(txCtx, idCur, createdAtCur, req.Limit, req.Filters)
+		return err
+	}); err != nil {
+		uc.l.Warn("app-user-audit error listing events: %v", err)
+		return []*domain.AuditEvent{}, "", domain.ErrInternal
+	}
+
+	if len(events) == int(req.Limit) {
+		last := events[len(events)-1]
+		nextCur = encodeCursor(last.CreatedAt, last.ID.String())
+	}
+	return events, nextCur, nil
+}
+
+// RecordFailure records the failure of the command of the context.
+// It implements the RecordFailure method of AuditCommands interface
+func (uc auditUseCase) RecordFailure(ctx context.Context, cause error) {
+	record, ok := domain.AuditRecordFromContext(ctx)
+	if !ok || record.Recorded() {
+		return
+	}
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		return uc.auditRepo.AddAuditEvent(txCtx, record.Event(domain.AuditFailure, cause))
+	}); err != nil {
+		uc.l.Warn("app-user-audit error recording failure: %v", err)
+		return
+	}
+	record.MarkRecorded()
+}
+
+// auditedTransaction records the audit event of the command of the context in the same transaction as its changes
+type auditedTransaction struct {
+	transaction domain.Transaction
+	auditRepo   domain.AuditRepoCommands
+}
+
+// NewAuditedTransaction decorates the transaction, so the first transaction committed by an audited command records its success.
+// Transactions without an audit record in the context are left untouched.
+func NewAuditedTransaction(transaction domain.Transaction, auditRepo domain.AuditRepoCommands) domain.Transaction {
+	return &auditedTransaction{transaction, auditRepo}
+}
+
+// BeginTx runs fn in a transaction, adding the audit event of the context before committing.
+// It implements the BeginTx method of domain.Transaction interface
+func (t auditedTransaction) BeginTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	record, ok := domain.AuditRecordFromContext(ctx)
+	if !ok || record.Recorded() {
+		return t.transaction.BeginTx(ctx, fn)
+	}
+	if err := t.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := fn(txCtx); err != nil {
+			return err
+		}
+		return t.auditRepo.AddAuditEvent(txCtx, record.Event(domain.AuditSuccess, nil))
+	}); err != nil {
+		return err
+	}
+	record.MarkRecorded()
+	return nil
+}
+
+// userDiff returns the changes between two versions of a user, passwords excluded.
+// A nil version stands for a user that does not exist.
+func userDiff(before *domain.User, after *domain.User) map[string]domain.FieldChange {
+	fields := func(u *domain.User) map[string]string {
+		if u == nil {
+			return map[string]string{}
+		}
+		return map[string]string{
+			"first_name":       u.FirstName,
+			"last_name":        u.LastName,
+			"nickname":         u.NickName,
+			"email":            u.Email,
+			"country_iso_code": u.CountryISOCode,
+			"phone":            u.Phone,
+			"phone_verified":   strconv.FormatBool(u.PhoneVerified),
+			"locale":           u.Locale,
+			"timezone":         u.Timezone,
+			"date_of_birth":    formatAuditDate(u.DateOfBirth),
+			"avatar_url":       u.AvatarURL,
+		}
+	}
+	old, updated := fields(before), fields(after)
+	diff := map[string]domain.FieldChange{}
+	for field, value := range updated {
+		if old[field] != value {
+			diff[field] = domain.FieldChange{Old: old[field], New: value}
+		}
+	}
+	for field, value := range old {
+		if _, ok := updated[field]; !ok {
+			diff[field] = domain.FieldChange{Old: value}
+		}
+	}
+	return diff
+}
+
+func formatAuditDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(DateLayout)
+}
@@ -0,0 +1,200 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_auditUseCase_ListAuditEvents(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewAuditRepoQueries(t)
+	commandsMock := domainMocks.NewAuditRepoCommands(t)
+	eventID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	invalidTarget := "invalid"
+	actor := "admin"
+	tnow := time.Now().UTC()
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewAuditUseCase(mockedLogger, transactionMock, queriesMock, commandsMock)
+	events := []*domain.AuditEvent{{ID: uuid.MustParse(eventID), Actor: actor, Method: "DeleteUser", Outcome: domain.AuditSuccess, CreatedAt: tnow}}
+
+	tests := []struct {
+		name          string
+		req           ListAuditEventsRequest
+		expectedMocks func()
+		want          []*domain.AuditEvent
+		wantCursor    string
+		wantErr       error
+	}{
+		{
+			name:    "invalid target user id",
+			req:     ListAuditEventsRequest{Limit: 1, Filters: domain.AuditEventFilters{TargetUserID: &invalidTarget}},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "invalid cursor",
+			req:     ListAuditEventsRequest{Limit: 1, Cursor: encodeCursor(tnow, "not-an-id")},
+			wantErr: domain.ErrInvalidPaginationCursor,
+		},
+		{
+			name: "repository error",
+			req:  ListAuditEventsRequest{Limit: 1},
+			expectedMocks: func() {
+				queriesMock.On("ListAuditEvents", mock.Anything, "", (*time.Time)(nil), int32(1), domain.AuditEventFilters{}).Return(nil, domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "full page returns a cursor",
+			req:  ListAuditEventsRequest{Limit: 1, Filters: domain.AuditEventFilters{Actor: &actor}},
+			expectedMocks: func() {
+				queriesMock.On("ListAuditEvents", mock.Anything, "", (*time.Time)(nil), int32(1), domain.AuditEventFilters{Actor: &actor}).Return(events, nil).Once()
+			},
+			want:       events,
+			wantCursor: encodeCursor(tnow, eventID),
+		},
+		{
+			name: "last page",
+			req:  ListAuditEventsRequest{Limit: 2, Cursor: encodeCursor(tnow, eventID)},
+			expectedMocks: func() {
+				queriesMock.On("ListAuditEvents", mock.Anything, eventID, mock.AnythingOfType("*time.Time"), int32(2), domain.AuditEventFilters{}).Return(events, nil).Once()
+			},
+			want: events,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, cursor, err := uc.ListAuditEvents(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCursor, cursor)
+		})
+	}
+}
+
+func Test_auditUseCase_RecordFailure(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewAuditRepoQueries(t)
+	commandsMock := domainMocks.NewAuditRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewAuditUseCase(mockedLogger, transactionMock, queriesMock, commandsMock)
+
+	t.Run("not audited", func(t *testing.T) {
+		uc.RecordFailure(context.Background(), domain.ErrUserNotFound)
+	})
+
+	t.Run("already recorded", func(t *testing.T) {
+		ctx, record := domain.WithAuditRecord(context.Background(), domain.AuditEvent{Method: "DeleteUser"})
+		record.MarkRecorded()
+		uc.RecordFailure(ctx, domain.ErrUserNotFound)
+	})
+
+	t.Run("records the failure", func(t *testing.T) {
+		ctx, record := domain.WithAuditRecord(context.Background(), domain.AuditEvent{Actor: "admin", Method: "DeleteUser"})
+		domain.SetAuditTarget(ctx, userID)
+		target := uuid.MustParse(userID)
+		commandsMock.On("AddAuditEvent", mock.Anything, &domain.AuditEvent{Actor: "admin", Method: "DeleteUser", TargetUserID: &target,
+			Outcome: domain.AuditFailure, Error: domain.ErrUserNotFound.Error()}).Return(nil).Once()
+		uc.RecordFailure(ctx, domain.ErrUserNotFound)
+		assert.True(t, record.Recorded())
+	})
+
+	t.Run("repository error is only logged", func(t *testing.T) {
+		ctx, record := domain.WithAuditRecord(context.Background(), domain.AuditEvent{Method: "DeleteUser"})
+		commandsMock.On("AddAuditEvent", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+		mockedLogger.On("Warn", mock.Anything, domain.ErrInternal).Once()
+		uc.RecordFailure(ctx, domain.ErrUserNotFound)
+		assert.False(t, record.Recorded())
+	})
+}
+
+func Test_auditedTransaction_BeginTx(t *testing.T) {
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewAuditRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	tx := NewAuditedTransaction(transactionMock, commandsMock)
+
+	t.Run("not audited", func(t *testing.T) {
+		assert.NoError(t, tx.BeginTx(context.Background(), func(ctx context.Context) error { return nil }))
+	})
+
+	t.Run("failed command is not recorded", func(t *testing.T) {
+		ctx, record := domain.WithAuditRecord(context.Background(), domain.AuditEvent{Method: "DeleteUser"})
+		err := tx.BeginTx(ctx, func(ctx context.Context) error { return domain.ErrUserNotFound })
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.False(t, record.Recorded())
+	})
+
+	t.Run("records the success once", func(t *testing.T) {
+		ctx, record := domain.WithAuditRecord(context.Background(), domain.AuditEvent{Method: "UpdateUser"})
+		target := uuid.MustParse(userID)
+		commandsMock.On("AddAuditEvent", mock.Anything, &domain.AuditEvent{Method: "UpdateUser", TargetUserID: &target, Outcome: domain.AuditSuccess,
+			Diff: map[string]domain.FieldChange{"email": {Old: "old@example.com", New: "new@example.com"}}}).Return(nil).Once()
+		assert.NoError(t, tx.BeginTx(ctx, func(txCtx context.Context) error {
+			domain.SetAuditTarget(txCtx, userID)
+			domain.AddAuditDiff(txCtx, map[string]domain.FieldChange{"email": {Old: "old@example.com", New: "new@example.com"}})
+			return nil
+		}))
+		assert.True(t, record.Recorded())
+		assert.NoError(t, tx.BeginTx(ctx, func(ctx context.Context) error { return nil }))
+	})
+
+	t.Run("committed failure", func(t *testing.T) {
+		ctx, _ := domain.WithAuditRecord(context.Background(), domain.AuditEvent{Method: "ConfirmPhoneVerification"})
+		commandsMock.On("AddAuditEvent", mock.Anything, &domain.AuditEvent{Method: "ConfirmPhoneVerification", Outcome: domain.AuditFailure,
+			Error: domain.ErrInvalidVerificationCode.Error()}).Return(nil).Once()
+		assert.NoError(t, tx.BeginTx(ctx, func(txCtx context.Context) error {
+			domain.SetAuditFailure(txCtx, domain.ErrInvalidVerificationCode)
+			return nil
+		}))
+	})
+
+	t.Run("audit error rolls back", func(t *testing.T) {
+		ctx, record := domain.WithAuditRecord(context.Background(), domain.AuditEvent{Method: "DeleteUser"})
+		commandsMock.On("AddAuditEvent", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+		err := tx.BeginTx(ctx, func(ctx context.Context) error { return nil })
+		assert.True(t, errors.Is(err, domain.ErrInternal))
+		assert.False(t, record.Recorded())
+	})
+}
+
+func Test_userDiff(t *testing.T) {
+	dob := time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := &domain.User{FirstName: "first", Email: "old@example.com", Password: "old"}
+	after := &domain.User{FirstName: "first", Email: "new@example.com", Password: "new", DateOfBirth: &dob}
+
+	assert.Equal(t, map[string]domain.FieldChange{
+		"email":         {Old: "old@example.com", New: "new@example.com"},
+		"date_of_birth": {New: "1990-05-01"},
+	}, userDiff(before, after))
+	assert.Equal(t, map[string]domain.FieldChange{
+		"first_name":     {New: "first"},
+		"email":          {New: "old@example.com"},
+		"phone_verified": {New: "false"},
+	}, userDiff(nil, before))
+}
@@ -0,0 +1,285 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // registers the gif decoder
+	"image/jpeg"
+	_ "image/png" // registers the png decoder
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	_avatarContentType = "image/jpeg"
+	_avatarJPEGQuality = 90
+)
+
+// allowedAvatarTypes maps the accepted content types to the format names of the registered image decoders
+var allowedAvatarTypes = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type AvatarCommands interface {
+	// UploadAvatar validates the image, stores a square thumbnail for each configured size
+	// and replaces the user's avatar, writing an AvatarUpdated event.
+	// Returns the URL of the largest thumbnail.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrUnsupportedAvatarType if the content type is not accepted or does not match the data.
+	// It returns domain.ErrAvatarTooLarge if the data or the image dimensions exceed the configured limits.
+	// It returns domain.ErrInvalidAvatar if the data cannot be read or decoded.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrInternal if it fails to store the avatar.
+	UploadAvatar(ctx context.Context, userID string, contentType string, data io.Reader) (avatarURL string, err error)
+
+	// DeleteAvatar removes the user's avatar, writing an AvatarDeleted event.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrAvatarNotSet if the user has no avatar.
+	// It returns domain.ErrInternal if it fails to delete the avatar.
+	DeleteAvatar(ctx context.Context, userID string) error
+}
+
+// AvatarConfig defines the upload limits and the sizes (in pixels) of the generated thumbnails
+type AvatarConfig struct {
+	MaxSize        int64
+	MaxDimension   int
+	ThumbnailSizes []int
+}
+
+type avatarUpdated struct {
+	UserID     string         `json:"user_id"`
+	AvatarURL  string         `json:"avatar_url"`
+	Thumbnails map[int]string `json:"thumbnails"`
+}
+
+type avatarDeleted struct {
+	UserID string `json:"user_id"`
+}
+
+type avatarUseCase struct {
+	l            logger.Interface
+	transaction  domain.Transaction
+	userCommands domain.UserRepoCommands
+	outboxRepo   domain.OutboxRepoCommands
+	blobStore    domain.BlobStore
+	cfg          AvatarConfig
+}
+
+func NewAvatarUseCase(logger logger.Interface, transaction domain.Transaction, userCommands domain.UserRepoCommands,
+	outboxRepo domain.OutboxRepoCommands, blobStore domain.BlobStore, cfg AvatarConfig) *avatarUseCase {
+	sizes := slices.Clone(cfg.ThumbnailSizes)
+	slices.Sort(sizes)
+	cfg.ThumbnailSizes = slices.Compact(sizes)
+	return &avatarUseCase{logger, transaction, userCommands, outboxRepo, blobStore, cfg}
+}
+
+// UploadAvatar stores the thumbnails of the image and replaces the user's avatar.
+// It implements the UploadAvatar method of AvatarCommands interface
+func (uc avatarUseCase) UploadAvatar(ctx context.Context, userID string, contentType string, data io.Reader) (string, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return "", domain.ErrInvalidUserID
+	}
+	domain.SetAuditTarget(ctx, userID)
+	if len(uc.cfg.ThumbnailSizes) == 0 {
+		uc.l.Error("app-user-avatar-upload no thumbnail sizes configured")
+		return "", domain.ErrInternal
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(data, uc.cfg.MaxSize+1))
+	if err != nil {
+		uc.l.Debug("app-user-avatar-upload error reading avatar of user %s: %v", userID, err)
+		return "", domain.ErrInvalidAvatar
+	}
+	if int64(len(raw)) > uc.cfg.MaxSize {
+		return "", domain.ErrAvatarTooLarge
+	}
+	img, err := uc.decode(raw, contentType)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := fmt.Sprintf("%s/%s", userID, uuid.NewString())
+	thumbnails := make(map[int]string, len(uc.cfg.ThumbnailSizes))
+	var avatarURL string
+	for _, size := range uc.cfg.ThumbnailSizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, squareThumbnail(img, size), &jpeg.Options{Quality: _avatarJPEGQuality}); err != nil {
+			uc.l.Warn("app-user-avatar-upload error encoding thumbnail of user %s: %v", userID, err)
+			uc.deleteBlobs(ctx, prefix)
+			return "", domain.ErrInternal
+		}
+		url, err := uc.blobStore.Put(ctx, fmt.Sprintf("%s/%d.jpg", prefix, size), _avatarContentType, buf.Bytes())
+		if err != nil {
+			uc.deleteBlobs(ctx, prefix)
+			return "", domain.ErrInternal
+		}
+		thumbnails[size] = url
+		avatarURL = url
+	}
+
+	var previousKey string
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		var err error
+		previousKey, err = uc.userCommands.UpdateAvatar(txCtx, userID, prefix, avatarURL)
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(avatarUpdated{
+			UserID:     userID,
+			AvatarURL:  avatarURL,
+			Thumbnails: thumbnails,
+		})
+		if err != nil {
+			return err
+		}
+		event := &domain.Event{
+			Type:    "AvatarUpdated",
+			Payload: payload,
+		}
+		if _, err := uc.outboxRepo.AddEvent(txCtx, event); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		uc.deleteBlobs(ctx, prefix)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return "", err
+		}
+		uc.l.Warn("app-user-avatar-upload error: %v", err)
+		return "", domain.ErrInternal
+	}
+
+	if previousKey != "" {
+		uc.deleteBlobs(ctx, previousKey)
+	}
+	return avatarURL, nil
+}
+
+// DeleteAvatar removes the user's avatar.
+// It implements the DeleteAvatar method of AvatarCommands interface
+func (uc avatarUseCase) DeleteAvatar(ctx context.Context, userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return domain.ErrInvalidUserID
+	}
+	domain.SetAuditTarget(ctx, userID)
+
+	var previousKey string
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		var err error
+		previousKey, err = uc.userCommands.UpdateAvatar(txCtx, userID, "", "")
+		if err != nil {
+			return err
+		}
+		if previousKey == "" {
+			return domain.ErrAvatarNotSet
+		}
+
+		payload, err := json.Marshal(avatarDeleted{UserID: userID})
+		if err != nil {
+			return err
+		}
+		event := &domain.Event{
+			Type:    "AvatarDeleted",
+			Payload: payload,
+		}
+		if _, err := uc.outboxRepo.AddEvent(txCtx, event); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrAvatarNotSet) {
+			return err
+		}
+		uc.l.Warn("app-user-avatar-delete error: %v", err)
+		return domain.ErrInternal
+	}
+
+	uc.deleteBlobs(ctx, previousKey)
+	return nil
+}
+
+// decode checks the declared content type against the data and decodes the image.
+// The dimensions are checked before decoding, so oversized images are never allocated.
+func (uc avatarUseCase) decode(raw []byte, contentType string) (image.Image, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, domain.ErrUnsupportedAvatarType
+	}
+	format, ok := allowedAvatarTypes[mediaType]
+	if !ok || http.DetectContentType(raw) != mediaType {
+		return nil, domain.ErrUnsupportedAvatarType
+	}
+
+	cfg, decodedFormat, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || decodedFormat != format || cfg.Width == 0 || cfg.Height == 0 {
+		return nil, domain.ErrInvalidAvatar
+	}
+	if cfg.Width > uc.cfg.MaxDimension || cfg.Height > uc.cfg.MaxDimension {
+		return nil, domain.ErrAvatarTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, domain.ErrInvalidAvatar
+	}
+	return img, nil
+}
+
+// deleteBlobs removes stored thumbnails on a best effort basis, leftovers are only logged
+func (uc avatarUseCase) deleteBlobs(ctx context.Context, prefix string) {
+	if err := uc.blobStore.DeletePrefix(ctx, prefix); err != nil {
+		uc.l.Warn("app-user-avatar error deleting blobs %s: %v", prefix, err)
+	}
+}
+
+// squareThumbnail crops the centered square of the image and scales it to size x size.
+// Each destination pixel averages the source pixels it covers, transparent areas become white.
+func squareThumbnail(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+
+	scaled := image.NewRGBA64(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		sy0, sy1 := y0+y*side/size, y0+(y+1)*side/size
+		if sy1 <= sy0 {
+			sy1 = sy0 + 1
+		}
+		for x := 0; x < size; x++ {
+			sx0, sx1 := x0+x*side/size, x0+(x+1)*side/size
+			if sx1 <= sx0 {
+				sx1 = sx0 + 1
+			}
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			scaled.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+
+	dst := image.NewRGBA(scaled.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), scaled, image.Point{}, draw.Over)
+	return dst
+}
@@ -0,0 +1,280 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// encodePNG returns a w x h png whose left half is red and right half is blue
+func encodePNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func Test_squareThumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 30, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 30; x++ {
+			c := color.RGBA{G: 255, A: 255}
+			if x < 10 || x >= 20 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+
+	// the centered square is fully green
+	got := squareThumbnail(src, 4)
+	assert.Equal(t, image.Rect(0, 0, 4, 4), got.Bounds())
+	assert.Equal(t, color.RGBA{G: 255, A: 255}, got.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{G: 255, A: 255}, got.RGBAAt(3, 3))
+
+	// upscaling repeats the source pixels
+	got = squareThumbnail(src, 20)
+	assert.Equal(t, image.Rect(0, 0, 20, 20), got.Bounds())
+	assert.Equal(t, color.RGBA{G: 255, A: 255}, got.RGBAAt(19, 0))
+
+	// transparent pixels become white
+	got = squareThumbnail(image.NewRGBA(image.Rect(0, 0, 2, 2)), 1)
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, got.RGBAAt(0, 0))
+}
+
+func Test_avatarUseCase_UploadAvatar(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewUserRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	blobStoreMock := domainMocks.NewBlobStore(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	validPNG := encodePNG(t, 40, 20)
+	mockedLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	uc := NewAvatarUseCase(mockedLogger, transactionMock, commandsMock, outboxCommandsMock, blobStoreMock, AvatarConfig{
+		MaxSize:        int64(len(validPNG)),
+		MaxDimension:   100,
+		ThumbnailSizes: []int{32, 16, 32},
+	})
+	thumbnailKey := func(size string) interface{} {
+		return mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, userID+"/") && strings.HasSuffix(key, "/"+size+".jpg")
+		})
+	}
+
+	tests := []struct {
+		name          string
+		userID        string
+		contentType   string
+		data          []byte
+		expectedMocks func()
+		want          string
+		wantErr       error
+	}{
+		{
+			name:        "invalid user id",
+			userID:      "invalid",
+			contentType: "image/png",
+			data:        validPNG,
+			wantErr:     domain.ErrInvalidUserID,
+		},
+		{
+			name:        "too large",
+			userID:      userID,
+			contentType: "image/png",
+			data:        append(validPNG, 0),
+			wantErr:     domain.ErrAvatarTooLarge,
+		},
+		{
+			name:        "unsupported content type",
+			userID:      userID,
+			contentType: "image/svg+xml",
+			data:        validPNG,
+			wantErr:     domain.ErrUnsupportedAvatarType,
+		},
+		{
+			name:        "content type does not match the data",
+			userID:      userID,
+			contentType: "image/jpeg",
+			data:        validPNG,
+			wantErr:     domain.ErrUnsupportedAvatarType,
+		},
+		{
+			name:        "corrupted image",
+			userID:      userID,
+			contentType: "image/png",
+			data:        validPNG[:len(validPNG)/2],
+			wantErr:     domain.ErrInvalidAvatar,
+		},
+		{
+			name:        "dimensions too large",
+			userID:      userID,
+			contentType: "image/png",
+			data:        encodePNG(t, 101, 1),
+			wantErr:     domain.ErrAvatarTooLarge,
+		},
+		{
+			name:        "failed to store the thumbnails",
+			userID:      userID,
+			contentType: "image/png",
+			data:        validPNG,
+			expectedMocks: func() {
+				blobStoreMock.On("Put", mock.Anything, thumbnailKey("16"), "image/jpeg", mock.Anything).Return("", domain.ErrInternal).Once()
+				blobStoreMock.On("DeletePrefix", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:        "user not found",
+			userID:      userID,
+			contentType: "image/png",
+			data:        validPNG,
+			expectedMocks: func() {
+				blobStoreMock.On("Put", mock.Anything, thumbnailKey("16"), "image/jpeg", mock.Anything).Return("/avatars/16.jpg", nil).Once()
+				blobStoreMock.On("Put", mock.Anything, thumbnailKey("32"), "image/jpeg", mock.Anything).Return("/avatars/32.jpg", nil).Once()
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(domain.ErrUserNotFound).Once()
+				commandsMock.On("UpdateAvatar", mock.Anything, userID, mock.AnythingOfType("string"), "/avatars/32.jpg").Return("", domain.ErrUserNotFound).Once()
+				blobStoreMock.On("DeletePrefix", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:        "success replacing a previous avatar",
+			userID:      userID,
+			contentType: "image/png; charset=binary",
+			data:        validPNG,
+			expectedMocks: func() {
+				blobStoreMock.On("Put", mock.Anything, thumbnailKey("16"), "image/jpeg", mock.Anything).Return("/avatars/16.jpg", nil).Once()
+				blobStoreMock.On("Put", mock.Anything, thumbnailKey("32"), "image/jpeg", mock.Anything).Return("/avatars/32.jpg", nil).Once()
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(nil).Once()
+				commandsMock.On("UpdateAvatar", mock.Anything, userID, mock.AnythingOfType("string"), "/avatars/32.jpg").Return(userID+"/previous", nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					return e.Type == "AvatarUpdated" && bytes.Contains(e.Payload, []byte(`"avatar_url":"/avatars/32.jpg"`))
+				})).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+				blobStoreMock.On("DeletePrefix", mock.Anything, userID+"/previous").Return(nil).Once()
+			},
+			want: "/avatars/32.jpg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.UploadAvatar(context.Background(), tt.userID, tt.contentType, bytes.NewReader(tt.data))
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_avatarUseCase_DeleteAvatar(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewUserRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	blobStoreMock := domainMocks.NewBlobStore(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	uc := NewAvatarUseCase(mockedLogger, transactionMock, commandsMock, outboxCommandsMock, blobStoreMock, AvatarConfig{})
+
+	tests := []struct {
+		name          string
+		userID        string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			userID:  "invalid",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:   "avatar not set",
+			userID: userID,
+			expectedMocks: func() {
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(domain.ErrAvatarNotSet).Once()
+				commandsMock.On("UpdateAvatar", mock.Anything, userID, "", "").Return("", nil).Once()
+			},
+			wantErr: domain.ErrAvatarNotSet,
+		},
+		{
+			name:   "failed to add event to outbox",
+			userID: userID,
+			expectedMocks: func() {
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(domain.ErrInternal).Once()
+				commandsMock.On("UpdateAvatar", mock.Anything, userID, "", "").Return(userID+"/previous", nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:   "success",
+			userID: userID,
+			expectedMocks: func() {
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(nil).Once()
+				commandsMock.On("UpdateAvatar", mock.Anything, userID, "", "").Return(userID+"/previous", nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					return e.Type == "AvatarDeleted"
+				})).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+				blobStoreMock.On("DeletePrefix", mock.Anything, userID+"/previous").Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.DeleteAvatar(context.Background(), tt.userID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 	"unicode"
 	"users/internal/domain"
 	"users/pkg/logger"
@@ -15,18 +16,25 @@ import (
 type UserCommands interface {
 	// CreateUser creates a new User and returns the created user id.
 	// It returns domain.ErrInvalidPW if an invalid password is provided.
+	// It returns domain.ErrInvalidPhone if an invalid phone number is provided.
+	// It returns domain.ErrInvalidLocale, domain.ErrInvalidTimezone or domain.ErrInvalidBirthDate if an invalid profile field is provided.
+	// It returns domain.ErrBirthDateRequired if a minimum age is configured and no date of birth is provided.
+	// It returns domain.ErrUserTooYoung if the user is under the configured minimum age.
 	// It returns domain.ErrUserAlreadyExists if the user conflicts in the unique fields (email or nickname).
 	// It returns domain.ErrInternal if it fails to create.
 	CreateUser(ctx context.Context, req AddUserRequest) (userID string, err error)
 
 	// UpdateUser updates a single User based on his id.
 	// This is not a partial update, all the user fields should be provided.
+	// Changing the phone number resets its verification.
 	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInvalidPhone if an invalid phone number is provided.
+	// It returns domain.ErrInvalidLocale, domain.ErrInvalidTimezone or domain.ErrInvalidBirthDate if an invalid profile field is provided.
 	// It returns domain.ErrUserNotFound if the user does not exist.
 	// It returns domain.ErrInternal if it fails to update.
 	UpdateUser(ctx context.Context, req UpdateUserRequest) error
 
-	// DeleteUser deletes a single User based on his id.
+	// DeleteUser deletes a single User based on his id, removing the user from every group.
 	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
 	// It returns domain.ErrUserNotFound if the user does not exist.
 	// It returns domain.ErrInternal if it fails to delete.
@@ -40,6 +48,10 @@ type AddUserRequest struct {
 	Email          string `json:"email"`
 	Password       string `json:"-"`
 	CountryISOCode string `json:"country"`
+	Phone          string `json:"phone"`
+	Locale         string `json:"locale"`
+	Timezone       string `json:"timezone"`
+	DateOfBirth    string `json:"date_of_birth"`
 }
 
 type UpdateUserRequest struct {
@@ -49,6 +61,22 @@ type UpdateUserRequest struct {
 	NickName       string `json:"nickname"`
 	Email          string `json:"email"`
 	CountryISOCode string `json:"country"`
+	Phone          string `json:"phone"`
+	Locale         string `json:"locale"`
+	Timezone       string `json:"timezone"`
+	DateOfBirth    string `json:"date_of_birth"`
+}
+
+// userCreated is the payload of the CreateUser event, the request along with the id of the created user
+type userCreated struct {
+	ID string `json:"id"`
+	AddUserRequest
+}
+
+// UserCommandsConfig defines the rules applied to the users' profile
+type UserCommandsConfig struct {
+	// MinAge is the minimum age in years required to create a user, 0 disables the check
+	MinAge int
 }
 
 type userUseCaseCommands struct {
@@ -56,10 +84,13 @@ type userUseCaseCommands struct {
 	repo        domain.UserRepoCommands
 	outboxRepo  domain.OutboxRepoCommands
 	transaction domain.Transaction
+	groupRepo   domain.GroupRepoCommands
+	cfg         UserCommandsConfig
 }
 
-func NewUserUseCaseCommands(logger logger.Interface, repo domain.UserRepoCommands, transaction domain.Transaction, outboxRepo domain.OutboxRepoCommands) *userUseCaseCommands {
-	return &userUseCaseCommands{logger, repo, outboxRepo, transaction}
+func NewUserUseCaseCommands(logger logger.Interface, repo domain.UserRepoCommands, transaction domain.Transaction, outboxRepo domain.OutboxRepoCommands,
+	groupRepo domain.GroupRepoCommands, cfg UserCommandsConfig) *userUseCaseCommands {
+	return &userUseCaseCommands{logger, repo, outboxRepo, transaction, groupRepo, cfg}
 }
 
 // CreateUser creates a new User and returns the created user id.
@@ -68,6 +99,26 @@ func (uc userUseCaseCommands) CreateUser(ctx context.Context, req AddUserRequest
 	if err := validatePassword(req.Password); err != nil {
 		return "", err
 	}
+	phone, err := normalizePhone(req.Phone, req.CountryISOCode)
+	if err != nil {
+		return "", err
+	}
+	req.Phone = phone
+
+	now := time.Now()
+	p, err := parseProfile(req.Locale, req.Timezone, req.DateOfBirth, now)
+	if err != nil {
+		return "", err
+	}
+	if uc.cfg.MinAge > 0 {
+		if p.dateOfBirth == nil {
+			return "", domain.ErrBirthDateRequired
+		}
+		if ageAt(*p.dateOfBirth, now) < uc.cfg.MinAge {
+			return "", domain.ErrUserTooYoung
+		}
+	}
+	req.Locale, req.Timezone = p.locale, p.timezone
 
 	hashedPassword, err := hashPassword(req.Password)
 	if err != nil {
@@ -81,6 +132,10 @@ func (uc userUseCaseCommands) CreateUser(ctx context.Context, req AddUserRequest
 		NickName:       req.NickName,
 		Email:          req.Email,
 		CountryISOCode: req.CountryISOCode,
+		Phone:          req.Phone,
+		Locale:         req.Locale,
+		Timezone:       req.Timezone,
+		DateOfBirth:    p.dateOfBirth,
 		Password:       hashedPassword,
 	}
 
@@ -91,7 +146,9 @@ func (uc userUseCaseCommands) CreateUser(ctx context.Context, req AddUserRequest
 		if err != nil {
 			return err
 		}
-		payload, err := json.Marshal(req)
+		domain.SetAuditTarget(txCtx, userID)
+		domain.AddAuditDiff(txCtx, userDiff(nil, &u))
+		payload, err := json.Marshal(userCreated{ID: userID, AddUserRequest: req})
 		if err != nil {
 			return err
 		}
@@ -119,12 +176,21 @@ func (uc userUseCaseCommands) DeleteUser(ctx context.Context, userID string) err
 	if _, err := uuid.Parse(userID); err != nil {
 		return domain.ErrInvalidUserID
 	}
+	domain.SetAuditTarget(ctx, userID)
 
 	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
-		err := uc.repo.DeleteUser(ctx, userID)
+		groupIDs, err := uc.groupRepo.RemoveUserMemberships(txCtx, userID)
 		if err != nil {
 			return err
 		}
+		if err := uc.repo.DeleteUser(txCtx, userID); err != nil {
+			return err
+		}
+		for _, groupID := range groupIDs {
+			if err := addGroupMemberEvent(txCtx, uc.outboxRepo, "GroupMemberRemoved", groupID, userID); err != nil {
+				return err
+			}
+		}
 		payload, err := json.Marshal(struct{ ID string }{
 			ID: userID,
 		})
@@ -156,6 +222,15 @@ func (uc userUseCaseCommands) UpdateUser(ctx context.Context, req UpdateUserRequ
 	if err != nil {
 		return domain.ErrInvalidUserID
 	}
+	domain.SetAuditTarget(ctx, req.ID)
+	if req.Phone, err = normalizePhone(req.Phone, req.CountryISOCode); err != nil {
+		return err
+	}
+	p, err := parseProfile(req.Locale, req.Timezone, req.DateOfBirth, time.Now())
+	if err != nil {
+		return err
+	}
+	req.Locale, req.Timezone = p.locale, p.timezone
 
 	u := domain.User{
 		ID:             userID,
@@ -164,13 +239,22 @@ func (uc userUseCaseCommands) UpdateUser(ctx context.Context, req UpdateUserRequ
 		NickName:       req.NickName,
 		Email:          req.Email,
 		CountryISOCode: req.CountryISOCode,
+		Phone:          req.Phone,
+		Locale:         req.Locale,
+		Timezone:       req.Timezone,
+		DateOfBirth:    p.dateOfBirth,
 	}
 
 	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
-		err = uc.repo.UpdateUser(ctx, &u)
+		previous, err := uc.repo.UpdateUser(txCtx, &u)
 		if err != nil {
 			return err
 		}
+		updated := *previous
+		updated.FirstName, updated.LastName, updated.NickName, updated.Email = u.FirstName, u.LastName, u.NickName, u.Email
+		updated.CountryISOCode, updated.Locale, updated.Timezone, updated.DateOfBirth = u.CountryISOCode, u.Locale, u.Timezone, u.DateOfBirth
+		updated.Phone, updated.PhoneVerified = u.Phone, previous.PhoneVerified && previous.Phone == u.Phone
+		domain.AddAuditDiff(txCtx, userDiff(previous, &updated))
 
 		payload, err := json.Marshal(req)
 		if err != nil {
@@ -1,10 +1,12 @@
 package user
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 	domainMocks "users/gen/mocks/users/domain"
 	loggerMocks "users/gen/mocks/users/pkg/logger"
 	"users/internal/domain"
@@ -101,6 +103,7 @@ func Test_userUseCaseCommands_CreateUser(t *testing.T) {
 	repoCommandsMock := domainMocks.NewUserRepoCommands(t)
 	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
 	transactionMock := domainMocks.NewTransaction(t)
+	groupCommandsMock := domainMocks.NewGroupRepoCommands(t)
 	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
 	mockedLogger.On("Debug", mock.Anything, mock.Anything)
 
@@ -112,7 +115,7 @@ func Test_userUseCaseCommands_CreateUser(t *testing.T) {
 		Email:          "email@email.pt",
 		Password:       "Password1!",
 	}
-	addUserReqMap, err := json.Marshal(exampleAddUserReq)
+	addUserReqMap, err := json.Marshal(userCreated{ID: expectedUserID, AddUserRequest: exampleAddUserReq})
 	assert.NoError(t, err)
 
 	type args struct {
@@ -235,10 +238,27 @@ func Test_userUseCaseCommands_CreateUser(t *testing.T) {
 			want:    "",
 			wantErr: domain.ErrInvalidPW,
 		},
+		{
+			name: "invalid phone",
+			args: args{
+				ctx: context.Background(),
+				req: AddUserRequest{
+					FirstName:      "first",
+					LastName:       "last",
+					NickName:       "nick",
+					CountryISOCode: "UK",
+					Email:          "email@email.pt",
+					Password:       "Password1!",
+					Phone:          "12345",
+				},
+			},
+			want:    "",
+			wantErr: domain.ErrInvalidPhone,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			commands := NewUserUseCaseCommands(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock)
+			commands := NewUserUseCaseCommands(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock, groupCommandsMock, UserCommandsConfig{})
 			if tt.expectedMocks != nil {
 				tt.expectedMocks(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock)
 			}
@@ -261,6 +281,7 @@ func Test_userUseCaseCommands_DeleteUser(t *testing.T) {
 	repoCommandsMock := domainMocks.NewUserRepoCommands(t)
 	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
 	transactionMock := domainMocks.NewTransaction(t)
+	groupCommandsMock := domainMocks.NewGroupRepoCommands(t)
 	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
 	mockedLogger.On("Warn", mock.Anything, mock.Anything)
 
@@ -268,6 +289,12 @@ func Test_userUseCaseCommands_DeleteUser(t *testing.T) {
 		"ID": expectedUserID,
 	})
 	assert.NoError(t, err)
+	groupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	memberRemovedPayload, err := json.Marshal(map[string]interface{}{
+		"group_id": groupID,
+		"user_id":  expectedUserID,
+	})
+	assert.NoError(t, err)
 
 	type args struct {
 		ctx    context.Context
@@ -291,7 +318,9 @@ func Test_userUseCaseCommands_DeleteUser(t *testing.T) {
 					fn := args.Get(1).(func(ctx context.Context) error)
 					fn(args.Get(0).(context.Context))
 				}).Return(nil).Once()
+				groupCommandsMock.On("RemoveUserMemberships", mock.Anything, expectedUserID).Return([]string{groupID}, nil).Once()
 				commands.On("DeleteUser", mock.Anything, expectedUserID).Return(nil).Once()
+				outbox.On("AddEvent", mock.Anything, &domain.Event{Type: "GroupMemberRemoved", Payload: memberRemovedPayload}).Return("1d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
 				outbox.On("AddEvent", mock.Anything, &domain.Event{Type: "DeleteUser", Payload: delUserReqMap}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
 			},
 		}, {
@@ -313,8 +342,23 @@ func Test_userUseCaseCommands_DeleteUser(t *testing.T) {
 					fn := args.Get(1).(func(ctx context.Context) error)
 					fn(args.Get(0).(context.Context))
 				}).Return(domain.ErrUserNotFound).Once()
+				groupCommandsMock.On("RemoveUserMemberships", mock.Anything, expectedUserID).Return(nil, nil).Once()
 				commands.On("DeleteUser", mock.Anything, expectedUserID).Return(domain.ErrUserNotFound).Once()
 			},
+		}, {
+			name: "failed to remove memberships",
+			args: args{
+				ctx:    context.Background(),
+				userID: expectedUserID,
+			},
+			wantErr: domain.ErrInternal,
+			expectedMocks: func(l *loggerMocks.Interface, commands *domainMocks.UserRepoCommands, tr *domainMocks.Transaction, outbox *domainMocks.OutboxRepoCommands) {
+				tr.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(domain.ErrInternal).Once()
+				groupCommandsMock.On("RemoveUserMemberships", mock.Anything, expectedUserID).Return(nil, domain.ErrInternal).Once()
+			},
 		}, {
 			name: "failed to send event",
 			args: args{
@@ -327,6 +371,7 @@ func Test_userUseCaseCommands_DeleteUser(t *testing.T) {
 					fn := args.Get(1).(func(ctx context.Context) error)
 					fn(args.Get(0).(context.Context))
 				}).Return(fmt.Errorf("something went wrong")).Once()
+				groupCommandsMock.On("RemoveUserMemberships", mock.Anything, expectedUserID).Return(nil, nil).Once()
 				commands.On("DeleteUser", mock.Anything, expectedUserID).Return(nil).Once()
 				outbox.On("AddEvent", mock.Anything, &domain.Event{Type: "DeleteUser", Payload: delUserReqMap}).Return("", fmt.Errorf("something went wrong")).Once()
 			},
@@ -334,7 +379,7 @@ func Test_userUseCaseCommands_DeleteUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			commands := NewUserUseCaseCommands(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock)
+			commands := NewUserUseCaseCommands(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock, groupCommandsMock, UserCommandsConfig{})
 			if tt.expectedMocks != nil {
 				tt.expectedMocks(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock)
 			}
@@ -354,6 +399,7 @@ func Test_userUseCaseCommands_UpdateUser(t *testing.T) {
 	repoCommandsMock := domainMocks.NewUserRepoCommands(t)
 	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
 	transactionMock := domainMocks.NewTransaction(t)
+	groupCommandsMock := domainMocks.NewGroupRepoCommands(t)
 	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
 	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything)
 
@@ -390,7 +436,7 @@ func Test_userUseCaseCommands_UpdateUser(t *testing.T) {
 					fn := args.Get(1).(func(ctx context.Context) error)
 					fn(args.Get(0).(context.Context))
 				}).Return(nil).Once()
-				commands.On("UpdateUser", mock.Anything, mock.Anything).Return(nil).Once()
+				commands.On("UpdateUser", mock.Anything, mock.Anything).Return(&domain.User{FirstName: "old"}, nil).Once()
 				outbox.On("AddEvent", mock.Anything, &domain.Event{Type: "UpdateUser", Payload: updateUserReqMap}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
 			},
 		}, {
@@ -412,7 +458,7 @@ func Test_userUseCaseCommands_UpdateUser(t *testing.T) {
 					fn := args.Get(1).(func(ctx context.Context) error)
 					fn(args.Get(0).(context.Context))
 				}).Return(domain.ErrUserNotFound).Once()
-				commands.On("UpdateUser", mock.Anything, mock.Anything).Return(domain.ErrUserNotFound).Once()
+				commands.On("UpdateUser", mock.Anything, mock.Anything).Return(nil, domain.ErrUserNotFound).Once()
 			},
 		}, {
 			name: "failed to send event",
@@ -426,14 +472,14 @@ func Test_userUseCaseCommands_UpdateUser(t *testing.T) {
 					fn := args.Get(1).(func(ctx context.Context) error)
 					fn(args.Get(0).(context.Context))
 				}).Return(fmt.Errorf("something went wrong")).Once()
-				commands.On("UpdateUser", mock.Anything, mock.Anything).Return(nil).Once()
+				commands.On("UpdateUser", mock.Anything, mock.Anything).Return(&domain.User{FirstName: "old"}, nil).Once()
 				outbox.On("AddEvent", mock.Anything, &domain.Event{Type: "UpdateUser", Payload: updateUserReqMap}).Return("", fmt.Errorf("something went wrong")).Once()
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			commands := NewUserUseCaseCommands(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock)
+			commands := NewUserUseCaseCommands(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock, groupCommandsMock, UserCommandsConfig{})
 			if tt.expectedMocks != nil {
 				tt.expectedMocks(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock)
 			}
@@ -447,3 +493,76 @@ func Test_userUseCaseCommands_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_userUseCaseCommands_CreateUser_MinAge(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	repoCommandsMock := domainMocks.NewUserRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	groupCommandsMock := domainMocks.NewGroupRepoCommands(t)
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	newReq := func(dateOfBirth string) AddUserRequest {
+		return AddUserRequest{
+			FirstName:      "first",
+			LastName:       "last",
+			NickName:       "nick",
+			CountryISOCode: "PT",
+			Email:          "email@email.pt",
+			Password:       "Password1!",
+			Locale:         "pt-pt",
+			Timezone:       "Europe/Lisbon",
+			DateOfBirth:    dateOfBirth,
+		}
+	}
+	tests := []struct {
+		name          string
+		req           AddUserRequest
+		expectedMocks func()
+		want          string
+		wantErr       error
+	}{
+		{
+			name:    "missing date of birth",
+			req:     newReq(""),
+			wantErr: domain.ErrBirthDateRequired,
+		},
+		{
+			name:    "too young",
+			req:     newReq(time.Now().AddDate(-16, 0, 1).Format(DateLayout)),
+			wantErr: domain.ErrUserTooYoung,
+		},
+		{
+			name: "old enough",
+			req:  newReq(time.Now().AddDate(-16, 0, 0).Format(DateLayout)),
+			expectedMocks: func() {
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(nil).Once()
+				repoCommandsMock.On("SaveUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.Locale == "pt-PT" && u.Timezone == "Europe/Lisbon" && u.DateOfBirth != nil
+				})).Return(expectedUserID, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					return e.Type == "CreateUser" && bytes.Contains(e.Payload, []byte(`"locale":"pt-PT"`))
+				})).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+			want: expectedUserID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := NewUserUseCaseCommands(mockedLogger, repoCommandsMock, transactionMock, outboxCommandsMock, groupCommandsMock, UserCommandsConfig{MinAge: 16})
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := commands.CreateUser(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"users/internal/domain"
+)
+
+// filterKind is the type of a filterable field, which defines its operators and values
+type filterKind int
+
+const (
+	filterText filterKind = iota
+	filterTimestamp
+	filterDate
+	filterBool
+	filterList
+)
+
+// _userFilterFields is the allow-list of the fields of the users filter expressions
+var _userFilterFields = map[string]filterKind{
+	"first_name":       filterText,
+	"last_name":        filterText,
+	"nick_name":        filterText,
+	"email":            filterText,
+	"country_iso_code": filterText,
+	"locale":           filterText,
+	"timezone":         filterText,
+	"status":           filterText,
+	"phone_verified":   filterBool,
+	"date_of_birth":    filterDate,
+	"created_at":       filterTimestamp,
+	"updated_at":       filterTimestamp,
+	"last_seen_at":     filterTimestamp,
+	"tags":             filterList,
+}
+
+// _filterOperators are the operators each kind of field supports
+var _filterOperators = map[filterKind][]string{
+	filterText:      {domain.FilterEquals, domain.FilterNotEquals, domain.FilterHas},
+	filterTimestamp: {domain.FilterEquals, domain.FilterNotEquals, domain.FilterLess, domain.FilterLessOrEqual, domain.FilterGreater, domain.FilterGreaterOrEqual},
+	filterDate:      {domain.FilterEquals, domain.FilterNotEquals, domain.FilterLess, domain.FilterLessOrEqual, domain.FilterGreater, domain.FilterGreaterOrEqual},
+	filterBool:      {domain.FilterEquals, domain.FilterNotEquals},
+	filterList:      {domain.FilterHas},
+}
+
+// _maxFilterDepth bounds the nesting of the filter expressions
+const _maxFilterDepth = 16
+
+type filterTokenKind int
+
+const (
+	tokenEOF filterTokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenMinus
+	tokenComparator
+	tokenText
+	tokenString
+)
+
+// filterToken is a lexeme of a filter expression, pos is the 1-based position of its first character
+type filterToken struct {
+	kind  filterTokenKind
+	value string
+	pos   int
+}
+
+func (t filterToken) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// parseUserFilter parses an AIP-160 filter expression over the fields of the users, e.g.
+// `country_iso_code = "PT" AND (first_name : "ann" OR nick_name : "ann")`.
+// It supports AND, OR, NOT and "-" to negate, parentheses, the comparators =, !=, <, <=, >, >= and : (has),
+// and quoted or bare values. As per AIP-160, OR binds tighter than AND and terms next to each other are ANDed.
+// It returns domain.ErrInvalidFilter with the position of the error if the filter is malformed, uses a field
+// outside of the allow-list or an operator or value the field does not support.
+func parseUserFilter(filter string) (*domain.FilterExpr, error) {
+	tokens, err := lexFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, filterError(next.pos, "unexpected %s", next)
+	}
+	return expr, nil
+}
+
+func filterError(pos int, format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", domain.ErrInvalidFilter, fmt.Sprintf(format, args...), pos)
+}
+
+// lexFilter splits the filter into tokens
+func lexFilter(filter string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r, pos := runes[i], i+1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{tokenLParen, "(", pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{tokenRParen, ")", pos})
+			i++
+		case r == '-':
+			tokens = append(tokens, filterToken{tokenMinus, "-", pos})
+			i++
+		case r == ':' || r == '=':
+			tokens = append(tokens, filterToken{tokenComparator, string(r), pos})
+			i++
+		case r == '<' || r == '>' || r == '!':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, filterError(pos, "unexpected %q", op)
+			}
+			tokens = append(tokens, filterToken{tokenComparator, op, pos})
+			i += len(op)
+		case r == '"' || r == '\'':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				value.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, filterError(pos, "unterminated string")
+			}
+			tokens = append(tokens, filterToken{tokenString, value.String(), pos})
+			i = j + 1
+		case isFilterTextRune(r):
+			j := i
+			for j < len(runes) && (isFilterTextRune(runes[j]) || runes[j] == '-') {
+				j++
+			}
+			tokens = append(tokens, filterToken{tokenText, string(runes[i:j]), pos})
+			i = j
+		default:
+			return nil, filterError(pos, "unexpected %q", r)
+		}
+	}
+	return append(tokens, filterToken{tokenEOF, "", len(runes) + 1}), nil
+}
+
+func isFilterTextRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// filterParser is a recursive descent parser of the AIP-160 grammar:
+//
+//	expression  = sequence { "AND" sequence }
+//	sequence    = factor { factor }
+//	factor      = term { "OR" term }
+//	term        = [ "NOT" | "-" ] simple
+//	simple      = restriction | "(" expression ")"
+//	restriction = field comparator value
+type filterParser struct {
+	tokens []filterToken
+	next   int
+	depth  int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.next]
+}
+
+func (p *filterParser) consume() filterToken {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenText && t.value == keyword
+}
+
+func (p *filterParser) expression() (*domain.FilterExpr, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > _maxFilterDepth {
+		return nil, filterError(p.peek().pos, "filter is nested too deeply")
+	}
+
+	args := []*domain.FilterExpr{}
+	for {
+		seq, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, seq)
+		if !p.isKeyword("AND") {
+			return combineFilters(domain.FilterAnd, args), nil
+		}
+		p.consume()
+	}
+}
+
+func (p *filterParser) sequence() (*domain.FilterExpr, error) {
+	args := []*domain.FilterExpr{}
+	for {
+		factor, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, factor)
+		next := p.peek()
+		if next.kind == tokenEOF || next.kind == tokenRParen || p.isKeyword("AND") {
+			return combineFilters(domain.FilterAnd, args), nil
+		}
+	}
+}
+
+func (p *filterParser) factor() (*domain.FilterExpr, error) {
+	args := []*domain.FilterExpr{}
+	for {
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, term)
+		if !p.isKeyword("OR") {
+			return combineFilters(domain.FilterOr, args), nil
+		}
+		p.consume()
+	}
+}
+
+func (p *filterParser) term() (*domain.FilterExpr, error) {
+	if p.isKeyword("NOT") || p.peek().kind == tokenMinus {
+		p.consume()
+		simple, err := p.simple()
+		if err != nil {
+			return nil, err
+		}
+		return &domain.FilterExpr{Op: domain.FilterNot, Args: []*domain.FilterExpr{simple}}, nil
+	}
+	return p.simple()
+}
+
+func (p *filterParser) simple() (*domain.FilterExpr, error) {
+	if p.peek().kind != tokenLParen {
+		return p.restriction()
+	}
+	p.consume()
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if closing := p.consume(); closing.kind != tokenRParen {
+		return nil, filterError(closing.pos, "expected \")\" instead of %s", closing)
+	}
+	return expr, nil
+}
+
+func (p *filterParser) restriction() (*domain.FilterExpr, error) {
+	field := p.consume()
+	if field.kind != tokenText || field.value == "AND" || field.value == "OR" || field.value == "NOT" {
+		return nil, filterError(field.pos, "expected a field instead of %s", field)
+	}
+	kind, ok := _userFilterFields[field.value]
+	if !ok {
+		return nil, filterError(field.pos, "unknown field %q", field.value)
+	}
+	comparator := p.consume()
+	if comparator.kind != tokenComparator {
+		return nil, filterError(comparator.pos, "expected a comparator after %s instead of %s", field.value, comparator)
+	}
+	if !supportsFilterOperator(kind, comparator.value) {
+		return nil, filterError(comparator.pos, "%s does not support the %s operator", field.value, comparator.value)
+	}
+	arg := p.consume()
+	if arg.kind != tokenString && arg.kind != tokenText {
+		return nil, filterError(arg.pos, "expected a value for %s instead of %s", field.value, arg)
+	}
+	value, err := filterValue(kind, arg.value)
+	if err != nil {
+		return nil, filterError(arg.pos, "invalid value %q for %s, %v", arg.value, field.value, err)
+	}
+	return &domain.FilterExpr{Op: comparator.value, Field: field.value, Value: value}, nil
+}
+
+func supportsFilterOperator(kind filterKind, op string) bool {
+	for _, supported := range _filterOperators[kind] {
+		if op == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// filterValue converts the value to the type of the field
+func filterValue(kind filterKind, value string) (any, error) {
+	switch kind {
+	case filterTimestamp:
+		ts, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("expected an RFC 3339 timestamp")
+		}
+		return ts, nil
+	case filterDate:
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, fmt.Errorf("expected a YYYY-MM-DD date")
+		}
+		return date, nil
+	case filterBool:
+		switch value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("expected true or false")
+	default:
+		return value, nil
+	}
+}
+
+// combineFilters returns the only expression, or the expressions combined by the logical operator
+func combineFilters(op string, args []*domain.FilterExpr) *domain.FilterExpr {
+	if len(args) == 1 {
+		return args[0]
+	}
+	return &domain.FilterExpr{Op: op, Args: args}
+}
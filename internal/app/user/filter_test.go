@@ -0,0 +1,134 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseUserFilter(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	born := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	hasAnn := func(field string) *domain.FilterExpr {
+		return &domain.FilterExpr{Op: domain.FilterHas, Field: field, Value: "ann"}
+	}
+	tests := []struct {
+		name    string
+		filter  string
+		want    *domain.FilterExpr
+		wantErr error
+	}{
+		{
+			name:   "single comparison",
+			filter: `country_iso_code = "PT"`,
+			want:   &domain.FilterExpr{Op: domain.FilterEquals, Field: "country_iso_code", Value: "PT"},
+		},
+		{
+			name:   "and with a nested or",
+			filter: `country_iso_code = "PT" AND created_at > "2024-01-01T00:00:00Z" AND (first_name : "ann" OR nick_name : "ann")`,
+			want: &domain.FilterExpr{Op: domain.FilterAnd, Args: []*domain.FilterExpr{
+				{Op: domain.FilterEquals, Field: "country_iso_code", Value: "PT"},
+				{Op: domain.FilterGreater, Field: "created_at", Value: since},
+				{Op: domain.FilterOr, Args: []*domain.FilterExpr{hasAnn("first_name"), hasAnn("nick_name")}},
+			}},
+		},
+		{
+			name:   "or binds tighter than and",
+			filter: `first_name:ann OR nick_name:ann AND phone_verified = true`,
+			want: &domain.FilterExpr{Op: domain.FilterAnd, Args: []*domain.FilterExpr{
+				{Op: domain.FilterOr, Args: []*domain.FilterExpr{hasAnn("first_name"), hasAnn("nick_name")}},
+				{Op: domain.FilterEquals, Field: "phone_verified", Value: true},
+			}},
+		},
+		{
+			name:   "implicit and with negations",
+			filter: `NOT tags:vip -status = 'dormant' date_of_birth <= 1990-05-17`,
+			want: &domain.FilterExpr{Op: domain.FilterAnd, Args: []*domain.FilterExpr{
+				{Op: domain.FilterNot, Args: []*domain.FilterExpr{{Op: domain.FilterHas, Field: "tags", Value: "vip"}}},
+				{Op: domain.FilterNot, Args: []*domain.FilterExpr{{Op: domain.FilterEquals, Field: "status", Value: "dormant"}}},
+				{Op: domain.FilterLessOrEqual, Field: "date_of_birth", Value: born},
+			}},
+		},
+		{
+			name:   "escaped quotes",
+			filter: `last_name != "o\"neil"`,
+			want:   &domain.FilterExpr{Op: domain.FilterNotEquals, Field: "last_name", Value: `o"neil`},
+		},
+		{
+			name:    "unknown field",
+			filter:  `country_iso_code = "PT" AND password = "x"`,
+			wantErr: fmt.Errorf("%w: unknown field \"password\" at position 29", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "unsupported operator",
+			filter:  `first_name > "ann"`,
+			wantErr: fmt.Errorf("%w: first_name does not support the > operator at position 12", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "invalid timestamp",
+			filter:  `updated_at >= "yesterday"`,
+			wantErr: fmt.Errorf("%w: invalid value \"yesterday\" for updated_at, expected an RFC 3339 timestamp at position 15", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "invalid bool",
+			filter:  `phone_verified = yes`,
+			wantErr: fmt.Errorf("%w: invalid value \"yes\" for phone_verified, expected true or false at position 18", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "missing comparator",
+			filter:  `first_name "ann"`,
+			wantErr: fmt.Errorf("%w: expected a comparator after first_name instead of \"ann\" at position 12", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "missing value",
+			filter:  `email :`,
+			wantErr: fmt.Errorf("%w: expected a value for email instead of end of filter at position 8", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "unbalanced parentheses",
+			filter:  `(first_name : "ann"`,
+			wantErr: fmt.Errorf("%w: expected \")\" instead of end of filter at position 20", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "trailing parenthesis",
+			filter:  `first_name : "ann")`,
+			wantErr: fmt.Errorf("%w: unexpected \")\" at position 19", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "dangling and",
+			filter:  `first_name : "ann" AND`,
+			wantErr: fmt.Errorf("%w: expected a field instead of end of filter at position 23", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "unterminated string",
+			filter:  `first_name : "ann`,
+			wantErr: fmt.Errorf("%w: unterminated string at position 14", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "unexpected character",
+			filter:  `first_name ~ "ann"`,
+			wantErr: fmt.Errorf("%w: unexpected '~' at position 12", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "nested too deeply",
+			filter:  strings.Repeat("(", 20) + `email:x` + strings.Repeat(")", 20),
+			wantErr: fmt.Errorf("%w: filter is nested too deeply at position 17", domain.ErrInvalidFilter),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUserFilter(tt.filter)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type GroupCommands interface {
+	// CreateGroup creates a new group and returns the created group id.
+	// It returns domain.ErrGroupAlreadyExists if a group with the same name already exists.
+	// It returns domain.ErrInternal if it fails to create.
+	CreateGroup(ctx context.Context, req CreateGroupRequest) (groupID string, err error)
+
+	// DeleteGroup deletes a group and its memberships.
+	// It returns domain.ErrInvalidGroupID if an invalid group id is provided.
+	// It returns domain.ErrGroupNotFound if the group does not exist.
+	// It returns domain.ErrInternal if it fails to delete.
+	DeleteGroup(ctx context.Context, groupID string) error
+
+	// AddGroupMember adds a user to a group and writes a GroupMemberAdded event.
+	// It returns domain.ErrInvalidGroupID or domain.ErrInvalidUserID if an invalid id is provided.
+	// It returns domain.ErrGroupNotFound if the group does not exist.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrGroupMemberAlreadyExists if the user is already a member of the group.
+	// It returns domain.ErrInternal if it fails to add the member.
+	AddGroupMember(ctx context.Context, groupID string, userID string) error
+
+	// RemoveGroupMember removes a user from a group and writes a GroupMemberRemoved event.
+	// It returns domain.ErrInvalidGroupID or domain.ErrInvalidUserID if an invalid id is provided.
+	// It returns domain.ErrGroupMemberNotFound if the user is not a member of the group.
+	// It returns domain.ErrInternal if it fails to remove the member.
+	RemoveGroupMember(ctx context.Context, groupID string, userID string) error
+}
+
+type GroupQueries interface {
+	// ListGroupMembers retrieves a paginated list of the members of a group, the most recently added first.
+	// It returns domain.ErrInvalidGroupID if an invalid group id is provided.
+	// It returns domain.ErrInvalidPaginationCursor if an invalid cursor is provided.
+	// It returns domain.ErrGroupNotFound if the group does not exist.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ListGroupMembers(ctx context.Context, req ListGroupMembersRequest) (members []*domain.GroupMember, nextCursor string, err error)
+
+	// ListUserGroups retrieves every group the user is a member of.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ListUserGroups(ctx context.Context, userID string) ([]*domain.Group, error)
+}
+
+type CreateGroupRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ListGroupMembersRequest struct {
+	GroupID string
+	Cursor  string
+	Limit   int32
+}
+
+type groupCreated struct {
+	GroupID string `json:"group_id"`
+	CreateGroupRequest
+}
+
+type groupDeleted struct {
+	GroupID string `json:"group_id"`
+}
+
+type groupMemberChanged struct {
+	GroupID string `json:"group_id"`
+	UserID  string `json:"user_id"`
+}
+
+type groupUseCase struct {
+	l             logger.Interface
+	transaction   domain.Transaction
+	groupQueries  domain.GroupRepoQueries
+	groupCommands domain.GroupRepoCommands
+	outboxRepo    domain.OutboxRepoCommands
+}
+
+func NewGroupUseCase(logger logger.Interface, transaction domain.Transaction, groupQueries domain.GroupRepoQueries,
+	groupCommands domain.GroupRepoCommands, outboxRepo domain.OutboxRepoCommands) *groupUseCase {
+	return &groupUseCase{logger, transaction, groupQueries, groupCommands, outboxRepo}
+}
+
+// CreateGroup creates a new group and returns the created group id.
+// It implements the CreateGroup method of GroupCommands interface
+func (uc groupUseCase) CreateGroup(ctx context.Context, req CreateGroupRequest) (groupID string, err error) {
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		groupID, err = uc.groupCommands.SaveGroup(txCtx, &domain.Group{Name: req.Name, Description: req.Description})
+		if err != nil {
+			return err
+		}
+		return addEvent(txCtx, uc.outboxRepo, "GroupCreated", groupCreated{GroupID: groupID, CreateGroupRequest: req})
+	}); err != nil {
+		if errors.Is(err, domain.ErrGroupAlreadyExists) {
+			return "", err
+		}
+		uc.l.Warn("app-user-groups-create error: %v", err)
+		return "", domain.ErrInternal
+	}
+	return groupID, nil
+}
+
+// DeleteGroup deletes a group and its memberships.
+// It implements the DeleteGroup method of GroupCommands interface
+func (uc groupUseCase) DeleteGroup(ctx context.Context, groupID string) error {
+	if _, err := uuid.Parse(groupID); err != nil {
+		return domain.ErrInvalidGroupID
+	}
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := uc.groupCommands.DeleteGroup(txCtx, groupID); err != nil {
+			return err
+		}
+		return addEvent(txCtx, uc.outboxRepo, "GroupDeleted", groupDeleted{GroupID: groupID})
+	}); err != nil {
+		if errors.Is(err, domain.ErrGroupNotFound) {
+			return err
+		}
+		uc.l.Warn("app-user-groups-delete error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// AddGroupMember adds a user to a group.
+// It implements the AddGroupMember method of GroupCommands interface
+func (uc groupUseCase) AddGroupMember(ctx context.Context, groupID string, userID string) error {
+	if err := validateMembership(groupID, userID); err != nil {
+		return err
+	}
+	domain.SetAuditTarget(ctx, userID)
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := uc.groupCommands.AddMember(txCtx, groupID, userID); err != nil {
+			return err
+		}
+		return addGroupMemberEvent(txCtx, uc.outboxRepo, "GroupMemberAdded", groupID, userID)
+	}); err != nil {
+		if errors.Is(err, domain.ErrGroupNotFound) || errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrGroupMemberAlreadyExists) {
+			return err
+		}
+		uc.l.Warn("app-user-groups-add-member error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// RemoveGroupMember removes a user from a group.
+// It implements the RemoveGroupMember method of GroupCommands interface
+func (uc groupUseCase) RemoveGroupMember(ctx context.Context, groupID string, userID string) error {
+	if err := validateMembership(groupID, userID); err != nil {
+		return err
+	}
+	domain.SetAuditTarget(ctx, userID)
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := uc.groupCommands.RemoveMember(txCtx, groupID, userID); err != nil {
+			return err
+		}
+		return addGroupMemberEvent(txCtx, uc.outboxRepo, "GroupMemberRemoved", groupID, userID)
+	}); err != nil {
+		if errors.Is(err, domain.ErrGroupMemberNotFound) {
+			return err
+		}
+		uc.l.Warn("app-user-groups-remove-member error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// ListGroupMembers retrieves a paginated list of the members of a group.
+// It implements the ListGroupMembers method of GroupQueries interface
+func (uc groupUseCase) ListGroupMembers(ctx context.Context, req ListGroupMembersRequest) (members []*domain.GroupMember, nextCur string, err error) {
+	if _, err := uuid.Parse(req.GroupID); err != nil {
+		return []*domain.GroupMember{}, "", domain.ErrInvalidGroupID
+	}
+	var addedAtCur *time.Time
+	var userIDCur string
+	if len(req.Cursor) > 0 {
+		var aCur time.Time
+		aCur, userIDCur, err = decodeCursor(req.Cursor)
+		if err != nil {
+			uc.l.Debug("app-user-groups error decoding cursor: %v", err)
+			return []*domain.GroupMember{}, "", domain.ErrInvalidPaginationCursor
+		}
+		addedAtCur = &aCur
+	}
+
+	if err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		if _, err := uc.groupQueries.GetGroup(txCtx, req.GroupID); err != nil {
+			return err
+		}
+		members, err = uc.groupQueries.ListGroupMembers(txCtx, req.GroupID, userIDCur, addedAtCur, req.Limit)
+		return err
+	}); err != nil {
+		if errors.Is(err, domain.ErrGroupNotFound) {
+			return []*domain.GroupMember{}, "", err
+		}
+		uc.l.Warn("app-user-groups error listing members of group %s: %v", req.GroupID, err)
+		return []*domain.GroupMember{}, "", domain.ErrInternal
+	}
+
+	if len(members) == int(req.Limit) {
+		last := members[len(members)-1]
+		nextCur = encodeCursor(last.AddedAt, last.ID.String())
+	}
+	return members, nextCur, nil
+}
+
+// ListUserGroups retrieves every group the user is a member of.
+// It implements the ListUserGroups method of GroupQueries interface
+func (uc groupUseCase) ListUserGroups(ctx context.Context, userID string) (groups []*domain.Group, err error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrInvalidUserID
+	}
+	if err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		groups, err = uc.groupQueries.ListUserGroups(txCtx, userID)
+		return err
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		uc.l.Warn("app-user-groups error listing groups of user %s: %v", userID, err)
+		return nil, domain.ErrInternal
+	}
+	return groups, nil
+}
+
+func validateMembership(groupID string, userID string) error {
+	if _, err := uuid.Parse(groupID); err != nil {
+		return domain.ErrInvalidGroupID
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return domain.ErrInvalidUserID
+	}
+	return nil
+}
+
+// addGroupMemberEvent writes a membership change event to the outbox
+func addGroupMemberEvent(ctx context.Context, outboxRepo domain.OutboxRepoCommands, eventType string, groupID string, userID string) error {
+	return addEvent(ctx, outboxRepo, eventType, groupMemberChanged{GroupID: groupID, UserID: userID})
+}
+
+// addEvent writes an event with the JSON encoded payload to the outbox
+func addEvent(ctx context.Context, outboxRepo domain.OutboxRepoCommands, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = outboxRepo.AddEvent(ctx, &domain.Event{Type: eventType, Payload: data})
+	return err
+}
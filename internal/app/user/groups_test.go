@@ -0,0 +1,414 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// passthroughTx runs the transaction function and returns its error
+func passthroughTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_groupUseCase_CreateGroup(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewGroupRepoQueries(t)
+	commandsMock := domainMocks.NewGroupRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	groupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewGroupUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+	req := CreateGroupRequest{Name: "admins", Description: "Administrators"}
+
+	tests := []struct {
+		name          string
+		expectedMocks func()
+		want          string
+		wantErr       error
+	}{
+		{
+			name: "already exists",
+			expectedMocks: func() {
+				commandsMock.On("SaveGroup", mock.Anything, &domain.Group{Name: "admins", Description: "Administrators"}).Return("", domain.ErrGroupAlreadyExists).Once()
+			},
+			wantErr: domain.ErrGroupAlreadyExists,
+		},
+		{
+			name: "failed to add event to outbox",
+			expectedMocks: func() {
+				commandsMock.On("SaveGroup", mock.Anything, mock.Anything).Return(groupID, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "success",
+			expectedMocks: func() {
+				commandsMock.On("SaveGroup", mock.Anything, mock.Anything).Return(groupID, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "GroupCreated",
+					Payload: []byte(`{"group_id":"` + groupID + `","name":"admins","description":"Administrators"}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+			want: groupID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.CreateGroup(context.Background(), req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_groupUseCase_DeleteGroup(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewGroupRepoQueries(t)
+	commandsMock := domainMocks.NewGroupRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	groupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewGroupUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		groupID       string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "invalid group id",
+			groupID: "invalid",
+			wantErr: domain.ErrInvalidGroupID,
+		},
+		{
+			name:    "not found",
+			groupID: groupID,
+			expectedMocks: func() {
+				commandsMock.On("DeleteGroup", mock.Anything, groupID).Return(domain.ErrGroupNotFound).Once()
+			},
+			wantErr: domain.ErrGroupNotFound,
+		},
+		{
+			name:    "success",
+			groupID: groupID,
+			expectedMocks: func() {
+				commandsMock.On("DeleteGroup", mock.Anything, groupID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "GroupDeleted",
+					Payload: []byte(`{"group_id":"` + groupID + `"}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.DeleteGroup(context.Background(), tt.groupID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_groupUseCase_AddGroupMember(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewGroupRepoQueries(t)
+	commandsMock := domainMocks.NewGroupRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	groupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewGroupUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		groupID       string
+		userID        string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "invalid group id",
+			groupID: "invalid",
+			userID:  userID,
+			wantErr: domain.ErrInvalidGroupID,
+		},
+		{
+			name:    "invalid user id",
+			groupID: groupID,
+			userID:  "invalid",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "already a member",
+			groupID: groupID,
+			userID:  userID,
+			expectedMocks: func() {
+				commandsMock.On("AddMember", mock.Anything, groupID, userID).Return(domain.ErrGroupMemberAlreadyExists).Once()
+			},
+			wantErr: domain.ErrGroupMemberAlreadyExists,
+		},
+		{
+			name:    "success",
+			groupID: groupID,
+			userID:  userID,
+			expectedMocks: func() {
+				commandsMock.On("AddMember", mock.Anything, groupID, userID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "GroupMemberAdded",
+					Payload: []byte(`{"group_id":"` + groupID + `","user_id":"` + userID + `"}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.AddGroupMember(context.Background(), tt.groupID, tt.userID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_groupUseCase_RemoveGroupMember(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewGroupRepoQueries(t)
+	commandsMock := domainMocks.NewGroupRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	groupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewGroupUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name: "not a member",
+			expectedMocks: func() {
+				commandsMock.On("RemoveMember", mock.Anything, groupID, userID).Return(domain.ErrGroupMemberNotFound).Once()
+			},
+			wantErr: domain.ErrGroupMemberNotFound,
+		},
+		{
+			name: "failed to add event to outbox",
+			expectedMocks: func() {
+				commandsMock.On("RemoveMember", mock.Anything, groupID, userID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "success",
+			expectedMocks: func() {
+				commandsMock.On("RemoveMember", mock.Anything, groupID, userID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "GroupMemberRemoved",
+					Payload: []byte(`{"group_id":"` + groupID + `","user_id":"` + userID + `"}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.RemoveGroupMember(context.Background(), groupID, userID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_groupUseCase_ListGroupMembers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewGroupRepoQueries(t)
+	commandsMock := domainMocks.NewGroupRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	groupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	addedAt := time.Date(2024, time.August, 22, 20, 9, 11, 938220000, time.UTC)
+	member := &domain.GroupMember{User: domain.User{ID: uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3")}, AddedAt: addedAt}
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewGroupUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+	var nilt *time.Time
+
+	tests := []struct {
+		name          string
+		req           ListGroupMembersRequest
+		expectedMocks func()
+		want          []*domain.GroupMember
+		wantNextCur   string
+		wantErr       error
+	}{
+		{
+			name:    "invalid group id",
+			req:     ListGroupMembersRequest{GroupID: "invalid", Limit: 1},
+			wantErr: domain.ErrInvalidGroupID,
+		},
+		{
+			name:    "invalid cursor",
+			req:     ListGroupMembersRequest{GroupID: groupID, Cursor: "invalid", Limit: 1},
+			wantErr: domain.ErrInvalidPaginationCursor,
+		},
+		{
+			name: "group not found",
+			req:  ListGroupMembersRequest{GroupID: groupID, Limit: 1},
+			expectedMocks: func() {
+				queriesMock.On("GetGroup", mock.Anything, groupID).Return(nil, domain.ErrGroupNotFound).Once()
+			},
+			wantErr: domain.ErrGroupNotFound,
+		},
+		{
+			name: "more pages",
+			req:  ListGroupMembersRequest{GroupID: groupID, Limit: 1},
+			expectedMocks: func() {
+				queriesMock.On("GetGroup", mock.Anything, groupID).Return(&domain.Group{}, nil).Once()
+				queriesMock.On("ListGroupMembers", mock.Anything, groupID, "", nilt, int32(1)).Return([]*domain.GroupMember{member}, nil).Once()
+			},
+			want:        []*domain.GroupMember{member},
+			wantNextCur: encodeCursor(addedAt, member.ID.String()),
+		},
+		{
+			name: "last page",
+			req:  ListGroupMembersRequest{GroupID: groupID, Cursor: encodeCursor(addedAt, member.ID.String()), Limit: 2},
+			expectedMocks: func() {
+				queriesMock.On("GetGroup", mock.Anything, groupID).Return(&domain.Group{}, nil).Once()
+				queriesMock.On("ListGroupMembers", mock.Anything, groupID, member.ID.String(), mock.MatchedBy(func(ts *time.Time) bool {
+					return ts.Equal(addedAt)
+				}), int32(2)).Return([]*domain.GroupMember{member}, nil).Once()
+			},
+			want: []*domain.GroupMember{member},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, gotNextCur, err := uc.ListGroupMembers(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantNextCur, gotNextCur)
+		})
+	}
+}
+
+func Test_groupUseCase_ListUserGroups(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewGroupRepoQueries(t)
+	commandsMock := domainMocks.NewGroupRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	groups := []*domain.Group{{ID: uuid.MustParse("5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"), Name: "admins"}}
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewGroupUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		userID        string
+		expectedMocks func()
+		want          []*domain.Group
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			userID:  "invalid",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:   "user not found",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("ListUserGroups", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:   "repository error",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("ListUserGroups", mock.Anything, userID).Return(nil, domain.ErrFailedToProcessData).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:   "success",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("ListUserGroups", mock.Anything, userID).Return(groups, nil).Once()
+			},
+			want: groups,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.ListUserGroups(context.Background(), tt.userID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
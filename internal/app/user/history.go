@@ -0,0 +1,138 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type HistoryQueries interface {
+	// ListUserVersions retrieves a paginated list of the recorded versions of a user, the most recent first.
+	// The versions of deleted users are kept for the configured retention period.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInvalidPaginationCursor if an invalid cursor is provided.
+	// It returns domain.ErrUserNotFound if the user has no recorded versions.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ListUserVersions(ctx context.Context, req ListUserVersionsRequest) (versions []*domain.UserVersion, nextCursor string, err error)
+
+	// GetUserAsOf retrieves the version of a user that was current at the provided time.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrUserNotFound if the user did not exist at that time or its history was purged.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	GetUserAsOf(ctx context.Context, userID string, at time.Time) (*domain.UserVersion, error)
+}
+
+type HistoryCommands interface {
+	// PurgeExpiredHistory deletes the history of the users deleted longer than the retention period ago, across every tenant.
+	// It does nothing if the retention period is not positive.
+	// It returns domain.ErrInternal if it fails to purge.
+	PurgeExpiredHistory(ctx context.Context) error
+}
+
+// HistoryConfig defines for how long the history of deleted users is kept
+type HistoryConfig struct {
+	Retention time.Duration
+}
+
+type ListUserVersionsRequest struct {
+	UserID string
+	Cursor string
+	Limit  int32
+}
+
+type historyUseCase struct {
+	l               logger.Interface
+	transaction     domain.Transaction
+	historyQueries  domain.UserHistoryRepoQueries
+	historyCommands domain.UserHistoryRepoCommands
+	cfg             HistoryConfig
+}
+
+func NewHistoryUseCase(logger logger.Interface, transaction domain.Transaction, historyQueries domain.UserHistoryRepoQueries,
+	historyCommands domain.UserHistoryRepoCommands, cfg HistoryConfig) *historyUseCase {
+	return &historyUseCase{logger, transaction, historyQueries, historyCommands, cfg}
+}
+
+// ListUserVersions retrieves a paginated list of the recorded versions of a user.
+// It implements the ListUserVersions method of HistoryQueries interface
+func (uc historyUseCase) ListUserVersions(ctx context.Context, req ListUserVersionsRequest) (versions []*domain.UserVersion, nextCur string, err error) {
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return []*domain.UserVersion{}, "", domain.ErrInvalidUserID
+	}
+	var recordedAtCur *time.Time
+	var versionCur int64
+	if len(req.Cursor) > 0 {
+		rCur, vCur, err := decodeCursor(req.Cursor)
+		if err == nil {
+			versionCur, err = strconv.ParseInt(vCur, 10, 64)
+		}
+		if err != nil {
+			uc.l.Debug("app-user-history error decoding cursor: %v", err)
+			return []*domain.UserVersion{}, "", domain.ErrInvalidPaginationCursor
+		}
+		recordedAtCur = &rCur
+	}
+
+	if err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		versions, err = uc.historyQueries.ListUserVersions(txCtx, req.UserID, versionCur, recordedAtCur, req.Limit)
+		return err
+	}); err != nil {
+		uc.l.Warn("app-user-history error listing versions of user %s: %v", req.UserID, err)
+		return []*domain.UserVersion{}, "", domain.ErrInternal
+	}
+	// later pages may be empty, but every user has at least the version recorded on creation
+	if len(versions) == 0 && recordedAtCur == nil {
+		return []*domain.UserVersion{}, "", domain.ErrUserNotFound
+	}
+
+	if len(versions) == int(req.Limit) {
+		last := versions[len(versions)-1]
+		nextCur = encodeCursor(last.RecordedAt, strconv.FormatInt(last.Version, 10))
+	}
+	return versions, nextCur, nil
+}
+
+// GetUserAsOf retrieves the version of a user that was current at the provided time.
+// It implements the GetUserAsOf method of HistoryQueries interface
+func (uc historyUseCase) GetUserAsOf(ctx context.Context, userID string, at time.Time) (version *domain.UserVersion, err error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrInvalidUserID
+	}
+	if err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		version, err = uc.historyQueries.GetUserVersionAt(txCtx, userID, at)
+		return err
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		uc.l.Warn("app-user-history error getting user %s as of %s: %v", userID, at, err)
+		return nil, domain.ErrInternal
+	}
+	if version.Operation == domain.UserVersionDelete {
+		return nil, domain.ErrUserNotFound
+	}
+	return version, nil
+}
+
+// PurgeExpiredHistory deletes the history of the users deleted longer than the retention period ago.
+// It implements the PurgeExpiredHistory method of HistoryCommands interface
+func (uc historyUseCase) PurgeExpiredHistory(ctx context.Context) error {
+	if uc.cfg.Retention <= 0 {
+		return nil
+	}
+	var purged int64
+	if err := uc.transaction.BeginTx(domain.WithAllTenants(ctx), func(txCtx context.Context) (err error) {
+		purged, err = uc.historyCommands.PurgeDeletedUsersHistory(txCtx, time.Now().Add(-uc.cfg.Retention))
+		return err
+	}); err != nil {
+		uc.l.Warn("app-user-history-purge error: %v", err)
+		return domain.ErrInternal
+	}
+	uc.l.Debug("app-user-history-purge deleted %d versions", purged)
+	return nil
+}
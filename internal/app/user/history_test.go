@@ -0,0 +1,207 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_historyUseCase_ListUserVersions(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewUserHistoryRepoQueries(t)
+	commandsMock := domainMocks.NewUserHistoryRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	tnow := time.Now().UTC()
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewHistoryUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, HistoryConfig{})
+	versions := []*domain.UserVersion{
+		{User: domain.User{ID: uuid.MustParse(userID), Email: "new@example.com"}, Version: 7, Operation: domain.UserVersionUpdate, RecordedAt: tnow},
+		{User: domain.User{ID: uuid.MustParse(userID), Email: "old@example.com"}, Version: 3, Operation: domain.UserVersionCreate, RecordedAt: tnow.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name          string
+		req           ListUserVersionsRequest
+		expectedMocks func()
+		want          []*domain.UserVersion
+		wantCursor    string
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			req:     ListUserVersionsRequest{UserID: "invalid", Limit: 2},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "invalid cursor",
+			req:     ListUserVersionsRequest{UserID: userID, Limit: 2, Cursor: encodeCursor(tnow, "not-a-version")},
+			wantErr: domain.ErrInvalidPaginationCursor,
+		},
+		{
+			name: "no versions",
+			req:  ListUserVersionsRequest{UserID: userID, Limit: 2},
+			expectedMocks: func() {
+				queriesMock.On("ListUserVersions", mock.Anything, userID, int64(0), (*time.Time)(nil), int32(2)).Return(nil, nil).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "repository error",
+			req:  ListUserVersionsRequest{UserID: userID, Limit: 2},
+			expectedMocks: func() {
+				queriesMock.On("ListUserVersions", mock.Anything, userID, int64(0), (*time.Time)(nil), int32(2)).Return(nil, domain.ErrFailedToProcessData).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "full page returns a cursor",
+			req:  ListUserVersionsRequest{UserID: userID, Limit: 2},
+			expectedMocks: func() {
+				queriesMock.On("ListUserVersions", mock.Anything, userID, int64(0), (*time.Time)(nil), int32(2)).Return(versions, nil).Once()
+			},
+			want:       versions,
+			wantCursor: encodeCursor(tnow.Add(-time.Hour), "3"),
+		},
+		{
+			name: "empty last page",
+			req:  ListUserVersionsRequest{UserID: userID, Limit: 2, Cursor: encodeCursor(tnow.Add(-time.Hour), "3")},
+			expectedMocks: func() {
+				cursor := tnow.Add(-time.Hour)
+				queriesMock.On("ListUserVersions", mock.Anything, userID, int64(3), &cursor, int32(2)).Return(nil, nil).Once()
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, cursor, err := uc.ListUserVersions(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCursor, cursor)
+		})
+	}
+}
+
+func Test_historyUseCase_GetUserAsOf(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewUserHistoryRepoQueries(t)
+	commandsMock := domainMocks.NewUserHistoryRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewHistoryUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, HistoryConfig{})
+	version := &domain.UserVersion{User: domain.User{ID: uuid.MustParse(userID), Email: "old@example.com"}, Version: 3, Operation: domain.UserVersionUpdate}
+
+	tests := []struct {
+		name          string
+		userID        string
+		expectedMocks func()
+		want          *domain.UserVersion
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			userID:  "invalid",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:   "not created yet",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUserVersionAt", mock.Anything, userID, at).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:   "already deleted",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUserVersionAt", mock.Anything, userID, at).Return(&domain.UserVersion{Version: 4, Operation: domain.UserVersionDelete}, nil).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:   "repository error",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUserVersionAt", mock.Anything, userID, at).Return(nil, domain.ErrFailedToProcessData).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:   "success",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUserVersionAt", mock.Anything, userID, at).Return(version, nil).Once()
+			},
+			want: version,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.GetUserAsOf(context.Background(), tt.userID, at)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_historyUseCase_PurgeExpiredHistory(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewUserHistoryRepoQueries(t)
+	commandsMock := domainMocks.NewUserHistoryRepoCommands(t)
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.MatchedBy(domain.IsAllTenants), mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	t.Run("disabled retention keeps the history", func(t *testing.T) {
+		uc := NewHistoryUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, HistoryConfig{})
+		assert.NoError(t, uc.PurgeExpiredHistory(context.Background()))
+	})
+
+	uc := NewHistoryUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, HistoryConfig{Retention: 24 * time.Hour})
+	deletedBefore := mock.MatchedBy(func(ts time.Time) bool {
+		return ts.Before(time.Now().Add(-23*time.Hour)) && ts.After(time.Now().Add(-25*time.Hour))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		commandsMock.On("PurgeDeletedUsersHistory", mock.Anything, deletedBefore).Return(int64(0), domain.ErrInternal).Once()
+		assert.EqualError(t, uc.PurgeExpiredHistory(context.Background()), domain.ErrInternal.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		commandsMock.On("PurgeDeletedUsersHistory", mock.Anything, deletedBefore).Return(int64(5), nil).Once()
+		assert.NoError(t, uc.PurgeExpiredHistory(context.Background()))
+	})
+}
@@ -0,0 +1,287 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const _invitationTokenBytes = 32
+
+type InvitationCommands interface {
+	// InviteUser creates an invitation to the email and requests its delivery through the InvitationCreated event,
+	// which carries the invitation token. Pending invitations to the same email are revoked.
+	// It returns domain.ErrUserAlreadyExists if a user with the same email already exists.
+	// It returns domain.ErrInternal if it fails to create the invitation.
+	InviteUser(ctx context.Context, req InviteUserRequest) (*domain.Invitation, error)
+
+	// AcceptInvitation creates the invited user with the provided nickname and password and returns the created user id.
+	// It returns domain.ErrInvalidPW if an invalid password is provided.
+	// It returns domain.ErrInvitationNotFound if there is no pending invitation with the token.
+	// It returns domain.ErrInvitationExpired if the invitation expired.
+	// It returns domain.ErrUserAlreadyExists if the user conflicts in the unique fields (email or nickname).
+	// It returns domain.ErrInternal if it fails to accept the invitation.
+	AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (userID string, err error)
+
+	// RevokeInvitation revokes a pending invitation and writes an InvitationRevoked event.
+	// It returns domain.ErrInvalidInvitationID if an invalid invitation id is provided.
+	// It returns domain.ErrInvitationNotFound if the invitation does not exist or is no longer pending.
+	// It returns domain.ErrInternal if it fails to revoke.
+	RevokeInvitation(ctx context.Context, invitationID string) error
+}
+
+type InvitationQueries interface {
+	// ListInvitations retrieves a paginated list of invitations, the most recent first.
+	// It returns domain.ErrInvalidInvitationStatus if an unknown status is provided.
+	// It returns domain.ErrInvalidPaginationCursor if an invalid cursor is provided.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ListInvitations(ctx context.Context, req ListInvitationsRequest) (invitations []*domain.Invitation, nextCursor string, err error)
+}
+
+type InviteUserRequest struct {
+	Email          string   `json:"email"`
+	FirstName      string   `json:"first_name"`
+	LastName       string   `json:"last_name"`
+	CountryISOCode string   `json:"country"`
+	Roles          []string `json:"roles"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string
+	NickName string
+	Password string
+}
+
+type ListInvitationsRequest struct {
+	// Status is one of pending, accepted, revoked or expired, empty lists every invitation
+	Status string
+	Cursor string
+	Limit  int32
+}
+
+// InvitationConfig defines the lifetime of the invitations
+type InvitationConfig struct {
+	TokenTTL time.Duration
+}
+
+type invitationCreated struct {
+	InvitationID string `json:"invitation_id"`
+	InviteUserRequest
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type invitationAccepted struct {
+	InvitationID string   `json:"invitation_id"`
+	UserID       string   `json:"user_id"`
+	Roles        []string `json:"roles"`
+}
+
+type invitationRevoked struct {
+	InvitationID string `json:"invitation_id"`
+}
+
+type invitationUseCase struct {
+	l                  logger.Interface
+	transaction        domain.Transaction
+	invitationQueries  domain.InvitationRepoQueries
+	invitationCommands domain.InvitationRepoCommands
+	userCommands       domain.UserRepoCommands
+	outboxRepo         domain.OutboxRepoCommands
+	cfg                InvitationConfig
+}
+
+func NewInvitationUseCase(logger logger.Interface, transaction domain.Transaction, invitationQueries domain.InvitationRepoQueries,
+	invitationCommands domain.InvitationRepoCommands, userCommands domain.UserRepoCommands, outboxRepo domain.OutboxRepoCommands,
+	cfg InvitationConfig) *invitationUseCase {
+	return &invitationUseCase{logger, transaction, invitationQueries, invitationCommands, userCommands, outboxRepo, cfg}
+}
+
+// InviteUser creates an invitation to the email.
+// It implements the InviteUser method of InvitationCommands interface
+func (uc invitationUseCase) InviteUser(ctx context.Context, req InviteUserRequest) (*domain.Invitation, error) {
+	token, err := generateInvitationToken()
+	if err != nil {
+		uc.l.Warn("app-user-invitations-invite token generation error: %v", err)
+		return nil, domain.ErrInternal
+	}
+	now := time.Now().UTC()
+	invitation := domain.Invitation{
+		Email:          req.Email,
+		FirstName:      req.FirstName,
+		LastName:       req.LastName,
+		CountryISOCode: req.CountryISOCode,
+		Roles:          req.Roles,
+		TokenHash:      hashInvitationToken(token),
+		Status:         domain.InvitationPending,
+		ExpiresAt:      now.Add(uc.cfg.TokenTTL),
+		CreatedAt:      now,
+	}
+
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		id, err := uc.invitationCommands.SaveInvitation(txCtx, &invitation)
+		if err != nil {
+			return err
+		}
+		if invitation.ID, err = uuid.Parse(id); err != nil {
+			return err
+		}
+		return addEvent(txCtx, uc.outboxRepo, "InvitationCreated", invitationCreated{
+			InvitationID:      id,
+			InviteUserRequest: req,
+			Token:             token,
+			ExpiresAt:         invitation.ExpiresAt,
+		})
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			return nil, err
+		}
+		uc.l.Warn("app-user-invitations-invite error: %v", err)
+		return nil, domain.ErrInternal
+	}
+	return &invitation, nil
+}
+
+// AcceptInvitation creates the invited user.
+// The configured minimum age is not checked, since the invitations are created by admins.
+// It implements the AcceptInvitation method of InvitationCommands interface
+func (uc invitationUseCase) AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (userID string, err error) {
+	if err := validatePassword(req.Password); err != nil {
+		return "", err
+	}
+	hashedPassword, err := hashPassword(req.Password)
+	if err != nil {
+		uc.l.Debug("app-user-invitations-accept - password hashing error: %s", err)
+		return "", domain.ErrInvalidPW
+	}
+
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		invitation, err := uc.invitationCommands.GetPendingInvitation(txCtx, hashInvitationToken(req.Token))
+		if err != nil {
+			return err
+		}
+		if time.Now().After(invitation.ExpiresAt) {
+			return domain.ErrInvitationExpired
+		}
+
+		user := domain.User{
+			FirstName:      invitation.FirstName,
+			LastName:       invitation.LastName,
+			NickName:       req.NickName,
+			Email:          invitation.Email,
+			CountryISOCode: invitation.CountryISOCode,
+			Password:       hashedPassword,
+		}
+		userID, err = uc.userCommands.SaveUser(txCtx, &user)
+		if err != nil {
+			return err
+		}
+		domain.SetAuditTarget(txCtx, userID)
+		domain.AddAuditDiff(txCtx, userDiff(nil, &user))
+		if err := uc.invitationCommands.MarkInvitationAccepted(txCtx, invitation.ID.String(), userID); err != nil {
+			return err
+		}
+
+		if err := addEvent(txCtx, uc.outboxRepo, "CreateUser", userCreated{ID: userID, AddUserRequest: AddUserRequest{
+			FirstName:      invitation.FirstName,
+			LastName:       invitation.LastName,
+			NickName:       req.NickName,
+			Email:          invitation.Email,
+			CountryISOCode: invitation.CountryISOCode,
+		}}); err != nil {
+			return err
+		}
+		return addEvent(txCtx, uc.outboxRepo, "InvitationAccepted", invitationAccepted{
+			InvitationID: invitation.ID.String(),
+			UserID:       userID,
+			Roles:        invitation.Roles,
+		})
+	}); err != nil {
+		if errors.Is(err, domain.ErrInvitationNotFound) || errors.Is(err, domain.ErrInvitationExpired) || errors.Is(err, domain.ErrUserAlreadyExists) {
+			return "", err
+		}
+		uc.l.Warn("app-user-invitations-accept error: %v", err)
+		return "", domain.ErrInternal
+	}
+	return userID, nil
+}
+
+// RevokeInvitation revokes a pending invitation.
+// It implements the RevokeInvitation method of InvitationCommands interface
+func (uc invitationUseCase) RevokeInvitation(ctx context.Context, invitationID string) error {
+	if _, err := uuid.Parse(invitationID); err != nil {
+		return domain.ErrInvalidInvitationID
+	}
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := uc.invitationCommands.RevokeInvitation(txCtx, invitationID); err != nil {
+			return err
+		}
+		return addEvent(txCtx, uc.outboxRepo, "InvitationRevoked", invitationRevoked{InvitationID: invitationID})
+	}); err != nil {
+		if errors.Is(err, domain.ErrInvitationNotFound) {
+			return err
+		}
+		uc.l.Warn("app-user-invitations-revoke error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// ListInvitations retrieves a paginated list of invitations.
+// It implements the ListInvitations method of InvitationQueries interface
+func (uc invitationUseCase) ListInvitations(ctx context.Context, req ListInvitationsRequest) (invitations []*domain.Invitation, nextCur string, err error) {
+	switch req.Status {
+	case "", domain.InvitationPending, domain.InvitationAccepted, domain.InvitationRevoked, domain.InvitationExpired:
+	default:
+		return []*domain.Invitation{}, "", domain.ErrInvalidInvitationStatus
+	}
+	var createdAtCur *time.Time
+	var idCur string
+	if len(req.Cursor) > 0 {
+		var cCur time.Time
+		cCur, idCur, err = decodeCursor(req.Cursor)
+		if err != nil {
+			uc.l.Debug("app-user-invitations error decoding cursor: %v", err)
+			return []*domain.Invitation{}, "", domain.ErrInvalidPaginationCursor
+		}
+		createdAtCur = &cCur
+	}
+
+	if err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		invitations, err = uc.invitationQueries.ListInvitations(txCtx, idCur, createdAtCur, req.Limit, req.Status)
+		return err
+	}); err != nil {
+		uc.l.Warn("app-user-invitations error listing invitations: %v", err)
+		return []*domain.Invitation{}, "", domain.ErrInternal
+	}
+
+	if len(invitations) == int(req.Limit) {
+		last := invitations[len(invitations)-1]
+		nextCur = encodeCursor(last.CreatedAt, last.ID.String())
+	}
+	return invitations, nextCur, nil
+}
+
+// generateInvitationToken generates a random url safe token
+func generateInvitationToken() (string, error) {
+	b := make([]byte, _invitationTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashInvitationToken hashes the token with sha256.
+// Unlike passwords, tokens are random and looked up by their hash, so a deterministic fast hash is enough.
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
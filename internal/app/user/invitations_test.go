@@ -0,0 +1,294 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_invitationUseCase_InviteUser(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewInvitationRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	invitationID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewInvitationUseCase(mockedLogger, transactionMock, domainMocks.NewInvitationRepoQueries(t), commandsMock,
+		domainMocks.NewUserRepoCommands(t), outboxCommandsMock, InvitationConfig{TokenTTL: time.Hour})
+	req := InviteUserRequest{Email: "staff@example.com", FirstName: "first", LastName: "last", Roles: []string{"admin"}}
+
+	tests := []struct {
+		name          string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name: "user already exists",
+			expectedMocks: func() {
+				commandsMock.On("SaveInvitation", mock.Anything, mock.Anything).Return("", domain.ErrUserAlreadyExists).Once()
+			},
+			wantErr: domain.ErrUserAlreadyExists,
+		},
+		{
+			name: "failed to add event to outbox",
+			expectedMocks: func() {
+				commandsMock.On("SaveInvitation", mock.Anything, mock.Anything).Return(invitationID, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "success",
+			expectedMocks: func() {
+				var savedHash string
+				commandsMock.On("SaveInvitation", mock.Anything, mock.MatchedBy(func(inv *domain.Invitation) bool {
+					savedHash = inv.TokenHash
+					return inv.Email == req.Email && inv.Status == domain.InvitationPending && len(inv.TokenHash) == 64
+				})).Return(invitationID, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					var payload invitationCreated
+					if e.Type != "InvitationCreated" || json.Unmarshal(e.Payload, &payload) != nil {
+						return false
+					}
+					// the event carries the token, only its hash is stored
+					return payload.InvitationID == invitationID && hashInvitationToken(payload.Token) == savedHash &&
+						assert.ObjectsAreEqual(payload.Roles, req.Roles)
+				})).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.InviteUser(context.Background(), req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, invitationID, got.ID.String())
+			assert.WithinDuration(t, time.Now().Add(time.Hour), got.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func Test_invitationUseCase_AcceptInvitation(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewInvitationRepoCommands(t)
+	userCommandsMock := domainMocks.NewUserRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	invitationID := uuid.MustParse("5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e")
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	token := "token"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewInvitationUseCase(mockedLogger, transactionMock, domainMocks.NewInvitationRepoQueries(t), commandsMock,
+		userCommandsMock, outboxCommandsMock, InvitationConfig{TokenTTL: time.Hour})
+	pending := func(expiresAt time.Time) *domain.Invitation {
+		return &domain.Invitation{ID: invitationID, Email: "staff@example.com", FirstName: "first", LastName: "last",
+			Roles: []string{"admin"}, Status: domain.InvitationPending, ExpiresAt: expiresAt}
+	}
+
+	tests := []struct {
+		name          string
+		req           AcceptInvitationRequest
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "invalid password",
+			req:     AcceptInvitationRequest{Token: token, NickName: "nick", Password: "weak"},
+			wantErr: domain.ErrInvalidPW,
+		},
+		{
+			name: "invitation not found",
+			req:  AcceptInvitationRequest{Token: token, NickName: "nick", Password: "strong123"},
+			expectedMocks: func() {
+				commandsMock.On("GetPendingInvitation", mock.Anything, hashInvitationToken(token)).Return(nil, domain.ErrInvitationNotFound).Once()
+			},
+			wantErr: domain.ErrInvitationNotFound,
+		},
+		{
+			name: "invitation expired",
+			req:  AcceptInvitationRequest{Token: token, NickName: "nick", Password: "strong123"},
+			expectedMocks: func() {
+				commandsMock.On("GetPendingInvitation", mock.Anything, hashInvitationToken(token)).Return(pending(time.Now().Add(-time.Minute)), nil).Once()
+			},
+			wantErr: domain.ErrInvitationExpired,
+		},
+		{
+			name: "nickname taken",
+			req:  AcceptInvitationRequest{Token: token, NickName: "nick", Password: "strong123"},
+			expectedMocks: func() {
+				commandsMock.On("GetPendingInvitation", mock.Anything, hashInvitationToken(token)).Return(pending(time.Now().Add(time.Hour)), nil).Once()
+				userCommandsMock.On("SaveUser", mock.Anything, mock.Anything).Return("", domain.ErrUserAlreadyExists).Once()
+			},
+			wantErr: domain.ErrUserAlreadyExists,
+		},
+		{
+			name: "success",
+			req:  AcceptInvitationRequest{Token: token, NickName: "nick", Password: "strong123"},
+			expectedMocks: func() {
+				commandsMock.On("GetPendingInvitation", mock.Anything, hashInvitationToken(token)).Return(pending(time.Now().Add(time.Hour)), nil).Once()
+				userCommandsMock.On("SaveUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.Email == "staff@example.com" && u.NickName == "nick" && u.Password != "strong123"
+				})).Return(userID, nil).Once()
+				commandsMock.On("MarkInvitationAccepted", mock.Anything, invitationID.String(), userID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					return e.Type == "CreateUser" && bytes.Contains(e.Payload, []byte(`"id":"`+userID+`"`))
+				})).Return("1", nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "InvitationAccepted",
+					Payload: []byte(`{"invitation_id":"` + invitationID.String() + `","user_id":"` + userID + `","roles":["admin"]}`),
+				}).Return("2", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.AcceptInvitation(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, userID, got)
+		})
+	}
+}
+
+func Test_invitationUseCase_RevokeInvitation(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewInvitationRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	invitationID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewInvitationUseCase(mockedLogger, transactionMock, domainMocks.NewInvitationRepoQueries(t), commandsMock,
+		domainMocks.NewUserRepoCommands(t), outboxCommandsMock, InvitationConfig{})
+
+	tests := []struct {
+		name          string
+		invitationID  string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:         "invalid invitation id",
+			invitationID: "invalid",
+			wantErr:      domain.ErrInvalidInvitationID,
+		},
+		{
+			name:         "not pending",
+			invitationID: invitationID,
+			expectedMocks: func() {
+				commandsMock.On("RevokeInvitation", mock.Anything, invitationID).Return(domain.ErrInvitationNotFound).Once()
+			},
+			wantErr: domain.ErrInvitationNotFound,
+		},
+		{
+			name:         "success",
+			invitationID: invitationID,
+			expectedMocks: func() {
+				commandsMock.On("RevokeInvitation", mock.Anything, invitationID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "InvitationRevoked",
+					Payload: []byte(`{"invitation_id":"` + invitationID + `"}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.RevokeInvitation(context.Background(), tt.invitationID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_invitationUseCase_ListInvitations(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewInvitationRepoQueries(t)
+	createdAt := time.Date(2024, time.August, 22, 20, 9, 11, 938220000, time.UTC)
+	invitation := &domain.Invitation{ID: uuid.MustParse("5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"), CreatedAt: createdAt}
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewInvitationUseCase(mockedLogger, transactionMock, queriesMock, domainMocks.NewInvitationRepoCommands(t),
+		domainMocks.NewUserRepoCommands(t), domainMocks.NewOutboxRepoCommands(t), InvitationConfig{})
+	var nilt *time.Time
+
+	tests := []struct {
+		name          string
+		req           ListInvitationsRequest
+		expectedMocks func()
+		want          []*domain.Invitation
+		wantNextCur   string
+		wantErr       error
+	}{
+		{
+			name:    "invalid status",
+			req:     ListInvitationsRequest{Status: "unknown", Limit: 1},
+			wantErr: domain.ErrInvalidInvitationStatus,
+		},
+		{
+			name:    "invalid cursor",
+			req:     ListInvitationsRequest{Cursor: "invalid", Limit: 1},
+			wantErr: domain.ErrInvalidPaginationCursor,
+		},
+		{
+			name: "more pages",
+			req:  ListInvitationsRequest{Status: domain.InvitationExpired, Limit: 1},
+			expectedMocks: func() {
+				queriesMock.On("ListInvitations", mock.Anything, "", nilt, int32(1), domain.InvitationExpired).Return([]*domain.Invitation{invitation}, nil).Once()
+			},
+			want:        []*domain.Invitation{invitation},
+			wantNextCur: encodeCursor(createdAt, invitation.ID.String()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, gotNextCur, err := uc.ListInvitations(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantNextCur, gotNextCur)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MergeKeepSource = "source"
+	MergeKeepTarget = "target"
+)
+
+type MergeCommands interface {
+	// MergeUsers merges the source user into the target user and writes a UserMerged event.
+	// The target keeps its values unless they are empty or the field resolution picks the source,
+	// the source becomes a tombstone that GetUser resolves to the target.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrSelfMerge if the source and the target are the same user.
+	// It returns domain.ErrInvalidMergeField if the field resolution has an unknown field or value.
+	// It returns domain.ErrUserNotFound if any of the users does not exist or was already merged.
+	// It returns domain.ErrUserAlreadyExists if the merged profile conflicts with another user.
+	// It returns domain.ErrInternal if it fails to merge.
+	MergeUsers(ctx context.Context, req MergeUsersRequest) error
+}
+
+// MergeUsersRequest maps each field to the user whose value is kept, MergeKeepSource or MergeKeepTarget
+type MergeUsersRequest struct {
+	SourceID        string
+	TargetID        string
+	FieldResolution map[string]string
+}
+
+type userMerged struct {
+	SourceID        string            `json:"source_id"`
+	TargetID        string            `json:"target_id"`
+	FieldResolution map[string]string `json:"field_resolution"`
+}
+
+// mergeFields copies the value of each mergeable field from the source into the target.
+// A field is copied when the resolution picks a set source value or when the target value is empty.
+var mergeFields = map[string]func(target *domain.User, source *domain.User, keepSource bool){
+	"first_name":       func(t, s *domain.User, keep bool) { mergeString(&t.FirstName, s.FirstName, keep) },
+	"last_name":        func(t, s *domain.User, keep bool) { mergeString(&t.LastName, s.LastName, keep) },
+	"nickname":         func(t, s *domain.User, keep bool) { mergeString(&t.NickName, s.NickName, keep) },
+	"email":            func(t, s *domain.User, keep bool) { mergeString(&t.Email, s.Email, keep) },
+	"country_iso_code": func(t, s *domain.User, keep bool) { mergeString(&t.CountryISOCode, s.CountryISOCode, keep) },
+	"locale":           func(t, s *domain.User, keep bool) { mergeString(&t.Locale, s.Locale, keep) },
+	"timezone":         func(t, s *domain.User, keep bool) { mergeString(&t.Timezone, s.Timezone, keep) },
+	"date_of_birth": func(t, s *domain.User, keep bool) {
+		if s.DateOfBirth != nil && (keep || t.DateOfBirth == nil) {
+			t.DateOfBirth = s.DateOfBirth
+		}
+	},
+	// the verification follows the phone it belongs to
+	"phone": func(t, s *domain.User, keep bool) {
+		if s.Phone != "" && (keep || t.Phone == "") {
+			t.Phone, t.PhoneVerified = s.Phone, s.PhoneVerified
+		}
+	},
+	"avatar": func(t, s *domain.User, keep bool) {
+		if s.AvatarKey != "" && (keep || t.AvatarKey == "") {
+			t.AvatarKey, t.AvatarURL = s.AvatarKey, s.AvatarURL
+		}
+	},
+}
+
+type mergeUseCase struct {
+	l             logger.Interface
+	transaction   domain.Transaction
+	mergeCommands domain.UserMergeRepoCommands
+	outboxRepo    domain.OutboxRepoCommands
+	blobStore     domain.BlobStore
+}
+
+func NewMergeUseCase(logger logger.Interface, transaction domain.Transaction, mergeCommands domain.UserMergeRepoCommands,
+	outboxRepo domain.OutboxRepoCommands, blobStore domain.BlobStore) *mergeUseCase {
+	return &mergeUseCase{logger, transaction, mergeCommands, outboxRepo, blobStore}
+}
+
+// MergeUsers merges the source user into the target user.
+// It implements the MergeUsers method of MergeCommands interface
+func (uc mergeUseCase) MergeUsers(ctx context.Context, req MergeUsersRequest) error {
+	sourceID, err := uuid.Parse(req.SourceID)
+	if err != nil {
+		return domain.ErrInvalidUserID
+	}
+	targetID, err := uuid.Parse(req.TargetID)
+	if err != nil {
+		return domain.ErrInvalidUserID
+	}
+	if sourceID == targetID {
+		return domain.ErrSelfMerge
+	}
+	domain.SetAuditTarget(ctx, targetID.String())
+	for field, keep := range req.FieldResolution {
+		if _, ok := mergeFields[field]; !ok || (keep != MergeKeepSource && keep != MergeKeepTarget) {
+			return domain.ErrInvalidMergeField
+		}
+	}
+
+	var discardedAvatar string
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		source, target, err := uc.mergeCommands.LockUsersForMerge(txCtx, sourceID.String(), targetID.String())
+		if err != nil {
+			return err
+		}
+		before := *target
+		targetAvatar := target.AvatarKey
+		for field, merge := range mergeFields {
+			merge(target, source, req.FieldResolution[field] == MergeKeepSource)
+		}
+		if err := uc.mergeCommands.MergeUsers(txCtx, sourceID.String(), target); err != nil {
+			return err
+		}
+		domain.AddAuditDiff(txCtx, userDiff(&before, target))
+		if target.AvatarKey == targetAvatar {
+			discardedAvatar = source.AvatarKey
+		} else {
+			discardedAvatar = targetAvatar
+		}
+		return addEvent(txCtx, uc.outboxRepo, "UserMerged", userMerged{
+			SourceID:        sourceID.String(),
+			TargetID:        targetID.String(),
+			FieldResolution: req.FieldResolution,
+		})
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrUserAlreadyExists) {
+			return err
+		}
+		uc.l.Warn("app-user-merge error: %v", err)
+		return domain.ErrInternal
+	}
+
+	if discardedAvatar != "" {
+		if err := uc.blobStore.DeletePrefix(ctx, discardedAvatar); err != nil {
+			uc.l.Warn("app-user-merge error deleting blobs %s: %v", discardedAvatar, err)
+		}
+	}
+	return nil
+}
+
+func mergeString(target *string, source string, keepSource bool) {
+	if source != "" && (keepSource || *target == "") {
+		*target = source
+	}
+}
@@ -0,0 +1,162 @@
+package user
+
+import (
+	"context"
+	"testing"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_mergeUseCase_MergeUsers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	mergeCommandsMock := domainMocks.NewUserMergeRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	blobStoreMock := domainMocks.NewBlobStore(t)
+	sourceID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	targetID := "0d913f6a-497b-4305-b3d1-3f53657e3a27"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewMergeUseCase(mockedLogger, transactionMock, mergeCommandsMock, outboxCommandsMock, blobStoreMock)
+
+	source := func() *domain.User {
+		return &domain.User{
+			ID:            uuid.MustParse(sourceID),
+			FirstName:     "John",
+			LastName:      "Doe",
+			NickName:      "jdoe",
+			Email:         "john@example.com",
+			Phone:         "+351910000000",
+			PhoneVerified: true,
+			Locale:        "pt-PT",
+			AvatarKey:     sourceID + "/a",
+			AvatarURL:     "/avatars/" + sourceID + "/a/256.jpg",
+		}
+	}
+	target := func() *domain.User {
+		return &domain.User{
+			ID:        uuid.MustParse(targetID),
+			FirstName: "Johnny",
+			LastName:  "Doe",
+			NickName:  "johnny",
+			Email:     "johnny@example.com",
+			AvatarKey: targetID + "/b",
+			AvatarURL: "/avatars/" + targetID + "/b/256.jpg",
+		}
+	}
+
+	tests := []struct {
+		name          string
+		req           MergeUsersRequest
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "invalid source id",
+			req:     MergeUsersRequest{SourceID: "invalid", TargetID: targetID},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "invalid target id",
+			req:     MergeUsersRequest{SourceID: sourceID, TargetID: "invalid"},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "self merge",
+			req:     MergeUsersRequest{SourceID: sourceID, TargetID: sourceID},
+			wantErr: domain.ErrSelfMerge,
+		},
+		{
+			name:    "unknown field",
+			req:     MergeUsersRequest{SourceID: sourceID, TargetID: targetID, FieldResolution: map[string]string{"password": "source"}},
+			wantErr: domain.ErrInvalidMergeField,
+		},
+		{
+			name:    "unknown resolution",
+			req:     MergeUsersRequest{SourceID: sourceID, TargetID: targetID, FieldResolution: map[string]string{"email": "both"}},
+			wantErr: domain.ErrInvalidMergeField,
+		},
+		{
+			name: "user not found",
+			req:  MergeUsersRequest{SourceID: sourceID, TargetID: targetID},
+			expectedMocks: func() {
+				mergeCommandsMock.On("LockUsersForMerge", mock.Anything, sourceID, targetID).Return(nil, nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "merged profile conflicts",
+			req:  MergeUsersRequest{SourceID: sourceID, TargetID: targetID},
+			expectedMocks: func() {
+				mergeCommandsMock.On("LockUsersForMerge", mock.Anything, sourceID, targetID).Return(source(), target(), nil).Once()
+				mergeCommandsMock.On("MergeUsers", mock.Anything, sourceID, mock.Anything).Return(domain.ErrUserAlreadyExists).Once()
+			},
+			wantErr: domain.ErrUserAlreadyExists,
+		},
+		{
+			name: "failed to add event to outbox",
+			req:  MergeUsersRequest{SourceID: sourceID, TargetID: targetID},
+			expectedMocks: func() {
+				mergeCommandsMock.On("LockUsersForMerge", mock.Anything, sourceID, targetID).Return(source(), target(), nil).Once()
+				mergeCommandsMock.On("MergeUsers", mock.Anything, sourceID, mock.Anything).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "success keeping the target and filling empty fields",
+			req:  MergeUsersRequest{SourceID: sourceID, TargetID: targetID},
+			expectedMocks: func() {
+				merged := target()
+				merged.Phone, merged.PhoneVerified, merged.Locale = "+351910000000", true, "pt-PT"
+				mergeCommandsMock.On("LockUsersForMerge", mock.Anything, sourceID, targetID).Return(source(), target(), nil).Once()
+				mergeCommandsMock.On("MergeUsers", mock.Anything, sourceID, merged).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserMerged",
+					Payload: []byte(`{"source_id":"` + sourceID + `","target_id":"` + targetID + `","field_resolution":null}`),
+				}).Return("1c0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e", nil).Once()
+				blobStoreMock.On("DeletePrefix", mock.Anything, sourceID+"/a").Return(nil).Once()
+			},
+		},
+		{
+			name: "success keeping source fields",
+			req: MergeUsersRequest{SourceID: sourceID, TargetID: targetID, FieldResolution: map[string]string{
+				"email":     "source",
+				"avatar":    "source",
+				"last_name": "target",
+			}},
+			expectedMocks: func() {
+				merged := target()
+				merged.Email = "john@example.com"
+				merged.AvatarKey, merged.AvatarURL = sourceID+"/a", "/avatars/"+sourceID+"/a/256.jpg"
+				merged.Phone, merged.PhoneVerified, merged.Locale = "+351910000000", true, "pt-PT"
+				mergeCommandsMock.On("LockUsersForMerge", mock.Anything, sourceID, targetID).Return(source(), target(), nil).Once()
+				mergeCommandsMock.On("MergeUsers", mock.Anything, sourceID, merged).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserMerged",
+					Payload: []byte(`{"source_id":"` + sourceID + `","target_id":"` + targetID + `","field_resolution":{"avatar":"source","email":"source","last_name":"target"}}`),
+				}).Return("1c0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e", nil).Once()
+				blobStoreMock.On("DeletePrefix", mock.Anything, targetID+"/b").Return(domain.ErrInternal).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.MergeUsers(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
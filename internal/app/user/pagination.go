@@ -1,10 +1,16 @@
 package user
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"users/internal/domain"
 )
 
 // decodeCursor takes a base64 encoded string and returns the timestamp and userID
@@ -34,3 +40,136 @@ func encodeCursor(ts time.Time, userID string) string {
 	key := fmt.Sprintf("%s|%s", ts.Format(time.RFC3339Nano), userID)
 	return base64.StdEncoding.EncodeToString([]byte(key))
 }
+
+// decodeSearchToken takes a base64 encoded string and returns the rank and userID
+func decodeSearchToken(encodedToken string) (rank float32, userID string, err error) {
+	token, err := base64.StdEncoding.DecodeString(encodedToken)
+	if err != nil {
+		return
+	}
+
+	splitted := strings.Split(string(token), "|")
+	if len(splitted) != 2 {
+		err = fmt.Errorf("page token is invalid")
+		return
+	}
+
+	r, err := strconv.ParseFloat(splitted[0], 32)
+	if err != nil {
+		err = fmt.Errorf("page token is invalid: rank")
+		return
+	}
+	return float32(r), splitted[1], nil
+}
+
+// encodeSearchToken returns a base64 encoded string based on the provided rank and userID.
+// The rank is formatted with the shortest representation that parses back to the same float32,
+// so that the next page resumes exactly after the last user.
+func encodeSearchToken(rank float32, userID string) string {
+	key := fmt.Sprintf("%s|%s", strconv.FormatFloat(float64(rank), 'g', -1, 32), userID)
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
+
+// _userCursorVersion prefixes the users cursors, a new version invalidates the cursors of the previous ones
+const _userCursorVersion = "v1"
+
+// userCursor is the payload of a users cursor: the order, the position of the last user of the page, or the first
+// one when paging backward, a hash of the filters the cursor was issued for, and when it expires
+type userCursor struct {
+	Field    string `json:"f"`
+	Desc     bool   `json:"d,omitempty"`
+	Value    string `json:"v"`
+	UserID   string `json:"u"`
+	Backward bool   `json:"b,omitempty"`
+	Filters  string `json:"h"`
+	Expiry   int64  `json:"e"`
+}
+
+// cursorSigner issues opaque users cursors, HMAC-signed with the first key and verified with any of them,
+// so that the keys are rotated without breaking the cursors in flight
+type cursorSigner struct {
+	keys [][]byte
+	ttl  time.Duration
+}
+
+func newCursorSigner(keys []string, ttl time.Duration) *cursorSigner {
+	s := &cursorSigner{ttl: ttl}
+	for _, key := range keys {
+		s.keys = append(s.keys, []byte(key))
+	}
+	return s
+}
+
+// sign returns the cursor positioned after the user, or before it when backward, in the format v1.<payload>.<signature>
+func (s *cursorSigner) sign(order domain.UserOrder, user *domain.User, backward bool, filtersHash string, now time.Time) (string, error) {
+	payload, err := json.Marshal(userCursor{
+		Field:    order.Field,
+		Desc:     order.Desc,
+		Value:    userSortValue(user, order.Field),
+		UserID:   user.ID.String(),
+		Backward: backward,
+		Filters:  filtersHash,
+		Expiry:   now.Add(s.ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := _userCursorVersion + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(s.mac(s.keys[0], signed)), nil
+}
+
+// verify returns the order and position of the cursor, and whether it pages backward.
+// It returns an error if the cursor is malformed, was not signed by any of the keys, has expired
+// or was issued for other filters.
+func (s *cursorSigner) verify(token string, filtersHash string, now time.Time) (domain.UserOrder, domain.UserCursor, bool, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != _userCursorVersion {
+		return domain.UserOrder{}, domain.UserCursor{}, false, fmt.Errorf("unknown cursor format")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !s.verifies(parts[0]+"."+parts[1], signature) {
+		return domain.UserOrder{}, domain.UserCursor{}, false, fmt.Errorf("cursor signature does not match")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return domain.UserOrder{}, domain.UserCursor{}, false, err
+	}
+	var c userCursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return domain.UserOrder{}, domain.UserCursor{}, false, err
+	}
+	if now.Unix() > c.Expiry {
+		return domain.UserOrder{}, domain.UserCursor{}, false, fmt.Errorf("cursor expired")
+	}
+	if c.Filters != filtersHash {
+		return domain.UserOrder{}, domain.UserCursor{}, false, fmt.Errorf("cursor was issued for other filters")
+	}
+	return domain.UserOrder{Field: c.Field, Desc: c.Desc}, domain.UserCursor{Value: c.Value, UserID: c.UserID}, c.Backward, nil
+}
+
+func (s *cursorSigner) verifies(signed string, signature []byte) bool {
+	for _, key := range s.keys {
+		if hmac.Equal(signature, s.mac(key, signed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *cursorSigner) mac(key []byte, signed string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(signed))
+	return h.Sum(nil)
+}
+
+// hashFilters returns a digest of the filters, the order of the tags is irrelevant
+func hashFilters(filters domain.UserSearchFilters) (string, error) {
+	filters.Tags = append([]string(nil), filters.Tags...)
+	sort.Strings(filters.Tags)
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
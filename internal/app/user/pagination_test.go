@@ -3,9 +3,12 @@ package user
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
+	"users/internal/domain"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -98,3 +101,75 @@ func Test_encodeCursor(t *testing.T) {
 		})
 	}
 }
+
+func Test_searchToken(t *testing.T) {
+	userID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+	rank := float32(0.0607927)
+
+	gotRank, gotUserID, err := decodeSearchToken(encodeSearchToken(rank, userID))
+	assert.NoError(t, err)
+	assert.Equal(t, rank, gotRank)
+	assert.Equal(t, userID, gotUserID)
+
+	_, _, err = decodeSearchToken(base64.StdEncoding.EncodeToString([]byte("a|b|c")))
+	assert.EqualError(t, err, "page token is invalid")
+	_, _, err = decodeSearchToken(base64.StdEncoding.EncodeToString([]byte("high|" + userID)))
+	assert.EqualError(t, err, "page token is invalid: rank")
+}
+
+func Test_cursorSigner(t *testing.T) {
+	user := &domain.User{
+		ID:        uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"),
+		LastName:  "van.der",
+		CreatedAt: time.Date(2024, 8, 22, 20, 30, 3, 5000, time.UTC),
+	}
+	byLastName := domain.UserOrder{Field: domain.UserSortLastName, Desc: true}
+	now := time.Now()
+	signer := newCursorSigner([]string{"key"}, time.Minute)
+
+	cursor, err := signer.sign(byLastName, user, false, "hash", now)
+	assert.NoError(t, err)
+	order, position, backward, err := signer.verify(cursor, "hash", now)
+	assert.NoError(t, err)
+	assert.Equal(t, byLastName, order)
+	assert.Equal(t, domain.UserCursor{Value: "van.der", UserID: user.ID.String()}, position)
+	assert.False(t, backward)
+
+	cursor, err = signer.sign(byLastName, user, true, "hash", now)
+	assert.NoError(t, err)
+	_, _, backward, err = signer.verify(cursor, "hash", now)
+	assert.NoError(t, err)
+	assert.True(t, backward)
+
+	cursor, err = signer.sign(domain.UserOrder{Field: domain.UserSortCreatedAt}, user, false, "hash", now)
+	assert.NoError(t, err)
+	_, position, _, err = signer.verify(cursor, "hash", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-08-22T20:30:03.000005Z", position.Value)
+
+	_, _, _, err = signer.verify(cursor, "hash", now.Add(2*time.Minute))
+	assert.EqualError(t, err, "cursor expired")
+	_, _, _, err = signer.verify(cursor, "other", now)
+	assert.EqualError(t, err, "cursor was issued for other filters")
+	_, _, _, err = signer.verify("v2"+cursor[2:], "hash", now)
+	assert.EqualError(t, err, "unknown cursor format")
+
+	// a payload changed by the client no longer matches the signature
+	parts := strings.Split(cursor, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"f":"created_at","v":"2030-01-01T00:00:00Z","u":"x","h":"hash","e":9999999999}`))
+	_, _, _, err = signer.verify(parts[0]+"."+forged+"."+parts[2], "hash", now)
+	assert.EqualError(t, err, "cursor signature does not match")
+}
+
+func Test_hashFilters(t *testing.T) {
+	first := "ann"
+	a, err := hashFilters(domain.UserSearchFilters{FirstName: &first, Tags: []string{"vip", "beta"}})
+	assert.NoError(t, err)
+	b, err := hashFilters(domain.UserSearchFilters{FirstName: &first, Tags: []string{"beta", "vip"}})
+	assert.NoError(t, err)
+	c, err := hashFilters(domain.UserSearchFilters{FirstName: &first, Tags: []string{"beta"}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
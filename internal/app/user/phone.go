@@ -0,0 +1,230 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/nyaruka/phonenumbers"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const _verificationCodeDigits = 6
+
+type PhoneVerificationCommands interface {
+	// StartPhoneVerification generates a one-time code for the user's phone number
+	// and requests its delivery through the PhoneVerificationRequested event.
+	// Any previous pending verification is replaced.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrPhoneNotSet if the user has no phone number.
+	// It returns domain.ErrPhoneAlreadyVerified if the user's phone number is already verified.
+	// It returns domain.ErrPhoneAlreadyInUse if the phone number is verified by another user.
+	// It returns domain.ErrInternal if it fails to start the verification.
+	StartPhoneVerification(ctx context.Context, userID string) (expiresAt time.Time, err error)
+
+	// ConfirmPhoneVerification checks the one-time code and flags the user's phone number as verified.
+	// Each wrong code counts as an attempt, up to the configured maximum.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrVerificationNotFound if there is no pending verification for the user's current phone number.
+	// It returns domain.ErrVerificationExpired if the code expired.
+	// It returns domain.ErrTooManyVerificationAttempts if the maximum attempts were reached.
+	// It returns domain.ErrInvalidVerificationCode if the code does not match.
+	// It returns domain.ErrPhoneAlreadyInUse if the phone number is verified by another user.
+	// It returns domain.ErrInternal if it fails to confirm the verification.
+	ConfirmPhoneVerification(ctx context.Context, userID string, code string) error
+}
+
+// PhoneVerificationConfig defines the one-time code lifetime and the maximum attempts to confirm it
+type PhoneVerificationConfig struct {
+	CodeTTL     time.Duration
+	MaxAttempts int32
+}
+
+type phoneVerificationRequested struct {
+	UserID    string    `json:"user_id"`
+	Phone     string    `json:"phone"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type phoneVerificationUseCase struct {
+	l                logger.Interface
+	transaction      domain.Transaction
+	userQueries      domain.UserRepoQueries
+	userCommands     domain.UserRepoCommands
+	verificationRepo domain.PhoneVerificationRepoCommands
+	outboxRepo       domain.OutboxRepoCommands
+	cfg              PhoneVerificationConfig
+}
+
+func NewPhoneVerificationUseCase(logger logger.Interface, transaction domain.Transaction, userQueries domain.UserRepoQueries, userCommands domain.UserRepoCommands,
+	verificationRepo domain.PhoneVerificationRepoCommands, outboxRepo domain.OutboxRepoCommands, cfg PhoneVerificationConfig) *phoneVerificationUseCase {
+	return &phoneVerificationUseCase{logger, transaction, userQueries, userCommands, verificationRepo, outboxRepo, cfg}
+}
+
+// StartPhoneVerification generates a one-time code for the user's phone number.
+// It implements the StartPhoneVerification method of PhoneVerificationCommands interface
+func (uc phoneVerificationUseCase) StartPhoneVerification(ctx context.Context, userID string) (time.Time, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return time.Time{}, domain.ErrInvalidUserID
+	}
+	domain.SetAuditTarget(ctx, userID)
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		uc.l.Warn("app-user-phone-start code generation error: %v", err)
+		return time.Time{}, domain.ErrInternal
+	}
+	codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		uc.l.Warn("app-user-phone-start code hashing error: %v", err)
+		return time.Time{}, domain.ErrInternal
+	}
+
+	var verification domain.PhoneVerification
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		u, err := uc.userQueries.GetUser(txCtx, userID, nil)
+		if err != nil {
+			return err
+		}
+		if u.MergedInto != nil {
+			return domain.ErrUserNotFound
+		}
+		if u.Phone == "" {
+			return domain.ErrPhoneNotSet
+		}
+		if u.PhoneVerified {
+			return domain.ErrPhoneAlreadyVerified
+		}
+		inUse, err := uc.userQueries.IsPhoneVerified(txCtx, u.Phone)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return domain.ErrPhoneAlreadyInUse
+		}
+
+		verification = domain.PhoneVerification{
+			UserID:    u.ID,
+			Phone:     u.Phone,
+			CodeHash:  string(codeHash),
+			ExpiresAt: time.Now().Add(uc.cfg.CodeTTL).UTC(),
+		}
+		if err := uc.verificationRepo.SaveVerification(txCtx, &verification); err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(phoneVerificationRequested{
+			UserID:    userID,
+			Phone:     verification.Phone,
+			Code:      code,
+			ExpiresAt: verification.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		event := &domain.Event{
+			Type:    "PhoneVerificationRequested",
+			Payload: payload,
+		}
+		if _, err := uc.outboxRepo.AddEvent(txCtx, event); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrPhoneNotSet),
+			errors.Is(err, domain.ErrPhoneAlreadyVerified), errors.Is(err, domain.ErrPhoneAlreadyInUse):
+			return time.Time{}, err
+		}
+		uc.l.Warn("app-user-phone-start error: %v", err)
+		return time.Time{}, domain.ErrInternal
+	}
+	return verification.ExpiresAt, nil
+}
+
+// ConfirmPhoneVerification checks the one-time code and flags the user's phone number as verified.
+// It implements the ConfirmPhoneVerification method of PhoneVerificationCommands interface
+func (uc phoneVerificationUseCase) ConfirmPhoneVerification(ctx context.Context, userID string, code string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return domain.ErrInvalidUserID
+	}
+	domain.SetAuditTarget(ctx, userID)
+
+	// a wrong code must still commit the attempt, so it is reported after the transaction
+	var codeErr error
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		v, err := uc.verificationRepo.GetVerification(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if time.Now().After(v.ExpiresAt) {
+			return domain.ErrVerificationExpired
+		}
+		if v.Attempts >= uc.cfg.MaxAttempts {
+			return domain.ErrTooManyVerificationAttempts
+		}
+		if bcrypt.CompareHashAndPassword([]byte(v.CodeHash), []byte(code)) != nil {
+			codeErr = domain.ErrInvalidVerificationCode
+			domain.SetAuditFailure(txCtx, codeErr)
+			return uc.verificationRepo.IncrementAttempts(txCtx, userID)
+		}
+
+		if err := uc.userCommands.MarkPhoneVerified(txCtx, userID, v.Phone); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				// the phone number changed after the verification started
+				return domain.ErrVerificationNotFound
+			}
+			return err
+		}
+		domain.AddAuditDiff(txCtx, map[string]domain.FieldChange{"phone_verified": {Old: "false", New: "true"}})
+		return uc.verificationRepo.DeleteVerification(txCtx, userID)
+	}); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrVerificationNotFound),
+			errors.Is(err, domain.ErrVerificationExpired),
+			errors.Is(err, domain.ErrTooManyVerificationAttempts),
+			errors.Is(err, domain.ErrPhoneAlreadyInUse):
+			return err
+		}
+		uc.l.Warn("app-user-phone-confirm error confirming phone of user %s: %v", userID, err)
+		return domain.ErrInternal
+	}
+	return codeErr
+}
+
+// normalizePhone validates the phone number and formats it as E.164.
+// Numbers without an international prefix are parsed using the country as the region.
+// An empty phone number is kept empty.
+func normalizePhone(phone string, countryISOCode string) (string, error) {
+	if strings.TrimSpace(phone) == "" {
+		return "", nil
+	}
+	num, err := phonenumbers.Parse(phone, strings.ToUpper(countryISOCode))
+	if err != nil || !phonenumbers.IsValidNumber(num) {
+		return "", domain.ErrInvalidPhone
+	}
+	return phonenumbers.Format(num, phonenumbers.E164), nil
+}
+
+// generateVerificationCode returns a random numeric code with _verificationCodeDigits digits
+func generateVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < _verificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", _verificationCodeDigits, n), nil
+}
@@ -0,0 +1,332 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_normalizePhone(t *testing.T) {
+	type args struct {
+		phone          string
+		countryISOCode string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "empty phone",
+			args: args{phone: " ", countryISOCode: "PT"},
+			want: "",
+		},
+		{
+			name: "national format uses the country",
+			args: args{phone: "912 345 678", countryISOCode: "pt"},
+			want: "+351912345678",
+		},
+		{
+			name: "international format ignores the country",
+			args: args{phone: "+44 7911 123456", countryISOCode: "PT"},
+			want: "+447911123456",
+		},
+		{
+			name:    "invalid number",
+			args:    args{phone: "12345", countryISOCode: "PT"},
+			wantErr: domain.ErrInvalidPhone,
+		},
+		{
+			name:    "not a number",
+			args:    args{phone: "not a phone", countryISOCode: "PT"},
+			wantErr: domain.ErrInvalidPhone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizePhone(tt.args.phone, tt.args.countryISOCode)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_generateVerificationCode(t *testing.T) {
+	code, err := generateVerificationCode()
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9]{6}$`), code)
+}
+
+func Test_phoneVerificationUseCase_StartPhoneVerification(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewUserRepoQueries(t)
+	commandsMock := domainMocks.NewUserRepoCommands(t)
+	verificationsMock := domainMocks.NewPhoneVerificationRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	cfg := PhoneVerificationConfig{CodeTTL: 5 * time.Minute, MaxAttempts: 3}
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	// the checks and the writes share a single transaction
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	tests := []struct {
+		name          string
+		userID        string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			userID:  "invalid",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:   "user not found",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:   "user merged into another user",
+			userID: userID,
+			expectedMocks: func() {
+				mergedInto := uuid.MustParse("5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e")
+				queriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{ID: uuid.MustParse(userID), MergedInto: &mergedInto}, nil).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:   "phone not set",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{ID: uuid.MustParse(userID)}, nil).Once()
+			},
+			wantErr: domain.ErrPhoneNotSet,
+		},
+		{
+			name:   "phone already verified",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{ID: uuid.MustParse(userID), Phone: "+351912345678", PhoneVerified: true}, nil).Once()
+			},
+			wantErr: domain.ErrPhoneAlreadyVerified,
+		},
+		{
+			name:   "phone verified by another user",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{ID: uuid.MustParse(userID), Phone: "+351912345678"}, nil).Once()
+				queriesMock.On("IsPhoneVerified", mock.Anything, "+351912345678").Return(true, nil).Once()
+			},
+			wantErr: domain.ErrPhoneAlreadyInUse,
+		},
+		{
+			name:   "failed to add event to outbox",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{ID: uuid.MustParse(userID), Phone: "+351912345678"}, nil).Once()
+				queriesMock.On("IsPhoneVerified", mock.Anything, "+351912345678").Return(false, nil).Once()
+				verificationsMock.On("SaveVerification", mock.Anything, mock.Anything).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:   "success",
+			userID: userID,
+			expectedMocks: func() {
+				var code string
+				queriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{ID: uuid.MustParse(userID), Phone: "+351912345678"}, nil).Once()
+				queriesMock.On("IsPhoneVerified", mock.Anything, "+351912345678").Return(false, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					codeMatch := regexp.MustCompile(`"code":"([0-9]{6})"`).FindSubmatch(e.Payload)
+					if codeMatch == nil {
+						return false
+					}
+					code = string(codeMatch[1])
+					return e.Type == "PhoneVerificationRequested"
+				})).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+				verificationsMock.On("SaveVerification", mock.Anything, mock.MatchedBy(func(v *domain.PhoneVerification) bool {
+					return v.UserID.String() == userID && v.Phone == "+351912345678" && v.CodeHash != ""
+				})).Run(func(args mock.Arguments) {
+					// the saved hash must match the code sent in the event
+					v := args.Get(1).(*domain.PhoneVerification)
+					t.Cleanup(func() {
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(v.CodeHash), []byte(code)))
+					})
+				}).Return(nil).Once()
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewPhoneVerificationUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, verificationsMock, outboxCommandsMock, cfg)
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.StartPhoneVerification(context.Background(), tt.userID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.WithinDuration(t, time.Now().Add(cfg.CodeTTL), got, time.Minute)
+		})
+	}
+}
+
+func Test_phoneVerificationUseCase_ConfirmPhoneVerification(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewUserRepoQueries(t)
+	commandsMock := domainMocks.NewUserRepoCommands(t)
+	verificationsMock := domainMocks.NewPhoneVerificationRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	cfg := PhoneVerificationConfig{CodeTTL: 5 * time.Minute, MaxAttempts: 3}
+	codeHash, err := bcrypt.GenerateFromPassword([]byte("123456"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	pending := func(attempts int32, expiresAt time.Time) *domain.PhoneVerification {
+		return &domain.PhoneVerification{
+			UserID:    uuid.MustParse(userID),
+			Phone:     "+351912345678",
+			CodeHash:  string(codeHash),
+			Attempts:  attempts,
+			ExpiresAt: expiresAt,
+		}
+	}
+	runTx := func(err error) {
+		transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(ctx context.Context) error)
+			fn(args.Get(0).(context.Context))
+		}).Return(err).Once()
+	}
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything)
+
+	tests := []struct {
+		name          string
+		userID        string
+		code          string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			userID:  "invalid",
+			code:    "123456",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:   "no pending verification",
+			userID: userID,
+			code:   "123456",
+			expectedMocks: func() {
+				runTx(domain.ErrVerificationNotFound)
+				verificationsMock.On("GetVerification", mock.Anything, userID).Return(nil, domain.ErrVerificationNotFound).Once()
+			},
+			wantErr: domain.ErrVerificationNotFound,
+		},
+		{
+			name:   "expired",
+			userID: userID,
+			code:   "123456",
+			expectedMocks: func() {
+				runTx(domain.ErrVerificationExpired)
+				verificationsMock.On("GetVerification", mock.Anything, userID).Return(pending(0, time.Now().Add(-time.Second)), nil).Once()
+			},
+			wantErr: domain.ErrVerificationExpired,
+		},
+		{
+			name:   "too many attempts",
+			userID: userID,
+			code:   "123456",
+			expectedMocks: func() {
+				runTx(domain.ErrTooManyVerificationAttempts)
+				verificationsMock.On("GetVerification", mock.Anything, userID).Return(pending(3, time.Now().Add(time.Minute)), nil).Once()
+			},
+			wantErr: domain.ErrTooManyVerificationAttempts,
+		},
+		{
+			name:   "wrong code counts an attempt",
+			userID: userID,
+			code:   "654321",
+			expectedMocks: func() {
+				runTx(nil)
+				verificationsMock.On("GetVerification", mock.Anything, userID).Return(pending(1, time.Now().Add(time.Minute)), nil).Once()
+				verificationsMock.On("IncrementAttempts", mock.Anything, userID).Return(nil).Once()
+			},
+			wantErr: domain.ErrInvalidVerificationCode,
+		},
+		{
+			name:   "phone changed meanwhile",
+			userID: userID,
+			code:   "123456",
+			expectedMocks: func() {
+				runTx(domain.ErrVerificationNotFound)
+				verificationsMock.On("GetVerification", mock.Anything, userID).Return(pending(0, time.Now().Add(time.Minute)), nil).Once()
+				commandsMock.On("MarkPhoneVerified", mock.Anything, userID, "+351912345678").Return(domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrVerificationNotFound,
+		},
+		{
+			name:   "failed to delete verification",
+			userID: userID,
+			code:   "123456",
+			expectedMocks: func() {
+				runTx(fmt.Errorf("something went wrong"))
+				verificationsMock.On("GetVerification", mock.Anything, userID).Return(pending(0, time.Now().Add(time.Minute)), nil).Once()
+				commandsMock.On("MarkPhoneVerified", mock.Anything, userID, "+351912345678").Return(nil).Once()
+				verificationsMock.On("DeleteVerification", mock.Anything, userID).Return(fmt.Errorf("something went wrong")).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:   "success",
+			userID: userID,
+			code:   "123456",
+			expectedMocks: func() {
+				runTx(nil)
+				verificationsMock.On("GetVerification", mock.Anything, userID).Return(pending(2, time.Now().Add(time.Minute)), nil).Once()
+				commandsMock.On("MarkPhoneVerified", mock.Anything, userID, "+351912345678").Return(nil).Once()
+				verificationsMock.On("DeleteVerification", mock.Anything, userID).Return(nil).Once()
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewPhoneVerificationUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, verificationsMock, outboxCommandsMock, cfg)
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.ConfirmPhoneVerification(context.Background(), tt.userID, tt.code)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// PreferenceType is the type of the values accepted by a preference
+type PreferenceType string
+
+const (
+	PreferenceBool   PreferenceType = "bool"
+	PreferenceEnum   PreferenceType = "enum"
+	PreferenceInt    PreferenceType = "int"
+	PreferenceString PreferenceType = "string"
+)
+
+type PreferencesQueries interface {
+	// GetPreferences returns every declared preference of the user, with the stored values merged over the defaults.
+	// Values are bool, int64 or string, depending on the preference type.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrInternal if it fails to fetch the preferences.
+	GetPreferences(ctx context.Context, userID string) (map[string]any, error)
+}
+
+type PreferencesCommands interface {
+	// UpdatePreferences stores the provided preferences and writes a PreferencesUpdated event.
+	// Returns the resulting preferences, merged over the defaults.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrEmptyRequest if no preferences are provided.
+	// It returns domain.ErrUnknownPreference if a key is not declared.
+	// It returns domain.ErrInvalidPreferenceValue if a value does not match the preference type.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrInternal if it fails to update the preferences.
+	UpdatePreferences(ctx context.Context, userID string, preferences map[string]any) (map[string]any, error)
+
+	// ResetPreferences restores the defaults of the provided preferences, or of all of them if no keys are provided,
+	// and writes a PreferencesUpdated event.
+	// Returns the resulting preferences, merged over the defaults.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrUnknownPreference if a key is not declared.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrInternal if it fails to reset the preferences.
+	ResetPreferences(ctx context.Context, userID string, keys []string) (map[string]any, error)
+}
+
+// PreferenceDefinition declares a preference key, its type and default value.
+// Enum preferences accept only the listed values.
+type PreferenceDefinition struct {
+	Key     string
+	Type    PreferenceType
+	Values  []string
+	Default any
+}
+
+// PreferencesConfig defines the declared preferences
+type PreferencesConfig struct {
+	Definitions []PreferenceDefinition
+}
+
+// NewPreferenceDefinition builds a PreferenceDefinition from its textual declaration, parsing the default value
+func NewPreferenceDefinition(key string, preferenceType string, values []string, defaultValue string) (PreferenceDefinition, error) {
+	def := PreferenceDefinition{Key: key, Type: PreferenceType(preferenceType), Values: values}
+	if key == "" {
+		return def, fmt.Errorf("preference key must not be empty")
+	}
+	var err error
+	switch def.Type {
+	case PreferenceBool:
+		def.Default, err = strconv.ParseBool(defaultValue)
+	case PreferenceInt:
+		def.Default, err = strconv.ParseInt(defaultValue, 10, 64)
+	case PreferenceString:
+		def.Default = defaultValue
+	case PreferenceEnum:
+		if !slices.Contains(values, defaultValue) {
+			err = fmt.Errorf("default %q is not one of %v", defaultValue, values)
+		}
+		def.Default = defaultValue
+	default:
+		err = fmt.Errorf("unknown type %q", preferenceType)
+	}
+	if err != nil {
+		return def, fmt.Errorf("invalid preference %s: %w", key, err)
+	}
+	return def, nil
+}
+
+type preferencesUpdated struct {
+	UserID      string         `json:"user_id"`
+	Keys        []string       `json:"keys"`
+	Preferences map[string]any `json:"preferences"`
+}
+
+type preferencesUseCase struct {
+	l                   logger.Interface
+	transaction         domain.Transaction
+	preferencesQueries  domain.PreferencesRepoQueries
+	preferencesCommands domain.PreferencesRepoCommands
+	outboxRepo          domain.OutboxRepoCommands
+	definitions         map[string]PreferenceDefinition
+}
+
+func NewPreferencesUseCase(logger logger.Interface, transaction domain.Transaction, preferencesQueries domain.PreferencesRepoQueries,
+	preferencesCommands domain.PreferencesRepoCommands, outboxRepo domain.OutboxRepoCommands, cfg PreferencesConfig) *preferencesUseCase {
+	definitions := make(map[string]PreferenceDefinition, len(cfg.Definitions))
+	for _, def := range cfg.Definitions {
+		definitions[def.Key] = def
+	}
+	return &preferencesUseCase{logger, transaction, preferencesQueries, preferencesCommands, outboxRepo, definitions}
+}
+
+// GetPreferences returns the user's preferences merged over the defaults.
+// It implements the GetPreferences method of PreferencesQueries interface
+func (uc preferencesUseCase) GetPreferences(ctx context.Context, userID string) (map[string]any, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrInvalidUserID
+	}
+	var stored map[string]json.RawMessage
+	err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		stored, err = uc.preferencesQueries.GetPreferences(txCtx, userID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		uc.l.Warn("app-user-preferences-get error getting preferences of user %s: %v", userID, err)
+		return nil, domain.ErrInternal
+	}
+	return uc.merge(stored), nil
+}
+
+// UpdatePreferences validates and stores the provided preferences.
+// It implements the UpdatePreferences method of PreferencesCommands interface
+func (uc preferencesUseCase) UpdatePreferences(ctx context.Context, userID string, preferences map[string]any) (map[string]any, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrInvalidUserID
+	}
+	if len(preferences) == 0 {
+		return nil, domain.ErrEmptyRequest
+	}
+	values := make(map[string]json.RawMessage, len(preferences))
+	for key, value := range preferences {
+		def, ok := uc.definitions[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUnknownPreference, key)
+		}
+		if !def.accepts(value) {
+			return nil, fmt.Errorf("%w: %s must be a valid %s", domain.ErrInvalidPreferenceValue, key, def.Type)
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			uc.l.Warn("app-user-preferences-update error encoding preference %s: %v", key, err)
+			return nil, domain.ErrInternal
+		}
+		values[key] = raw
+	}
+
+	return uc.change(ctx, userID, sortedKeys(preferences), func(txCtx context.Context) error {
+		return uc.preferencesCommands.SavePreferences(txCtx, userID, values)
+	})
+}
+
+// ResetPreferences restores the defaults of the provided preferences.
+// It implements the ResetPreferences method of PreferencesCommands interface
+func (uc preferencesUseCase) ResetPreferences(ctx context.Context, userID string, keys []string) (map[string]any, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrInvalidUserID
+	}
+	for _, key := range keys {
+		if _, ok := uc.definitions[key]; !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUnknownPreference, key)
+		}
+	}
+
+	changed := keys
+	if len(changed) == 0 {
+		changed = sortedKeys(uc.definitions)
+	}
+	return uc.change(ctx, userID, changed, func(txCtx context.Context) error {
+		return uc.preferencesCommands.DeletePreferences(txCtx, userID, keys)
+	})
+}
+
+// change applies the update and writes the PreferencesUpdated event with the resulting preferences in the same transaction
+func (uc preferencesUseCase) change(ctx context.Context, userID string, keys []string, update func(txCtx context.Context) error) (map[string]any, error) {
+	domain.SetAuditTarget(ctx, userID)
+	var result map[string]any
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		previous, err := uc.preferencesQueries.GetPreferences(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if err := update(txCtx); err != nil {
+			return err
+		}
+		stored, err := uc.preferencesQueries.GetPreferences(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		result = uc.merge(stored)
+		domain.AddAuditDiff(txCtx, preferencesDiff(uc.merge(previous), result))
+
+		payload, err := json.Marshal(preferencesUpdated{
+			UserID:      userID,
+			Keys:        keys,
+			Preferences: result,
+		})
+		if err != nil {
+			return err
+		}
+		event := &domain.Event{
+			Type:    "PreferencesUpdated",
+			Payload: payload,
+		}
+		if _, err := uc.outboxRepo.AddEvent(txCtx, event); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		uc.l.Warn("app-user-preferences error changing preferences of user %s: %v", userID, err)
+		return nil, domain.ErrInternal
+	}
+	return result, nil
+}
+
+// preferencesDiff returns the changed preferences, keyed by "preferences.<key>"
+func preferencesDiff(before map[string]any, after map[string]any) map[string]domain.FieldChange {
+	diff := map[string]domain.FieldChange{}
+	for key, value := range after {
+		old, updated := fmt.Sprint(before[key]), fmt.Sprint(value)
+		if old != updated {
+			diff["preferences."+key] = domain.FieldChange{Old: old, New: updated}
+		}
+	}
+	return diff
+}
+
+// merge returns the defaults overridden by the stored values.
+// Stored values of undeclared keys, or that no longer match the declared type, are ignored.
+func (uc preferencesUseCase) merge(stored map[string]json.RawMessage) map[string]any {
+	result := make(map[string]any, len(uc.definitions))
+	for key, def := range uc.definitions {
+		result[key] = def.Default
+		raw, ok := stored[key]
+		if !ok {
+			continue
+		}
+		if value, err := def.decode(raw); err == nil {
+			result[key] = value
+		} else {
+			uc.l.Debug("app-user-preferences ignoring stored value of %s: %v", key, err)
+		}
+	}
+	return result
+}
+
+// accepts checks if the value matches the preference type
+func (def PreferenceDefinition) accepts(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return def.Type == PreferenceBool
+	case int64:
+		return def.Type == PreferenceInt
+	case string:
+		return def.Type == PreferenceString || (def.Type == PreferenceEnum && slices.Contains(def.Values, v))
+	}
+	return false
+}
+
+// decode parses a stored JSON value according to the preference type
+func (def PreferenceDefinition) decode(raw json.RawMessage) (any, error) {
+	var value any
+	var err error
+	switch def.Type {
+	case PreferenceBool:
+		var b bool
+		err = json.Unmarshal(raw, &b)
+		value = b
+	case PreferenceInt:
+		var i int64
+		err = json.Unmarshal(raw, &i)
+		value = i
+	default:
+		var s string
+		err = json.Unmarshal(raw, &s)
+		value = s
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !def.accepts(value) {
+		return nil, domain.ErrInvalidPreferenceValue
+	}
+	return value, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
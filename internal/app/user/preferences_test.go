@@ -0,0 +1,361 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testPreferences = PreferencesConfig{Definitions: []PreferenceDefinition{
+	{Key: "marketing_emails", Type: PreferenceBool, Default: false},
+	{Key: "digest_frequency", Type: PreferenceEnum, Values: []string{"never", "daily", "weekly"}, Default: "weekly"},
+	{Key: "items_per_page", Type: PreferenceInt, Default: int64(20)},
+	{Key: "signature", Type: PreferenceString, Default: ""},
+}}
+
+func TestNewPreferenceDefinition(t *testing.T) {
+	type args struct {
+		key            string
+		preferenceType string
+		values         []string
+		defaultValue   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    PreferenceDefinition
+		wantErr error
+	}{
+		{
+			name: "bool",
+			args: args{key: "newsletter", preferenceType: "bool", defaultValue: "true"},
+			want: PreferenceDefinition{Key: "newsletter", Type: PreferenceBool, Default: true},
+		},
+		{
+			name: "int",
+			args: args{key: "items_per_page", preferenceType: "int", defaultValue: "20"},
+			want: PreferenceDefinition{Key: "items_per_page", Type: PreferenceInt, Default: int64(20)},
+		},
+		{
+			name: "enum",
+			args: args{key: "theme", preferenceType: "enum", values: []string{"light", "dark"}, defaultValue: "dark"},
+			want: PreferenceDefinition{Key: "theme", Type: PreferenceEnum, Values: []string{"light", "dark"}, Default: "dark"},
+		},
+		{
+			name: "string",
+			args: args{key: "signature", preferenceType: "string"},
+			want: PreferenceDefinition{Key: "signature", Type: PreferenceString, Default: ""},
+		},
+		{
+			name:    "invalid bool default",
+			args:    args{key: "newsletter", preferenceType: "bool", defaultValue: "maybe"},
+			wantErr: fmt.Errorf(`invalid preference newsletter: strconv.ParseBool: parsing "maybe": invalid syntax`),
+		},
+		{
+			name:    "enum default not allowed",
+			args:    args{key: "theme", preferenceType: "enum", values: []string{"light"}, defaultValue: "dark"},
+			wantErr: fmt.Errorf(`invalid preference theme: default "dark" is not one of [light]`),
+		},
+		{
+			name:    "unknown type",
+			args:    args{key: "ratio", preferenceType: "float", defaultValue: "0.5"},
+			wantErr: fmt.Errorf(`invalid preference ratio: unknown type "float"`),
+		},
+		{
+			name:    "empty key",
+			args:    args{preferenceType: "string"},
+			wantErr: fmt.Errorf("preference key must not be empty"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPreferenceDefinition(tt.args.key, tt.args.preferenceType, tt.args.values, tt.args.defaultValue)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_preferencesUseCase_GetPreferences(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewPreferencesRepoQueries(t)
+	commandsMock := domainMocks.NewPreferencesRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	mockedLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	uc := NewPreferencesUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock, testPreferences)
+
+	tests := []struct {
+		name          string
+		userID        string
+		expectedMocks func()
+		want          map[string]any
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			userID:  "invalid",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:   "user not found",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:   "repository error",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(nil, domain.ErrFailedToProcessData).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:   "stored values merged over the defaults",
+			userID: userID,
+			expectedMocks: func() {
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(map[string]json.RawMessage{
+					"marketing_emails": json.RawMessage(`true`),
+					"digest_frequency": json.RawMessage(`"monthly"`), // no longer allowed
+					"items_per_page":   json.RawMessage(`50`),
+					"removed":          json.RawMessage(`1`),
+				}, nil).Once()
+			},
+			want: map[string]any{
+				"marketing_emails": true,
+				"digest_frequency": "weekly",
+				"items_per_page":   int64(50),
+				"signature":        "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.GetPreferences(context.Background(), tt.userID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_preferencesUseCase_UpdatePreferences(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewPreferencesRepoQueries(t)
+	commandsMock := domainMocks.NewPreferencesRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	uc := NewPreferencesUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock, testPreferences)
+
+	tests := []struct {
+		name          string
+		userID        string
+		preferences   map[string]any
+		expectedMocks func()
+		want          map[string]any
+		wantErr       error
+	}{
+		{
+			name:        "invalid user id",
+			userID:      "invalid",
+			preferences: map[string]any{"marketing_emails": true},
+			wantErr:     domain.ErrInvalidUserID,
+		},
+		{
+			name:    "empty request",
+			userID:  userID,
+			wantErr: domain.ErrEmptyRequest,
+		},
+		{
+			name:        "unknown key",
+			userID:      userID,
+			preferences: map[string]any{"theme": "dark"},
+			wantErr:     fmt.Errorf("unknown preference: theme"),
+		},
+		{
+			name:        "wrong type",
+			userID:      userID,
+			preferences: map[string]any{"items_per_page": "50"},
+			wantErr:     fmt.Errorf("invalid preference value: items_per_page must be a valid int"),
+		},
+		{
+			name:        "enum value not allowed",
+			userID:      userID,
+			preferences: map[string]any{"digest_frequency": "monthly"},
+			wantErr:     fmt.Errorf("invalid preference value: digest_frequency must be a valid enum"),
+		},
+		{
+			name:        "user not found",
+			userID:      userID,
+			preferences: map[string]any{"marketing_emails": true},
+			expectedMocks: func() {
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(domain.ErrUserNotFound).Once()
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(map[string]json.RawMessage{}, nil).Once()
+				commandsMock.On("SavePreferences", mock.Anything, userID, mock.Anything).Return(domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:        "success",
+			userID:      userID,
+			preferences: map[string]any{"marketing_emails": true, "digest_frequency": "daily"},
+			expectedMocks: func() {
+				stored := map[string]json.RawMessage{"marketing_emails": json.RawMessage(`true`), "digest_frequency": json.RawMessage(`"daily"`)}
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(nil).Once()
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(map[string]json.RawMessage{}, nil).Once()
+				commandsMock.On("SavePreferences", mock.Anything, userID, stored).Return(nil).Once()
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(stored, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					return e.Type == "PreferencesUpdated" &&
+						bytes.Contains(e.Payload, []byte(`"keys":["digest_frequency","marketing_emails"]`)) &&
+						bytes.Contains(e.Payload, []byte(`"items_per_page":20`))
+				})).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+			want: map[string]any{
+				"marketing_emails": true,
+				"digest_frequency": "daily",
+				"items_per_page":   int64(20),
+				"signature":        "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.UpdatePreferences(context.Background(), tt.userID, tt.preferences)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_preferencesUseCase_ResetPreferences(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	queriesMock := domainMocks.NewPreferencesRepoQueries(t)
+	commandsMock := domainMocks.NewPreferencesRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	uc := NewPreferencesUseCase(mockedLogger, transactionMock, queriesMock, commandsMock, outboxCommandsMock, testPreferences)
+	defaults := map[string]any{
+		"marketing_emails": false,
+		"digest_frequency": "weekly",
+		"items_per_page":   int64(20),
+		"signature":        "",
+	}
+
+	tests := []struct {
+		name          string
+		userID        string
+		keys          []string
+		expectedMocks func()
+		want          map[string]any
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			userID:  "invalid",
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "unknown key",
+			userID:  userID,
+			keys:    []string{"theme"},
+			wantErr: fmt.Errorf("unknown preference: theme"),
+		},
+		{
+			name:   "failed to add event to outbox",
+			userID: userID,
+			keys:   []string{"marketing_emails"},
+			expectedMocks: func() {
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(domain.ErrInternal).Once()
+				commandsMock.On("DeletePreferences", mock.Anything, userID, []string{"marketing_emails"}).Return(nil).Once()
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(map[string]json.RawMessage{}, nil).Twice()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:   "reset all",
+			userID: userID,
+			expectedMocks: func() {
+				transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(ctx context.Context) error)
+					fn(args.Get(0).(context.Context))
+				}).Return(nil).Once()
+				commandsMock.On("DeletePreferences", mock.Anything, userID, []string(nil)).Return(nil).Once()
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(map[string]json.RawMessage{"marketing_emails": json.RawMessage(`true`)}, nil).Once()
+				queriesMock.On("GetPreferences", mock.Anything, userID).Return(map[string]json.RawMessage{}, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.MatchedBy(func(e *domain.Event) bool {
+					return e.Type == "PreferencesUpdated" &&
+						bytes.Contains(e.Payload, []byte(`"keys":["digest_frequency","items_per_page","marketing_emails","signature"]`))
+				})).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+			want: defaults,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := uc.ResetPreferences(context.Background(), tt.userID, tt.keys)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"strings"
+	"time"
+	"users/internal/domain"
+
+	"golang.org/x/text/language"
+)
+
+// DateLayout is the format of the dates exchanged with the api, ex: dates of birth
+const DateLayout = time.DateOnly
+
+// profile holds the validated optional profile fields of a user
+type profile struct {
+	locale      string
+	timezone    string
+	dateOfBirth *time.Time
+}
+
+// parseProfile validates and normalizes the optional profile fields.
+// Empty fields are kept empty.
+// It returns domain.ErrInvalidLocale if the locale is not a valid BCP-47 tag.
+// It returns domain.ErrInvalidTimezone if the timezone is not in the IANA database.
+// It returns domain.ErrInvalidBirthDate if the date of birth is not a past YYYY-MM-DD date.
+func parseProfile(locale, timezone, dateOfBirth string, now time.Time) (p profile, err error) {
+	if locale = strings.TrimSpace(locale); locale != "" {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return p, domain.ErrInvalidLocale
+		}
+		p.locale = tag.String()
+	}
+
+	// time.LoadLocation treats "" and "UTC" alike and accepts "Local", which is not an IANA name
+	if timezone = strings.TrimSpace(timezone); timezone != "" {
+		if timezone == "Local" {
+			return p, domain.ErrInvalidTimezone
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return p, domain.ErrInvalidTimezone
+		}
+		p.timezone = timezone
+	}
+
+	if dateOfBirth != "" {
+		dob, err := time.Parse(DateLayout, dateOfBirth)
+		if err != nil || dob.After(now) {
+			return p, domain.ErrInvalidBirthDate
+		}
+		p.dateOfBirth = &dob
+	}
+	return p, nil
+}
+
+// ageAt returns the age in full years at the given time
+func ageAt(dateOfBirth time.Time, now time.Time) int {
+	years := now.Year() - dateOfBirth.Year()
+	if now.Month() < dateOfBirth.Month() || (now.Month() == dateOfBirth.Month() && now.Day() < dateOfBirth.Day()) {
+		years--
+	}
+	return years
+}
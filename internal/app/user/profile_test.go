@@ -0,0 +1,92 @@
+package user
+
+import (
+	"testing"
+	"time"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseProfile(t *testing.T) {
+	now := time.Date(2024, 8, 22, 20, 30, 3, 0, time.UTC)
+	dob := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		locale      string
+		timezone    string
+		dateOfBirth string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    profile
+		wantErr error
+	}{
+		{
+			name: "empty fields",
+			args: args{},
+			want: profile{},
+		},
+		{
+			name: "normalized fields",
+			args: args{locale: "pt-pt", timezone: "Europe/Lisbon", dateOfBirth: "2000-02-29"},
+			want: profile{locale: "pt-PT", timezone: "Europe/Lisbon", dateOfBirth: &dob},
+		},
+		{
+			name:    "invalid locale",
+			args:    args{locale: "not a locale"},
+			wantErr: domain.ErrInvalidLocale,
+		},
+		{
+			name:    "unknown timezone",
+			args:    args{timezone: "Europe/Nowhere"},
+			wantErr: domain.ErrInvalidTimezone,
+		},
+		{
+			name:    "local timezone",
+			args:    args{timezone: "Local"},
+			wantErr: domain.ErrInvalidTimezone,
+		},
+		{
+			name:    "invalid date of birth",
+			args:    args{dateOfBirth: "2001-02-29"},
+			wantErr: domain.ErrInvalidBirthDate,
+		},
+		{
+			name:    "date of birth in the future",
+			args:    args{dateOfBirth: "2024-08-23"},
+			wantErr: domain.ErrInvalidBirthDate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProfile(tt.args.locale, tt.args.timezone, tt.args.dateOfBirth, now)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ageAt(t *testing.T) {
+	dob := time.Date(2008, 8, 22, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{name: "day before birthday", now: time.Date(2024, 8, 21, 23, 59, 0, 0, time.UTC), want: 15},
+		{name: "on birthday", now: time.Date(2024, 8, 22, 0, 0, 0, 0, time.UTC), want: 16},
+		{name: "month after birthday", now: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), want: 16},
+		{name: "month before birthday", now: time.Date(2024, 7, 30, 0, 0, 0, 0, time.UTC), want: 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ageAt(dob, tt.now))
+		})
+	}
+}
@@ -3,6 +3,9 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 	"users/internal/domain"
 	"users/pkg/logger"
@@ -14,39 +17,175 @@ type ListUsersRequest struct {
 	Cursor  string
 	Limit   int32
 	Filters domain.UserSearchFilters
+	// Fuzzy matches the text filters by similarity, tolerating typos
+	Fuzzy bool
+	// OrderBy is a sortable field optionally followed by asc or desc, e.g. "last_name desc". Defaults to "updated_at desc"
+	OrderBy string
+	// TotalCount is TotalCountExact or TotalCountEstimated to count the users matching the filters, empty to skip the count
+	TotalCount string
+	// Filter is an AIP-160 filter expression, e.g. `country_iso_code = "PT" AND created_at > "2024-01-01T00:00:00Z"`,
+	// combined with the other filters
+	Filter string
+	// Fields is the read mask of the users, all of the domain.UserFields when empty
+	Fields []string
+}
+
+// Total count modes of ListUsers
+const (
+	TotalCountExact     = "exact"
+	TotalCountEstimated = "estimated"
+)
+
+// _exactCountThreshold is the planner estimate below which the estimated count is replaced by the exact one, as it is cheap
+const _exactCountThreshold = 10000
+
+type ListUsersResponse struct {
+	Users []*domain.User
+	// NextCursor continues after the last user, only set when HasMore
+	NextCursor string
+	// PrevCursor continues backward before the first user, set when the page was reached with a cursor
+	PrevCursor string
+	// HasMore tells whether there are users after the page
+	HasMore bool
+	// TotalCount is the number of users matching the filters, nil unless requested
+	TotalCount *int64
+	// TotalCountEstimated tells whether the count is the planner estimate
+	TotalCountEstimated bool
+}
+
+// UserQueriesConfig defines how the users are matched and paginated
+type UserQueriesConfig struct {
+	// FuzzyThreshold is the minimum trigram similarity, between 0 and 1, of the fuzzy matches. 0 disables the fuzzy mode
+	FuzzyThreshold float64
+	// CursorKeys sign the ListUsers cursors, the first one signs and all of them verify. At least one is required
+	CursorKeys []string
+	// CursorTTL is for how long a ListUsers cursor is accepted
+	CursorTTL time.Duration
+	// MaxBatchSize is the maximum number of users fetched by BatchGetUsers
+	MaxBatchSize int
+	// StatsCacheTTL is for how long the user statistics are cached, per tenant and request. 0 disables the cache
+	StatsCacheTTL time.Duration
+}
+
+// LookupUserRequest identifies a user by exactly one of its unique fields
+type LookupUserRequest struct {
+	Email    string
+	NickName string
+}
+
+// ExportUsersRequest filters the exported users as ListUsersRequest does
+type ExportUsersRequest struct {
+	Filters domain.UserSearchFilters
+	// Fuzzy matches the text filters by similarity, tolerating typos
+	Fuzzy bool
+	// Filter is an AIP-160 filter expression, combined with the other filters
+	Filter string
+}
+
+// _exportBatchSize is the number of users fetched from the export cursor at a time
+const _exportBatchSize = 500
+
+type SearchUsersRequest struct {
+	Query     string
+	Limit     int32
+	PageToken string
 }
 
 type UserQueries interface {
 	// GetUser retrieves a single User based on his id.
+	// Users merged into another user only have the ID and MergedInto fields set.
+	// Only the fields, out of domain.UserFields and domain.UserDetailFields, are loaded, all of them when empty.
 	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInvalidReadMask if any of the fields is unknown.
 	// It returns domain.ErrUserNotFound if the user does not exist.
 	// It returns domain.ErrInternal if it fails to fetch from the repository.
-	GetUser(ctx context.Context, userID string) (user *domain.User, err error)
+	GetUser(ctx context.Context, userID string, fields []string) (user *domain.User, err error)
+
+	// LookupUser retrieves the user whose email or nickname, exactly one of them, matches the request.
+	// The value is trimmed and matched regardless of case, users merged into another user are not matched.
+	// It returns domain.ErrInvalidUserLookup if neither or both of the email and nickname are provided.
+	// It returns domain.ErrUserNotFound if no user matches.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	LookupUser(ctx context.Context, req LookupUserRequest) (user *domain.User, err error)
+
+	// BatchGetUsers retrieves the users with the ids in a single read, in the order of the request and once per id.
+	// Users merged into another user only have the ID and MergedInto fields set.
+	// The ids of the users that do not exist are returned apart, in the order of the request.
+	// It returns domain.ErrTooManyUserIDs if more ids than the configured maximum are provided.
+	// It returns domain.ErrInvalidUserID if any of the ids is invalid.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	BatchGetUsers(ctx context.Context, userIDs []string) (users []*domain.User, missingIDs []string, err error)
 
 	// ListUsers retrieves a paginated list of users.
-	// It supports cursor-based pagination and filtering.
-	// It returns domain.ErrInvalidPaginationCursor if an invalid cursor is provided.
+	// It supports cursor-based pagination and filtering, the text filters match substrings or, in fuzzy mode, similar values.
+	// Users are sorted by updated_at, created_at, last_name or nickname, in either direction. The cursor carries the order.
+	// It returns domain.ErrInvalidOrderBy if the order is not one of the sortable fields followed by asc or desc.
+	// The filter expression supports a subset of AIP-160 over an allow-list of fields.
+	// It returns domain.ErrInvalidFilter, with the position of the error, if the filter expression is invalid.
+	// Cursors are opaque and signed, and only valid for the filters and order they were issued for, until they expire.
+	// The next cursor pages forward and the previous cursor backward, a page is looked ahead by one user so that
+	// HasMore is exact and the last page has no next cursor.
+	// The total count is exact, or the planner estimate on large tables, only when requested.
+	// Only the fields of the read mask, out of domain.UserFields, and the sort field are loaded, all of them when empty.
+	// It returns domain.ErrInvalidReadMask if any of the fields is unknown.
+	// It returns domain.ErrInvalidPaginationCursor if the cursor is malformed, was tampered with, has expired or
+	// was issued for other filters or another order.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ListUsers(ctx context.Context, req ListUsersRequest) (page ListUsersResponse, err error)
+
+	// ExportUsers calls fn with every user matching the filters, oldest first, read from a single consistent snapshot.
+	// The users are fetched in batches, so the export is never held in memory. It stops at the first error of fn and returns it.
+	// The number of users passed to fn is returned, also when the export fails.
+	// It returns domain.ErrInvalidFilter, with the position of the error, if the filter expression is invalid.
+	// It returns the error of the context if it is cancelled.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ExportUsers(ctx context.Context, req ExportUsersRequest, fn func(user *domain.User) error) (exported int64, err error)
+
+	// GetUserStats aggregates the users: the total, the counts per country, status and phone verification, and the
+	// signups per day, week or month of the range, defaulting to the 30 days until now.
+	// The statistics may be cached for the configured TTL, ComputedAt tells when they were computed.
+	// It returns domain.ErrInvalidStatsRange if the period is unknown, the range is empty or has too many periods.
 	// It returns domain.ErrInternal if it fails to fetch from the repository.
-	ListUsers(ctx context.Context, req ListUsersRequest) (users []*domain.User, nextCursor string, err error)
+	GetUserStats(ctx context.Context, req UserStatsRequest) (stats *domain.UserStats, err error)
+
+	// SearchUsers retrieves a paginated list of the users matching a full-text query, the most relevant first.
+	// The query matches the names, nickname and email, and accepts quoted phrases, "or" and "-" to exclude terms.
+	// It returns domain.ErrEmptySearchQuery if the query is blank.
+	// It returns domain.ErrInvalidPaginationCursor if an invalid page token is provided.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	SearchUsers(ctx context.Context, req SearchUsersRequest) (results []*domain.UserSearchResult, nextPageToken string, err error)
 }
 
 type userUseCaseQueries struct {
-	l    logger.Interface
-	repo domain.UserRepoQueries
+	l           logger.Interface
+	repo        domain.UserRepoQueries
+	transaction domain.Transaction
+	cfg         UserQueriesConfig
+	cursors     *cursorSigner
+	stats       *statsCache
 }
 
-func NewUserUseCaseQueries(logger logger.Interface, repo domain.UserRepoQueries) *userUseCaseQueries {
-	return &userUseCaseQueries{logger, repo}
+// NewUserUseCaseQueries creates the user queries use case.
+// Reads run inside a transaction, so the row-level security of the tenant in the context applies.
+func NewUserUseCaseQueries(logger logger.Interface, repo domain.UserRepoQueries, transaction domain.Transaction, cfg UserQueriesConfig) *userUseCaseQueries {
+	return &userUseCaseQueries{logger, repo, transaction, cfg, newCursorSigner(cfg.CursorKeys, cfg.CursorTTL), newStatsCache(cfg.StatsCacheTTL)}
 }
 
 // GetUser retrieves a single User based on his id.
 // It implements the ListUsers method of UserQueries interface
-func (uc userUseCaseQueries) GetUser(ctx context.Context, userID string) (du *domain.User, _ error) {
+func (uc userUseCaseQueries) GetUser(ctx context.Context, userID string, fields []string) (du *domain.User, _ error) {
 	if _, err := uuid.Parse(userID); err != nil {
 		return du, domain.ErrInvalidUserID
 	}
+	fields, err := parseReadMask(fields, domain.UserFields, domain.UserDetailFields)
+	if err != nil {
+		return du, err
+	}
 
-	du, err := uc.repo.GetUser(ctx, userID)
+	err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		du, err = uc.repo.GetUser(txCtx, userID, fields)
+		return err
+	})
 	if err != nil {
 		if !errors.Is(err, domain.ErrUserNotFound) {
 			uc.l.Warn("App-user-queries error getting user %s: %v", userID, err)
@@ -57,30 +196,340 @@ func (uc userUseCaseQueries) GetUser(ctx context.Context, userID string) (du *do
 	return du, nil
 }
 
+// LookupUser retrieves the user by email or nickname.
+// It implements the LookupUser method of UserQueries interface
+func (uc userUseCaseQueries) LookupUser(ctx context.Context, req LookupUserRequest) (du *domain.User, _ error) {
+	email, nickName := strings.TrimSpace(req.Email), strings.TrimSpace(req.NickName)
+	field, value := domain.UserLookupEmail, email
+	if nickName != "" {
+		field, value = domain.UserLookupNickName, nickName
+	}
+	if (email == "") == (nickName == "") {
+		return du, domain.ErrInvalidUserLookup
+	}
+
+	err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		du, err = uc.repo.LookupUser(txCtx, field, value)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			uc.l.Warn("App-user-queries error looking up user by %s: %v", field, err)
+			return du, domain.ErrInternal
+		}
+		return du, err
+	}
+	return du, nil
+}
+
+// BatchGetUsers retrieves the users with the ids.
+// It implements the BatchGetUsers method of UserQueries interface
+func (uc userUseCaseQueries) BatchGetUsers(ctx context.Context, userIDs []string) (users []*domain.User, missingIDs []string, err error) {
+	if len(userIDs) > uc.cfg.MaxBatchSize {
+		return []*domain.User{}, []string{}, fmt.Errorf("%w: at most %d", domain.ErrTooManyUserIDs, uc.cfg.MaxBatchSize)
+	}
+	// the ids are compared in their canonical form, once each
+	requested := make([]string, 0, len(userIDs))
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			return []*domain.User{}, []string{}, domain.ErrInvalidUserID
+		}
+		if !seen[id.String()] {
+			seen[id.String()] = true
+			requested = append(requested, id.String())
+		}
+	}
+
+	var found []*domain.User
+	err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		found, err = uc.repo.BatchGetUsers(txCtx, requested)
+		return err
+	})
+	if err != nil {
+		uc.l.Warn("App-user-queries error batch getting users: %v", err)
+		return []*domain.User{}, []string{}, domain.ErrInternal
+	}
+
+	byID := make(map[string]*domain.User, len(found))
+	for _, u := range found {
+		byID[u.ID.String()] = u
+	}
+	users, missingIDs = make([]*domain.User, 0, len(found)), []string{}
+	for _, id := range requested {
+		if u, ok := byID[id]; ok {
+			users = append(users, u)
+		} else {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	return users, missingIDs, nil
+}
+
 // ListUsers retrieves a paginated list of users.
 // It implements the ListUsers method of UserQueries interface
-func (uc userUseCaseQueries) ListUsers(ctx context.Context, req ListUsersRequest) (users []*domain.User, nextCur string, err error) {
-	var updatedAtCur *time.Time
-	var userIDCur string
+func (uc userUseCaseQueries) ListUsers(ctx context.Context, req ListUsersRequest) (page ListUsersResponse, err error) {
+	page.Users = []*domain.User{}
+	order := domain.DefaultUserOrder
+	if req.OrderBy != "" {
+		if order, err = parseOrderBy(req.OrderBy); err != nil {
+			return page, err
+		}
+	}
+	if req.Fuzzy {
+		req.Filters.FuzzyThreshold = uc.cfg.FuzzyThreshold
+	}
+	if req.Filter != "" {
+		if req.Filters.Expr, err = parseUserFilter(req.Filter); err != nil {
+			return page, err
+		}
+	}
+	fields, err := parseReadMask(req.Fields, domain.UserFields)
+	if err != nil {
+		return page, err
+	}
+	if len(fields) > 0 {
+		// the cursors are built from the sort field
+		fields = appendField(fields, userSortFieldName(order.Field))
+	}
+	filtersHash, err := hashFilters(req.Filters)
+	if err != nil {
+		uc.l.Warn("App-user-queries error hashing filters: %v", err)
+		return page, domain.ErrInternal
+	}
+	now := time.Now()
+	var cursor *domain.UserCursor
+	var backward bool
 	if len(req.Cursor) > 0 {
-		var uCur time.Time
-		uCur, userIDCur, err = decodeCursor(req.Cursor)
+		cursorOrder, c, b, err := uc.cursors.verify(req.Cursor, filtersHash, now)
+		if err == nil && cursorOrder != order {
+			err = fmt.Errorf("cursor was issued for the order %s %s", cursorOrder.Field, orderDirection(cursorOrder))
+		}
 		if err != nil {
 			uc.l.Debug("App-user-queries error decoding cursor: %v", err)
-			return []*domain.User{}, "", domain.ErrInvalidPaginationCursor
+			return page, fmt.Errorf("%w: %v", domain.ErrInvalidPaginationCursor, err)
 		}
-		updatedAtCur = &uCur
+		cursor, backward = &c, b
 	}
-	users, err = uc.repo.ListUsers(ctx, userIDCur, updatedAtCur, req.Limit, req.Filters)
+
+	// backward pages are fetched in the reverse order, from the cursor on
+	fetchOrder := order
+	if backward {
+		fetchOrder.Desc = !order.Desc
+	}
+	var users []*domain.User
+	var count int64
+	var estimated bool
+	err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		// one more user tells whether there are more
+		users, err = uc.repo.ListUsers(txCtx, fetchOrder, cursor, req.Limit+1, req.Filters, fields)
+		if err != nil || req.TotalCount == "" {
+			return err
+		}
+		count, estimated, err = uc.countUsers(txCtx, req.Filters, req.TotalCount == TotalCountEstimated)
+		return err
+	})
 	if err != nil {
 		uc.l.Debug("App-user-queries error list users: %v", err)
-		return []*domain.User{}, "", domain.ErrInternal
+		return page, domain.ErrInternal
 	}
 
-	if len(users) == int(req.Limit) {
-		lastUser := users[len(users)-1]
-		nextCur = encodeCursor(lastUser.UpdatedAt, lastUser.ID.String())
+	lookedAhead := len(users) > int(req.Limit)
+	if lookedAhead {
+		users = users[:req.Limit]
+	}
+	if backward {
+		slices.Reverse(users)
+	}
+	page.Users = users
+	if req.TotalCount != "" {
+		page.TotalCount, page.TotalCountEstimated = &count, estimated
+	}
+	if len(users) == 0 {
+		return page, nil
 	}
 
+	// a backward page always has the user of the cursor after it, while a forward one was reached from a previous page
+	page.HasMore = lookedAhead || backward
+	hasPrev := cursor != nil
+	if backward {
+		hasPrev = lookedAhead
+	}
+	if page.HasMore {
+		if page.NextCursor, err = uc.cursors.sign(order, users[len(users)-1], false, filtersHash, now); err != nil {
+			uc.l.Warn("App-user-queries error signing cursor: %v", err)
+			return ListUsersResponse{Users: []*domain.User{}}, domain.ErrInternal
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = uc.cursors.sign(order, users[0], true, filtersHash, now); err != nil {
+			uc.l.Warn("App-user-queries error signing cursor: %v", err)
+			return ListUsersResponse{Users: []*domain.User{}}, domain.ErrInternal
+		}
+	}
+	return page, nil
+}
+
+// countUsers counts the users matching the filters, estimating them if requested.
+// Estimates below _exactCountThreshold are replaced by the exact count.
+func (uc userUseCaseQueries) countUsers(ctx context.Context, filters domain.UserSearchFilters, estimate bool) (count int64, estimated bool, err error) {
+	if estimate {
+		if count, err = uc.repo.CountUsers(ctx, filters, true); err != nil || count >= _exactCountThreshold {
+			return count, err == nil, err
+		}
+	}
+	count, err = uc.repo.CountUsers(ctx, filters, false)
+	return count, false, err
+}
+
+// parseOrderBy parses a sortable field optionally followed by asc, the default, or desc
+func parseOrderBy(orderBy string) (domain.UserOrder, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 || len(parts) > 2 {
+		return domain.UserOrder{}, fmt.Errorf("%w: %s", domain.ErrInvalidOrderBy, orderBy)
+	}
+	order := domain.UserOrder{Field: parts[0]}
+	switch order.Field {
+	case domain.UserSortUpdatedAt, domain.UserSortCreatedAt, domain.UserSortLastName, domain.UserSortNickName:
+	default:
+		return domain.UserOrder{}, fmt.Errorf("%w: %s", domain.ErrInvalidOrderBy, orderBy)
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return domain.UserOrder{}, fmt.Errorf("%w: %s", domain.ErrInvalidOrderBy, orderBy)
+		}
+	}
+	return order, nil
+}
+
+func orderDirection(order domain.UserOrder) string {
+	if order.Desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// userSortFieldName returns the name of the field the users are sorted by
+func userSortFieldName(sortField string) string {
+	if sortField == domain.UserSortNickName {
+		return "nick_name"
+	}
+	return sortField
+}
+
+// parseReadMask returns the fields of the read mask once each, in the order of the mask
+// It returns domain.ErrInvalidReadMask if any of the fields is not one of the allowed ones
+func parseReadMask(fields []string, allowed ...[]string) ([]string, error) {
+	var mask []string
+	for _, field := range fields {
+		known := false
+		for _, names := range allowed {
+			known = known || slices.Contains(names, field)
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown field %s", domain.ErrInvalidReadMask, field)
+		}
+		mask = appendField(mask, field)
+	}
+	return mask, nil
+}
+
+// appendField appends the field unless it is already in the fields
+func appendField(fields []string, field string) []string {
+	if slices.Contains(fields, field) {
+		return fields
+	}
+	return append(fields, field)
+}
+
+// userSortValue returns the value of the sort field of the user, timestamps in the RFC 3339 format
+func userSortValue(user *domain.User, field string) string {
+	switch field {
+	case domain.UserSortCreatedAt:
+		return user.CreatedAt.Format(time.RFC3339Nano)
+	case domain.UserSortLastName:
+		return user.LastName
+	case domain.UserSortNickName:
+		return user.NickName
+	default:
+		return user.UpdatedAt.Format(time.RFC3339Nano)
+	}
+}
+
+// ExportUsers streams the users matching the filters to fn.
+// It implements the ExportUsers method of UserQueries interface
+func (uc userUseCaseQueries) ExportUsers(ctx context.Context, req ExportUsersRequest, fn func(user *domain.User) error) (exported int64, err error) {
+	if req.Fuzzy {
+		req.Filters.FuzzyThreshold = uc.cfg.FuzzyThreshold
+	}
+	if req.Filter != "" {
+		if req.Filters.Expr, err = parseUserFilter(req.Filter); err != nil {
+			return 0, err
+		}
+	}
+
+	var fnErr error
+	err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		return uc.repo.ExportUsers(txCtx, req.Filters, _exportBatchSize, func(user *domain.User) error {
+			if fnErr = fn(user); fnErr != nil {
+				return fnErr
+			}
+			exported++
+			return nil
+		})
+	})
+	switch {
+	case err == nil:
+		return exported, nil
+	case ctx.Err() != nil:
+		uc.l.Debug("App-user-queries export cancelled after %d users: %v", exported, err)
+		return exported, ctx.Err()
+	case fnErr != nil:
+		return exported, fnErr
+	default:
+		uc.l.Warn("App-user-queries error exporting users: %v", err)
+		return exported, domain.ErrInternal
+	}
+}
+
+// SearchUsers retrieves a paginated list of the users matching a full-text query.
+// It implements the SearchUsers method of UserQueries interface
+func (uc userUseCaseQueries) SearchUsers(ctx context.Context, req SearchUsersRequest) (results []*domain.UserSearchResult, nextToken string, err error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return []*domain.UserSearchResult{}, "", domain.ErrEmptySearchQuery
+	}
+	var rankCur *float32
+	var userIDCur string
+	if len(req.PageToken) > 0 {
+		var rCur float32
+		rCur, userIDCur, err = decodeSearchToken(req.PageToken)
+		if err == nil {
+			_, err = uuid.Parse(userIDCur)
+		}
+		if err != nil {
+			uc.l.Debug("App-user-queries error decoding page token: %v", err)
+			return []*domain.UserSearchResult{}, "", domain.ErrInvalidPaginationCursor
+		}
+		rankCur = &rCur
+	}
+	err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		results, err = uc.repo.SearchUsers(txCtx, query, rankCur, userIDCur, req.Limit)
+		return err
+	})
+	if err != nil {
+		uc.l.Debug("App-user-queries error search users: %v", err)
+		return []*domain.UserSearchResult{}, "", domain.ErrInternal
+	}
+
+	if len(results) == int(req.Limit) {
+		last := results[len(results)-1]
+		nextToken = encodeSearchToken(last.Rank, last.User.ID.String())
+	}
 	return
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 	domainMocks "users/gen/mocks/users/domain"
@@ -18,10 +19,14 @@ import (
 func Test_userUseCaseQueries_GetUser(t *testing.T) {
 	mockedLogger := loggerMocks.NewInterface(t)
 	repoQueriesMock := domainMocks.NewUserRepoQueries(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
 	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
 	type args struct {
 		ctx    context.Context
 		userID string
+		fields []string
 	}
 	tests := []struct {
 		name          string
@@ -42,7 +47,7 @@ func Test_userUseCaseQueries_GetUser(t *testing.T) {
 			wantDu:  &domain.User{},
 			wantErr: domain.ErrInternal,
 			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
-				queries.On("GetUser", mock.Anything, expectedUserID).Return(nil, fmt.Errorf("something went wrong")).Once()
+				queries.On("GetUser", mock.Anything, expectedUserID, []string(nil)).Return(nil, fmt.Errorf("something went wrong")).Once()
 				mockedLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Once()
 
 			},
@@ -53,7 +58,7 @@ func Test_userUseCaseQueries_GetUser(t *testing.T) {
 			wantDu:  &domain.User{},
 			wantErr: domain.ErrUserNotFound,
 			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
-				queries.On("GetUser", mock.Anything, expectedUserID).Return(nil, domain.ErrUserNotFound).Once()
+				queries.On("GetUser", mock.Anything, expectedUserID, []string(nil)).Return(nil, domain.ErrUserNotFound).Once()
 			},
 		},
 		{
@@ -62,17 +67,32 @@ func Test_userUseCaseQueries_GetUser(t *testing.T) {
 			wantDu:  &domain.User{NickName: "nick"},
 			wantErr: nil,
 			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
-				queries.On("GetUser", mock.Anything, expectedUserID).Return(&domain.User{NickName: "nick"}, nil).Once()
+				queries.On("GetUser", mock.Anything, expectedUserID, []string(nil)).Return(&domain.User{NickName: "nick"}, nil).Once()
 			},
 		},
+		{
+			name:    "read mask",
+			args:    args{ctx: context.Background(), userID: expectedUserID, fields: []string{"nick_name", "followers_count", "nick_name"}},
+			wantDu:  &domain.User{NickName: "nick", FollowersCount: 2},
+			wantErr: nil,
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("GetUser", mock.Anything, expectedUserID, []string{"nick_name", "followers_count"}).Return(&domain.User{NickName: "nick", FollowersCount: 2}, nil).Once()
+			},
+		},
+		{
+			name:    "unknown read mask field",
+			args:    args{ctx: context.Background(), userID: expectedUserID, fields: []string{"nick_name", "password"}},
+			wantDu:  &domain.User{},
+			wantErr: fmt.Errorf("%w: unknown field password", domain.ErrInvalidReadMask),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock)
+			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock, transactionMock, UserQueriesConfig{FuzzyThreshold: 0.3})
 			if tt.expectedMocks != nil {
 				tt.expectedMocks(mockedLogger, repoQueriesMock)
 			}
-			gotDu, err := uc.GetUser(tt.args.ctx, tt.args.userID)
+			gotDu, err := uc.GetUser(tt.args.ctx, tt.args.userID, tt.args.fields)
 			if tt.wantErr == nil {
 				assert.NoError(t, err)
 			} else {
@@ -86,10 +106,160 @@ func Test_userUseCaseQueries_GetUser(t *testing.T) {
 	}
 }
 
+func Test_userUseCaseQueries_LookupUser(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	repoQueriesMock := domainMocks.NewUserRepoQueries(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+	found := &domain.User{ID: uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"), NickName: "bob", Email: "bob@example.com"}
+	tests := []struct {
+		name          string
+		req           LookupUserRequest
+		expectedMocks func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries)
+		want          *domain.User
+		wantErr       error
+	}{
+		{
+			name: "by email",
+			req:  LookupUserRequest{Email: " Bob@Example.com "},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("LookupUser", mock.Anything, domain.UserLookupEmail, "Bob@Example.com").Return(found, nil).Once()
+			},
+			want: found,
+		},
+		{
+			name: "by nickname",
+			req:  LookupUserRequest{NickName: "bob"},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("LookupUser", mock.Anything, domain.UserLookupNickName, "bob").Return(found, nil).Once()
+			},
+			want: found,
+		},
+		{
+			name: "not found",
+			req:  LookupUserRequest{NickName: "bobby"},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("LookupUser", mock.Anything, domain.UserLookupNickName, "bobby").Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "neither",
+			req:     LookupUserRequest{Email: "  "},
+			wantErr: domain.ErrInvalidUserLookup,
+		},
+		{
+			name:    "both",
+			req:     LookupUserRequest{Email: "bob@example.com", NickName: "bob"},
+			wantErr: domain.ErrInvalidUserLookup,
+		},
+		{
+			name: "repository error",
+			req:  LookupUserRequest{Email: "bob@example.org"},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				l.On("Warn", mock.Anything, mock.Anything, mock.Anything).Once()
+				queries.On("LookupUser", mock.Anything, domain.UserLookupEmail, "bob@example.org").Return(nil, domain.ErrFailedToProcessData).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock, transactionMock, UserQueriesConfig{})
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(mockedLogger, repoQueriesMock)
+			}
+			got, err := uc.LookupUser(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_userUseCaseQueries_BatchGetUsers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	repoQueriesMock := domainMocks.NewUserRepoQueries(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+	user1 := &domain.User{ID: uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"), NickName: "one"}
+	user2 := &domain.User{ID: uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"), NickName: "two"}
+	missingID := "7a2d1c4e-0b8f-4d3a-9e6c-5f1b2a3c4d5e"
+	tests := []struct {
+		name          string
+		userIDs       []string
+		expectedMocks func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries)
+		wantUsers     []*domain.User
+		wantMissing   []string
+		wantErr       error
+	}{
+		{
+			name:    "request order, once per id",
+			userIDs: []string{user2.ID.String(), missingID, strings.ToUpper(user1.ID.String()), user2.ID.String()},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("BatchGetUsers", mock.Anything, []string{user2.ID.String(), missingID, user1.ID.String()}).Return(
+					[]*domain.User{user1, user2}, nil).Once()
+			},
+			wantUsers:   []*domain.User{user2, user1},
+			wantMissing: []string{missingID},
+		},
+		{
+			name:    "none found",
+			userIDs: []string{missingID},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("BatchGetUsers", mock.Anything, []string{missingID}).Return(nil, nil).Once()
+			},
+			wantUsers:   []*domain.User{},
+			wantMissing: []string{missingID},
+		},
+		{
+			name:    "too many ids",
+			userIDs: []string{user1.ID.String(), user2.ID.String(), missingID, missingID, missingID},
+			wantErr: fmt.Errorf("%w: at most 4", domain.ErrTooManyUserIDs),
+		},
+		{
+			name:    "invalid id",
+			userIDs: []string{user1.ID.String(), "invalid"},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "repository error",
+			userIDs: []string{user1.ID.String()},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				l.On("Warn", mock.Anything, mock.Anything).Once()
+				queries.On("BatchGetUsers", mock.Anything, []string{user1.ID.String()}).Return(nil, domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock, transactionMock, UserQueriesConfig{MaxBatchSize: 4})
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(mockedLogger, repoQueriesMock)
+			}
+			gotUsers, gotMissing, err := uc.BatchGetUsers(context.Background(), tt.userIDs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUsers, gotUsers)
+			assert.Equal(t, tt.wantMissing, gotMissing)
+		})
+	}
+}
+
 func Test_userUseCaseQueries_ListUsers(t *testing.T) {
 	tnow := time.Now()
 	mockedLogger := loggerMocks.NewInterface(t)
 	repoQueriesMock := domainMocks.NewUserRepoQueries(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
 	domainUser1 := domain.User{
 		ID:             uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"),
 		FirstName:      "nick",
@@ -110,8 +280,23 @@ func Test_userUseCaseQueries_ListUsers(t *testing.T) {
 		CreatedAt:      tnow,
 		UpdatedAt:      tnow,
 	}
-	expectedCursorTime := time.Date(2024, time.August, 22, 20, 9, 11, 938220000, time.Local)
-	var nilt *time.Time
+	var nilc *domain.UserCursor
+	byLastName := domain.UserOrder{Field: domain.UserSortLastName, Desc: true}
+	cfg := UserQueriesConfig{FuzzyThreshold: 0.3, CursorKeys: []string{"new-key", "old-key"}, CursorTTL: time.Hour}
+	signCursor := func(key string, issuedAt time.Time, order domain.UserOrder, user *domain.User, backward bool, filters domain.UserSearchFilters) string {
+		hash, err := hashFilters(filters)
+		assert.NoError(t, err)
+		cursor, err := newCursorSigner([]string{key}, cfg.CursorTTL).sign(order, user, backward, hash, issuedAt)
+		assert.NoError(t, err)
+		return cursor
+	}
+	lastNameCursor := signCursor("new-key", tnow, byLastName, &domainUser1, false, domain.UserSearchFilters{})
+	expectedCursor := &domain.UserCursor{Value: tnow.Format(time.RFC3339Nano), UserID: domainUser1.ID.String()}
+	backwardCursor := signCursor("new-key", tnow, domain.DefaultUserOrder, &domainUser1, true, domain.UserSearchFilters{})
+	ascending := domain.UserOrder{Field: domain.UserSortUpdatedAt}
+	domainUser3 := domainUser2
+	domainUser3.ID = uuid.MustParse("7a2d1c4e-0b8f-4d3a-9e6c-5f1b2a3c4d5e")
+	count := func(n int64) *int64 { return &n }
 	type args struct {
 		ctx context.Context
 		req ListUsersRequest
@@ -122,6 +307,9 @@ func Test_userUseCaseQueries_ListUsers(t *testing.T) {
 		args          args
 		wantUsers     []*domain.User
 		wantNextCur   bool
+		wantPrevCur   bool
+		wantCount     *int64
+		wantEstimated bool
 		wantErr       error
 	}{
 		{
@@ -135,7 +323,7 @@ func Test_userUseCaseQueries_ListUsers(t *testing.T) {
 			},
 			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
 				l.On("Debug", mock.Anything, mock.Anything).Once()
-				queries.On("ListUsers", mock.Anything, "", nilt, int32(2), domain.UserSearchFilters{}).Return(
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), domain.UserSearchFilters{}, []string(nil)).Return(
 					[]*domain.User{}, fmt.Errorf("something went wrong")).Once()
 			},
 			wantUsers:   []*domain.User{},
@@ -152,50 +340,508 @@ func Test_userUseCaseQueries_ListUsers(t *testing.T) {
 				},
 			},
 			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
-				queries.On("ListUsers", mock.Anything, "", nilt, int32(2), domain.UserSearchFilters{}).Return(
-					[]*domain.User{&domainUser1, &domainUser2}, nil).Once()
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), domain.UserSearchFilters{}, []string(nil)).Return(
+					[]*domain.User{&domainUser1, &domainUser2, &domainUser3}, nil).Once()
 			},
 			wantUsers:   []*domain.User{&domainUser1, &domainUser2},
 			wantNextCur: true,
 			wantErr:     nil,
 		},
 		{
-			name: "with cursor",
+			name: "last page of exactly limit users",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Filters: domain.UserSearchFilters{LastName: &domainUser1.LastName}},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), domain.UserSearchFilters{LastName: &domainUser1.LastName}, []string(nil)).Return(
+					[]*domain.User{&domainUser1, &domainUser2}, nil).Once()
+			},
+			wantUsers:   []*domain.User{&domainUser1, &domainUser2},
+			wantNextCur: false,
+			wantErr:     nil,
+		},
+		{
+			name: "fuzzy",
 			args: args{
 				ctx: context.Background(),
 				req: ListUsersRequest{
-					Cursor: "MjAyNC0wOC0yMlQyMDowOToxMS45MzgyMiswMTowMHxjMTJlMjNmMy1mNWUzLTQxYmMtYWVjYS05ZDY2YmQwYjk2YTM=",
-					Limit:  2,
+					Limit:   2,
+					Filters: domain.UserSearchFilters{FirstName: &domainUser1.FirstName},
+					Fuzzy:   true,
 				},
 			},
 			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
-				queries.On("ListUsers", mock.Anything, "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3", &expectedCursorTime, int32(2), domain.UserSearchFilters{}).Return(
-					[]*domain.User{&domainUser1, &domainUser2}, nil).Once()
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), domain.UserSearchFilters{FirstName: &domainUser1.FirstName, FuzzyThreshold: 0.3}, []string(nil)).Return(
+					[]*domain.User{&domainUser1}, nil).Once()
+			},
+			wantUsers:   []*domain.User{&domainUser1},
+			wantNextCur: false,
+			wantErr:     nil,
+		},
+		{
+			name: "invalid order",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, OrderBy: "email"},
+			},
+			wantErr: fmt.Errorf("%w: email", domain.ErrInvalidOrderBy),
+		},
+		{
+			name: "filter expression",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Filter: `country_iso_code = "PT" AND tags:vip`},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), domain.UserSearchFilters{Expr: &domain.FilterExpr{
+					Op: domain.FilterAnd, Args: []*domain.FilterExpr{
+						{Op: domain.FilterEquals, Field: "country_iso_code", Value: "PT"},
+						{Op: domain.FilterHas, Field: "tags", Value: "vip"},
+					},
+				}}, []string(nil)).Return([]*domain.User{&domainUser1}, nil).Once()
+			},
+			wantUsers: []*domain.User{&domainUser1},
+			wantErr:   nil,
+		},
+		{
+			name: "read mask with the sort field",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, OrderBy: "nickname", Fields: []string{"first_name", "email"}},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ListUsers", mock.Anything, domain.UserOrder{Field: domain.UserSortNickName}, nilc, int32(3), domain.UserSearchFilters{},
+					[]string{"first_name", "email", "nick_name"}).Return([]*domain.User{&domainUser1}, nil).Once()
+			},
+			wantUsers: []*domain.User{&domainUser1},
+			wantErr:   nil,
+		},
+		{
+			name: "read mask of a detail field",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Fields: []string{"followers_count"}},
+			},
+			wantErr: fmt.Errorf("%w: unknown field followers_count", domain.ErrInvalidReadMask),
+		},
+		{
+			name: "invalid filter expression",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Filter: `country_iso_code == "PT"`},
+			},
+			wantErr: fmt.Errorf("%w: expected a value for country_iso_code instead of \"=\" at position 19", domain.ErrInvalidFilter),
+		},
+		{
+			name: "invalid direction",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, OrderBy: "last_name down"},
+			},
+			wantErr: fmt.Errorf("%w: last_name down", domain.ErrInvalidOrderBy),
+		},
+		{
+			name: "cursor of another order",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, OrderBy: "last_name", Cursor: lastNameCursor},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				l.On("Debug", mock.Anything, mock.Anything).Once()
+			},
+			wantErr: fmt.Errorf("%w: cursor was issued for the order last_name desc", domain.ErrInvalidPaginationCursor),
+		},
+		{
+			name: "unsigned cursor",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Cursor: encodeCursor(tnow, domainUser1.ID.String())},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				l.On("Debug", mock.Anything, mock.Anything).Once()
+			},
+			wantErr: fmt.Errorf("%w: unknown cursor format", domain.ErrInvalidPaginationCursor),
+		},
+		{
+			name: "tampered cursor",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Cursor: signCursor("forged-key", tnow, domain.DefaultUserOrder, &domainUser1, false, domain.UserSearchFilters{})},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				l.On("Debug", mock.Anything, mock.Anything).Once()
+			},
+			wantErr: fmt.Errorf("%w: cursor signature does not match", domain.ErrInvalidPaginationCursor),
+		},
+		{
+			name: "expired cursor",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Cursor: signCursor("new-key", tnow.Add(-2*time.Hour), domain.DefaultUserOrder, &domainUser1, false, domain.UserSearchFilters{})},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				l.On("Debug", mock.Anything, mock.Anything).Once()
+			},
+			wantErr: fmt.Errorf("%w: cursor expired", domain.ErrInvalidPaginationCursor),
+		},
+		{
+			name: "cursor of other filters",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Cursor: signCursor("new-key", tnow, domain.DefaultUserOrder, &domainUser1, false, domain.UserSearchFilters{Tags: []string{"vip"}})},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				l.On("Debug", mock.Anything, mock.Anything).Once()
+			},
+			wantErr: fmt.Errorf("%w: cursor was issued for other filters", domain.ErrInvalidPaginationCursor),
+		},
+		{
+			name: "sorted by last name with cursor",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, OrderBy: "last_name desc", Cursor: lastNameCursor},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ListUsers", mock.Anything, byLastName, &domain.UserCursor{Value: "nock", UserID: domainUser1.ID.String()}, int32(3), domain.UserSearchFilters{}, []string(nil)).Return(
+					[]*domain.User{&domainUser2}, nil).Once()
+			},
+			wantUsers:   []*domain.User{&domainUser2},
+			wantNextCur: false,
+			wantPrevCur: true,
+			wantErr:     nil,
+		},
+		{
+			name: "with cursor signed by a rotated key",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{
+					Cursor:  signCursor("old-key", tnow, domain.DefaultUserOrder, &domainUser1, false, domain.UserSearchFilters{Tags: []string{"vip", "beta"}}),
+					Limit:   2,
+					Filters: domain.UserSearchFilters{Tags: []string{"beta", "vip"}},
+				},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, expectedCursor, int32(3), domain.UserSearchFilters{Tags: []string{"beta", "vip"}}, []string(nil)).Return(
+					[]*domain.User{&domainUser1, &domainUser2, &domainUser3}, nil).Once()
 			},
 			wantUsers:   []*domain.User{&domainUser1, &domainUser2},
 			wantNextCur: true,
+			wantPrevCur: true,
+			wantErr:     nil,
+		},
+		{
+			name: "backward to the first page",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, Cursor: backwardCursor},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ListUsers", mock.Anything, ascending, expectedCursor, int32(3), domain.UserSearchFilters{}, []string(nil)).Return(
+					[]*domain.User{&domainUser2, &domainUser3}, nil).Once()
+			},
+			wantUsers:   []*domain.User{&domainUser3, &domainUser2},
+			wantNextCur: true,
+			wantPrevCur: false,
 			wantErr:     nil,
 		},
+		{
+			name: "backward with more pages",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 1, Cursor: backwardCursor},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ListUsers", mock.Anything, ascending, expectedCursor, int32(2), domain.UserSearchFilters{}, []string(nil)).Return(
+					[]*domain.User{&domainUser2, &domainUser3}, nil).Once()
+			},
+			wantUsers:   []*domain.User{&domainUser2},
+			wantNextCur: true,
+			wantPrevCur: true,
+			wantErr:     nil,
+		},
+		{
+			name: "exact count",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, TotalCount: TotalCountExact, Filters: domain.UserSearchFilters{NickName: &domainUser1.NickName}},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				filters := domain.UserSearchFilters{NickName: &domainUser1.NickName}
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), filters, []string(nil)).Return(
+					[]*domain.User{&domainUser1}, nil).Once()
+				queries.On("CountUsers", mock.Anything, filters, false).Return(int64(1), nil).Once()
+			},
+			wantUsers: []*domain.User{&domainUser1},
+			wantCount: count(1),
+			wantErr:   nil,
+		},
+		{
+			name: "estimated count",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, TotalCount: TotalCountEstimated, Filters: domain.UserSearchFilters{CountryISOCode: &domainUser1.CountryISOCode}},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				filters := domain.UserSearchFilters{CountryISOCode: &domainUser1.CountryISOCode}
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), filters, []string(nil)).Return(
+					[]*domain.User{&domainUser1, &domainUser2, &domainUser3}, nil).Once()
+				queries.On("CountUsers", mock.Anything, filters, true).Return(int64(250000), nil).Once()
+			},
+			wantUsers:     []*domain.User{&domainUser1, &domainUser2},
+			wantNextCur:   true,
+			wantCount:     count(250000),
+			wantEstimated: true,
+			wantErr:       nil,
+		},
+		{
+			name: "small estimated count is exact",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, TotalCount: TotalCountEstimated, Filters: domain.UserSearchFilters{Email: &domainUser1.Email}},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				filters := domain.UserSearchFilters{Email: &domainUser1.Email}
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), filters, []string(nil)).Return(
+					[]*domain.User{}, nil).Once()
+				queries.On("CountUsers", mock.Anything, filters, true).Return(int64(3), nil).Once()
+				queries.On("CountUsers", mock.Anything, filters, false).Return(int64(0), nil).Once()
+			},
+			wantUsers: []*domain.User{},
+			wantCount: count(0),
+			wantErr:   nil,
+		},
+		{
+			name: "error counting users",
+			args: args{
+				ctx: context.Background(),
+				req: ListUsersRequest{Limit: 2, TotalCount: TotalCountExact, Filters: domain.UserSearchFilters{FirstName: &domainUser2.FirstName}},
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				filters := domain.UserSearchFilters{FirstName: &domainUser2.FirstName}
+				l.On("Debug", mock.Anything, mock.Anything).Once()
+				queries.On("ListUsers", mock.Anything, domain.DefaultUserOrder, nilc, int32(3), filters, []string(nil)).Return(
+					[]*domain.User{&domainUser1}, nil).Once()
+				queries.On("CountUsers", mock.Anything, filters, false).Return(int64(0), domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock)
+			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock, transactionMock, cfg)
 			if tt.expectedMocks != nil {
 				tt.expectedMocks(mockedLogger, repoQueriesMock)
 			}
-			gotUsers, gotNextCur, err := uc.ListUsers(tt.args.ctx, tt.args.req)
+			got, err := uc.ListUsers(tt.args.ctx, tt.args.req)
 			if tt.wantErr == nil {
 				assert.NoError(t, err)
 			} else {
 				assert.EqualError(t, err, tt.wantErr.Error())
 				return
 			}
-			if !reflect.DeepEqual(gotUsers, tt.wantUsers) {
-				t.Errorf("userUseCaseQueries.ListUsers() gotUsers = %v, want %v", gotUsers, tt.wantUsers)
+			if !reflect.DeepEqual(got.Users, tt.wantUsers) {
+				t.Errorf("userUseCaseQueries.ListUsers() gotUsers = %v, want %v", got.Users, tt.wantUsers)
+			}
+			if (len(got.NextCursor) > 0) != tt.wantNextCur || got.HasMore != tt.wantNextCur {
+				t.Errorf("userUseCaseQueries.ListUsers() gotNextCur = %v, want %v", got.NextCursor, tt.wantNextCur)
 			}
-			if (len(gotNextCur) > 0) != tt.wantNextCur {
-				t.Errorf("userUseCaseQueries.ListUsers() gotNextCur = %v, want %v", gotNextCur, tt.wantNextCur)
+			if (len(got.PrevCursor) > 0) != tt.wantPrevCur {
+				t.Errorf("userUseCaseQueries.ListUsers() gotPrevCur = %v, want %v", got.PrevCursor, tt.wantPrevCur)
+			}
+			assert.Equal(t, tt.wantCount, got.TotalCount)
+			assert.Equal(t, tt.wantEstimated, got.TotalCountEstimated)
+		})
+	}
+}
+
+func Test_userUseCaseQueries_ExportUsers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	repoQueriesMock := domainMocks.NewUserRepoQueries(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+	user1 := &domain.User{ID: uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"), NickName: "ann"}
+	user2 := &domain.User{ID: uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"), NickName: "bob"}
+	// exportUsers replays the users to the callback of the use case, as the repository does
+	exportUsers := func(users ...*domain.User) func(context.Context, domain.UserSearchFilters, int, func(*domain.User) error) error {
+		return func(_ context.Context, _ domain.UserSearchFilters, _ int, fn func(*domain.User) error) error {
+			for _, u := range users {
+				if err := fn(u); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	errSend := fmt.Errorf("transport is closing")
+	tests := []struct {
+		name          string
+		ctx           func() context.Context
+		req           ExportUsersRequest
+		sendErr       error
+		expectedMocks func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries)
+		wantUsers     []*domain.User
+		wantExported  int64
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  ExportUsersRequest{Filter: `country_iso_code = "PT"`, Fuzzy: true},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ExportUsers", mock.Anything, domain.UserSearchFilters{
+					FuzzyThreshold: 0.3,
+					Expr:           &domain.FilterExpr{Op: domain.FilterEquals, Field: "country_iso_code", Value: "PT"},
+				}, _exportBatchSize, mock.Anything).Return(exportUsers(user1, user2)).Once()
+			},
+			wantUsers:    []*domain.User{user1, user2},
+			wantExported: 2,
+		},
+		{
+			name:    "invalid filter",
+			req:     ExportUsersRequest{Filter: `password = "x"`},
+			wantErr: fmt.Errorf("%w: unknown field \"password\" at position 1", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "send failure",
+			sendErr: errSend,
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ExportUsers", mock.Anything, domain.UserSearchFilters{}, _exportBatchSize, mock.Anything).Return(exportUsers(user1, user2)).Once()
+			},
+			wantErr: errSend,
+		},
+		{
+			name: "cancelled",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ExportUsers", mock.Anything, domain.UserSearchFilters{}, _exportBatchSize, mock.Anything).Return(domain.ErrInternal).Once()
+				l.On("Debug", mock.Anything, mock.Anything, mock.Anything).Once()
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "repository failure after some users",
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("ExportUsers", mock.Anything, domain.UserSearchFilters{}, _exportBatchSize, mock.Anything).Return(
+					func(ctx context.Context, filters domain.UserSearchFilters, batchSize int, fn func(*domain.User) error) error {
+						if err := exportUsers(user1)(ctx, filters, batchSize, fn); err != nil {
+							return err
+						}
+						return domain.ErrFailedToProcessData
+					}).Once()
+				l.On("Warn", mock.Anything, mock.Anything).Once()
+			},
+			wantUsers:    []*domain.User{user1},
+			wantExported: 1,
+			wantErr:      domain.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock, transactionMock, UserQueriesConfig{FuzzyThreshold: 0.3})
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(mockedLogger, repoQueriesMock)
+			}
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+			var got []*domain.User
+			exported, err := uc.ExportUsers(ctx, tt.req, func(user *domain.User) error {
+				if tt.sendErr != nil {
+					return tt.sendErr
+				}
+				got = append(got, user)
+				return nil
+			})
+			assert.Equal(t, tt.wantUsers, got)
+			assert.Equal(t, tt.wantExported, exported)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_userUseCaseQueries_SearchUsers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	repoQueriesMock := domainMocks.NewUserRepoQueries(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	userID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+	results := []*domain.UserSearchResult{{
+		User:       &domain.User{ID: uuid.MustParse(userID), FirstName: "John"},
+		Rank:       0.6,
+		Highlights: map[string]string{"first_name": "<mark>John</mark>"},
+	}}
+	rankCur := float32(0.6)
+
+	uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock, transactionMock, UserQueriesConfig{FuzzyThreshold: 0.3})
+
+	tests := []struct {
+		name          string
+		req           SearchUsersRequest
+		expectedMocks func()
+		want          []*domain.UserSearchResult
+		wantToken     string
+		wantErr       error
+	}{
+		{
+			name:    "blank query",
+			req:     SearchUsersRequest{Query: "  ", Limit: 1},
+			wantErr: domain.ErrEmptySearchQuery,
+		},
+		{
+			name:    "invalid page token",
+			req:     SearchUsersRequest{Query: "john", Limit: 1, PageToken: encodeSearchToken(0.6, "not-an-id")},
+			wantErr: domain.ErrInvalidPaginationCursor,
+		},
+		{
+			name: "repository error",
+			req:  SearchUsersRequest{Query: "john", Limit: 1},
+			expectedMocks: func() {
+				repoQueriesMock.On("SearchUsers", mock.Anything, "john", (*float32)(nil), "", int32(1)).Return(nil, domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "full page returns a token",
+			req:  SearchUsersRequest{Query: " john ", Limit: 1},
+			expectedMocks: func() {
+				repoQueriesMock.On("SearchUsers", mock.Anything, "john", (*float32)(nil), "", int32(1)).Return(results, nil).Once()
+			},
+			want:      results,
+			wantToken: encodeSearchToken(0.6, userID),
+		},
+		{
+			name: "last page",
+			req:  SearchUsersRequest{Query: "john", Limit: 2, PageToken: encodeSearchToken(0.6, userID)},
+			expectedMocks: func() {
+				repoQueriesMock.On("SearchUsers", mock.Anything, "john", &rankCur, userID, int32(2)).Return(results, nil).Once()
+			},
+			want: results,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, token, err := uc.SearchUsers(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
 			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantToken, token)
 		})
 	}
 }
@@ -0,0 +1,267 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type RelationshipCommands interface {
+	// FollowUser makes the user follow the target and writes a UserFollowed event.
+	// It returns domain.ErrInvalidUserID if an invalid id is provided.
+	// It returns domain.ErrSelfRelationship if the user and the target are the same.
+	// It returns domain.ErrUserNotFound if any of the users does not exist.
+	// It returns domain.ErrUserBlocked if any of the users blocked the other.
+	// It returns domain.ErrAlreadyFollowing if the user already follows the target.
+	// It returns domain.ErrInternal if it fails to follow.
+	FollowUser(ctx context.Context, userID string, targetID string) error
+
+	// UnfollowUser makes the user stop following the target and writes a UserUnfollowed event.
+	// It returns domain.ErrInvalidUserID if an invalid id is provided.
+	// It returns domain.ErrSelfRelationship if the user and the target are the same.
+	// It returns domain.ErrNotFollowing if the user does not follow the target.
+	// It returns domain.ErrInternal if it fails to unfollow.
+	UnfollowUser(ctx context.Context, userID string, targetID string) error
+
+	// BlockUser makes the user block the target, removing the follows in both directions.
+	// It writes a UserBlocked event, preceded by a UserUnfollowed event for each removed follow.
+	// It returns domain.ErrInvalidUserID if an invalid id is provided.
+	// It returns domain.ErrSelfRelationship if the user and the target are the same.
+	// It returns domain.ErrUserNotFound if any of the users does not exist.
+	// It returns domain.ErrAlreadyBlocked if the user already blocked the target.
+	// It returns domain.ErrInternal if it fails to block.
+	BlockUser(ctx context.Context, userID string, targetID string) error
+
+	// UnblockUser removes the block of the user on the target and writes a UserUnblocked event.
+	// The follows removed by the block are not restored.
+	// It returns domain.ErrInvalidUserID if an invalid id is provided.
+	// It returns domain.ErrSelfRelationship if the user and the target are the same.
+	// It returns domain.ErrNotBlocked if the user did not block the target.
+	// It returns domain.ErrInternal if it fails to unblock.
+	UnblockUser(ctx context.Context, userID string, targetID string) error
+}
+
+type RelationshipQueries interface {
+	// ListFollowers retrieves a paginated list of the users following the user, the most recent first.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInvalidPaginationCursor if an invalid cursor is provided.
+	// It returns domain.ErrUserNotFound if the user does not exist.
+	// It returns domain.ErrInternal if it fails to fetch from the repository.
+	ListFollowers(ctx context.Context, req ListRelationshipsRequest) (users []*domain.RelatedUser, nextCursor string, err error)
+
+	// ListFollowing retrieves a paginated list of the users followed by the user, the most recent first.
+	// It returns the same errors as ListFollowers.
+	ListFollowing(ctx context.Context, req ListRelationshipsRequest) (users []*domain.RelatedUser, nextCursor string, err error)
+
+	// ListBlocked retrieves a paginated list of the users blocked by the user, the most recent first.
+	// It returns the same errors as ListFollowers.
+	ListBlocked(ctx context.Context, req ListRelationshipsRequest) (users []*domain.RelatedUser, nextCursor string, err error)
+}
+
+type ListRelationshipsRequest struct {
+	UserID string
+	Cursor string
+	Limit  int32
+}
+
+type relationshipChanged struct {
+	UserID   string `json:"user_id"`
+	TargetID string `json:"target_id"`
+}
+
+// listRelationshipsFunc matches the list methods of domain.RelationshipRepoQueries
+type listRelationshipsFunc func(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error)
+
+type relationshipUseCase struct {
+	l                    logger.Interface
+	transaction          domain.Transaction
+	userQueries          domain.UserRepoQueries
+	relationshipQueries  domain.RelationshipRepoQueries
+	relationshipCommands domain.RelationshipRepoCommands
+	outboxRepo           domain.OutboxRepoCommands
+}
+
+func NewRelationshipUseCase(logger logger.Interface, transaction domain.Transaction, userQueries domain.UserRepoQueries,
+	relationshipQueries domain.RelationshipRepoQueries, relationshipCommands domain.RelationshipRepoCommands,
+	outboxRepo domain.OutboxRepoCommands) *relationshipUseCase {
+	return &relationshipUseCase{logger, transaction, userQueries, relationshipQueries, relationshipCommands, outboxRepo}
+}
+
+// FollowUser makes the user follow the target.
+// It implements the FollowUser method of RelationshipCommands interface
+func (uc relationshipUseCase) FollowUser(ctx context.Context, userID string, targetID string) error {
+	if err := validateRelationship(userID, targetID); err != nil {
+		return err
+	}
+	domain.SetAuditTarget(ctx, userID)
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := uc.relationshipCommands.Follow(txCtx, userID, targetID); err != nil {
+			return err
+		}
+		return addRelationshipEvent(txCtx, uc.outboxRepo, "UserFollowed", userID, targetID)
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrUserBlocked) || errors.Is(err, domain.ErrAlreadyFollowing) {
+			return err
+		}
+		uc.l.Warn("app-user-relationships-follow error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// UnfollowUser makes the user stop following the target.
+// It implements the UnfollowUser method of RelationshipCommands interface
+func (uc relationshipUseCase) UnfollowUser(ctx context.Context, userID string, targetID string) error {
+	if err := validateRelationship(userID, targetID); err != nil {
+		return err
+	}
+	domain.SetAuditTarget(ctx, userID)
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := uc.relationshipCommands.Unfollow(txCtx, userID, targetID); err != nil {
+			return err
+		}
+		return addRelationshipEvent(txCtx, uc.outboxRepo, "UserUnfollowed", userID, targetID)
+	}); err != nil {
+		if errors.Is(err, domain.ErrNotFollowing) {
+			return err
+		}
+		uc.l.Warn("app-user-relationships-unfollow error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// BlockUser makes the user block the target, removing the follows in both directions.
+// It implements the BlockUser method of RelationshipCommands interface
+func (uc relationshipUseCase) BlockUser(ctx context.Context, userID string, targetID string) error {
+	if err := validateRelationship(userID, targetID); err != nil {
+		return err
+	}
+	domain.SetAuditTarget(ctx, userID)
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		wasFollowing, wasFollowed, err := uc.relationshipCommands.Block(txCtx, userID, targetID)
+		if err != nil {
+			return err
+		}
+		if wasFollowing {
+			if err := addRelationshipEvent(txCtx, uc.outboxRepo, "UserUnfollowed", userID, targetID); err != nil {
+				return err
+			}
+		}
+		if wasFollowed {
+			if err := addRelationshipEvent(txCtx, uc.outboxRepo, "UserUnfollowed", targetID, userID); err != nil {
+				return err
+			}
+		}
+		return addRelationshipEvent(txCtx, uc.outboxRepo, "UserBlocked", userID, targetID)
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrAlreadyBlocked) {
+			return err
+		}
+		uc.l.Warn("app-user-relationships-block error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// UnblockUser removes the block of the user on the target.
+// It implements the UnblockUser method of RelationshipCommands interface
+func (uc relationshipUseCase) UnblockUser(ctx context.Context, userID string, targetID string) error {
+	if err := validateRelationship(userID, targetID); err != nil {
+		return err
+	}
+	domain.SetAuditTarget(ctx, userID)
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		if err := uc.relationshipCommands.Unblock(txCtx, userID, targetID); err != nil {
+			return err
+		}
+		return addRelationshipEvent(txCtx, uc.outboxRepo, "UserUnblocked", userID, targetID)
+	}); err != nil {
+		if errors.Is(err, domain.ErrNotBlocked) {
+			return err
+		}
+		uc.l.Warn("app-user-relationships-unblock error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// ListFollowers retrieves a paginated list of the users following the user.
+// It implements the ListFollowers method of RelationshipQueries interface
+func (uc relationshipUseCase) ListFollowers(ctx context.Context, req ListRelationshipsRequest) ([]*domain.RelatedUser, string, error) {
+	return uc.list(ctx, "followers", uc.relationshipQueries.ListFollowers, req)
+}
+
+// ListFollowing retrieves a paginated list of the users followed by the user.
+// It implements the ListFollowing method of RelationshipQueries interface
+func (uc relationshipUseCase) ListFollowing(ctx context.Context, req ListRelationshipsRequest) ([]*domain.RelatedUser, string, error) {
+	return uc.list(ctx, "following", uc.relationshipQueries.ListFollowing, req)
+}
+
+// ListBlocked retrieves a paginated list of the users blocked by the user.
+// It implements the ListBlocked method of RelationshipQueries interface
+func (uc relationshipUseCase) ListBlocked(ctx context.Context, req ListRelationshipsRequest) ([]*domain.RelatedUser, string, error) {
+	return uc.list(ctx, "blocked", uc.relationshipQueries.ListBlocked, req)
+}
+
+// list retrieves a page of related users with the provided repository list function
+func (uc relationshipUseCase) list(ctx context.Context, name string, listFn listRelationshipsFunc, req ListRelationshipsRequest) (users []*domain.RelatedUser, nextCur string, err error) {
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return []*domain.RelatedUser{}, "", domain.ErrInvalidUserID
+	}
+	var sinceCur *time.Time
+	var userIDCur string
+	if len(req.Cursor) > 0 {
+		var sCur time.Time
+		sCur, userIDCur, err = decodeCursor(req.Cursor)
+		if err != nil {
+			uc.l.Debug("app-user-relationships error decoding cursor: %v", err)
+			return []*domain.RelatedUser{}, "", domain.ErrInvalidPaginationCursor
+		}
+		sinceCur = &sCur
+	}
+
+	if err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		if _, err := uc.userQueries.GetUser(txCtx, req.UserID, nil); err != nil {
+			return err
+		}
+		users, err = listFn(txCtx, req.UserID, userIDCur, sinceCur, req.Limit)
+		return err
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return []*domain.RelatedUser{}, "", err
+		}
+		uc.l.Warn("app-user-relationships error listing %s of user %s: %v", name, req.UserID, err)
+		return []*domain.RelatedUser{}, "", domain.ErrInternal
+	}
+
+	if len(users) == int(req.Limit) {
+		last := users[len(users)-1]
+		nextCur = encodeCursor(last.Since, last.ID.String())
+	}
+	return users, nextCur, nil
+}
+
+func validateRelationship(userID string, targetID string) error {
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.ErrInvalidUserID
+	}
+	target, err := uuid.Parse(targetID)
+	if err != nil {
+		return domain.ErrInvalidUserID
+	}
+	if user == target {
+		return domain.ErrSelfRelationship
+	}
+	return nil
+}
+
+// addRelationshipEvent writes a relationship change event to the outbox
+func addRelationshipEvent(ctx context.Context, outboxRepo domain.OutboxRepoCommands, eventType string, userID string, targetID string) error {
+	return addEvent(ctx, outboxRepo, eventType, relationshipChanged{UserID: userID, TargetID: targetID})
+}
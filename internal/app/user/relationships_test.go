@@ -0,0 +1,300 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_relationshipUseCase_FollowUser(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewRelationshipRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	targetID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewRelationshipUseCase(mockedLogger, transactionMock, domainMocks.NewUserRepoQueries(t),
+		domainMocks.NewRelationshipRepoQueries(t), commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		userID        string
+		targetID      string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:     "invalid target id",
+			userID:   userID,
+			targetID: "invalid",
+			wantErr:  domain.ErrInvalidUserID,
+		},
+		{
+			name:     "self follow",
+			userID:   userID,
+			targetID: userID,
+			wantErr:  domain.ErrSelfRelationship,
+		},
+		{
+			name:     "blocked",
+			userID:   userID,
+			targetID: targetID,
+			expectedMocks: func() {
+				commandsMock.On("Follow", mock.Anything, userID, targetID).Return(domain.ErrUserBlocked).Once()
+			},
+			wantErr: domain.ErrUserBlocked,
+		},
+		{
+			name:     "failed to add event to outbox",
+			userID:   userID,
+			targetID: targetID,
+			expectedMocks: func() {
+				commandsMock.On("Follow", mock.Anything, userID, targetID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:     "success",
+			userID:   userID,
+			targetID: targetID,
+			expectedMocks: func() {
+				commandsMock.On("Follow", mock.Anything, userID, targetID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserFollowed",
+					Payload: []byte(`{"user_id":"` + userID + `","target_id":"` + targetID + `"}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.FollowUser(context.Background(), tt.userID, tt.targetID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_relationshipUseCase_BlockUser(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewRelationshipRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	targetID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewRelationshipUseCase(mockedLogger, transactionMock, domainMocks.NewUserRepoQueries(t),
+		domainMocks.NewRelationshipRepoQueries(t), commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name: "already blocked",
+			expectedMocks: func() {
+				commandsMock.On("Block", mock.Anything, userID, targetID).Return(false, false, domain.ErrAlreadyBlocked).Once()
+			},
+			wantErr: domain.ErrAlreadyBlocked,
+		},
+		{
+			name: "removes follows in both directions",
+			expectedMocks: func() {
+				commandsMock.On("Block", mock.Anything, userID, targetID).Return(true, true, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserUnfollowed",
+					Payload: []byte(`{"user_id":"` + userID + `","target_id":"` + targetID + `"}`),
+				}).Return("1", nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserUnfollowed",
+					Payload: []byte(`{"user_id":"` + targetID + `","target_id":"` + userID + `"}`),
+				}).Return("2", nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserBlocked",
+					Payload: []byte(`{"user_id":"` + userID + `","target_id":"` + targetID + `"}`),
+				}).Return("3", nil).Once()
+			},
+		},
+		{
+			name: "without follows",
+			expectedMocks: func() {
+				commandsMock.On("Block", mock.Anything, userID, targetID).Return(false, false, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserBlocked",
+					Payload: []byte(`{"user_id":"` + userID + `","target_id":"` + targetID + `"}`),
+				}).Return("4", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.BlockUser(context.Background(), userID, targetID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_relationshipUseCase_UnblockUser(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewRelationshipRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	targetID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewRelationshipUseCase(mockedLogger, transactionMock, domainMocks.NewUserRepoQueries(t),
+		domainMocks.NewRelationshipRepoQueries(t), commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name: "not blocked",
+			expectedMocks: func() {
+				commandsMock.On("Unblock", mock.Anything, userID, targetID).Return(domain.ErrNotBlocked).Once()
+			},
+			wantErr: domain.ErrNotBlocked,
+		},
+		{
+			name: "repository error",
+			expectedMocks: func() {
+				commandsMock.On("Unblock", mock.Anything, userID, targetID).Return(domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "success",
+			expectedMocks: func() {
+				commandsMock.On("Unblock", mock.Anything, userID, targetID).Return(nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserUnblocked",
+					Payload: []byte(`{"user_id":"` + userID + `","target_id":"` + targetID + `"}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.UnblockUser(context.Background(), userID, targetID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_relationshipUseCase_ListFollowers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	userQueriesMock := domainMocks.NewUserRepoQueries(t)
+	queriesMock := domainMocks.NewRelationshipRepoQueries(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	since := time.Date(2024, time.August, 22, 20, 9, 11, 938220000, time.UTC)
+	follower := &domain.RelatedUser{User: domain.User{ID: uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3")}, Since: since}
+	mockedLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx)
+
+	uc := NewRelationshipUseCase(mockedLogger, transactionMock, userQueriesMock, queriesMock,
+		domainMocks.NewRelationshipRepoCommands(t), domainMocks.NewOutboxRepoCommands(t))
+	var nilt *time.Time
+
+	tests := []struct {
+		name          string
+		req           ListRelationshipsRequest
+		expectedMocks func()
+		want          []*domain.RelatedUser
+		wantNextCur   string
+		wantErr       error
+	}{
+		{
+			name:    "invalid user id",
+			req:     ListRelationshipsRequest{UserID: "invalid", Limit: 1},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "invalid cursor",
+			req:     ListRelationshipsRequest{UserID: userID, Cursor: "invalid", Limit: 1},
+			wantErr: domain.ErrInvalidPaginationCursor,
+		},
+		{
+			name: "user not found",
+			req:  ListRelationshipsRequest{UserID: userID, Limit: 1},
+			expectedMocks: func() {
+				userQueriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "more pages",
+			req:  ListRelationshipsRequest{UserID: userID, Limit: 1},
+			expectedMocks: func() {
+				userQueriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{}, nil).Once()
+				queriesMock.On("ListFollowers", mock.Anything, userID, "", nilt, int32(1)).Return([]*domain.RelatedUser{follower}, nil).Once()
+			},
+			want:        []*domain.RelatedUser{follower},
+			wantNextCur: encodeCursor(since, follower.ID.String()),
+		},
+		{
+			name: "last page",
+			req:  ListRelationshipsRequest{UserID: userID, Cursor: encodeCursor(since, follower.ID.String()), Limit: 2},
+			expectedMocks: func() {
+				userQueriesMock.On("GetUser", mock.Anything, userID, []string(nil)).Return(&domain.User{}, nil).Once()
+				queriesMock.On("ListFollowers", mock.Anything, userID, follower.ID.String(), mock.MatchedBy(func(ts *time.Time) bool {
+					return ts.Equal(since)
+				}), int32(2)).Return([]*domain.RelatedUser{follower}, nil).Once()
+			},
+			want: []*domain.RelatedUser{follower},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, gotNextCur, err := uc.ListFollowers(context.Background(), tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantNextCur, gotNextCur)
+		})
+	}
+}
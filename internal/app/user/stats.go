@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"users/internal/domain"
+)
+
+// UserStatsRequest defines the signups of the user statistics
+type UserStatsRequest struct {
+	// Period is domain.UserStatsDay, the default, domain.UserStatsWeek or domain.UserStatsMonth
+	Period string
+	// From and To bound the signups, from inclusive and to exclusive. To defaults to now and From to 30 days before To
+	From *time.Time
+	To   *time.Time
+}
+
+// _defaultStatsRange is the range of the signups when From is not set
+const _defaultStatsRange = 30 * 24 * time.Hour
+
+// _maxSignupPeriods bounds the number of periods of the signups
+const _maxSignupPeriods = 1000
+
+// GetUserStats aggregates the users, going through the cache first.
+// It implements the GetUserStats method of UserQueries interface
+func (uc userUseCaseQueries) GetUserStats(ctx context.Context, req UserStatsRequest) (stats *domain.UserStats, err error) {
+	period := req.Period
+	if period == "" {
+		period = domain.UserStatsDay
+	}
+	now := time.Now()
+	to := now
+	if req.To != nil {
+		to = *req.To
+	}
+	from := to.Add(-_defaultStatsRange)
+	if req.From != nil {
+		from = *req.From
+	}
+	switch {
+	case period != domain.UserStatsDay && period != domain.UserStatsWeek && period != domain.UserStatsMonth:
+		return nil, fmt.Errorf("%w: unknown period %s", domain.ErrInvalidStatsRange, period)
+	case !from.Before(to):
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidStatsRange)
+	case signupPeriods(period, from, to) > _maxSignupPeriods:
+		return nil, fmt.Errorf("%w: at most %d periods", domain.ErrInvalidStatsRange, _maxSignupPeriods)
+	}
+
+	// the request is cached as given, so that the default range keeps following now
+	tenantID, _ := domain.TenantFromContext(ctx)
+	key := fmt.Sprintf("%s|%s|%s|%s", tenantID, period, formatOptionalTime(req.From), formatOptionalTime(req.To))
+	if stats, ok := uc.stats.get(key, now); ok {
+		return stats, nil
+	}
+
+	err = uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		stats, err = uc.repo.GetUserStats(txCtx, period, from, to)
+		return err
+	})
+	if err != nil {
+		uc.l.Warn("App-user-queries error getting user stats: %v", err)
+		return nil, domain.ErrInternal
+	}
+	stats.ComputedAt = now
+	uc.stats.set(key, stats, now)
+	return stats, nil
+}
+
+// signupPeriods returns the number of periods the range spans, at least
+func signupPeriods(period string, from time.Time, to time.Time) int {
+	switch period {
+	case domain.UserStatsWeek:
+		return int(to.Sub(from)/(7*24*time.Hour)) + 1
+	case domain.UserStatsMonth:
+		return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+	default:
+		return int(to.Sub(from)/(24*time.Hour)) + 1
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+type cachedStats struct {
+	stats     *domain.UserStats
+	expiresAt time.Time
+}
+
+// statsCache keeps the user statistics for a TTL. A zero TTL disables the cache.
+type statsCache struct {
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[string]cachedStats
+}
+
+func newStatsCache(ttl time.Duration) *statsCache {
+	return &statsCache{ttl: ttl, entries: make(map[string]cachedStats)}
+}
+
+func (c *statsCache) get(key string, now time.Time) (*domain.UserStats, bool) {
+	c.mu.RLock()
+	cached, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok || !now.Before(cached.expiresAt) {
+		return nil, false
+	}
+	return cached.stats, true
+}
+
+// set caches the statistics, dropping the expired ones so that the cache does not grow with the requests
+func (c *statsCache) set(key string, stats *domain.UserStats, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, cached := range c.entries {
+		if !now.Before(cached.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedStats{stats: stats, expiresAt: now.Add(c.ttl)}
+}
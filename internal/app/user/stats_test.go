@@ -0,0 +1,118 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_userUseCaseQueries_GetUserStats(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	farTo := from.AddDate(3, 0, 0)
+	stats := func() *domain.UserStats {
+		return &domain.UserStats{
+			Total:     3,
+			ByCountry: map[string]int64{"PT": 2, "UK": 1},
+			ByStatus:  map[string]int64{domain.UserActive: 3},
+			Signups:   []domain.UserSignups{{PeriodStart: from, Count: 3}, {PeriodStart: from.AddDate(0, 1, 0)}, {PeriodStart: from.AddDate(0, 2, 0)}},
+		}
+	}
+	tests := []struct {
+		name          string
+		req           UserStatsRequest
+		cacheTTL      time.Duration
+		calls         int
+		expectedMocks func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries)
+		want          *domain.UserStats
+		wantErr       error
+	}{
+		{
+			name: "monthly signups",
+			req:  UserStatsRequest{Period: domain.UserStatsMonth, From: &from, To: &to},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("GetUserStats", mock.Anything, domain.UserStatsMonth, from, to).Return(stats(), nil).Twice()
+			},
+			calls: 2,
+			want:  stats(),
+		},
+		{
+			name: "cached",
+			req:  UserStatsRequest{Period: domain.UserStatsMonth, From: &from, To: &to},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("GetUserStats", mock.Anything, domain.UserStatsMonth, from, to).Return(stats(), nil).Once()
+			},
+			cacheTTL: time.Minute,
+			calls:    2,
+			want:     stats(),
+		},
+		{
+			name: "default range",
+			req:  UserStatsRequest{To: &to},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("GetUserStats", mock.Anything, domain.UserStatsDay, to.AddDate(0, 0, -30), to).Return(stats(), nil).Once()
+			},
+			calls: 1,
+			want:  stats(),
+		},
+		{
+			name:    "unknown period",
+			req:     UserStatsRequest{Period: "year"},
+			calls:   1,
+			wantErr: fmt.Errorf("%w: unknown period year", domain.ErrInvalidStatsRange),
+		},
+		{
+			name:    "empty range",
+			req:     UserStatsRequest{From: &to, To: &from},
+			calls:   1,
+			wantErr: fmt.Errorf("%w: from must be before to", domain.ErrInvalidStatsRange),
+		},
+		{
+			name:    "too many periods",
+			req:     UserStatsRequest{From: &from, To: &farTo},
+			calls:   1,
+			wantErr: fmt.Errorf("%w: at most 1000 periods", domain.ErrInvalidStatsRange),
+		},
+		{
+			name: "repository failure",
+			req:  UserStatsRequest{Period: domain.UserStatsWeek, From: &from, To: &to},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.UserRepoQueries) {
+				queries.On("GetUserStats", mock.Anything, domain.UserStatsWeek, from, to).Return(nil, domain.ErrFailedToProcessData).Once()
+				l.On("Warn", mock.Anything, mock.Anything).Once()
+			},
+			calls:   1,
+			wantErr: domain.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedLogger := loggerMocks.NewInterface(t)
+			repoQueriesMock := domainMocks.NewUserRepoQueries(t)
+			transactionMock := domainMocks.NewTransaction(t)
+			transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(mockedLogger, repoQueriesMock)
+			}
+			uc := NewUserUseCaseQueries(mockedLogger, repoQueriesMock, transactionMock, UserQueriesConfig{StatsCacheTTL: tt.cacheTTL})
+			for i := 0; i < tt.calls; i++ {
+				got, err := uc.GetUserStats(context.Background(), tt.req)
+				if tt.wantErr != nil {
+					assert.EqualError(t, err, tt.wantErr.Error())
+					continue
+				}
+				assert.NoError(t, err)
+				assert.False(t, got.ComputedAt.IsZero())
+				want := *tt.want
+				want.ComputedAt = got.ComputedAt
+				assert.Equal(t, &want, got)
+			}
+		})
+	}
+}
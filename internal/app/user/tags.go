@@ -0,0 +1,152 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// _tagPattern accepts lowercase labels such as vip, fraud-review or beta_2024
+var _tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+type TagCommands interface {
+	// TagUsers assigns the tags to every user in a single transaction, creating the missing tags.
+	// It writes a UserTagged event for each user that got new tags.
+	// It returns domain.ErrEmptyRequest if no users or tags are provided.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInvalidTag if a tag is not a lowercase label of up to 64 letters, digits, '-' or '_'.
+	// It returns domain.ErrUserNotFound if any of the users does not exist, in which case no user is tagged.
+	// It returns domain.ErrInternal if it fails to tag the users.
+	TagUsers(ctx context.Context, userIDs []string, tags []string) error
+
+	// UntagUsers removes the tags from every user in a single transaction.
+	// It writes a UserUntagged event for each user that lost tags.
+	// It returns domain.ErrEmptyRequest if no users or tags are provided.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInvalidTag if an invalid tag is provided.
+	// It returns domain.ErrInternal if it fails to untag the users.
+	UntagUsers(ctx context.Context, userIDs []string, tags []string) error
+}
+
+type userTagsChanged struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+type tagUseCase struct {
+	l           logger.Interface
+	transaction domain.Transaction
+	tagCommands domain.TagRepoCommands
+	outboxRepo  domain.OutboxRepoCommands
+}
+
+func NewTagUseCase(logger logger.Interface, transaction domain.Transaction, tagCommands domain.TagRepoCommands,
+	outboxRepo domain.OutboxRepoCommands) *tagUseCase {
+	return &tagUseCase{logger, transaction, tagCommands, outboxRepo}
+}
+
+// TagUsers assigns the tags to every user.
+// It implements the TagUsers method of TagCommands interface
+func (uc tagUseCase) TagUsers(ctx context.Context, userIDs []string, tags []string) error {
+	userIDs, tags, err := validateTagging(userIDs, tags)
+	if err != nil {
+		return err
+	}
+	if len(userIDs) == 1 {
+		domain.SetAuditTarget(ctx, userIDs[0])
+	}
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		added, err := uc.tagCommands.TagUsers(txCtx, userIDs, tags)
+		if err != nil {
+			return err
+		}
+		return addTagEvents(txCtx, uc.outboxRepo, "UserTagged", added)
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+		uc.l.Warn("app-user-tags-tag error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// UntagUsers removes the tags from every user.
+// It implements the UntagUsers method of TagCommands interface
+func (uc tagUseCase) UntagUsers(ctx context.Context, userIDs []string, tags []string) error {
+	userIDs, tags, err := validateTagging(userIDs, tags)
+	if err != nil {
+		return err
+	}
+	if len(userIDs) == 1 {
+		domain.SetAuditTarget(ctx, userIDs[0])
+	}
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) error {
+		removed, err := uc.tagCommands.UntagUsers(txCtx, userIDs, tags)
+		if err != nil {
+			return err
+		}
+		return addTagEvents(txCtx, uc.outboxRepo, "UserUntagged", removed)
+	}); err != nil {
+		uc.l.Warn("app-user-tags-untag error: %v", err)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// validateTagging validates the user ids and tags, returning them without duplicates
+func validateTagging(userIDs []string, tags []string) ([]string, []string, error) {
+	if len(userIDs) == 0 || len(tags) == 0 {
+		return nil, nil, domain.ErrEmptyRequest
+	}
+	for _, id := range userIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, nil, domain.ErrInvalidUserID
+		}
+	}
+	for _, tag := range tags {
+		if !_tagPattern.MatchString(tag) {
+			return nil, nil, fmt.Errorf("%w: %s", domain.ErrInvalidTag, tag)
+		}
+	}
+	return unique(userIDs), unique(tags), nil
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// addTagEvents writes an event with the changed tags of each user, the assignments are grouped by user
+func addTagEvents(ctx context.Context, outboxRepo domain.OutboxRepoCommands, eventType string, assignments []domain.UserTag) error {
+	var changes []*userTagsChanged
+	byUser := map[string]*userTagsChanged{}
+	for _, assignment := range assignments {
+		userID := assignment.UserID.String()
+		change, ok := byUser[userID]
+		if !ok {
+			change = &userTagsChanged{UserID: userID}
+			byUser[userID] = change
+			changes = append(changes, change)
+		}
+		change.Tags = append(change.Tags, assignment.Tag)
+	}
+	for _, change := range changes {
+		if err := addEvent(ctx, outboxRepo, eventType, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
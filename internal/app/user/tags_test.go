@@ -0,0 +1,179 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_tagUseCase_TagUsers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewTagRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	otherUserID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewTagUseCase(mockedLogger, transactionMock, commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		userIDs       []string
+		tags          []string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "empty request",
+			userIDs: []string{userID},
+			wantErr: domain.ErrEmptyRequest,
+		},
+		{
+			name:    "invalid user id",
+			userIDs: []string{userID, "invalid"},
+			tags:    []string{"vip"},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "invalid tag",
+			userIDs: []string{userID},
+			tags:    []string{"Fraud Review"},
+			wantErr: fmt.Errorf("invalid tag: Fraud Review"),
+		},
+		{
+			name:    "user not found",
+			userIDs: []string{userID, otherUserID},
+			tags:    []string{"vip"},
+			expectedMocks: func() {
+				commandsMock.On("TagUsers", mock.Anything, []string{userID, otherUserID}, []string{"vip"}).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "failed to add event to outbox",
+			userIDs: []string{userID},
+			tags:    []string{"vip"},
+			expectedMocks: func() {
+				commandsMock.On("TagUsers", mock.Anything, []string{userID}, []string{"vip"}).
+					Return([]domain.UserTag{{UserID: uuid.MustParse(userID), Tag: "vip"}}, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, mock.Anything).Return("", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:    "success writes an event per changed user",
+			userIDs: []string{userID, otherUserID, userID},
+			tags:    []string{"vip", "beta", "vip"},
+			expectedMocks: func() {
+				commandsMock.On("TagUsers", mock.Anything, []string{userID, otherUserID}, []string{"vip", "beta"}).
+					Return([]domain.UserTag{
+						{UserID: uuid.MustParse(userID), Tag: "beta"},
+						{UserID: uuid.MustParse(userID), Tag: "vip"},
+					}, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserTagged",
+					Payload: []byte(`{"user_id":"` + userID + `","tags":["beta","vip"]}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.TagUsers(context.Background(), tt.userIDs, tt.tags)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func Test_tagUseCase_UntagUsers(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	transactionMock := domainMocks.NewTransaction(t)
+	commandsMock := domainMocks.NewTagRepoCommands(t)
+	outboxCommandsMock := domainMocks.NewOutboxRepoCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	otherUserID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+	mockedLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+
+	uc := NewTagUseCase(mockedLogger, transactionMock, commandsMock, outboxCommandsMock)
+
+	tests := []struct {
+		name          string
+		userIDs       []string
+		tags          []string
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name:    "empty request",
+			tags:    []string{"vip"},
+			wantErr: domain.ErrEmptyRequest,
+		},
+		{
+			name:    "repository error",
+			userIDs: []string{userID},
+			tags:    []string{"vip"},
+			expectedMocks: func() {
+				commandsMock.On("UntagUsers", mock.Anything, []string{userID}, []string{"vip"}).Return(nil, domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:    "nothing removed writes no events",
+			userIDs: []string{userID},
+			tags:    []string{"beta"},
+			expectedMocks: func() {
+				commandsMock.On("UntagUsers", mock.Anything, []string{userID}, []string{"beta"}).Return(nil, nil).Once()
+			},
+		},
+		{
+			name:    "success",
+			userIDs: []string{userID, otherUserID},
+			tags:    []string{"vip"},
+			expectedMocks: func() {
+				commandsMock.On("UntagUsers", mock.Anything, []string{userID, otherUserID}, []string{"vip"}).
+					Return([]domain.UserTag{
+						{UserID: uuid.MustParse(userID), Tag: "vip"},
+						{UserID: uuid.MustParse(otherUserID), Tag: "vip"},
+					}, nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserUntagged",
+					Payload: []byte(`{"user_id":"` + userID + `","tags":["vip"]}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a27", nil).Once()
+				outboxCommandsMock.On("AddEvent", mock.Anything, &domain.Event{
+					Type:    "UserUntagged",
+					Payload: []byte(`{"user_id":"` + otherUserID + `","tags":["vip"]}`),
+				}).Return("0d913f6a-497b-4305-b3d1-3f53657e3a28", nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			err := uc.UntagUsers(context.Background(), tt.userIDs, tt.tags)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type WatchQueries interface {
+	// WatchUsers streams the creations, updates and deletions of the users of the tenant, in the order they were committed,
+	// calling fn with every change matching the request. Merges delete the source and update the target, and the
+	// dormancy and reactivation of a user update it. The outbox is read whenever events of the tenant are committed,
+	// and fn is called with a heartbeat, a change without type, after every heartbeat interval without changes.
+	// Every change and heartbeat carries a resume token that resumes the watch right after it, without missing changes,
+	// except the delete of a merge, whose token replays the whole merge.
+	// Without a resume token, it starts with the changes committed after the call.
+	// It returns when the context is done or fn fails, with the error of the context or of fn.
+	// It returns domain.ErrWatchClosed when the server stops watching the outbox, e.g. on shutdown.
+	// It returns domain.ErrInvalidFilter if an unknown change type is provided.
+	// It returns domain.ErrInvalidUserID if an invalid user id is provided.
+	// It returns domain.ErrInvalidResumeToken if an invalid resume token is provided.
+	// It returns domain.ErrInternal if it fails to read the outbox.
+	WatchUsers(ctx context.Context, req WatchUsersRequest, fn func(*domain.UserChange) error) error
+}
+
+// WatchConfig defines how often a heartbeat is sent to the watchers without changes
+type WatchConfig struct {
+	HeartbeatInterval time.Duration
+}
+
+type WatchUsersRequest struct {
+	// Types are domain.UserChangeCreate, domain.UserChangeUpdate or domain.UserChangeDelete, every type when empty
+	Types []string
+	// UserIDs restricts the changes to the users, every user when empty
+	UserIDs     []string
+	ResumeToken string
+}
+
+const (
+	_defaultWatchHeartbeat = 30 * time.Second
+	// _watchBatchSize is the number of events read from the outbox at once
+	_watchBatchSize = 100
+)
+
+// _userChangeTypes maps the outbox events of the users to the types of change they carry
+var _userChangeTypes = map[string][]string{
+	"CreateUser": {domain.UserChangeCreate},
+	"UpdateUser": {domain.UserChangeUpdate},
+	"DeleteUser": {domain.UserChangeDelete},
+	// the source of a merge is tombstoned and the target rewritten
+	"UserMerged": {domain.UserChangeDelete, domain.UserChangeUpdate},
+	// dormancy and reactivation change the status of the user
+	"UserDormant":     {domain.UserChangeUpdate},
+	"UserReactivated": {domain.UserChangeUpdate},
+}
+
+type watchUseCase struct {
+	l             logger.Interface
+	transaction   domain.Transaction
+	outboxQueries domain.OutboxRepoQueries
+	listener      domain.OutboxListener
+	cfg           WatchConfig
+}
+
+func NewWatchUseCase(logger logger.Interface, transaction domain.Transaction, outboxQueries domain.OutboxRepoQueries,
+	listener domain.OutboxListener, cfg WatchConfig) *watchUseCase {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = _defaultWatchHeartbeat
+	}
+	return &watchUseCase{logger, transaction, outboxQueries, listener, cfg}
+}
+
+// WatchUsers streams the changes of the users committed after the resume token.
+// It implements the WatchUsers method of WatchQueries interface
+func (uc watchUseCase) WatchUsers(ctx context.Context, req WatchUsersRequest, fn func(*domain.UserChange) error) error {
+	changeTypes, eventTypes, err := watchedEventTypes(req.Types)
+	if err != nil {
+		return err
+	}
+	userIDs := map[string]bool{}
+	for _, id := range req.UserIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return domain.ErrInvalidUserID
+		}
+		userIDs[id] = true
+	}
+
+	// subscribing before reading the outbox, the commits made meanwhile still wake the watch up
+	tenantID, _ := domain.TenantFromContext(ctx)
+	wake, unsubscribe := uc.listener.Subscribe(tenantID)
+	defer unsubscribe()
+
+	var last int64
+	if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+		last, err = uc.outboxQueries.GetLastCommittedPosition(txCtx)
+		return err
+	}); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		uc.l.Warn("app-user-watch error getting the last position: %v", err)
+		return domain.ErrInternal
+	}
+	position := last
+	if len(req.ResumeToken) > 0 {
+		if position, err = decodeResumeToken(req.ResumeToken); err != nil || position > last {
+			uc.l.Debug("app-user-watch invalid resume token %q: %v", req.ResumeToken, err)
+			return domain.ErrInvalidResumeToken
+		}
+	}
+
+	heartbeat := time.NewTicker(uc.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+	idle := false
+	for {
+		// the outbox is also read on heartbeats, in case a notification was missed
+		sent, err := uc.sendChanges(ctx, &position, eventTypes, changeTypes, userIDs, fn)
+		if err != nil {
+			return err
+		}
+		if sent {
+			heartbeat.Reset(uc.cfg.HeartbeatInterval)
+		} else if idle {
+			if err := fn(&domain.UserChange{ResumeToken: encodeResumeToken(position)}); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-wake:
+			if !ok {
+				return domain.ErrWatchClosed
+			}
+			idle = false
+		case <-heartbeat.C:
+			idle = true
+		}
+	}
+}
+
+// sendChanges calls fn with the changes matching the types and users committed after the position, advancing it.
+// It returns whether any change was sent.
+func (uc watchUseCase) sendChanges(ctx context.Context, position *int64, eventTypes []string, changeTypes map[string]bool,
+	userIDs map[string]bool, fn func(*domain.UserChange) error) (sent bool, err error) {
+	for {
+		var events []*domain.Event
+		if err := uc.transaction.BeginTx(ctx, func(txCtx context.Context) (err error) {
+			events, err = uc.outboxQueries.GetCommittedEvents(txCtx, *position, eventTypes, _watchBatchSize)
+			return err
+		}); err != nil {
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+			uc.l.Warn("app-user-watch error reading the outbox after %d: %v", *position, err)
+			return sent, domain.ErrInternal
+		}
+		for _, event := range events {
+			changes, err := userChanges(event)
+			if err != nil {
+				uc.l.Warn("app-user-watch error decoding event %s: %v", event.ID, err)
+			}
+			for i, change := range changes {
+				if !changeTypes[change.Type] || (len(userIDs) > 0 && !userIDs[change.UserID]) {
+					continue
+				}
+				// the changes before the last one of the event resume before the event, replaying it instead of missing changes
+				if i < len(changes)-1 {
+					change.ResumeToken = encodeResumeToken(*position)
+				}
+				if err := fn(change); err != nil {
+					return sent, err
+				}
+				sent = true
+			}
+			*position = event.Position
+		}
+		if len(events) < _watchBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// watchedEventTypes returns the types of change, every type when empty, and the outbox events carrying them
+func watchedEventTypes(types []string) (map[string]bool, []string, error) {
+	changeTypes := map[string]bool{}
+	for _, t := range types {
+		switch t {
+		case domain.UserChangeCreate, domain.UserChangeUpdate, domain.UserChangeDelete:
+			changeTypes[t] = true
+		default:
+			return nil, nil, fmt.Errorf("%w: unknown change type %q", domain.ErrInvalidFilter, t)
+		}
+	}
+	if len(changeTypes) == 0 {
+		changeTypes = map[string]bool{domain.UserChangeCreate: true, domain.UserChangeUpdate: true, domain.UserChangeDelete: true}
+	}
+	eventTypes := []string{}
+	for eventType, eventChangeTypes := range _userChangeTypes {
+		if slices.ContainsFunc(eventChangeTypes, func(t string) bool { return changeTypes[t] }) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	slices.Sort(eventTypes)
+	return changeTypes, eventTypes, nil
+}
+
+// userChanges converts an outbox event into the changes of the users it carries, in the order of _userChangeTypes
+func userChanges(event *domain.Event) ([]*domain.UserChange, error) {
+	var payload struct {
+		ID       string `json:"id"`
+		UserID   string `json:"user_id"`
+		SourceID string `json:"source_id"`
+		TargetID string `json:"target_id"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, err
+	}
+	var userIDs []string
+	switch event.Type {
+	case "UserMerged":
+		userIDs = []string{payload.SourceID, payload.TargetID}
+	case "UserDormant", "UserReactivated":
+		userIDs = []string{payload.UserID}
+	default:
+		userIDs = []string{payload.ID}
+	}
+	changes := make([]*domain.UserChange, 0, len(userIDs))
+	for i, changeType := range _userChangeTypes[event.Type] {
+		changes = append(changes, &domain.UserChange{
+			Type:        changeType,
+			UserID:      userIDs[i],
+			Payload:     event.Payload,
+			CreatedAt:   event.CreatedAt,
+			ResumeToken: encodeResumeToken(event.Position),
+		})
+	}
+	return changes, nil
+}
+
+// encodeResumeToken encodes the position in the outbox as an opaque token
+func encodeResumeToken(position int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(position, 10)))
+}
+
+func decodeResumeToken(token string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	position, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if position < 0 {
+		return 0, fmt.Errorf("negative position %d", position)
+	}
+	return position, nil
+}
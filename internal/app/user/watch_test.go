@@ -0,0 +1,260 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	domainMocks "users/gen/mocks/users/domain"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_watchUseCase_WatchUsers(t *testing.T) {
+	userA, userB := "0f913f6a-497b-4305-b3d1-3f53657e3a25", "1f913f6a-497b-4305-b3d1-3f53657e3a26"
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	allTypes := []string{"CreateUser", "DeleteUser", "UpdateUser", "UserDormant", "UserMerged", "UserReactivated"}
+	event := func(position int64, eventType, payload string) *domain.Event {
+		return &domain.Event{Type: eventType, Payload: []byte(payload), Position: position, CreatedAt: createdAt}
+	}
+	createA := event(11, "CreateUser", `{"id":"`+userA+`","first_name":"ann"}`)
+	updateB := event(12, "UpdateUser", `{"id":"`+userB+`","first_name":"bob"}`)
+	deleteA := event(13, "DeleteUser", `{"ID":"`+userA+`"}`)
+	mergeAB := event(13, "UserMerged", `{"source_id":"`+userA+`","target_id":"`+userB+`","field_resolution":{"email":"source"}}`)
+	dormantB := event(14, "UserDormant", `{"user_id":"`+userB+`","status":"dormant","last_seen_at":null}`)
+	reactivatedA := event(15, "UserReactivated", `{"user_id":"`+userA+`"}`)
+	change := func(changeType string, userID string, e *domain.Event) *domain.UserChange {
+		return &domain.UserChange{Type: changeType, UserID: userID, Payload: e.Payload, CreatedAt: createdAt, ResumeToken: encodeResumeToken(e.Position)}
+	}
+	// resumedBefore is a change resuming at the position before its event
+	resumedBefore := func(c *domain.UserChange, position int64) *domain.UserChange {
+		c.ResumeToken = encodeResumeToken(position)
+		return c
+	}
+	errStream := errors.New("stream closed")
+
+	tests := []struct {
+		name      string
+		req       WatchUsersRequest
+		heartbeat time.Duration
+		// woken is whether a commit is notified once the outbox was first read
+		woken bool
+		// closed is whether the listener is stopped
+		closed        bool
+		expectedMocks func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries)
+		// stopAfter is the number of changes after which the watcher goes away, failing if the stream fails
+		stopAfter  int
+		streamFail bool
+		want       []*domain.UserChange
+		wantErr    error
+	}{
+		{
+			name:  "resumes after the token and wakes up on commits",
+			req:   WatchUsersRequest{ResumeToken: encodeResumeToken(10)},
+			woken: true,
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(12), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(10), allTypes, int32(_watchBatchSize)).Return([]*domain.Event{createA, updateB}, nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), allTypes, int32(_watchBatchSize)).Return([]*domain.Event{deleteA}, nil).Once()
+			},
+			stopAfter: 3,
+			want: []*domain.UserChange{
+				change(domain.UserChangeCreate, userA, createA),
+				change(domain.UserChangeUpdate, userB, updateB),
+				change(domain.UserChangeDelete, userA, deleteA),
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name:  "filtered by type and user",
+			req:   WatchUsersRequest{Types: []string{domain.UserChangeCreate, domain.UserChangeDelete}, UserIDs: []string{userA}},
+			woken: true,
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(10), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(10), []string{"CreateUser", "DeleteUser", "UserMerged"}, int32(_watchBatchSize)).Return(
+					[]*domain.Event{createA, event(12, "DeleteUser", `{"ID":"`+userB+`"}`)}, nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), []string{"CreateUser", "DeleteUser", "UserMerged"}, int32(_watchBatchSize)).Return(
+					[]*domain.Event{deleteA}, nil).Once()
+			},
+			stopAfter: 2,
+			want:      []*domain.UserChange{change(domain.UserChangeCreate, userA, createA), change(domain.UserChangeDelete, userA, deleteA)},
+			wantErr:   context.Canceled,
+		},
+		{
+			name: "merges, dormancy and reactivation",
+			req:  WatchUsersRequest{ResumeToken: encodeResumeToken(12)},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(15), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), allTypes, int32(_watchBatchSize)).Return(
+					[]*domain.Event{mergeAB, dormantB, reactivatedA}, nil).Once()
+			},
+			stopAfter: 4,
+			want: []*domain.UserChange{
+				resumedBefore(change(domain.UserChangeDelete, userA, mergeAB), 12),
+				change(domain.UserChangeUpdate, userB, mergeAB),
+				change(domain.UserChangeUpdate, userB, dormantB),
+				change(domain.UserChangeUpdate, userA, reactivatedA),
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "merges filtered by type",
+			req:  WatchUsersRequest{Types: []string{domain.UserChangeDelete}, ResumeToken: encodeResumeToken(12)},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(13), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), []string{"DeleteUser", "UserMerged"}, int32(_watchBatchSize)).Return(
+					[]*domain.Event{mergeAB}, nil).Once()
+			},
+			stopAfter: 1,
+			want:      []*domain.UserChange{resumedBefore(change(domain.UserChangeDelete, userA, mergeAB), 12)},
+			wantErr:   context.Canceled,
+		},
+		{
+			name: "merges filtered by user",
+			req:  WatchUsersRequest{Types: []string{domain.UserChangeUpdate}, UserIDs: []string{userB}, ResumeToken: encodeResumeToken(12)},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(15), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), []string{"UpdateUser", "UserDormant", "UserMerged", "UserReactivated"},
+					int32(_watchBatchSize)).Return([]*domain.Event{mergeAB, dormantB, reactivatedA}, nil).Once()
+			},
+			stopAfter: 2,
+			want:      []*domain.UserChange{change(domain.UserChangeUpdate, userB, mergeAB), change(domain.UserChangeUpdate, userB, dormantB)},
+			wantErr:   context.Canceled,
+		},
+		{
+			name:      "heartbeat without changes",
+			heartbeat: time.Millisecond,
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(12), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), allTypes, int32(_watchBatchSize)).Return([]*domain.Event{}, nil)
+			},
+			stopAfter: 1,
+			want:      []*domain.UserChange{{ResumeToken: encodeResumeToken(12)}},
+			wantErr:   context.Canceled,
+		},
+		{
+			name:   "listener stopped",
+			closed: true,
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(12), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), allTypes, int32(_watchBatchSize)).Return([]*domain.Event{}, nil).Once()
+			},
+			wantErr: domain.ErrWatchClosed,
+		},
+		{
+			name:  "stream failure",
+			req:   WatchUsersRequest{ResumeToken: encodeResumeToken(10)},
+			woken: true,
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(12), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(10), allTypes, int32(_watchBatchSize)).Return([]*domain.Event{createA, updateB}, nil).Once()
+			},
+			streamFail: true,
+			want:       []*domain.UserChange{change(domain.UserChangeCreate, userA, createA)},
+			wantErr:    errStream,
+		},
+		{
+			name: "outbox failure",
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(12), nil).Once()
+				queries.On("GetCommittedEvents", mock.Anything, int64(12), allTypes, int32(_watchBatchSize)).Return(nil, domain.ErrInternal).Once()
+				l.On("Warn", mock.Anything, int64(12), domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "malformed resume token",
+			req:  WatchUsersRequest{ResumeToken: "not a token"},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(12), nil).Once()
+				l.On("Debug", mock.Anything, "not a token", mock.Anything).Once()
+			},
+			wantErr: domain.ErrInvalidResumeToken,
+		},
+		{
+			name: "resume token ahead of the outbox",
+			req:  WatchUsersRequest{ResumeToken: encodeResumeToken(13)},
+			expectedMocks: func(l *loggerMocks.Interface, queries *domainMocks.OutboxRepoQueries) {
+				queries.On("GetLastCommittedPosition", mock.Anything).Return(int64(12), nil).Once()
+				l.On("Debug", mock.Anything, encodeResumeToken(13), nil).Once()
+			},
+			wantErr: domain.ErrInvalidResumeToken,
+		},
+		{
+			name:    "unknown change type",
+			req:     WatchUsersRequest{Types: []string{"merge"}},
+			wantErr: fmt.Errorf("%w: unknown change type \"merge\"", domain.ErrInvalidFilter),
+		},
+		{
+			name:    "invalid user id",
+			req:     WatchUsersRequest{UserIDs: []string{"abc"}},
+			wantErr: domain.ErrInvalidUserID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedLogger := loggerMocks.NewInterface(t)
+			transactionMock := domainMocks.NewTransaction(t)
+			queriesMock := domainMocks.NewOutboxRepoQueries(t)
+			listenerMock := domainMocks.NewOutboxListener(t)
+			transactionMock.On("BeginTx", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(passthroughTx).Maybe()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(mockedLogger, queriesMock)
+			}
+
+			ctx, cancel := context.WithCancel(domain.WithTenant(context.Background(), "tenant"))
+			defer cancel()
+			wake := make(chan struct{}, 1)
+			if tt.closed {
+				close(wake)
+			}
+			unsubscribed := false
+			listenerMock.On("Subscribe", "tenant").Return((<-chan struct{})(wake), func() { unsubscribed = true }).Maybe()
+
+			got := []*domain.UserChange{}
+			uc := NewWatchUseCase(mockedLogger, transactionMock, queriesMock, listenerMock, WatchConfig{HeartbeatInterval: tt.heartbeat})
+			if tt.heartbeat == 0 {
+				uc.cfg.HeartbeatInterval = time.Hour
+			}
+			err := uc.WatchUsers(ctx, tt.req, func(c *domain.UserChange) error {
+				got = append(got, c)
+				if tt.streamFail {
+					return errStream
+				}
+				if len(got) == len(tt.want)-1 && tt.woken {
+					wake <- struct{}{}
+				}
+				if len(got) == tt.stopAfter {
+					cancel()
+				}
+				return nil
+			})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if len(tt.want) > 0 {
+				assert.Equal(t, tt.want, got)
+			}
+			if len(listenerMock.Calls) > 0 {
+				assert.True(t, unsubscribed, "the watch must unsubscribe when it returns")
+			}
+		})
+	}
+}
+
+func Test_decodeResumeToken(t *testing.T) {
+	position, err := decodeResumeToken(encodeResumeToken(42))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), position)
+
+	for _, token := range []string{"", "!!", encodeResumeToken(-1), "YWJj"} {
+		_, err := decodeResumeToken(token)
+		assert.Error(t, err, token)
+	}
+}
@@ -0,0 +1,14 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+)
+
+func (us UserHandler) RecordLogin(ctx context.Context, req *gen.UserID) (*gen.UserID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.activity.RecordActivity(ctx, req.GetId())
+	return &gen.UserID{Id: req.GetId()}, err
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUserServerImpl_RecordLogin(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockActivity := appmocks.NewActivityCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		activity:       mockActivity,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.UserID
+		expectedMocks func(ctx context.Context)
+		want          *gen.UserID
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.UserID{Id: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockActivity.On("RecordActivity", ctx, expectedUserID).Return(nil).Once()
+			},
+			want: &gen.UserID{Id: expectedUserID},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.UserID{Id: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockActivity.On("RecordActivity", ctx, expectedUserID).Return(domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:    "invalid user id",
+			req:     &gen.UserID{Id: "invalid"},
+			wantErr: fmt.Errorf("validation error:\n - id: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.RecordLogin(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.RecordLogin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_activityInterceptor(t *testing.T) {
+	mockActivity := appmocks.NewActivityCommands(t)
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	info := &grpc.UnaryServerInfo{FullMethod: gen.UserService_GetUser_FullMethodName}
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		handlerErr    error
+		expectedMocks func()
+	}{
+		{
+			name: "no actor",
+			md:   metadata.MD{},
+		},
+		{
+			name: "actor that is not a user",
+			md:   metadata.Pairs(ActorHeader, "admin"),
+		},
+		{
+			name: "user actor",
+			md:   metadata.Pairs(ActorHeader, userID),
+			expectedMocks: func() {
+				mockActivity.On("RecordActivity", mock.Anything, userID).Return(nil).Once()
+			},
+		},
+		{
+			name:       "failed request is still activity",
+			md:         metadata.Pairs(ActorHeader, userID),
+			handlerErr: domain.ErrUserNotFound,
+			expectedMocks: func() {
+				mockActivity.On("RecordActivity", mock.Anything, userID).Return(domain.ErrInternal).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := activityInterceptor(mockActivity)(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.handlerErr
+			})
+			assert.Equal(t, tt.handlerErr, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"users/internal/app"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// recordActivity records the activity of the actor of the request, when it is a user of the tenant of the request.
+// Actors that are not users, such as services, and users of other tenants are not tracked.
+func recordActivity(ctx context.Context, activity app.ActivityCommands) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return
+	}
+	actor := firstMetadataValue(md, ActorHeader)
+	if _, err := uuid.Parse(actor); err != nil {
+		return
+	}
+	// failures are logged by the use case and never fail the request
+	_ = activity.RecordActivity(ctx, actor)
+}
+
+func activityInterceptor(activity app.ActivityCommands) func(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		recordActivity(ctx, activity)
+		return resp, err
+	}
+}
+
+func streamActivityInterceptor(activity app.ActivityCommands) func(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		recordActivity(ss.Context(), activity)
+		return err
+	}
+}
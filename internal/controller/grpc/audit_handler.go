@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) ListAuditEvents(ctx context.Context, req *gen.ListAuditEventsRequest) (*gen.ListAuditEventsResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	filters := domain.AuditEventFilters{
+		Actor:        req.Actor,
+		TargetUserID: req.TargetUserId,
+		Method:       req.Method,
+	}
+	if req.After != nil {
+		after := req.GetAfter().AsTime()
+		filters.After = &after
+	}
+	if req.Before != nil {
+		before := req.GetBefore().AsTime()
+		filters.Before = &before
+	}
+	resp := &gen.ListAuditEventsResponse{}
+	events, nextCursor, err := us.audit.ListAuditEvents(ctx, user.ListAuditEventsRequest{
+		Cursor:  req.GetCursor(),
+		Limit:   req.GetLimit(),
+		Filters: filters,
+	})
+	if err != nil {
+		return resp, err
+	}
+	resp.NextCursor = nextCursor
+	resp.Events = make([]*gen.AuditEvent, 0, len(events))
+	for _, event := range events {
+		resp.Events = append(resp.Events, protoAuditEvent(event))
+	}
+	return resp, nil
+}
+
+// protoAuditEvent maps a domain audit event into the proto message exposed by the api
+func protoAuditEvent(event *domain.AuditEvent) *gen.AuditEvent {
+	e := &gen.AuditEvent{
+		Id:        event.ID.String(),
+		Actor:     event.Actor,
+		Method:    event.Method,
+		RequestId: event.RequestID,
+		SourceIp:  event.SourceIP,
+		Outcome:   event.Outcome,
+		Error:     event.Error,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+	if event.TargetUserID != nil {
+		e.TargetUserId = event.TargetUserID.String()
+	}
+	if len(event.Diff) > 0 {
+		e.Diff = make(map[string]*gen.FieldChange, len(event.Diff))
+		for field, change := range event.Diff {
+			e.Diff[field] = &gen.FieldChange{Old: change.Old, New: change.New}
+		}
+	}
+	return e
+}
@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_ListAuditEvents(t *testing.T) {
+	expectedEventID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	targetID := uuid.MustParse(expectedUserID)
+	actor := "admin"
+	tnow := time.Now()
+
+	mockAudit := appmocks.NewAuditService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		audit:          mockAudit,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.ListAuditEventsRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.ListAuditEventsResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.ListAuditEventsRequest{Limit: 1, Actor: &actor, After: timestamppb.New(tnow)},
+			expectedMocks: func(ctx context.Context) {
+				after := timestamppb.New(tnow).AsTime()
+				mockAudit.On("ListAuditEvents", ctx, user.ListAuditEventsRequest{Limit: 1, Filters: domain.AuditEventFilters{Actor: &actor, After: &after}}).
+					Return([]*domain.AuditEvent{{
+						ID: uuid.MustParse(expectedEventID), Actor: actor, Method: "UpdateUser", TargetUserID: &targetID, RequestID: "req-1",
+						SourceIP: "10.0.0.2", Outcome: domain.AuditSuccess, CreatedAt: tnow,
+						Diff: map[string]domain.FieldChange{"email": {Old: "old@example.com", New: "new@example.com"}},
+					}}, "next", nil).Once()
+			},
+			want: &gen.ListAuditEventsResponse{
+				Events: []*gen.AuditEvent{{
+					Id: expectedEventID, Actor: actor, Method: "UpdateUser", TargetUserId: expectedUserID, RequestId: "req-1",
+					SourceIp: "10.0.0.2", Outcome: "success", CreatedAt: timestamppb.New(tnow),
+					Diff: map[string]*gen.FieldChange{"email": {Old: "old@example.com", New: "new@example.com"}},
+				}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.ListAuditEventsRequest{Limit: 1},
+			expectedMocks: func(ctx context.Context) {
+				mockAudit.On("ListAuditEvents", ctx, user.ListAuditEventsRequest{Limit: 1}).Return(nil, "", domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:    "invalid target user id",
+			req:     &gen.ListAuditEventsRequest{Limit: 1, TargetUserId: proto.String("invalid")},
+			wantErr: fmt.Errorf("validation error:\n - target_user_id: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.ListAuditEvents(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.ListAuditEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
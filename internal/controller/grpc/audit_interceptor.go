@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"path"
+	"strings"
+	gen "users/gen/proto/go"
+	"users/internal/app"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// ActorHeader is the request metadata key identifying who runs the request, set by the api gateway
+	ActorHeader = "x-actor-id"
+	// RequestIDHeader is the request metadata key correlating the request, generated when absent
+	RequestIDHeader = "x-request-id"
+	// ForwardedForHeader is the request metadata key carrying the client address, appended as the last hop by the gateway
+	ForwardedForHeader = "x-forwarded-for"
+)
+
+// _unauditedMethods are the reads and the activity tracking, every other method runs an audited command
+var _unauditedMethods = map[string]bool{
+	gen.UserService_GetUser_FullMethodName:          true,
+	gen.UserService_BatchGetUsers_FullMethodName:    true,
+	gen.UserService_LookupUser_FullMethodName:       true,
+	gen.UserService_ListUsers_FullMethodName:        true,
+	gen.UserService_ExportUsers_FullMethodName:      true,
+	gen.UserService_GetUserStats_FullMethodName:     true,
+	gen.UserService_WatchUsers_FullMethodName:       true,
+	gen.UserService_SearchUsers_FullMethodName:      true,
+	gen.UserService_GetPreferences_FullMethodName:   true,
+	gen.UserService_ListGroupMembers_FullMethodName: true,
+	gen.UserService_ListUserGroups_FullMethodName:   true,
+	gen.UserService_ListFollowers_FullMethodName:    true,
+	gen.UserService_ListFollowing_FullMethodName:    true,
+	gen.UserService_ListBlocked_FullMethodName:      true,
+	gen.UserService_ListInvitations_FullMethodName:  true,
+	gen.UserService_ListUserVersions_FullMethodName: true,
+	gen.UserService_GetUserAsOf_FullMethodName:      true,
+	gen.UserService_ListAuditEvents_FullMethodName:  true,
+	gen.UserService_RecordLogin_FullMethodName:      true,
+}
+
+// startAudit returns a copy of the context carrying the audit record of the command, along with the request id.
+// The request id is taken from the metadata or generated.
+func startAudit(ctx context.Context, fullMethod string) (context.Context, string) {
+	event := domain.AuditEvent{Method: path.Base(fullMethod)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		event.Actor = firstMetadataValue(md, ActorHeader)
+		event.RequestID = firstMetadataValue(md, RequestIDHeader)
+	}
+	if event.RequestID == "" {
+		event.RequestID = uuid.NewString()
+	}
+	event.SourceIP = sourceIP(ctx)
+	auditCtx, _ := domain.WithAuditRecord(ctx, event)
+	return auditCtx, event.RequestID
+}
+
+// sourceIP returns the address of the client of the request.
+// The gateway dials the server over loopback and appends the address of its own client as the last hop of
+// x-forwarded-for, the hops before it are supplied by the client and never trusted. Direct calls are attributed to their peer.
+func sourceIP(ctx context.Context) string {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if addr := net.ParseIP(ip); addr == nil || !addr.IsLoopback() {
+		return ip
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if hops := md.Get(ForwardedForHeader); len(hops) > 0 {
+		forwarded := hops[len(hops)-1]
+		if last := strings.TrimSpace(forwarded[strings.LastIndex(forwarded, ",")+1:]); last != "" {
+			ip = last
+		}
+	}
+	return ip
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func auditInterceptor(audit app.AuditService) func(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _unauditedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		auditCtx, requestID := startAudit(ctx, info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+		resp, err := handler(auditCtx, req)
+		if err != nil {
+			audit.RecordFailure(auditCtx, err)
+		}
+		return resp, err
+	}
+}
+
+func streamAuditInterceptor(audit app.AuditService) func(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _unauditedMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		auditCtx, requestID := startAudit(ss.Context(), info.FullMethod)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: auditCtx})
+		if err != nil {
+			audit.RecordFailure(auditCtx, err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func Test_auditInterceptor(t *testing.T) {
+	mockAudit := appmocks.NewAuditService(t)
+	peerAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 5000}
+	gatewayAddr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5001}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		// peer is the address of the caller, peerAddr when nil
+		peer          net.Addr
+		method        string
+		handlerErr    error
+		expectedMocks func()
+		wantAudited   bool
+		want          domain.AuditEvent
+	}{
+		{
+			name:   "read only method is not audited",
+			md:     metadata.Pairs(ActorHeader, "admin"),
+			method: gen.UserService_GetUser_FullMethodName,
+		},
+		{
+			name: "command through the gateway",
+			md: metadata.Pairs(ActorHeader, "admin", RequestIDHeader, "req-1", ForwardedForHeader, "198.51.100.1",
+				ForwardedForHeader, "203.0.113.7, 10.0.0.1"),
+			peer:        gatewayAddr,
+			method:      gen.UserService_DeleteUser_FullMethodName,
+			wantAudited: true,
+			want:        domain.AuditEvent{Actor: "admin", Method: "DeleteUser", RequestID: "req-1", SourceIP: "10.0.0.1"},
+		},
+		{
+			name:        "forwarded address of a direct call is ignored",
+			md:          metadata.Pairs(RequestIDHeader, "req-4", ForwardedForHeader, "203.0.113.7"),
+			method:      gen.UserService_UpdateUser_FullMethodName,
+			wantAudited: true,
+			want:        domain.AuditEvent{Method: "UpdateUser", RequestID: "req-4", SourceIP: "10.0.0.2"},
+		},
+		{
+			name:        "source ip from peer",
+			md:          metadata.Pairs(RequestIDHeader, "req-2"),
+			method:      gen.UserService_UpdateUser_FullMethodName,
+			wantAudited: true,
+			want:        domain.AuditEvent{Method: "UpdateUser", RequestID: "req-2", SourceIP: "10.0.0.2"},
+		},
+		{
+			name:       "failed command is recorded",
+			md:         metadata.Pairs(ActorHeader, "admin", RequestIDHeader, "req-3"),
+			method:     gen.UserService_DeleteUser_FullMethodName,
+			handlerErr: domain.ErrUserNotFound,
+			expectedMocks: func() {
+				mockAudit.On("RecordFailure", mock.Anything, domain.ErrUserNotFound).Once()
+			},
+			wantAudited: true,
+			want:        domain.AuditEvent{Actor: "admin", Method: "DeleteUser", RequestID: "req-3", SourceIP: "10.0.0.2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			addr := tt.peer
+			if addr == nil {
+				addr = peerAddr
+			}
+			ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), tt.md), &peer.Peer{Addr: addr})
+			var record *domain.AuditRecord
+			var audited bool
+			_, err := auditInterceptor(mockAudit)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				record, audited = domain.AuditRecordFromContext(ctx)
+				return nil, tt.handlerErr
+			})
+			assert.ErrorIs(t, err, tt.handlerErr)
+			assert.Equal(t, tt.wantAudited, audited)
+			if tt.wantAudited {
+				assert.Equal(t, &tt.want, record.Event("", nil))
+			}
+		})
+	}
+}
+
+func Test_streamAuditInterceptor(t *testing.T) {
+	mockAudit := appmocks.NewAuditService(t)
+
+	stream := &uploadAvatarStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(ActorHeader, "admin"))}
+	var event *domain.AuditEvent
+	err := streamAuditInterceptor(mockAudit)(nil, stream, &grpc.StreamServerInfo{FullMethod: gen.UserService_UploadAvatar_FullMethodName},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			record, ok := domain.AuditRecordFromContext(ss.Context())
+			assert.True(t, ok)
+			event = record.Event("", nil)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", event.Actor)
+	assert.Equal(t, "UploadAvatar", event.Method)
+	assert.NoError(t, uuid.Validate(event.RequestID), "generated request id")
+	assert.Equal(t, []string{event.RequestID}, stream.header.Get(RequestIDHeader))
+}
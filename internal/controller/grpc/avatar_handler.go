@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+)
+
+func (us UserHandler) UploadAvatar(stream gen.UserService_UploadAvatarServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if err := us.protoValidator.Validate(req); err != nil {
+		return err
+	}
+	metadata := req.GetMetadata()
+	if metadata == nil {
+		return domain.ErrInvalidAvatar
+	}
+
+	avatarURL, err := us.avatarCommands.UploadAvatar(stream.Context(), metadata.GetId(), metadata.GetContentType(),
+		&avatarStreamReader{stream: stream, validator: us.protoValidator})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&gen.UploadAvatarResponse{AvatarUrl: avatarURL})
+}
+
+func (us UserHandler) DeleteAvatar(ctx context.Context, req *gen.UserID) (*gen.UserID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.avatarCommands.DeleteAvatar(ctx, req.GetId())
+	return &gen.UserID{Id: req.GetId()}, err
+}
+
+// avatarStreamReader exposes the chunks of an UploadAvatar stream as an io.Reader
+type avatarStreamReader struct {
+	stream    gen.UserService_UploadAvatarServer
+	validator *protovalidate.Validator
+	buf       []byte
+}
+
+func (r *avatarStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if err := r.validator.Validate(req); err != nil {
+			return 0, err
+		}
+		if req.GetMetadata() != nil {
+			// the metadata is only accepted as the first message
+			return 0, domain.ErrInvalidAvatar
+		}
+		r.buf = req.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
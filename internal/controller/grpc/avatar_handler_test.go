@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// uploadAvatarStream replays the requests of an UploadAvatar stream and records the response
+type uploadAvatarStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*gen.UploadAvatarRequest
+	response *gen.UploadAvatarResponse
+	header   metadata.MD
+}
+
+func (s *uploadAvatarStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *uploadAvatarStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadAvatarStream) Recv() (*gen.UploadAvatarRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *uploadAvatarStream) SendAndClose(resp *gen.UploadAvatarResponse) error {
+	s.response = resp
+	return nil
+}
+
+func metadataRequest(id string, contentType string) *gen.UploadAvatarRequest {
+	return &gen.UploadAvatarRequest{Data: &gen.UploadAvatarRequest_Metadata{Metadata: &gen.AvatarMetadata{Id: id, ContentType: contentType}}}
+}
+
+func chunkRequest(chunk string) *gen.UploadAvatarRequest {
+	return &gen.UploadAvatarRequest{Data: &gen.UploadAvatarRequest_Chunk{Chunk: []byte(chunk)}}
+}
+
+func TestUserServerImpl_UploadAvatar(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockAvatarCommands := appmocks.NewAvatarCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		avatarCommands: mockAvatarCommands,
+		protoValidator: protoValidator,
+	}
+
+	// readAll drains the reader passed to the service layer, like the use case does
+	readAll := func(want string, readErr error) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			data, err := io.ReadAll(args.Get(3).(io.Reader))
+			if readErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, want, string(data))
+			} else {
+				assert.EqualError(t, err, readErr.Error())
+			}
+		}
+	}
+
+	tests := []struct {
+		name          string
+		requests      []*gen.UploadAvatarRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.UploadAvatarResponse
+		wantErr       error
+	}{
+		{
+			name:     "success",
+			requests: []*gen.UploadAvatarRequest{metadataRequest(expectedUserID, "image/png"), chunkRequest("ab"), chunkRequest("cd")},
+			expectedMocks: func(ctx context.Context) {
+				mockAvatarCommands.On("UploadAvatar", ctx, expectedUserID, "image/png", mock.Anything).
+					Run(readAll("abcd", nil)).Return("/avatars/256.jpg", nil).Once()
+			},
+			want: &gen.UploadAvatarResponse{AvatarUrl: "/avatars/256.jpg"},
+		},
+		{
+			name:     "metadata sent twice",
+			requests: []*gen.UploadAvatarRequest{metadataRequest(expectedUserID, "image/png"), chunkRequest("ab"), metadataRequest(expectedUserID, "image/png")},
+			expectedMocks: func(ctx context.Context) {
+				mockAvatarCommands.On("UploadAvatar", ctx, expectedUserID, "image/png", mock.Anything).
+					Run(readAll("", domain.ErrInvalidAvatar)).Return("", domain.ErrInvalidAvatar).Once()
+			},
+			wantErr: domain.ErrInvalidAvatar,
+		},
+		{
+			name:     "service layer error",
+			requests: []*gen.UploadAvatarRequest{metadataRequest(expectedUserID, "image/svg+xml"), chunkRequest("<svg/>")},
+			expectedMocks: func(ctx context.Context) {
+				mockAvatarCommands.On("UploadAvatar", ctx, expectedUserID, "image/svg+xml", mock.Anything).
+					Return("", domain.ErrUnsupportedAvatarType).Once()
+			},
+			wantErr: domain.ErrUnsupportedAvatarType,
+		},
+		{
+			name:     "chunk before metadata",
+			requests: []*gen.UploadAvatarRequest{chunkRequest("ab")},
+			wantErr:  domain.ErrInvalidAvatar,
+		},
+		{
+			name:     "invalid id",
+			requests: []*gen.UploadAvatarRequest{metadataRequest("something wrong", "image/png")},
+			wantErr:  fmt.Errorf("validation error:\n - metadata.id: value must be a valid UUID [string.uuid]"),
+		},
+		{
+			name:    "empty stream",
+			wantErr: io.EOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			stream := &uploadAvatarStream{ctx: ctx, requests: tt.requests}
+			err := server.UploadAvatar(stream)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(stream.response, tt.want) {
+				t.Errorf("UserServerImpl.UploadAvatar() = %v, want %v", stream.response, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_DeleteAvatar(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockAvatarCommands := appmocks.NewAvatarCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		avatarCommands: mockAvatarCommands,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.UserID
+		expectedMocks func(ctx context.Context)
+		want          *gen.UserID
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.UserID{Id: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockAvatarCommands.On("DeleteAvatar", ctx, expectedUserID).Return(nil).Once()
+			},
+			want: &gen.UserID{Id: expectedUserID},
+		},
+		{
+			name: "avatar not set",
+			req:  &gen.UserID{Id: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockAvatarCommands.On("DeleteAvatar", ctx, expectedUserID).Return(domain.ErrAvatarNotSet).Once()
+			},
+			wantErr: domain.ErrAvatarNotSet,
+		},
+		{
+			name:    "invalid id",
+			req:     &gen.UserID{Id: "something wrong"},
+			wantErr: fmt.Errorf("validation error:\n - id: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.DeleteAvatar(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserServerImpl.DeleteAvatar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
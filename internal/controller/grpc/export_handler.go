@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"errors"
+	"strconv"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ExportedUsersTrailer is the trailer metadata key carrying the number of users sent by ExportUsers
+const ExportedUsersTrailer = "x-exported-users"
+
+func (us UserHandler) ExportUsers(req *gen.ExportUsersRequest, stream grpc.ServerStreamingServer[gen.ReadableUserFields]) error {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return err
+	}
+	bornAfter, err := parseDate(req.BornAfter)
+	if err != nil {
+		return err
+	}
+	bornBefore, err := parseDate(req.BornBefore)
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	exported, err := us.serviceQueries.ExportUsers(ctx,
+		user.ExportUsersRequest{
+			Fuzzy:  req.GetFuzzy(),
+			Filter: req.GetFilter(),
+			Filters: domain.UserSearchFilters{
+				FirstName:      req.FirstName,
+				LastName:       req.LastName,
+				NickName:       req.NickName,
+				CountryISOCode: req.CountryIsoCode,
+				Email:          req.Email,
+				Locale:         req.Locale,
+				Timezone:       req.Timezone,
+				BornAfter:      bornAfter,
+				BornBefore:     bornBefore,
+				Tags:           req.GetTags(),
+				MatchAllTags:   req.GetTagsMatch() == "all",
+				SeenAfter:      optionalTime(req.SeenAfter),
+				SeenBefore:     optionalTime(req.SeenBefore),
+			},
+		}, func(u *domain.User) error {
+			return stream.Send(readableUserFields(u))
+		})
+	// the count tells the client how far a failed export went
+	stream.SetTrailer(metadata.Pairs(ExportedUsersTrailer, strconv.FormatInt(exported, 10)))
+	switch {
+	case errors.Is(err, domain.ErrInvalidFilter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return err
+}
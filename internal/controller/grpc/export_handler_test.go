@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// exportUsersStream records the users and the trailer sent by an ExportUsers stream
+type exportUsersStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    []*gen.ReadableUserFields
+	trailer metadata.MD
+}
+
+func (s *exportUsersStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportUsersStream) Send(u *gen.ReadableUserFields) error {
+	s.sent = append(s.sent, u)
+	return nil
+}
+
+func (s *exportUsersStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func TestUserHandler_ExportUsers(t *testing.T) {
+	mockServiceQueries := appmocks.NewUserServiceQueries(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+	server := &UserHandler{serviceQueries: mockServiceQueries, protoValidator: protoValidator}
+
+	exported := &domain.User{ID: uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"), NickName: "ann"}
+	// exportUsers passes the users to the callback of the handler, as the use case does
+	exportUsers := func(err error, users ...*domain.User) func(context.Context, user.ExportUsersRequest, func(*domain.User) error) (int64, error) {
+		return func(_ context.Context, _ user.ExportUsersRequest, fn func(*domain.User) error) (int64, error) {
+			for _, u := range users {
+				if err := fn(u); err != nil {
+					return 0, err
+				}
+			}
+			return int64(len(users)), err
+		}
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		req           *gen.ExportUsersRequest
+		expectedMocks func(ctx context.Context)
+		wantSent      []*gen.ReadableUserFields
+		wantTrailer   metadata.MD
+		wantErr       error
+	}{
+		{
+			name: "success",
+			ctx:  context.Background(),
+			req:  &gen.ExportUsersRequest{CountryIsoCode: proto.String("PT"), Filter: proto.String("tags:vip")},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ExportUsers", ctx, user.ExportUsersRequest{
+					Filter: "tags:vip", Filters: domain.UserSearchFilters{CountryISOCode: proto.String("PT")},
+				}, mock.Anything).Return(exportUsers(nil, exported)).Once()
+			},
+			wantSent:    []*gen.ReadableUserFields{readableUserFields(exported)},
+			wantTrailer: metadata.Pairs(ExportedUsersTrailer, "1"),
+		},
+		{
+			name: "invalid filter",
+			ctx:  context.Background(),
+			req:  &gen.ExportUsersRequest{Filter: proto.String(`password = "x"`)},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ExportUsers", ctx, user.ExportUsersRequest{Filter: `password = "x"`}, mock.Anything).Return(
+					int64(0), fmt.Errorf("%w: unknown field \"password\" at position 1", domain.ErrInvalidFilter)).Once()
+			},
+			wantTrailer: metadata.Pairs(ExportedUsersTrailer, "0"),
+			wantErr:     status.Error(codes.InvalidArgument, `invalid filter: unknown field "password" at position 1`),
+		},
+		{
+			name: "cancelled",
+			ctx:  cancelled,
+			req:  &gen.ExportUsersRequest{},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ExportUsers", ctx, user.ExportUsersRequest{}, mock.Anything).Return(exportUsers(context.Canceled, exported)).Once()
+			},
+			wantSent:    []*gen.ReadableUserFields{readableUserFields(exported)},
+			wantTrailer: metadata.Pairs(ExportedUsersTrailer, "1"),
+			wantErr:     status.Error(codes.Canceled, context.Canceled.Error()),
+		},
+		{
+			name:    "invalid request",
+			ctx:     context.Background(),
+			req:     &gen.ExportUsersRequest{CountryIsoCode: proto.String("PRT")},
+			wantErr: fmt.Errorf("validation error:\n - country_iso_code: value length must be 2 characters [string.len]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(tt.ctx)
+			}
+			stream := &exportUsersStream{ctx: tt.ctx}
+			err := server.ExportUsers(tt.req, stream)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+			assert.Equal(t, len(tt.wantSent), len(stream.sent))
+			for i := range tt.wantSent {
+				assert.True(t, proto.Equal(tt.wantSent[i], stream.sent[i]), "sent %v, want %v", stream.sent[i], tt.wantSent[i])
+			}
+			assert.Equal(t, tt.wantTrailer, stream.trailer)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) CreateGroup(ctx context.Context, req *gen.CreateGroupRequest) (*gen.GroupID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	groupID, err := us.groups.CreateGroup(ctx, user.CreateGroupRequest{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	})
+	return &gen.GroupID{Id: groupID}, err
+}
+
+func (us UserHandler) DeleteGroup(ctx context.Context, req *gen.GroupID) (*gen.GroupID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.groups.DeleteGroup(ctx, req.GetId())
+	return &gen.GroupID{Id: req.GetId()}, err
+}
+
+func (us UserHandler) AddGroupMember(ctx context.Context, req *gen.GroupMemberRequest) (*gen.GroupMemberRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.groups.AddGroupMember(ctx, req.GetGroupId(), req.GetUserId())
+	return &gen.GroupMemberRequest{GroupId: req.GetGroupId(), UserId: req.GetUserId()}, err
+}
+
+func (us UserHandler) RemoveGroupMember(ctx context.Context, req *gen.GroupMemberRequest) (*gen.GroupMemberRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.groups.RemoveGroupMember(ctx, req.GetGroupId(), req.GetUserId())
+	return &gen.GroupMemberRequest{GroupId: req.GetGroupId(), UserId: req.GetUserId()}, err
+}
+
+func (us UserHandler) ListGroupMembers(ctx context.Context, req *gen.ListGroupMembersRequest) (*gen.ListGroupMembersResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	resp := &gen.ListGroupMembersResponse{}
+	members, nextCursor, err := us.groups.ListGroupMembers(ctx, user.ListGroupMembersRequest{
+		GroupID: req.GetId(),
+		Cursor:  req.GetCursor(),
+		Limit:   req.GetLimit(),
+	})
+	if err != nil {
+		return resp, err
+	}
+	resp.NextCursor = nextCursor
+	resp.Members = make([]*gen.GroupMember, 0, len(members))
+	for _, member := range members {
+		resp.Members = append(resp.Members, &gen.GroupMember{
+			User:    readableUserFields(&member.User),
+			AddedAt: timestamppb.New(member.AddedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (us UserHandler) ListUserGroups(ctx context.Context, req *gen.UserID) (*gen.ListUserGroupsResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	resp := &gen.ListUserGroupsResponse{}
+	groups, err := us.groups.ListUserGroups(ctx, req.GetId())
+	if err != nil {
+		return resp, err
+	}
+	resp.Groups = make([]*gen.Group, 0, len(groups))
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, protoGroup(group))
+	}
+	return resp, nil
+}
+
+// protoGroup maps a domain group into the proto message exposed by the api
+func protoGroup(group *domain.Group) *gen.Group {
+	return &gen.Group{
+		Id:          group.ID.String(),
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedAt:   timestamppb.New(group.CreatedAt),
+	}
+}
@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_CreateGroup(t *testing.T) {
+	expectedGroupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+
+	mockGroups := appmocks.NewGroupService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		groups:         mockGroups,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.CreateGroupRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.GroupID
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.CreateGroupRequest{Name: "admins", Description: "Administrators"},
+			expectedMocks: func(ctx context.Context) {
+				mockGroups.On("CreateGroup", ctx, user.CreateGroupRequest{Name: "admins", Description: "Administrators"}).Return(expectedGroupID, nil).Once()
+			},
+			want: &gen.GroupID{Id: expectedGroupID},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.CreateGroupRequest{Name: "admins"},
+			expectedMocks: func(ctx context.Context) {
+				mockGroups.On("CreateGroup", ctx, user.CreateGroupRequest{Name: "admins"}).Return("", domain.ErrGroupAlreadyExists).Once()
+			},
+			wantErr: domain.ErrGroupAlreadyExists,
+		},
+		{
+			name:    "missing name",
+			req:     &gen.CreateGroupRequest{},
+			wantErr: fmt.Errorf("validation error:\n - name: value length must be at least 1 characters [string.min_len]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.CreateGroup(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.CreateGroup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_AddGroupMember(t *testing.T) {
+	expectedGroupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockGroups := appmocks.NewGroupService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		groups:         mockGroups,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.GroupMemberRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.GroupMemberRequest
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.GroupMemberRequest{GroupId: expectedGroupID, UserId: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockGroups.On("AddGroupMember", ctx, expectedGroupID, expectedUserID).Return(nil).Once()
+			},
+			want: &gen.GroupMemberRequest{GroupId: expectedGroupID, UserId: expectedUserID},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.GroupMemberRequest{GroupId: expectedGroupID, UserId: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockGroups.On("AddGroupMember", ctx, expectedGroupID, expectedUserID).Return(domain.ErrGroupMemberAlreadyExists).Once()
+			},
+			wantErr: domain.ErrGroupMemberAlreadyExists,
+		},
+		{
+			name:    "invalid user id",
+			req:     &gen.GroupMemberRequest{GroupId: expectedGroupID, UserId: "something wrong"},
+			wantErr: fmt.Errorf("validation error:\n - user_id: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.AddGroupMember(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.AddGroupMember() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_ListGroupMembers(t *testing.T) {
+	expectedGroupID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	tnow := time.Now()
+	member := &domain.GroupMember{
+		User:    domain.User{ID: uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"), NickName: "nick", CreatedAt: tnow, UpdatedAt: tnow},
+		AddedAt: tnow,
+	}
+
+	mockGroups := appmocks.NewGroupService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		groups:         mockGroups,
+		protoValidator: protoValidator,
+	}
+	cursor := "abc"
+
+	tests := []struct {
+		name          string
+		req           *gen.ListGroupMembersRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.ListGroupMembersResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.ListGroupMembersRequest{Id: expectedGroupID, Limit: 1, Cursor: &cursor},
+			expectedMocks: func(ctx context.Context) {
+				mockGroups.On("ListGroupMembers", ctx, user.ListGroupMembersRequest{GroupID: expectedGroupID, Cursor: cursor, Limit: 1}).
+					Return([]*domain.GroupMember{member}, "next", nil).Once()
+			},
+			want: &gen.ListGroupMembersResponse{
+				Members:    []*gen.GroupMember{{User: readableUserFields(&member.User), AddedAt: timestamppb.New(tnow)}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.ListGroupMembersRequest{Id: expectedGroupID, Limit: 1},
+			expectedMocks: func(ctx context.Context) {
+				mockGroups.On("ListGroupMembers", ctx, user.ListGroupMembersRequest{GroupID: expectedGroupID, Limit: 1}).
+					Return(nil, "", domain.ErrGroupNotFound).Once()
+			},
+			wantErr: domain.ErrGroupNotFound,
+		},
+		{
+			name:    "invalid limit",
+			req:     &gen.ListGroupMembersRequest{Id: expectedGroupID},
+			wantErr: fmt.Errorf("validation error:\n - limit: value must be greater than or equal to 1 [int32.gte]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.ListGroupMembers(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.ListGroupMembers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
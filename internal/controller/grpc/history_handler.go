@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) ListUserVersions(ctx context.Context, req *gen.ListUserVersionsRequest) (*gen.ListUserVersionsResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	resp := &gen.ListUserVersionsResponse{}
+	versions, nextCursor, err := us.history.ListUserVersions(ctx, user.ListUserVersionsRequest{
+		UserID: req.GetId(),
+		Cursor: req.GetCursor(),
+		Limit:  req.GetLimit(),
+	})
+	if err != nil {
+		return resp, err
+	}
+	resp.NextCursor = nextCursor
+	resp.Versions = make([]*gen.UserVersion, 0, len(versions))
+	for _, version := range versions {
+		resp.Versions = append(resp.Versions, protoUserVersion(version))
+	}
+	return resp, nil
+}
+
+func (us UserHandler) GetUserAsOf(ctx context.Context, req *gen.GetUserAsOfRequest) (*gen.UserVersion, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	version, err := us.history.GetUserAsOf(ctx, req.GetId(), req.GetAsOf().AsTime())
+	if err != nil {
+		return &gen.UserVersion{}, err
+	}
+	return protoUserVersion(version), nil
+}
+
+// protoUserVersion maps a domain user version into the proto message exposed by the api
+func protoUserVersion(version *domain.UserVersion) *gen.UserVersion {
+	v := &gen.UserVersion{
+		Version:    version.Version,
+		Operation:  version.Operation,
+		User:       readableUserFields(&version.User),
+		RecordedAt: timestamppb.New(version.RecordedAt),
+	}
+	if version.MergedInto != nil {
+		v.MergedInto = version.MergedInto.String()
+	}
+	return v
+}
@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_ListUserVersions(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	tnow := time.Now()
+
+	mockHistory := appmocks.NewHistoryService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		history:        mockHistory,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.ListUserVersionsRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.ListUserVersionsResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.ListUserVersionsRequest{Id: expectedUserID, Limit: 1},
+			expectedMocks: func(ctx context.Context) {
+				mockHistory.On("ListUserVersions", ctx, user.ListUserVersionsRequest{UserID: expectedUserID, Limit: 1}).
+					Return([]*domain.UserVersion{{
+						User:       domain.User{ID: uuid.MustParse(expectedUserID), Email: "old@example.com", CreatedAt: tnow, UpdatedAt: tnow},
+						Version:    3,
+						Operation:  domain.UserVersionUpdate,
+						RecordedAt: tnow,
+					}}, "next", nil).Once()
+			},
+			want: &gen.ListUserVersionsResponse{
+				Versions: []*gen.UserVersion{{
+					Version:   3,
+					Operation: "update",
+					User: &gen.ReadableUserFields{Id: expectedUserID, Email: "old@example.com",
+						CreatedAt: timestamppb.New(tnow), UpdatedAt: timestamppb.New(tnow)},
+					RecordedAt: timestamppb.New(tnow),
+				}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.ListUserVersionsRequest{Id: expectedUserID, Limit: 1},
+			expectedMocks: func(ctx context.Context) {
+				mockHistory.On("ListUserVersions", ctx, user.ListUserVersionsRequest{UserID: expectedUserID, Limit: 1}).
+					Return([]*domain.UserVersion{}, "", domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "invalid limit",
+			req:     &gen.ListUserVersionsRequest{Id: expectedUserID},
+			wantErr: fmt.Errorf("validation error:\n - limit: value must be greater than or equal to 1 [int32.gte]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.ListUserVersions(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.ListUserVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_GetUserAsOf(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	mergedInto := uuid.MustParse("5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e")
+	asOf := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mockHistory := appmocks.NewHistoryService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		history:        mockHistory,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.GetUserAsOfRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.UserVersion
+		wantErr       error
+	}{
+		{
+			name: "merged user",
+			req:  &gen.GetUserAsOfRequest{Id: expectedUserID, AsOf: timestamppb.New(asOf)},
+			expectedMocks: func(ctx context.Context) {
+				mockHistory.On("GetUserAsOf", ctx, expectedUserID, asOf).Return(&domain.UserVersion{
+					User:       domain.User{ID: uuid.MustParse(expectedUserID), NickName: "nick", MergedInto: &mergedInto, CreatedAt: asOf, UpdatedAt: asOf},
+					Version:    9,
+					Operation:  domain.UserVersionUpdate,
+					RecordedAt: asOf,
+				}, nil).Once()
+			},
+			want: &gen.UserVersion{
+				Version:   9,
+				Operation: "update",
+				User: &gen.ReadableUserFields{Id: expectedUserID, NickName: "nick",
+					CreatedAt: timestamppb.New(asOf), UpdatedAt: timestamppb.New(asOf)},
+				MergedInto: mergedInto.String(),
+				RecordedAt: timestamppb.New(asOf),
+			},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.GetUserAsOfRequest{Id: expectedUserID, AsOf: timestamppb.New(asOf)},
+			expectedMocks: func(ctx context.Context) {
+				mockHistory.On("GetUserAsOf", ctx, expectedUserID, asOf).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "missing as_of",
+			req:     &gen.GetUserAsOfRequest{Id: expectedUserID},
+			wantErr: fmt.Errorf("validation error:\n - as_of: value is required [required]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.GetUserAsOf(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.GetUserAsOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
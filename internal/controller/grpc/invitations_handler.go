@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) InviteUser(ctx context.Context, req *gen.InviteUserRequest) (*gen.Invitation, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	invitation, err := us.invitations.InviteUser(ctx, user.InviteUserRequest{
+		Email:          req.GetEmail(),
+		FirstName:      req.GetFirstName(),
+		LastName:       req.GetLastName(),
+		CountryISOCode: req.GetCountryIsoCode(),
+		Roles:          req.GetRoles(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return protoInvitation(invitation), nil
+}
+
+func (us UserHandler) AcceptInvitation(ctx context.Context, req *gen.AcceptInvitationRequest) (*gen.UserID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	userID, err := us.invitations.AcceptInvitation(ctx, user.AcceptInvitationRequest{
+		Token:    req.GetToken(),
+		NickName: req.GetNickName(),
+		Password: req.GetPassword(),
+	})
+	return &gen.UserID{Id: userID}, err
+}
+
+func (us UserHandler) ListInvitations(ctx context.Context, req *gen.ListInvitationsRequest) (*gen.ListInvitationsResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	resp := &gen.ListInvitationsResponse{}
+	invitations, nextCursor, err := us.invitations.ListInvitations(ctx, user.ListInvitationsRequest{
+		Status: req.GetStatus(),
+		Cursor: req.GetCursor(),
+		Limit:  req.GetLimit(),
+	})
+	if err != nil {
+		return resp, err
+	}
+	resp.NextCursor = nextCursor
+	resp.Invitations = make([]*gen.Invitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		resp.Invitations = append(resp.Invitations, protoInvitation(invitation))
+	}
+	return resp, nil
+}
+
+func (us UserHandler) RevokeInvitation(ctx context.Context, req *gen.InvitationID) (*gen.InvitationID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.invitations.RevokeInvitation(ctx, req.GetId())
+	return &gen.InvitationID{Id: req.GetId()}, err
+}
+
+// protoInvitation maps a domain invitation into the proto message exposed by the api
+func protoInvitation(invitation *domain.Invitation) *gen.Invitation {
+	resp := &gen.Invitation{
+		Id:             invitation.ID.String(),
+		Email:          invitation.Email,
+		FirstName:      invitation.FirstName,
+		LastName:       invitation.LastName,
+		CountryIsoCode: invitation.CountryISOCode,
+		Roles:          invitation.Roles,
+		Status:         invitation.Status,
+		ExpiresAt:      timestamppb.New(invitation.ExpiresAt),
+		CreatedAt:      timestamppb.New(invitation.CreatedAt),
+	}
+	if invitation.UserID != nil {
+		resp.UserId = invitation.UserID.String()
+	}
+	return resp
+}
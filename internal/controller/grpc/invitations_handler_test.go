@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_InviteUser(t *testing.T) {
+	expectedInvitationID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	tnow := time.Now()
+
+	mockInvitations := appmocks.NewInvitationService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		invitations:    mockInvitations,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.InviteUserRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.Invitation
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.InviteUserRequest{Email: "staff@example.com", FirstName: "first", LastName: "last", Roles: []string{"admin"}},
+			expectedMocks: func(ctx context.Context) {
+				mockInvitations.On("InviteUser", ctx, user.InviteUserRequest{Email: "staff@example.com", FirstName: "first", LastName: "last", Roles: []string{"admin"}}).
+					Return(&domain.Invitation{ID: uuid.MustParse(expectedInvitationID), Email: "staff@example.com", FirstName: "first", LastName: "last",
+						Roles: []string{"admin"}, Status: domain.InvitationPending, ExpiresAt: tnow, CreatedAt: tnow}, nil).Once()
+			},
+			want: &gen.Invitation{Id: expectedInvitationID, Email: "staff@example.com", FirstName: "first", LastName: "last",
+				Roles: []string{"admin"}, Status: "pending", ExpiresAt: timestamppb.New(tnow), CreatedAt: timestamppb.New(tnow)},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.InviteUserRequest{Email: "staff@example.com", FirstName: "first", LastName: "last"},
+			expectedMocks: func(ctx context.Context) {
+				mockInvitations.On("InviteUser", ctx, user.InviteUserRequest{Email: "staff@example.com", FirstName: "first", LastName: "last"}).
+					Return(nil, domain.ErrUserAlreadyExists).Once()
+			},
+			wantErr: domain.ErrUserAlreadyExists,
+		},
+		{
+			name:    "duplicated roles",
+			req:     &gen.InviteUserRequest{Email: "staff@example.com", FirstName: "first", LastName: "last", Roles: []string{"admin", "admin"}},
+			wantErr: fmt.Errorf("validation error:\n - roles: repeated value must contain unique items [repeated.unique]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.InviteUser(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.InviteUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_AcceptInvitation(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockInvitations := appmocks.NewInvitationService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		invitations:    mockInvitations,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.AcceptInvitationRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.UserID
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.AcceptInvitationRequest{Token: "token", NickName: "nick", Password: "strong123"},
+			expectedMocks: func(ctx context.Context) {
+				mockInvitations.On("AcceptInvitation", ctx, user.AcceptInvitationRequest{Token: "token", NickName: "nick", Password: "strong123"}).
+					Return(expectedUserID, nil).Once()
+			},
+			want: &gen.UserID{Id: expectedUserID},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.AcceptInvitationRequest{Token: "token", NickName: "nick", Password: "strong123"},
+			expectedMocks: func(ctx context.Context) {
+				mockInvitations.On("AcceptInvitation", ctx, user.AcceptInvitationRequest{Token: "token", NickName: "nick", Password: "strong123"}).
+					Return("", domain.ErrInvitationExpired).Once()
+			},
+			wantErr: domain.ErrInvitationExpired,
+		},
+		{
+			name:    "missing token",
+			req:     &gen.AcceptInvitationRequest{NickName: "nick", Password: "strong123"},
+			wantErr: fmt.Errorf("validation error:\n - token: value length must be at least 1 characters [string.min_len]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.AcceptInvitation(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.AcceptInvitation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (us UserHandler) LookupUser(ctx context.Context, req *gen.LookupUserRequest) (*gen.UserResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	found, err := us.serviceQueries.LookupUser(ctx, user.LookupUserRequest{
+		Email:    req.GetEmail(),
+		NickName: req.GetNickName(),
+	})
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return &gen.UserResponse{}, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidUserLookup):
+		return &gen.UserResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return &gen.UserResponse{}, err
+	}
+	return &gen.UserResponse{
+		User:           readableUserFields(found),
+		FollowersCount: found.FollowersCount,
+		FollowingCount: found.FollowingCount,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_LookupUser(t *testing.T) {
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	timeFreeze := time.Now()
+
+	mockServiceQueries := appmocks.NewUserServiceQueries(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		serviceQueries: mockServiceQueries,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.LookupUserRequest
+		expectedMocks func()
+		want          *gen.UserResponse
+		wantErr       error
+	}{
+		{
+			name: "by email",
+			req:  &gen.LookupUserRequest{Key: &gen.LookupUserRequest_Email{Email: "bob@example.com"}},
+			expectedMocks: func() {
+				mockServiceQueries.On("LookupUser", mock.Anything, user.LookupUserRequest{Email: "bob@example.com"}).Return(&domain.User{
+					ID: uuid.MustParse(userID), NickName: "bob", Email: "bob@example.com", CreatedAt: timeFreeze, UpdatedAt: timeFreeze, FollowersCount: 2,
+				}, nil).Once()
+			},
+			want: &gen.UserResponse{
+				User:           &gen.ReadableUserFields{Id: userID, NickName: "bob", Email: "bob@example.com", CreatedAt: timestamppb.New(timeFreeze), UpdatedAt: timestamppb.New(timeFreeze)},
+				FollowersCount: 2,
+			},
+		},
+		{
+			name: "not found",
+			req:  &gen.LookupUserRequest{Key: &gen.LookupUserRequest_NickName{NickName: "bob"}},
+			expectedMocks: func() {
+				mockServiceQueries.On("LookupUser", mock.Anything, user.LookupUserRequest{NickName: "bob"}).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: status.Error(codes.NotFound, domain.ErrUserNotFound.Error()),
+		},
+		{
+			name: "blank",
+			req:  &gen.LookupUserRequest{Key: &gen.LookupUserRequest_NickName{NickName: " "}},
+			expectedMocks: func() {
+				mockServiceQueries.On("LookupUser", mock.Anything, user.LookupUserRequest{NickName: " "}).Return(nil, domain.ErrInvalidUserLookup).Once()
+			},
+			wantErr: status.Error(codes.InvalidArgument, domain.ErrInvalidUserLookup.Error()),
+		},
+		{
+			name: "service error",
+			req:  &gen.LookupUserRequest{Key: &gen.LookupUserRequest_Email{Email: "bob@example.org"}},
+			expectedMocks: func() {
+				mockServiceQueries.On("LookupUser", mock.Anything, user.LookupUserRequest{Email: "bob@example.org"}).Return(nil, domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:    "no key",
+			req:     &gen.LookupUserRequest{},
+			wantErr: fmt.Errorf("validation error:\n - key: exactly one field is required in oneof [required]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := server.LookupUser(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(tt.want, got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
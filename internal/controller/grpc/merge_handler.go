@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+)
+
+func (us UserHandler) MergeUsers(ctx context.Context, req *gen.MergeUsersRequest) (*gen.UserID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.merges.MergeUsers(ctx, user.MergeUsersRequest{
+		SourceID:        req.GetSourceId(),
+		TargetID:        req.GetTargetId(),
+		FieldResolution: req.GetFieldResolution(),
+	})
+	return &gen.UserID{Id: req.GetTargetId()}, err
+}
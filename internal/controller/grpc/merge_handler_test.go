@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUserServerImpl_MergeUsers(t *testing.T) {
+	sourceID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	targetID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockMerges := appmocks.NewMergeCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		merges:         mockMerges,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.MergeUsersRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.UserID
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.MergeUsersRequest{SourceId: sourceID, TargetId: targetID, FieldResolution: map[string]string{"email": "source"}},
+			expectedMocks: func(ctx context.Context) {
+				mockMerges.On("MergeUsers", ctx, user.MergeUsersRequest{SourceID: sourceID, TargetID: targetID, FieldResolution: map[string]string{"email": "source"}}).
+					Return(nil).Once()
+			},
+			want: &gen.UserID{Id: targetID},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.MergeUsersRequest{SourceId: sourceID, TargetId: targetID},
+			expectedMocks: func(ctx context.Context) {
+				mockMerges.On("MergeUsers", ctx, user.MergeUsersRequest{SourceID: sourceID, TargetID: targetID}).Return(domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "invalid source id",
+			req:     &gen.MergeUsersRequest{SourceId: "invalid", TargetId: targetID},
+			wantErr: fmt.Errorf("validation error:\n - source_id: value must be a valid UUID [string.uuid]"),
+		},
+		{
+			name:    "invalid field resolution",
+			req:     &gen.MergeUsersRequest{SourceId: sourceID, TargetId: targetID, FieldResolution: map[string]string{"email": "both"}},
+			wantErr: fmt.Errorf("validation error:\n - field_resolution[\"email\"]: value must be in list [\"source\", \"target\"] [string.in]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.MergeUsers(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.MergeUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
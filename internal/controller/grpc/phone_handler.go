@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) StartPhoneVerification(ctx context.Context, req *gen.UserID) (*gen.StartPhoneVerificationResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	expiresAt, err := us.phoneCommands.StartPhoneVerification(ctx, req.GetId())
+	if err != nil {
+		return &gen.StartPhoneVerificationResponse{}, err
+	}
+	return &gen.StartPhoneVerificationResponse{ExpiresAt: timestamppb.New(expiresAt)}, nil
+}
+
+func (us UserHandler) ConfirmPhoneVerification(ctx context.Context, req *gen.ConfirmPhoneVerificationRequest) (*gen.UserID, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.phoneCommands.ConfirmPhoneVerification(ctx, req.GetId(), req.GetCode())
+	return &gen.UserID{Id: req.GetId()}, err
+}
@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_StartPhoneVerification(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	expiresAt := time.Now()
+
+	mockPhoneCommands := appmocks.NewPhoneVerificationCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		phoneCommands:  mockPhoneCommands,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.UserID
+		expectedMocks func(ctx context.Context)
+		want          *gen.StartPhoneVerificationResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.UserID{Id: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockPhoneCommands.On("StartPhoneVerification", ctx, expectedUserID).Return(expiresAt, nil).Once()
+			},
+			want: &gen.StartPhoneVerificationResponse{ExpiresAt: timestamppb.New(expiresAt)},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.UserID{Id: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockPhoneCommands.On("StartPhoneVerification", ctx, expectedUserID).Return(time.Time{}, domain.ErrPhoneNotSet).Once()
+			},
+			wantErr: domain.ErrPhoneNotSet,
+		},
+		{
+			name:    "invalid id",
+			req:     &gen.UserID{Id: "something wrong"},
+			wantErr: fmt.Errorf("validation error:\n - id: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.StartPhoneVerification(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserServerImpl.StartPhoneVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_ConfirmPhoneVerification(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockPhoneCommands := appmocks.NewPhoneVerificationCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		phoneCommands:  mockPhoneCommands,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.ConfirmPhoneVerificationRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.UserID
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.ConfirmPhoneVerificationRequest{Id: expectedUserID, Code: "123456"},
+			expectedMocks: func(ctx context.Context) {
+				mockPhoneCommands.On("ConfirmPhoneVerification", ctx, expectedUserID, "123456").Return(nil).Once()
+			},
+			want: &gen.UserID{Id: expectedUserID},
+		},
+		{
+			name: "wrong code",
+			req:  &gen.ConfirmPhoneVerificationRequest{Id: expectedUserID, Code: "654321"},
+			expectedMocks: func(ctx context.Context) {
+				mockPhoneCommands.On("ConfirmPhoneVerification", ctx, expectedUserID, "654321").Return(domain.ErrInvalidVerificationCode).Once()
+			},
+			wantErr: domain.ErrInvalidVerificationCode,
+		},
+		{
+			name:    "invalid code format",
+			req:     &gen.ConfirmPhoneVerificationRequest{Id: expectedUserID, Code: "12a"},
+			wantErr: fmt.Errorf("validation error:\n - code: value does not match regex pattern `^[0-9]{6}$` [string.pattern]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.ConfirmPhoneVerification(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserServerImpl.ConfirmPhoneVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+)
+
+func (us UserHandler) GetPreferences(ctx context.Context, req *gen.UserID) (*gen.PreferencesResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	preferences, err := us.preferences.GetPreferences(ctx, req.GetId())
+	if err != nil {
+		return &gen.PreferencesResponse{}, err
+	}
+	return &gen.PreferencesResponse{Preferences: preferenceValues(preferences)}, nil
+}
+
+func (us UserHandler) UpdatePreferences(ctx context.Context, req *gen.UpdatePreferencesRequest) (*gen.PreferencesResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	values := make(map[string]any, len(req.GetPreferences()))
+	for key, value := range req.GetPreferences() {
+		switch kind := value.GetKind().(type) {
+		case *gen.PreferenceValue_BoolValue:
+			values[key] = kind.BoolValue
+		case *gen.PreferenceValue_IntValue:
+			values[key] = kind.IntValue
+		case *gen.PreferenceValue_StringValue:
+			values[key] = kind.StringValue
+		default:
+			return nil, domain.ErrInvalidPreferenceValue
+		}
+	}
+	preferences, err := us.preferences.UpdatePreferences(ctx, req.GetId(), values)
+	if err != nil {
+		return &gen.PreferencesResponse{}, err
+	}
+	return &gen.PreferencesResponse{Preferences: preferenceValues(preferences)}, nil
+}
+
+func (us UserHandler) ResetPreferences(ctx context.Context, req *gen.ResetPreferencesRequest) (*gen.PreferencesResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	preferences, err := us.preferences.ResetPreferences(ctx, req.GetId(), req.GetKeys())
+	if err != nil {
+		return &gen.PreferencesResponse{}, err
+	}
+	return &gen.PreferencesResponse{Preferences: preferenceValues(preferences)}, nil
+}
+
+// preferenceValues maps the bool, int64 and string values of the service layer to their proto representation
+func preferenceValues(preferences map[string]any) map[string]*gen.PreferenceValue {
+	values := make(map[string]*gen.PreferenceValue, len(preferences))
+	for key, value := range preferences {
+		switch v := value.(type) {
+		case bool:
+			values[key] = &gen.PreferenceValue{Kind: &gen.PreferenceValue_BoolValue{BoolValue: v}}
+		case int64:
+			values[key] = &gen.PreferenceValue{Kind: &gen.PreferenceValue_IntValue{IntValue: v}}
+		case string:
+			values[key] = &gen.PreferenceValue{Kind: &gen.PreferenceValue_StringValue{StringValue: v}}
+		}
+	}
+	return values
+}
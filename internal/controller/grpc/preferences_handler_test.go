@@ -0,0 +1,169 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUserServerImpl_UpdatePreferences(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockPreferences := appmocks.NewPreferencesService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		preferences:    mockPreferences,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.UpdatePreferencesRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.PreferencesResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req: &gen.UpdatePreferencesRequest{Id: expectedUserID, Preferences: map[string]*gen.PreferenceValue{
+				"marketing_emails": {Kind: &gen.PreferenceValue_BoolValue{BoolValue: true}},
+				"items_per_page":   {Kind: &gen.PreferenceValue_IntValue{IntValue: 50}},
+				"digest_frequency": {Kind: &gen.PreferenceValue_StringValue{StringValue: "daily"}},
+			}},
+			expectedMocks: func(ctx context.Context) {
+				mockPreferences.On("UpdatePreferences", ctx, expectedUserID, map[string]any{
+					"marketing_emails": true,
+					"items_per_page":   int64(50),
+					"digest_frequency": "daily",
+				}).Return(map[string]any{
+					"marketing_emails": true,
+					"items_per_page":   int64(50),
+					"digest_frequency": "daily",
+				}, nil).Once()
+			},
+			want: &gen.PreferencesResponse{Preferences: map[string]*gen.PreferenceValue{
+				"marketing_emails": {Kind: &gen.PreferenceValue_BoolValue{BoolValue: true}},
+				"items_per_page":   {Kind: &gen.PreferenceValue_IntValue{IntValue: 50}},
+				"digest_frequency": {Kind: &gen.PreferenceValue_StringValue{StringValue: "daily"}},
+			}},
+		},
+		{
+			name: "service layer error",
+			req: &gen.UpdatePreferencesRequest{Id: expectedUserID, Preferences: map[string]*gen.PreferenceValue{
+				"theme": {Kind: &gen.PreferenceValue_StringValue{StringValue: "dark"}},
+			}},
+			expectedMocks: func(ctx context.Context) {
+				mockPreferences.On("UpdatePreferences", ctx, expectedUserID, map[string]any{"theme": "dark"}).Return(nil, domain.ErrUnknownPreference).Once()
+			},
+			wantErr: domain.ErrUnknownPreference,
+		},
+		{
+			name: "value without kind",
+			req: &gen.UpdatePreferencesRequest{Id: expectedUserID, Preferences: map[string]*gen.PreferenceValue{
+				"theme": {},
+			}},
+			wantErr: fmt.Errorf("validation error:\n - preferences[\"theme\"].kind: exactly one field is required in oneof [required]"),
+		},
+		{
+			name:    "no preferences",
+			req:     &gen.UpdatePreferencesRequest{Id: expectedUserID},
+			wantErr: fmt.Errorf("validation error:\n - preferences: map must be at least 1 entries [map.min_pairs]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.UpdatePreferences(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.UpdatePreferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_ResetPreferences(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockPreferences := appmocks.NewPreferencesService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		preferences:    mockPreferences,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.ResetPreferencesRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.PreferencesResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.ResetPreferencesRequest{Id: expectedUserID, Keys: []string{"marketing_emails"}},
+			expectedMocks: func(ctx context.Context) {
+				mockPreferences.On("ResetPreferences", ctx, expectedUserID, []string{"marketing_emails"}).
+					Return(map[string]any{"marketing_emails": false}, nil).Once()
+			},
+			want: &gen.PreferencesResponse{Preferences: map[string]*gen.PreferenceValue{
+				"marketing_emails": {Kind: &gen.PreferenceValue_BoolValue{BoolValue: false}},
+			}},
+		},
+		{
+			name: "user not found",
+			req:  &gen.ResetPreferencesRequest{Id: expectedUserID},
+			expectedMocks: func(ctx context.Context) {
+				mockPreferences.On("ResetPreferences", ctx, expectedUserID, []string(nil)).Return(nil, domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "invalid id",
+			req:     &gen.ResetPreferencesRequest{Id: "something wrong"},
+			wantErr: fmt.Errorf("validation error:\n - id: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.ResetPreferences(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.ResetPreferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
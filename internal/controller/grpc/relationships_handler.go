@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) FollowUser(ctx context.Context, req *gen.RelationshipRequest) (*gen.RelationshipRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.relationships.FollowUser(ctx, req.GetId(), req.GetTargetId())
+	return &gen.RelationshipRequest{Id: req.GetId(), TargetId: req.GetTargetId()}, err
+}
+
+func (us UserHandler) UnfollowUser(ctx context.Context, req *gen.RelationshipRequest) (*gen.RelationshipRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.relationships.UnfollowUser(ctx, req.GetId(), req.GetTargetId())
+	return &gen.RelationshipRequest{Id: req.GetId(), TargetId: req.GetTargetId()}, err
+}
+
+func (us UserHandler) BlockUser(ctx context.Context, req *gen.RelationshipRequest) (*gen.RelationshipRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.relationships.BlockUser(ctx, req.GetId(), req.GetTargetId())
+	return &gen.RelationshipRequest{Id: req.GetId(), TargetId: req.GetTargetId()}, err
+}
+
+func (us UserHandler) UnblockUser(ctx context.Context, req *gen.RelationshipRequest) (*gen.RelationshipRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.relationships.UnblockUser(ctx, req.GetId(), req.GetTargetId())
+	return &gen.RelationshipRequest{Id: req.GetId(), TargetId: req.GetTargetId()}, err
+}
+
+func (us UserHandler) ListFollowers(ctx context.Context, req *gen.ListRelationshipsRequest) (*gen.ListRelationshipsResponse, error) {
+	return us.listRelationships(ctx, req, us.relationships.ListFollowers)
+}
+
+func (us UserHandler) ListFollowing(ctx context.Context, req *gen.ListRelationshipsRequest) (*gen.ListRelationshipsResponse, error) {
+	return us.listRelationships(ctx, req, us.relationships.ListFollowing)
+}
+
+func (us UserHandler) ListBlocked(ctx context.Context, req *gen.ListRelationshipsRequest) (*gen.ListRelationshipsResponse, error) {
+	return us.listRelationships(ctx, req, us.relationships.ListBlocked)
+}
+
+// listRelationships validates the request and maps the page returned by the provided list method
+func (us UserHandler) listRelationships(ctx context.Context, req *gen.ListRelationshipsRequest,
+	listFn func(context.Context, user.ListRelationshipsRequest) ([]*domain.RelatedUser, string, error)) (*gen.ListRelationshipsResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	resp := &gen.ListRelationshipsResponse{}
+	users, nextCursor, err := listFn(ctx, user.ListRelationshipsRequest{
+		UserID: req.GetId(),
+		Cursor: req.GetCursor(),
+		Limit:  req.GetLimit(),
+	})
+	if err != nil {
+		return resp, err
+	}
+	resp.NextCursor = nextCursor
+	resp.Users = make([]*gen.RelatedUser, 0, len(users))
+	for _, related := range users {
+		resp.Users = append(resp.Users, &gen.RelatedUser{
+			User:  readableUserFields(&related.User),
+			Since: timestamppb.New(related.Since),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_BlockUser(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	expectedTargetID := "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"
+
+	mockRelationships := appmocks.NewRelationshipService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		relationships:  mockRelationships,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.RelationshipRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.RelationshipRequest
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.RelationshipRequest{Id: expectedUserID, TargetId: expectedTargetID},
+			expectedMocks: func(ctx context.Context) {
+				mockRelationships.On("BlockUser", ctx, expectedUserID, expectedTargetID).Return(nil).Once()
+			},
+			want: &gen.RelationshipRequest{Id: expectedUserID, TargetId: expectedTargetID},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.RelationshipRequest{Id: expectedUserID, TargetId: expectedTargetID},
+			expectedMocks: func(ctx context.Context) {
+				mockRelationships.On("BlockUser", ctx, expectedUserID, expectedTargetID).Return(domain.ErrAlreadyBlocked).Once()
+			},
+			wantErr: domain.ErrAlreadyBlocked,
+		},
+		{
+			name:    "invalid target id",
+			req:     &gen.RelationshipRequest{Id: expectedUserID, TargetId: "something wrong"},
+			wantErr: fmt.Errorf("validation error:\n - target_id: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.BlockUser(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.BlockUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_ListFollowers(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	tnow := time.Now()
+	follower := &domain.RelatedUser{
+		User:  domain.User{ID: uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"), NickName: "nick", CreatedAt: tnow, UpdatedAt: tnow},
+		Since: tnow,
+	}
+
+	mockRelationships := appmocks.NewRelationshipService(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		relationships:  mockRelationships,
+		protoValidator: protoValidator,
+	}
+	cursor := "abc"
+
+	tests := []struct {
+		name          string
+		req           *gen.ListRelationshipsRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.ListRelationshipsResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.ListRelationshipsRequest{Id: expectedUserID, Limit: 1, Cursor: &cursor},
+			expectedMocks: func(ctx context.Context) {
+				mockRelationships.On("ListFollowers", ctx, user.ListRelationshipsRequest{UserID: expectedUserID, Cursor: cursor, Limit: 1}).
+					Return([]*domain.RelatedUser{follower}, "next", nil).Once()
+			},
+			want: &gen.ListRelationshipsResponse{
+				Users:      []*gen.RelatedUser{{User: readableUserFields(&follower.User), Since: timestamppb.New(tnow)}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.ListRelationshipsRequest{Id: expectedUserID, Limit: 1},
+			expectedMocks: func(ctx context.Context) {
+				mockRelationships.On("ListFollowers", ctx, user.ListRelationshipsRequest{UserID: expectedUserID, Limit: 1}).
+					Return(nil, "", domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "invalid limit",
+			req:     &gen.ListRelationshipsRequest{Id: expectedUserID},
+			wantErr: fmt.Errorf("validation error:\n - limit: value must be greater than or equal to 1 [int32.gte]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.ListFollowers(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.ListFollowers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
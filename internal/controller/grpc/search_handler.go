@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+)
+
+func (us UserHandler) SearchUsers(ctx context.Context, req *gen.SearchUsersRequest) (*gen.SearchUsersResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	resp := &gen.SearchUsersResponse{}
+	results, nextPageToken, err := us.serviceQueries.SearchUsers(ctx, user.SearchUsersRequest{
+		Query:     req.GetQuery(),
+		Limit:     req.GetLimit(),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return resp, err
+	}
+	resp.NextPageToken = nextPageToken
+	resp.Results = make([]*gen.UserSearchResult, 0, len(results))
+	for _, result := range results {
+		resp.Results = append(resp.Results, &gen.UserSearchResult{
+			User:       readableUserFields(result.User),
+			Rank:       result.Rank,
+			Highlights: result.Highlights,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_SearchUsers(t *testing.T) {
+	expectedUserID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	pageToken := "MC42fDBmOTEzZjZhLTQ5N2ItNDMwNS1iM2QxLTNmNTM2NTdlM2EyNQ=="
+	timeFreeze := time.Now()
+
+	mockServiceQueries := appmocks.NewUserServiceQueries(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		serviceQueries: mockServiceQueries,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.SearchUsersRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.SearchUsersResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.SearchUsersRequest{Query: "john", Limit: 1, PageToken: &pageToken},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("SearchUsers", ctx, user.SearchUsersRequest{Query: "john", Limit: 1, PageToken: pageToken}).Return([]*domain.UserSearchResult{{
+					User:       &domain.User{ID: uuid.MustParse(expectedUserID), FirstName: "John", CreatedAt: timeFreeze, UpdatedAt: timeFreeze},
+					Rank:       0.6,
+					Highlights: map[string]string{"first_name": "<mark>John</mark>"},
+				}}, "next", nil).Once()
+			},
+			want: &gen.SearchUsersResponse{
+				Results: []*gen.UserSearchResult{{
+					User: &gen.ReadableUserFields{Id: expectedUserID, FirstName: "John",
+						CreatedAt: timestamppb.New(timeFreeze), UpdatedAt: timestamppb.New(timeFreeze)},
+					Rank:       0.6,
+					Highlights: map[string]string{"first_name": "<mark>John</mark>"},
+				}},
+				NextPageToken: "next",
+			},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.SearchUsersRequest{Query: "john", Limit: 1},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("SearchUsers", ctx, user.SearchUsersRequest{Query: "john", Limit: 1}).Return(nil, "", domain.ErrInvalidPaginationCursor).Once()
+			},
+			wantErr: domain.ErrInvalidPaginationCursor,
+		},
+		{
+			name:    "empty query",
+			req:     &gen.SearchUsersRequest{Limit: 1},
+			wantErr: fmt.Errorf("validation error:\n - query: value length must be at least 1 characters [string.min_len]"),
+		},
+		{
+			name:    "limit too high",
+			req:     &gen.SearchUsersRequest{Query: "john", Limit: 101},
+			wantErr: fmt.Errorf("validation error:\n - limit: value must be greater than or equal to 1 and less than or equal to 100 [int32.gte_lte]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.SearchUsers(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.SearchUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
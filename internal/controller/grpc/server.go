@@ -13,16 +13,31 @@ import (
 
 // Setup creates a grpcServer, configures the necessary interceptors and registers the following services:
 // - UserServiceServer
-func Setup(l logger.Interface, commands app.UserServiceCommands, queries app.UserServiceQueries) (*grpc.Server, error) {
-	if l == nil || commands == nil || queries == nil {
-		return nil, fmt.Errorf("invalid input parameters: logger, commands, and queries must not be nil")
+// Every request is scoped to the tenant in the x-tenant-id metadata, or to the defaultTenant when it is absent.
+// Every command is recorded in the audit log, with the actor in the x-actor-id metadata.
+func Setup(l logger.Interface, commands app.UserServiceCommands, queries app.UserServiceQueries, phoneCommands app.PhoneVerificationCommands,
+	avatarCommands app.AvatarCommands, preferences app.PreferencesService, groups app.GroupService,
+	relationships app.RelationshipService, invitations app.InvitationService, merges app.MergeCommands,
+	history app.HistoryService, audit app.AuditService, tags app.TagCommands, activity app.ActivityCommands, watch app.WatchQueries, tenants app.TenantQueries,
+	defaultTenant string) (*grpc.Server, error) {
+	if l == nil || commands == nil || queries == nil || phoneCommands == nil || avatarCommands == nil || preferences == nil || groups == nil || relationships == nil ||
+		invitations == nil || merges == nil || history == nil || audit == nil || tags == nil || activity == nil || watch == nil ||
+		tenants == nil {
+		return nil, fmt.Errorf("invalid input parameters: logger, commands, queries, phoneCommands, avatarCommands, preferences, groups, relationships, invitations, merges, history, audit, tags, activity, watch and tenants must not be nil")
 	}
-	server := grpc.NewServer(grpc.ChainUnaryInterceptor(loggerInterceptor(l)))
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(loggerInterceptor(l), tenantInterceptor(tenants, defaultTenant), activityInterceptor(activity),
+			auditInterceptor(audit)),
+		grpc.ChainStreamInterceptor(streamLoggerInterceptor(l), streamTenantInterceptor(tenants, defaultTenant),
+			streamActivityInterceptor(activity), streamAuditInterceptor(audit)),
+	)
 	v, err := protovalidate.New()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize validator: %w", err)
 	}
-	gen.RegisterUserServiceServer(server, &UserHandler{l: l, serviceCommands: commands, serviceQueries: queries, protoValidator: v})
+	gen.RegisterUserServiceServer(server, &UserHandler{l: l, serviceCommands: commands, serviceQueries: queries, phoneCommands: phoneCommands, avatarCommands: avatarCommands,
+		preferences: preferences, groups: groups, relationships: relationships, invitations: invitations, merges: merges, history: history, audit: audit, tags: tags, activity: activity,
+		watch: watch, protoValidator: v})
 	return server, nil
 }
 
@@ -42,3 +57,20 @@ func loggerInterceptor(l logger.Interface) func(
 		return resp, err
 	}
 }
+
+func streamLoggerInterceptor(l logger.Interface) func(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			l.Info("gRPC stream: %s, error: %v", info.FullMethod, err)
+		} else {
+			l.Debug("gRPC stream: %s, ok", info.FullMethod)
+		}
+		return err
+	}
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) GetUserStats(ctx context.Context, req *gen.GetUserStatsRequest) (*gen.UserStatsResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	stats, err := us.serviceQueries.GetUserStats(ctx, user.UserStatsRequest{
+		Period: req.GetPeriod(),
+		From:   optionalTime(req.From),
+		To:     optionalTime(req.To),
+	})
+	switch {
+	case errors.Is(err, domain.ErrInvalidStatsRange):
+		return &gen.UserStatsResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return &gen.UserStatsResponse{}, err
+	}
+	resp := &gen.UserStatsResponse{
+		Total:           stats.Total,
+		ByCountry:       stats.ByCountry,
+		ByStatus:        stats.ByStatus,
+		PhoneVerified:   stats.PhoneVerified,
+		PhoneUnverified: stats.PhoneUnverified,
+		Signups:         make([]*gen.UserSignups, 0, len(stats.Signups)),
+		ComputedAt:      timestamppb.New(stats.ComputedAt),
+	}
+	for _, signups := range stats.Signups {
+		resp.Signups = append(resp.Signups, &gen.UserSignups{PeriodStart: timestamppb.New(signups.PeriodStart), Count: signups.Count})
+	}
+	return resp, nil
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserServerImpl_GetUserStats(t *testing.T) {
+	computedAt := time.Now()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	mockServiceQueries := appmocks.NewUserServiceQueries(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		serviceQueries: mockServiceQueries,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.GetUserStatsRequest
+		expectedMocks func()
+		want          *gen.UserStatsResponse
+		wantErr       error
+	}{
+		{
+			name: "weekly signups",
+			req:  &gen.GetUserStatsRequest{Period: proto.String("week"), From: timestamppb.New(from), To: timestamppb.New(to)},
+			expectedMocks: func() {
+				mockServiceQueries.On("GetUserStats", mock.Anything, user.UserStatsRequest{Period: "week", From: &from, To: &to}).Return(&domain.UserStats{
+					Total:           3,
+					ByCountry:       map[string]int64{"PT": 2, "UK": 1},
+					ByStatus:        map[string]int64{domain.UserActive: 2, domain.UserDormant: 1},
+					PhoneVerified:   1,
+					PhoneUnverified: 2,
+					Signups:         []domain.UserSignups{{PeriodStart: from, Count: 2}, {PeriodStart: from.AddDate(0, 0, 7)}},
+					ComputedAt:      computedAt,
+				}, nil).Once()
+			},
+			want: &gen.UserStatsResponse{
+				Total:           3,
+				ByCountry:       map[string]int64{"PT": 2, "UK": 1},
+				ByStatus:        map[string]int64{"active": 2, "dormant": 1},
+				PhoneVerified:   1,
+				PhoneUnverified: 2,
+				Signups: []*gen.UserSignups{
+					{PeriodStart: timestamppb.New(from), Count: 2},
+					{PeriodStart: timestamppb.New(from.AddDate(0, 0, 7))},
+				},
+				ComputedAt: timestamppb.New(computedAt),
+			},
+		},
+		{
+			name: "invalid range",
+			req:  &gen.GetUserStatsRequest{From: timestamppb.New(to), To: timestamppb.New(from)},
+			expectedMocks: func() {
+				mockServiceQueries.On("GetUserStats", mock.Anything, user.UserStatsRequest{From: &to, To: &from}).Return(nil,
+					fmt.Errorf("%w: from must be before to", domain.ErrInvalidStatsRange)).Once()
+			},
+			wantErr: status.Error(codes.InvalidArgument, "invalid stats range: from must be before to"),
+		},
+		{
+			name: "service error",
+			req:  &gen.GetUserStatsRequest{},
+			expectedMocks: func() {
+				mockServiceQueries.On("GetUserStats", mock.Anything, user.UserStatsRequest{}).Return(nil, domain.ErrInternal).Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name:    "unknown period",
+			req:     &gen.GetUserStatsRequest{Period: proto.String("year")},
+			wantErr: fmt.Errorf("validation error:\n - period: value must be in list [\"day\", \"week\", \"month\"] [string.in]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := server.GetUserStats(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(tt.want, got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
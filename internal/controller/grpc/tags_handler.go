@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	"context"
+	gen "users/gen/proto/go"
+)
+
+func (us UserHandler) TagUsers(ctx context.Context, req *gen.TagUsersRequest) (*gen.TagUsersRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.tags.TagUsers(ctx, req.GetIds(), req.GetTags())
+	return &gen.TagUsersRequest{Ids: req.GetIds(), Tags: req.GetTags()}, err
+}
+
+func (us UserHandler) UntagUsers(ctx context.Context, req *gen.TagUsersRequest) (*gen.TagUsersRequest, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	err := us.tags.UntagUsers(ctx, req.GetIds(), req.GetTags())
+	return &gen.TagUsersRequest{Ids: req.GetIds(), Tags: req.GetTags()}, err
+}
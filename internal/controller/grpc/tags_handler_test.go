@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	loggermocks "users/gen/mocks/users/pkg/logger"
+	gen "users/gen/proto/go"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUserServerImpl_TagUsers(t *testing.T) {
+	expectedUserIDs := []string{"0f913f6a-497b-4305-b3d1-3f53657e3a25", "c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3"}
+
+	mockTags := appmocks.NewTagCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		tags:           mockTags,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.TagUsersRequest
+		expectedMocks func(ctx context.Context)
+		want          *gen.TagUsersRequest
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.TagUsersRequest{Ids: expectedUserIDs, Tags: []string{"vip", "fraud-review"}},
+			expectedMocks: func(ctx context.Context) {
+				mockTags.On("TagUsers", ctx, expectedUserIDs, []string{"vip", "fraud-review"}).Return(nil).Once()
+			},
+			want: &gen.TagUsersRequest{Ids: expectedUserIDs, Tags: []string{"vip", "fraud-review"}},
+		},
+		{
+			name: "service layer error",
+			req:  &gen.TagUsersRequest{Ids: expectedUserIDs, Tags: []string{"vip"}},
+			expectedMocks: func(ctx context.Context) {
+				mockTags.On("TagUsers", ctx, expectedUserIDs, []string{"vip"}).Return(domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "invalid tag",
+			req:     &gen.TagUsersRequest{Ids: expectedUserIDs, Tags: []string{"VIP"}},
+			wantErr: fmt.Errorf("validation error:\n - tags[0]: value does not match regex pattern `^[a-z0-9][a-z0-9_-]{0,63}$` [string.pattern]"),
+		},
+		{
+			name:    "no users",
+			req:     &gen.TagUsersRequest{Tags: []string{"vip"}},
+			wantErr: fmt.Errorf("validation error:\n - ids: value must contain at least 1 item(s) [repeated.min_items]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(ctx)
+			}
+			got, err := server.TagUsers(ctx, tt.req)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UserServerImpl.TagUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserServerImpl_UntagUsers(t *testing.T) {
+	expectedUserIDs := []string{"0f913f6a-497b-4305-b3d1-3f53657e3a25"}
+
+	mockTags := appmocks.NewTagCommands(t)
+	mockLogger := loggermocks.NewInterface(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		l:              mockLogger,
+		tags:           mockTags,
+		protoValidator: protoValidator,
+	}
+
+	ctx := context.Background()
+	mockTags.On("UntagUsers", ctx, expectedUserIDs, []string{"beta"}).Return(nil).Once()
+	got, err := server.UntagUsers(ctx, &gen.TagUsersRequest{Ids: expectedUserIDs, Tags: []string{"beta"}})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(got, &gen.TagUsersRequest{Ids: expectedUserIDs, Tags: []string{"beta"}}))
+}
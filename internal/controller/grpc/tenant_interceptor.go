@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"users/internal/app"
+	"users/internal/domain"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TenantHeader is the request metadata key carrying the tenant ID or slug
+const TenantHeader = "x-tenant-id"
+
+// resolveTenant scopes the context to the tenant referenced in the request metadata.
+// When the metadata is absent, the defaultTenant is used, if configured.
+func resolveTenant(ctx context.Context, tenants app.TenantQueries, defaultTenant string) (context.Context, error) {
+	ref := defaultTenant
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TenantHeader); len(values) > 0 && values[0] != "" {
+			ref = values[0]
+		}
+	}
+	if ref == "" {
+		return nil, domain.ErrTenantRequired
+	}
+	tenant, err := tenants.ResolveTenant(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return domain.WithTenant(ctx, tenant.ID.String()), nil
+}
+
+func tenantInterceptor(tenants app.TenantQueries, defaultTenant string) func(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		tenantCtx, err := resolveTenant(ctx, tenants, defaultTenant)
+		if err != nil {
+			return nil, err
+		}
+		return handler(tenantCtx, req)
+	}
+}
+
+// contextServerStream overrides the context of the stream, e.g. with the tenant scoped one
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func streamTenantInterceptor(tenants app.TenantQueries, defaultTenant string) func(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tenantCtx, err := resolveTenant(ss.Context(), tenants, defaultTenant)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: tenantCtx})
+	}
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	appmocks "users/gen/mocks/users/app"
+	"users/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_tenantInterceptor(t *testing.T) {
+	defaultTenant := &domain.Tenant{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Slug: "default"}
+	brandTenant := &domain.Tenant{ID: uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"), Slug: "brand"}
+	mockTenants := appmocks.NewTenantQueries(t)
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		defaultTenant string
+		expectedMocks func()
+		wantTenant    string
+		wantErr       error
+	}{
+		{
+			name:          "tenant from metadata",
+			md:            metadata.Pairs(TenantHeader, "brand"),
+			defaultTenant: "default",
+			expectedMocks: func() {
+				mockTenants.On("ResolveTenant", mock.Anything, "brand").Return(brandTenant, nil).Once()
+			},
+			wantTenant: brandTenant.ID.String(),
+		},
+		{
+			name:          "default tenant",
+			defaultTenant: "default",
+			expectedMocks: func() {
+				mockTenants.On("ResolveTenant", mock.Anything, "default").Return(defaultTenant, nil).Once()
+			},
+			wantTenant: defaultTenant.ID.String(),
+		},
+		{
+			name:    "tenant required",
+			md:      metadata.Pairs("other", "value"),
+			wantErr: domain.ErrTenantRequired,
+		},
+		{
+			name: "unknown tenant",
+			md:   metadata.Pairs(TenantHeader, "unknown"),
+			expectedMocks: func() {
+				mockTenants.On("ResolveTenant", mock.Anything, "unknown").Return(nil, domain.ErrTenantNotFound).Once()
+			},
+			wantErr: domain.ErrTenantNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			var gotTenant string
+			_, err := tenantInterceptor(mockTenants, tt.defaultTenant)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				gotTenant, _ = domain.TenantFromContext(ctx)
+				return nil, nil
+			})
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.wantTenant, gotTenant)
+		})
+	}
+}
+
+func Test_streamTenantInterceptor(t *testing.T) {
+	brandTenant := &domain.Tenant{ID: uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"), Slug: "brand"}
+	mockTenants := appmocks.NewTenantQueries(t)
+	mockTenants.On("ResolveTenant", mock.Anything, brandTenant.ID.String()).Return(brandTenant, nil).Once()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TenantHeader, brandTenant.ID.String()))
+	var gotTenant string
+	err := streamTenantInterceptor(mockTenants, "")(nil, &uploadAvatarStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		gotTenant, _ = domain.TenantFromContext(ss.Context())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, brandTenant.ID.String(), gotTenant)
+}
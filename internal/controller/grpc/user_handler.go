@@ -2,6 +2,9 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 	gen "users/gen/proto/go"
 	"users/internal/app"
 	"users/internal/app/user"
@@ -10,6 +13,8 @@ import (
 	"users/pkg/logger"
 
 	"github.com/bufbuild/protovalidate-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -18,6 +23,18 @@ type UserHandler struct {
 	l               logger.Interface
 	serviceCommands app.UserServiceCommands
 	serviceQueries  app.UserServiceQueries
+	phoneCommands   app.PhoneVerificationCommands
+	avatarCommands  app.AvatarCommands
+	preferences     app.PreferencesService
+	groups          app.GroupService
+	relationships   app.RelationshipService
+	invitations     app.InvitationService
+	merges          app.MergeCommands
+	history         app.HistoryService
+	audit           app.AuditService
+	tags            app.TagCommands
+	activity        app.ActivityCommands
+	watch           app.WatchQueries
 	protoValidator  *protovalidate.Validator
 }
 
@@ -32,6 +49,10 @@ func (us UserHandler) CreateUser(ctx context.Context, cur *gen.CreateUserRequest
 		CountryISOCode: cur.GetCountryIsoCode(),
 		Email:          cur.GetEmail(),
 		Password:       cur.GetPassword(),
+		Phone:          cur.GetPhone(),
+		Locale:         cur.GetLocale(),
+		Timezone:       cur.GetTimezone(),
+		DateOfBirth:    cur.GetDateOfBirth(),
 	})
 	return &gen.UserID{Id: userID}, err
 }
@@ -56,27 +77,64 @@ func (us UserHandler) UpdateUser(ctx context.Context, uur *gen.UpdateUserRequest
 		NickName:       pbUser.GetNickName(),
 		CountryISOCode: pbUser.GetCountryIsoCode(),
 		Email:          pbUser.GetEmail(),
+		Phone:          pbUser.GetPhone(),
+		Locale:         pbUser.GetLocale(),
+		Timezone:       pbUser.GetTimezone(),
+		DateOfBirth:    pbUser.GetDateOfBirth(),
 	})
 	return &gen.UserID{Id: uur.GetId()}, err
 }
 
-func (us UserHandler) GetUser(ctx context.Context, gur *gen.UserID) (*gen.UserResponse, error) {
+func (us UserHandler) GetUser(ctx context.Context, gur *gen.GetUserRequest) (*gen.UserResponse, error) {
 	if err := us.protoValidator.Validate(gur); err != nil {
 		return nil, err
 	}
-	user, err := us.serviceQueries.GetUser(ctx, gur.GetId())
+	paths := gur.GetReadMask().GetPaths()
+	user, err := us.serviceQueries.GetUser(ctx, gur.GetId(), paths)
+	if errors.Is(err, domain.ErrInvalidReadMask) {
+		return &gen.UserResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil || user == nil {
 		return &gen.UserResponse{}, err
 	}
-	return &gen.UserResponse{User: &gen.ReadableUserFields{
-		Id:             user.ID.String(),
-		FirstName:      user.FirstName,
-		LastName:       user.LastName,
-		NickName:       user.NickName,
-		Email:          user.Email,
-		CountryIsoCode: user.CountryISOCode,
-		CreatedAt:      timestamppb.New(user.CreatedAt),
-		UpdatedAt:      timestamppb.New(user.UpdatedAt)}}, err
+	if user.MergedInto != nil {
+		return &gen.UserResponse{MergedInto: user.MergedInto.String()}, nil
+	}
+	resp := &gen.UserResponse{
+		User:           maskUserFields(readableUserFields(user), paths),
+		FollowersCount: user.FollowersCount,
+		FollowingCount: user.FollowingCount,
+	}
+	if len(paths) > 0 && !slices.Contains(paths, "followers_count") {
+		resp.FollowersCount = 0
+	}
+	if len(paths) > 0 && !slices.Contains(paths, "following_count") {
+		resp.FollowingCount = 0
+	}
+	return resp, err
+}
+
+func (us UserHandler) BatchGetUsers(ctx context.Context, req *gen.BatchGetUsersRequest) (*gen.BatchGetUsersResponse, error) {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return nil, err
+	}
+	users, missingIDs, err := us.serviceQueries.BatchGetUsers(ctx, req.GetIds())
+	if err != nil {
+		return &gen.BatchGetUsersResponse{}, err
+	}
+	resp := &gen.BatchGetUsersResponse{
+		Users:      make([]*gen.ReadableUserFields, 0, len(users)),
+		MissingIds: missingIDs,
+		MergedInto: map[string]string{},
+	}
+	for _, user := range users {
+		if user.MergedInto != nil {
+			resp.MergedInto[user.ID.String()] = user.MergedInto.String()
+			continue
+		}
+		resp.Users = append(resp.Users, readableUserFields(user))
+	}
+	return resp, nil
 }
 
 func (us UserHandler) ListUsers(ctx context.Context, lur *gen.ListUsersRequest) (*gen.ListUsersResponse, error) {
@@ -87,34 +145,134 @@ func (us UserHandler) ListUsers(ctx context.Context, lur *gen.ListUsersRequest)
 		return nil, err
 	}
 	var resp = &gen.ListUsersResponse{}
-	userList, nextCursor, err := us.serviceQueries.ListUsers(ctx,
+	bornAfter, err := parseDate(lur.BornAfter)
+	if err != nil {
+		return resp, err
+	}
+	bornBefore, err := parseDate(lur.BornBefore)
+	if err != nil {
+		return resp, err
+	}
+	page, err := us.serviceQueries.ListUsers(ctx,
 		user.ListUsersRequest{
-			Cursor: lur.GetCursor(),
-			Limit:  lur.GetLimit(),
+			Cursor:     lur.GetCursor(),
+			Limit:      lur.GetLimit(),
+			Fuzzy:      lur.GetFuzzy(),
+			OrderBy:    lur.GetOrderBy(),
+			TotalCount: lur.GetTotalCount(),
+			Filter:     lur.GetFilter(),
+			Fields:     lur.GetReadMask().GetPaths(),
 			Filters: domain.UserSearchFilters{
 				FirstName:      lur.FirstName,
 				LastName:       lur.LastName,
 				NickName:       lur.NickName,
 				CountryISOCode: lur.CountryIsoCode,
 				Email:          lur.Email,
+				Locale:         lur.Locale,
+				Timezone:       lur.Timezone,
+				BornAfter:      bornAfter,
+				BornBefore:     bornBefore,
+				Tags:           lur.GetTags(),
+				MatchAllTags:   lur.GetTagsMatch() == "all",
+				SeenAfter:      optionalTime(lur.SeenAfter),
+				SeenBefore:     optionalTime(lur.SeenBefore),
 			},
 		})
+	if errors.Is(err, domain.ErrInvalidPaginationCursor) {
+		// the client has to restart from the first page
+		return resp, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidReadMask) {
+		return resp, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return resp, err
 	}
-	resp.NextCursor = nextCursor
-	resp.Users = make([]*gen.ReadableUserFields, 0, len(userList))
-	for _, user := range userList {
-		resp.Users = append(resp.Users, &gen.ReadableUserFields{
-			Id:             user.ID.String(),
-			FirstName:      user.FirstName,
-			LastName:       user.LastName,
-			NickName:       user.NickName,
-			Email:          user.Email,
-			CountryIsoCode: user.CountryISOCode,
-			CreatedAt:      timestamppb.New(user.CreatedAt),
-			UpdatedAt:      timestamppb.New(user.UpdatedAt),
-		})
+	resp.NextCursor = page.NextCursor
+	resp.PrevCursor = page.PrevCursor
+	resp.HasMore = page.HasMore
+	resp.TotalCount = page.TotalCount
+	resp.TotalCountEstimated = page.TotalCountEstimated
+	resp.Users = make([]*gen.ReadableUserFields, 0, len(page.Users))
+	for _, user := range page.Users {
+		resp.Users = append(resp.Users, maskUserFields(readableUserFields(user), lur.GetReadMask().GetPaths()))
 	}
 	return resp, nil
 }
+
+// maskUserFields clears the fields of the user outside of the read mask paths, but the id, unless the mask is empty
+func maskUserFields(user *gen.ReadableUserFields, paths []string) *gen.ReadableUserFields {
+	if len(paths) == 0 {
+		return user
+	}
+	m := user.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		name := string(fields.Get(i).Name())
+		if name != "id" && !slices.Contains(paths, name) {
+			m.Clear(fields.Get(i))
+		}
+	}
+	return user
+}
+
+// readableUserFields maps a domain user into the proto message exposed by the api
+func readableUserFields(user *domain.User) *gen.ReadableUserFields {
+	return &gen.ReadableUserFields{
+		Id:             user.ID.String(),
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		NickName:       user.NickName,
+		Email:          user.Email,
+		CountryIsoCode: user.CountryISOCode,
+		Phone:          user.Phone,
+		PhoneVerified:  user.PhoneVerified,
+		Locale:         user.Locale,
+		Timezone:       user.Timezone,
+		DateOfBirth:    formatDate(user.DateOfBirth),
+		AvatarUrl:      user.AvatarURL,
+		CreatedAt:      timestamppb.New(user.CreatedAt),
+		UpdatedAt:      timestamppb.New(user.UpdatedAt),
+		Tags:           user.Tags,
+		LastSeenAt:     optionalTimestamp(user.LastSeenAt),
+		Status:         user.Status,
+	}
+}
+
+// optionalTime converts an optional timestamp, nil if not set
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// optionalTimestamp converts an optional time, nil if not set
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// parseDate parses an optional YYYY-MM-DD date
+// It returns domain.ErrInvalidBirthDate if the date is not valid
+func parseDate(date *string) (*time.Time, error) {
+	if date == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(user.DateLayout, *date)
+	if err != nil {
+		return nil, domain.ErrInvalidBirthDate
+	}
+	return &t, nil
+}
+
+// formatDate returns the date in the YYYY-MM-DD format, or an empty string if not set
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(user.DateLayout)
+}
@@ -15,6 +15,11 @@ import (
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -433,7 +438,7 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 
 	type args struct {
 		ctx context.Context
-		cur *gen.UserID
+		cur *gen.GetUserRequest
 	}
 	tests := []struct {
 		name          string
@@ -446,10 +451,10 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 			name: "success",
 			args: args{
 				ctx: context.Background(),
-				cur: &gen.UserID{Id: expectedUserID},
+				cur: &gen.GetUserRequest{Id: expectedUserID},
 			},
 			expectedMocks: func(ctx context.Context) {
-				mockServiceQueries.On("GetUser", ctx, expectedUserID).Return(&domain.User{
+				mockServiceQueries.On("GetUser", ctx, expectedUserID, []string(nil)).Return(&domain.User{
 					ID:             uuid.MustParse(expectedUserID),
 					FirstName:      "first",
 					LastName:       "last",
@@ -459,6 +464,8 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 					Password:       "",
 					CreatedAt:      timeFreeze,
 					UpdatedAt:      timeFreeze,
+					FollowersCount: 3,
+					FollowingCount: 1,
 				}, nil).Once()
 			},
 			want: &gen.UserResponse{
@@ -472,17 +479,66 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 					CreatedAt:      timestamppb.New(timeFreeze),
 					UpdatedAt:      timestamppb.New(timeFreeze),
 				},
+				FollowersCount: 3,
+				FollowingCount: 1,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "read mask",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.GetUserRequest{Id: expectedUserID, ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"nick_name", "followers_count"}}},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("GetUser", ctx, expectedUserID, []string{"nick_name", "followers_count"}).Return(&domain.User{
+					ID:             uuid.MustParse(expectedUserID),
+					NickName:       "nick",
+					FollowersCount: 3,
+				}, nil).Once()
+			},
+			want: &gen.UserResponse{
+				User:           &gen.ReadableUserFields{Id: expectedUserID, NickName: "nick"},
+				FollowersCount: 3,
 			},
 			wantErr: nil,
 		},
+		{
+			name: "invalid read mask",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.GetUserRequest{Id: expectedUserID, ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}}},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("GetUser", ctx, expectedUserID, []string{"password"}).Return(nil,
+					fmt.Errorf("%w: unknown field password", domain.ErrInvalidReadMask)).Once()
+			},
+			wantErr: status.Error(codes.InvalidArgument, "invalid read_mask: unknown field password"),
+		},
+		{
+			name: "merged user",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.GetUserRequest{Id: expectedUserID},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mergedInto := uuid.MustParse("5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e")
+				mockServiceQueries.On("GetUser", ctx, expectedUserID, []string(nil)).Return(&domain.User{
+					ID:         uuid.MustParse(expectedUserID),
+					MergedInto: &mergedInto,
+				}, nil).Once()
+			},
+			want:    &gen.UserResponse{MergedInto: "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"},
+			wantErr: nil,
+		},
 		{
 			name: "service layer error",
 			args: args{
 				ctx: context.Background(),
-				cur: &gen.UserID{Id: expectedUserID},
+				cur: &gen.GetUserRequest{Id: expectedUserID},
 			},
 			expectedMocks: func(ctx context.Context) {
-				mockServiceQueries.On("GetUser", ctx, expectedUserID).Return(nil, domain.ErrInvalidUserID).Once()
+				mockServiceQueries.On("GetUser", ctx, expectedUserID, []string(nil)).Return(nil, domain.ErrInvalidUserID).Once()
 			},
 			want:    &gen.UserResponse{},
 			wantErr: domain.ErrInvalidUserID,
@@ -491,10 +547,10 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 			name: "empty user returned",
 			args: args{
 				ctx: context.Background(),
-				cur: &gen.UserID{Id: expectedUserID},
+				cur: &gen.GetUserRequest{Id: expectedUserID},
 			},
 			expectedMocks: func(ctx context.Context) {
-				mockServiceQueries.On("GetUser", ctx, expectedUserID).Return(nil, nil).Once()
+				mockServiceQueries.On("GetUser", ctx, expectedUserID, []string(nil)).Return(nil, nil).Once()
 			},
 			want:    &gen.UserResponse{},
 			wantErr: nil,
@@ -503,7 +559,7 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 			name: "empty id",
 			args: args{
 				ctx: context.Background(),
-				cur: &gen.UserID{
+				cur: &gen.GetUserRequest{
 					Id: "",
 				},
 			},
@@ -514,7 +570,7 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 			name: "invalid id",
 			args: args{
 				ctx: context.Background(),
-				cur: &gen.UserID{
+				cur: &gen.GetUserRequest{
 					Id: "something wrong",
 				},
 			},
@@ -534,13 +590,87 @@ func TestUserServerImpl_GetUser(t *testing.T) {
 				assert.EqualError(t, err, tt.wantErr.Error())
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !proto.Equal(got, tt.want) {
 				t.Errorf("UserServerImpl.GetUser() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestUserServerImpl_BatchGetUsers(t *testing.T) {
+	timeFreeze := time.Now()
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	mergedID := "5a0d3ac4-3c5f-4b3f-9d8e-0d1c2b3a4f5e"
+	missingID := "7a2d1c4e-0b8f-4d3a-9e6c-5f1b2a3c4d5e"
+	mergedInto := uuid.MustParse("c12e23f3-f5e3-41bc-aeca-9d66bd0b96a3")
+
+	mockServiceQueries := appmocks.NewUserServiceQueries(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+
+	server := &UserHandler{
+		serviceQueries: mockServiceQueries,
+		protoValidator: protoValidator,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.BatchGetUsersRequest
+		expectedMocks func()
+		want          *gen.BatchGetUsersResponse
+		wantErr       error
+	}{
+		{
+			name: "success",
+			req:  &gen.BatchGetUsersRequest{Ids: []string{missingID, mergedID, userID}},
+			expectedMocks: func() {
+				mockServiceQueries.On("BatchGetUsers", mock.Anything, []string{missingID, mergedID, userID}).Return([]*domain.User{
+					{ID: uuid.MustParse(mergedID), MergedInto: &mergedInto},
+					{ID: uuid.MustParse(userID), FirstName: "first", CreatedAt: timeFreeze, UpdatedAt: timeFreeze},
+				}, []string{missingID}, nil).Once()
+			},
+			want: &gen.BatchGetUsersResponse{
+				Users:      []*gen.ReadableUserFields{{Id: userID, FirstName: "first", CreatedAt: timestamppb.New(timeFreeze), UpdatedAt: timestamppb.New(timeFreeze)}},
+				MissingIds: []string{missingID},
+				MergedInto: map[string]string{mergedID: mergedInto.String()},
+			},
+		},
+		{
+			name: "too many ids",
+			req:  &gen.BatchGetUsersRequest{Ids: []string{userID, missingID}},
+			expectedMocks: func() {
+				mockServiceQueries.On("BatchGetUsers", mock.Anything, []string{userID, missingID}).Return(
+					[]*domain.User{}, []string{}, fmt.Errorf("%w: at most 1", domain.ErrTooManyUserIDs)).Once()
+			},
+			wantErr: fmt.Errorf("%w: at most 1", domain.ErrTooManyUserIDs),
+		},
+		{
+			name:    "no ids",
+			req:     &gen.BatchGetUsersRequest{},
+			wantErr: fmt.Errorf("validation error:\n - ids: value must contain at least 1 item(s) [repeated.min_items]"),
+		},
+		{
+			name:    "invalid id",
+			req:     &gen.BatchGetUsersRequest{Ids: []string{"something wrong"}},
+			wantErr: fmt.Errorf("validation error:\n - ids[0]: value must be a valid UUID [string.uuid]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := server.BatchGetUsers(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(tt.want, got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
 func TestUserServerImpl_ListUsers(t *testing.T) {
 	timeFreeze := time.Now()
 	expectedCursor := "MjAyNC0wOC0xOFQxMTozNjo1MS45OTcyNjRafGFiZTA3NWQ2LWViMDQtNGQyNS04ZDlmLTk5YTdkN2M2YWY0ZA=="
@@ -577,7 +707,7 @@ func TestUserServerImpl_ListUsers(t *testing.T) {
 			expectedMocks: func(ctx context.Context) {
 				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
 					Cursor: "", Limit: 2,
-				}).Return([]*domain.User{
+				}).Return(user.ListUsersResponse{Users: []*domain.User{
 					{
 						ID:             uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"),
 						FirstName:      "first",
@@ -599,10 +729,11 @@ func TestUserServerImpl_ListUsers(t *testing.T) {
 						CreatedAt:      timeFreeze,
 						UpdatedAt:      timeFreeze,
 					},
-				}, expectedCursor, nil).Once()
+				}, NextCursor: expectedCursor, HasMore: true}, nil).Once()
 			},
 			want: &gen.ListUsersResponse{
 				NextCursor: expectedCursor,
+				HasMore:    true,
 				Users: []*gen.ReadableUserFields{
 					{
 						Id:             "0f913f6a-497b-4305-b3d1-3f53657e3a25",
@@ -633,14 +764,15 @@ func TestUserServerImpl_ListUsers(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				cur: &gen.ListUsersRequest{
-					Limit:  2,
-					Cursor: &expectedCursor,
+					Limit:      2,
+					Cursor:     &expectedCursor,
+					TotalCount: proto.String("estimated"),
 				},
 			},
 			expectedMocks: func(ctx context.Context) {
 				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
-					Cursor: expectedCursor, Limit: 2,
-				}).Return([]*domain.User{
+					Cursor: expectedCursor, Limit: 2, TotalCount: user.TotalCountEstimated,
+				}).Return(user.ListUsersResponse{PrevCursor: expectedCursor, TotalCount: proto.Int64(120000), TotalCountEstimated: true, Users: []*domain.User{
 					{
 						ID:             uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"),
 						FirstName:      "first",
@@ -652,10 +784,13 @@ func TestUserServerImpl_ListUsers(t *testing.T) {
 						CreatedAt:      timeFreeze,
 						UpdatedAt:      timeFreeze,
 					},
-				}, "", nil).Once()
+				}}, nil).Once()
 			},
 			want: &gen.ListUsersResponse{
-				NextCursor: "",
+				NextCursor:          "",
+				PrevCursor:          expectedCursor,
+				TotalCount:          proto.Int64(120000),
+				TotalCountEstimated: true,
 				Users: []*gen.ReadableUserFields{
 					{
 						Id:             "0f913f6a-497b-4305-b3d1-3f53657e3a25",
@@ -671,6 +806,182 @@ func TestUserServerImpl_ListUsers(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "success - with all tags",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:     2,
+					Tags:      []string{"vip", "beta"},
+					TagsMatch: proto.String("all"),
+				},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
+					Limit: 2, Filters: domain.UserSearchFilters{Tags: []string{"vip", "beta"}, MatchAllTags: true},
+				}).Return(user.ListUsersResponse{Users: []*domain.User{
+					{
+						ID:        uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"),
+						FirstName: "first",
+						CreatedAt: timeFreeze,
+						UpdatedAt: timeFreeze,
+						Tags:      []string{"beta", "vip"},
+					},
+				}}, nil).Once()
+			},
+			want: &gen.ListUsersResponse{
+				Users: []*gen.ReadableUserFields{
+					{
+						Id:        "0f913f6a-497b-4305-b3d1-3f53657e3a25",
+						FirstName: "first",
+						CreatedAt: timestamppb.New(timeFreeze),
+						UpdatedAt: timestamppb.New(timeFreeze),
+						Tags:      []string{"beta", "vip"},
+					},
+				},
+			},
+		},
+		{
+			name: "success - last seen range",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:      2,
+					SeenAfter:  timestamppb.New(timeFreeze.Add(-time.Hour)),
+					SeenBefore: timestamppb.New(timeFreeze),
+				},
+			},
+			expectedMocks: func(ctx context.Context) {
+				seenAfter := timeFreeze.Add(-time.Hour).UTC()
+				seenBefore := timeFreeze.UTC()
+				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
+					Limit: 2, Filters: domain.UserSearchFilters{SeenAfter: &seenAfter, SeenBefore: &seenBefore},
+				}).Return(user.ListUsersResponse{Users: []*domain.User{
+					{
+						ID:         uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"),
+						FirstName:  "first",
+						CreatedAt:  timeFreeze,
+						UpdatedAt:  timeFreeze,
+						LastSeenAt: &seenBefore,
+						Status:     domain.UserActive,
+					},
+				}}, nil).Once()
+			},
+			want: &gen.ListUsersResponse{
+				Users: []*gen.ReadableUserFields{
+					{
+						Id:         "0f913f6a-497b-4305-b3d1-3f53657e3a25",
+						FirstName:  "first",
+						CreatedAt:  timestamppb.New(timeFreeze),
+						UpdatedAt:  timestamppb.New(timeFreeze),
+						LastSeenAt: timestamppb.New(timeFreeze),
+						Status:     "active",
+					},
+				},
+			},
+		},
+		{
+			name: "success - fuzzy",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:    2,
+					LastName: proto.String("smiht"),
+					Fuzzy:    true,
+				},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
+					Limit: 2, Fuzzy: true, Filters: domain.UserSearchFilters{LastName: proto.String("smiht")},
+				}).Return(user.ListUsersResponse{Users: []*domain.User{}}, nil).Once()
+			},
+			want: &gen.ListUsersResponse{Users: []*gen.ReadableUserFields{}},
+		},
+		{
+			name: "success - ordered",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:   2,
+					OrderBy: proto.String("last_name desc"),
+				},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{Limit: 2, OrderBy: "last_name desc"}).Return(user.ListUsersResponse{Users: []*domain.User{}}, nil).Once()
+			},
+			want: &gen.ListUsersResponse{Users: []*gen.ReadableUserFields{}},
+		},
+		{
+			name: "invalid tags match",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:     2,
+					Tags:      []string{"vip"},
+					TagsMatch: proto.String("some"),
+				},
+			},
+			wantErr: fmt.Errorf("validation error:\n - tags_match: value must be in list [\"any\", \"all\"] [string.in]"),
+		},
+		{
+			name: "invalid filter",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:  2,
+					Filter: proto.String(`password = "x"`),
+				},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
+					Limit: 2, Filter: `password = "x"`,
+				}).Return(user.ListUsersResponse{}, fmt.Errorf("%w: unknown field \"password\" at position 1", domain.ErrInvalidFilter)).Once()
+			},
+			wantErr: status.Error(codes.InvalidArgument, `invalid filter: unknown field "password" at position 1`),
+		},
+		{
+			name: "success - read mask",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:    2,
+					OrderBy:  proto.String("last_name"),
+					ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name", "email"}},
+				},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
+					Limit: 2, OrderBy: "last_name", Fields: []string{"first_name", "email"},
+				}).Return(user.ListUsersResponse{Users: []*domain.User{
+					{
+						ID:        uuid.MustParse("0f913f6a-497b-4305-b3d1-3f53657e3a25"),
+						FirstName: "first",
+						LastName:  "last",
+						Email:     "something",
+					},
+				}}, nil).Once()
+			},
+			want: &gen.ListUsersResponse{
+				Users: []*gen.ReadableUserFields{
+					{
+						Id:        "0f913f6a-497b-4305-b3d1-3f53657e3a25",
+						FirstName: "first",
+						Email:     "something",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid total count",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:      2,
+					TotalCount: proto.String("approximate"),
+				},
+			},
+			wantErr: fmt.Errorf("validation error:\n - total_count: value must be in list [\"exact\", \"estimated\"] [string.in]"),
+		},
 		{
 			name: "error",
 			args: args{
@@ -683,11 +994,27 @@ func TestUserServerImpl_ListUsers(t *testing.T) {
 			expectedMocks: func(ctx context.Context) {
 				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
 					Cursor: expectedCursor, Limit: 2,
-				}).Return([]*domain.User{}, "", fmt.Errorf("something went wrong")).Once()
+				}).Return(user.ListUsersResponse{}, fmt.Errorf("something went wrong")).Once()
 			},
 			want:    &gen.ListUsersResponse{},
 			wantErr: fmt.Errorf("something went wrong"),
 		},
+		{
+			name: "invalid cursor",
+			args: args{
+				ctx: context.Background(),
+				cur: &gen.ListUsersRequest{
+					Limit:  3,
+					Cursor: &expectedCursor,
+				},
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockServiceQueries.On("ListUsers", ctx, user.ListUsersRequest{
+					Cursor: expectedCursor, Limit: 3,
+				}).Return(user.ListUsersResponse{}, fmt.Errorf("%w: cursor expired", domain.ErrInvalidPaginationCursor)).Once()
+			},
+			wantErr: status.Error(codes.InvalidArgument, "invalid pagination cursor: cursor expired"),
+		},
 		{
 			name: "nil payload",
 			args: args{
@@ -721,7 +1048,7 @@ func TestUserServerImpl_ListUsers(t *testing.T) {
 				assert.EqualError(t, err, tt.wantErr.Error())
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !proto.Equal(got, tt.want) {
 				t.Errorf("UserServerImpl.ListUsers() = %v, want %v", got, tt.want)
 			}
 		})
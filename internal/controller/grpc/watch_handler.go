@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"errors"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (us UserHandler) WatchUsers(req *gen.WatchUsersRequest, stream grpc.ServerStreamingServer[gen.UserChange]) error {
+	if err := us.protoValidator.Validate(req); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	err := us.watch.WatchUsers(ctx, user.WatchUsersRequest{
+		Types:       req.GetFilter().GetTypes(),
+		UserIDs:     req.GetFilter().GetUserIds(),
+		ResumeToken: req.GetResumeToken(),
+	}, func(c *domain.UserChange) error {
+		return stream.Send(userChange(c))
+	})
+	switch {
+	case errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidUserID) || errors.Is(err, domain.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrWatchClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return err
+}
+
+// userChange converts a change of a user, or a heartbeat when it has no type
+func userChange(c *domain.UserChange) *gen.UserChange {
+	if c.Type == "" {
+		return &gen.UserChange{Heartbeat: true, ResumeToken: c.ResumeToken}
+	}
+	return &gen.UserChange{
+		Type:        c.Type,
+		UserId:      c.UserID,
+		Payload:     string(c.Payload),
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		ResumeToken: c.ResumeToken,
+	}
+}
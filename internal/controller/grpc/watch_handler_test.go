@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	appmocks "users/gen/mocks/users/app"
+	gen "users/gen/proto/go"
+	"users/internal/app/user"
+	"users/internal/domain"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// watchUsersStream records the changes sent by a WatchUsers stream
+type watchUsersStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*gen.UserChange
+}
+
+func (s *watchUsersStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchUsersStream) Send(c *gen.UserChange) error {
+	s.sent = append(s.sent, c)
+	return nil
+}
+
+func TestUserHandler_WatchUsers(t *testing.T) {
+	mockWatch := appmocks.NewWatchQueries(t)
+	protoValidator, err := protovalidate.New()
+	assert.NoError(t, err, "creating protovalidator instance")
+	server := &UserHandler{watch: mockWatch, protoValidator: protoValidator}
+
+	userID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := &domain.UserChange{Type: domain.UserChangeCreate, UserID: userID, Payload: []byte(`{"id":"` + userID + `"}`),
+		CreatedAt: createdAt, ResumeToken: "MTE"}
+	// watchUsers passes the changes to the callback of the handler, as the use case does
+	watchUsers := func(err error, changes ...*domain.UserChange) func(context.Context, user.WatchUsersRequest, func(*domain.UserChange) error) error {
+		return func(_ context.Context, _ user.WatchUsersRequest, fn func(*domain.UserChange) error) error {
+			for _, c := range changes {
+				if err := fn(c); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		req           *gen.WatchUsersRequest
+		expectedMocks func(ctx context.Context)
+		wantSent      []*gen.UserChange
+		wantErr       error
+	}{
+		{
+			name: "changes and heartbeats until the client goes away",
+			ctx:  cancelled,
+			req: &gen.WatchUsersRequest{
+				Filter:      &gen.WatchUsersFilter{Types: []string{"create"}, UserIds: []string{userID}},
+				ResumeToken: "MTA",
+			},
+			expectedMocks: func(ctx context.Context) {
+				mockWatch.On("WatchUsers", ctx, user.WatchUsersRequest{
+					Types: []string{"create"}, UserIDs: []string{userID}, ResumeToken: "MTA",
+				}, mock.Anything).Return(watchUsers(context.Canceled, created, &domain.UserChange{ResumeToken: "MTI"})).Once()
+			},
+			wantSent: []*gen.UserChange{
+				{Type: "create", UserId: userID, Payload: `{"id":"` + userID + `"}`, CreatedAt: timestamppb.New(createdAt), ResumeToken: "MTE"},
+				{Heartbeat: true, ResumeToken: "MTI"},
+			},
+			wantErr: status.Error(codes.Canceled, context.Canceled.Error()),
+		},
+		{
+			name: "invalid resume token",
+			ctx:  context.Background(),
+			req:  &gen.WatchUsersRequest{ResumeToken: "x"},
+			expectedMocks: func(ctx context.Context) {
+				mockWatch.On("WatchUsers", ctx, user.WatchUsersRequest{ResumeToken: "x"}, mock.Anything).Return(domain.ErrInvalidResumeToken).Once()
+			},
+			wantErr: status.Error(codes.InvalidArgument, domain.ErrInvalidResumeToken.Error()),
+		},
+		{
+			name: "closed by the server",
+			ctx:  context.Background(),
+			req:  &gen.WatchUsersRequest{},
+			expectedMocks: func(ctx context.Context) {
+				mockWatch.On("WatchUsers", ctx, user.WatchUsersRequest{}, mock.Anything).Return(domain.ErrWatchClosed).Once()
+			},
+			wantErr: status.Error(codes.Unavailable, domain.ErrWatchClosed.Error()),
+		},
+		{
+			name:    "invalid request",
+			ctx:     context.Background(),
+			req:     &gen.WatchUsersRequest{Filter: &gen.WatchUsersFilter{Types: []string{"merge"}}},
+			wantErr: fmt.Errorf("validation error:\n - filter.types[0]: value must be in list [\"create\", \"update\", \"delete\"] [string.in]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks(tt.ctx)
+			}
+			stream := &watchUsersStream{ctx: tt.ctx}
+			err := server.WatchUsers(tt.req, stream)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+			assert.Equal(t, len(tt.wantSent), len(stream.sent))
+			for i := range tt.wantSent {
+				assert.True(t, proto.Equal(tt.wantSent[i], stream.sent[i]), "sent %v, want %v", stream.sent[i], tt.wantSent[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	gen "users/gen/proto/go"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_avatarFormField = "avatar"
+	_avatarChunkSize = 64 * 1024
+)
+
+// uploadAvatarHandler streams the "avatar" part of a multipart/form-data request to the UploadAvatar RPC
+func uploadAvatarHandler(mux *runtime.ServeMux, client gen.UserServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := outgoingContext(r)
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+
+		part, err := avatarPart(r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		defer part.Close()
+
+		stream, err := client.UploadAvatar(ctx)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		err = stream.Send(&gen.UploadAvatarRequest{Data: &gen.UploadAvatarRequest_Metadata{Metadata: &gen.AvatarMetadata{
+			Id:          pathParams["id"],
+			ContentType: part.Header.Get("Content-Type"),
+		}}})
+		buf := make([]byte, _avatarChunkSize)
+		for err == nil {
+			var n int
+			n, err = part.Read(buf)
+			if n > 0 {
+				// io.EOF from Send means the server ended the stream, its status is returned by CloseAndRecv
+				if sendErr := stream.Send(&gen.UploadAvatarRequest{Data: &gen.UploadAvatarRequest_Chunk{Chunk: buf[:n]}}); sendErr != nil {
+					err = sendErr
+				}
+			}
+		}
+		if !errors.Is(err, io.EOF) {
+			runtime.HTTPError(ctx, mux, outbound, w, r, status.Errorf(codes.InvalidArgument, "failed to read avatar: %v", err))
+			return
+		}
+
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		body, err := outbound.Marshal(resp)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", outbound.ContentType(resp))
+		w.Write(body)
+	}
+}
+
+// avatarPart returns the avatar part of the multipart request without buffering it
+func avatarPart(r *http.Request) (*multipart.Part, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "expected a multipart/form-data request: %v", err)
+	}
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "missing %q form field", _avatarFormField)
+		}
+		if part.FormName() == _avatarFormField {
+			return part, nil
+		}
+		part.Close()
+	}
+}
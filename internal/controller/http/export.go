@@ -0,0 +1,164 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	gen "users/gen/proto/go"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	_ndjsonContentType = "application/x-ndjson"
+	_csvContentType    = "text/csv"
+	// _exportedUsersTrailer is the number of users written by the export
+	_exportedUsersTrailer = "X-Exported-Users"
+	// _exportStatusTrailer is "complete", or the error the export failed with once the response had started
+	_exportStatusTrailer = "X-Export-Status"
+	// _exportFlushEvery is the number of users written between flushes of the response
+	_exportFlushEvery = 100
+)
+
+// _exportCSVHeader are the columns of the CSV export
+var _exportCSVHeader = []string{"id", "first_name", "last_name", "nick_name", "email", "country_iso_code", "phone", "phone_verified",
+	"locale", "timezone", "date_of_birth", "avatar_url", "tags", "status", "created_at", "updated_at", "last_seen_at"}
+
+// exportUsersHandler streams the ExportUsers RPC as NDJSON, or as CSV when the Accept header asks for text/csv.
+// The users are written as they are received, and the response is flushed regularly, so the export is never buffered.
+// Once the response has started, the outcome and the number of exported users are sent as trailers.
+// The export is cancelled when the client goes away.
+func exportUsersHandler(mux *runtime.ServeMux, client gen.UserServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+
+		req := &gen.ExportUsersRequest{}
+		if err := runtime.PopulateQueryParameters(req, r.URL.Query(), utilities.NewDoubleArray(nil)); err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		stream, err := client.ExportUsers(ctx, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		// the first message tells whether the export started, its errors can still be returned with their status
+		user, err := stream.Recv()
+		if err != nil && !errors.Is(err, io.EOF) {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		var encoder userEncoder = &ndjsonEncoder{w: w, marshaler: outbound}
+		contentType := _ndjsonContentType
+		if acceptsCSV(r.Header.Get("Accept")) {
+			encoder, contentType = &csvEncoder{w: csv.NewWriter(w)}, _csvContentType
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Trailer", _exportedUsersTrailer+", "+_exportStatusTrailer)
+		w.WriteHeader(http.StatusOK)
+		if err := encoder.Begin(); err != nil {
+			return
+		}
+
+		rc := http.NewResponseController(w)
+		var exported int64
+		for err == nil {
+			if err = encoder.Encode(user); err != nil {
+				// the client went away, the deferred cancel stops the export
+				return
+			}
+			if exported++; exported%_exportFlushEvery == 0 {
+				encoder.Flush()
+				rc.Flush()
+			}
+			user, err = stream.Recv()
+		}
+		encoder.Flush()
+
+		exportStatus := "complete"
+		if !errors.Is(err, io.EOF) {
+			exportStatus = "failed: " + status.Convert(err).Message()
+		}
+		w.Header().Set(_exportedUsersTrailer, strconv.FormatInt(exported, 10))
+		w.Header().Set(_exportStatusTrailer, exportStatus)
+	}
+}
+
+// acceptsCSV tells whether any of the media types of the Accept header is text/csv
+func acceptsCSV(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		if mediaType, _, err := mime.ParseMediaType(mediaRange); err == nil && mediaType == _csvContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// userEncoder writes the exported users to the response
+type userEncoder interface {
+	Begin() error
+	Encode(user *gen.ReadableUserFields) error
+	Flush()
+}
+
+// ndjsonEncoder writes a JSON object per line, as the gateway marshals the messages
+type ndjsonEncoder struct {
+	w         io.Writer
+	marshaler runtime.Marshaler
+}
+
+func (e *ndjsonEncoder) Begin() error { return nil }
+
+func (e *ndjsonEncoder) Encode(user *gen.ReadableUserFields) error {
+	line, err := e.marshaler.Marshal(user)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(line, '\n'))
+	return err
+}
+
+func (e *ndjsonEncoder) Flush() {}
+
+// csvEncoder writes a header row followed by a row per user, the tags are separated by ";"
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) Begin() error {
+	return e.w.Write(_exportCSVHeader)
+}
+
+func (e *csvEncoder) Encode(user *gen.ReadableUserFields) error {
+	return e.w.Write([]string{
+		user.GetId(), user.GetFirstName(), user.GetLastName(), user.GetNickName(), user.GetEmail(), user.GetCountryIsoCode(),
+		user.GetPhone(), strconv.FormatBool(user.GetPhoneVerified()), user.GetLocale(), user.GetTimezone(), user.GetDateOfBirth(),
+		user.GetAvatarUrl(), strings.Join(user.GetTags(), ";"), user.GetStatus(), formatTimestamp(user.GetCreatedAt()),
+		formatTimestamp(user.GetUpdatedAt()), formatTimestamp(user.GetLastSeenAt()),
+	})
+}
+
+func (e *csvEncoder) Flush() {
+	e.w.Flush()
+}
+
+// formatTimestamp formats the timestamp as RFC 3339, empty if not set
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339Nano)
+}
@@ -3,7 +3,9 @@ package http
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 	"users/internal/app"
 	"users/pkg/logger"
 
@@ -13,10 +15,24 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
-// Setup creates a new gin Engine, configures the middlewares and registers the routes
-func Setup(l logger.Interface, grpcServerPort int32, healthCheck app.HealthCheckQueries) (*gin.Engine, error) {
+const (
+	// _tenantHeader carries the tenant ID or slug of the request
+	_tenantHeader = "X-Tenant-Id"
+	// _actorHeader identifies who runs the request, for the audit log
+	_actorHeader = "X-Actor-Id"
+	// _requestIDHeader correlates the request, the gRPC server generates one when absent
+	_requestIDHeader = "X-Request-Id"
+)
+
+// _forwardedHeaders are forwarded to the gRPC server as metadata
+var _forwardedHeaders = []string{_tenantHeader, _actorHeader, _requestIDHeader}
+
+// Setup creates a new gin Engine, configures the middlewares and registers the routes.
+// If avatarsDir is set, the stored avatars are served under /avatars.
+func Setup(l logger.Interface, grpcServerPort int32, healthCheck app.HealthCheckQueries, avatarsDir string) (*gin.Engine, error) {
 	engine := gin.New()
 	engine.Use(l.GinLoggerFn())
 	engine.Use(gin.Recovery())
@@ -31,6 +47,10 @@ func Setup(l logger.Interface, grpcServerPort int32, healthCheck app.HealthCheck
 		c.JSON(http.StatusInternalServerError, gin.H{"status": "unhealthy"})
 	})
 
+	if avatarsDir != "" {
+		engine.Static("/avatars", avatarsDir)
+	}
+
 	mux, err := configureGRPCGateway(grpcServerPort)
 	if err != nil {
 		return engine, err
@@ -42,15 +62,64 @@ func Setup(l logger.Interface, grpcServerPort int32, healthCheck app.HealthCheck
 func configureGRPCGateway(grpcServerPort int32) (*runtime.ServeMux, error) {
 	mux := runtime.NewServeMux(
 		runtime.WithErrorHandler(customHTTPErrorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
 	)
-	err := gen.RegisterUserServiceHandlerFromEndpoint(context.Background(), mux, fmt.Sprintf("127.0.0.1:%d", grpcServerPort),
-		[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())})
+	conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", grpcServerPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return nil, err
 	}
+	if err := gen.RegisterUserServiceHandler(context.Background(), mux, conn); err != nil {
+		return nil, err
+	}
+	// multipart uploads are not supported by the generated gateway, they are forwarded to the UploadAvatar stream
+	if err := mux.HandlePath(http.MethodPost, "/v1/users/{id}/avatar", uploadAvatarHandler(mux, gen.NewUserServiceClient(conn))); err != nil {
+		return nil, err
+	}
+	// the export is streamed as NDJSON or CSV rather than as the stream of JSON messages of the generated gateway
+	if err := mux.HandlePath(http.MethodGet, "/v1/users:export", exportUsersHandler(mux, gen.NewUserServiceClient(conn))); err != nil {
+		return nil, err
+	}
 	return mux, nil
 }
 
+// outgoingContext returns the context of the request carrying the forwarded headers and the client address as gRPC metadata,
+// for the handlers that call the gRPC server without the generated gateway
+func outgoingContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	for _, header := range _forwardedHeaders {
+		if value := r.Header.Get(header); value != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(header), value)
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		forwarded := host
+		if prior := r.Header.Get("X-Forwarded-For"); prior != "" {
+			forwarded = prior + ", " + host
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", forwarded)
+	}
+	return ctx
+}
+
+// headerMatcher forwards the tenant, actor and request id headers to the gRPC server, on top of the default headers
+func headerMatcher(key string) (string, bool) {
+	for _, header := range _forwardedHeaders {
+		if http.CanonicalHeaderKey(key) == header {
+			return strings.ToLower(header), true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns the request id as a plain header, other metadata keeps the default Grpc-Metadata- prefix
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == _requestIDHeader {
+		return _requestIDHeader, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 func customHTTPErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, writer http.ResponseWriter, request *http.Request, err error) {
 	newError := runtime.HTTPStatusError{
 		HTTPStatus: http.StatusBadRequest,
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User statuses, set by the dormant users job
+const (
+	UserActive      = "active"
+	UserDormant     = "dormant"
+	UserDeactivated = "deactivated"
+)
+
+type (
+	// ActivityRepoCommands is an interface for persisting the activity of the users
+	ActivityRepoCommands interface {
+		// TouchUser sets the last time the user was seen, unless it was already set after notBefore.
+		// Dormant users become active again. Unknown users, and the users of other tenants than the context's, are ignored.
+		// Returns whether the user was dormant.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		TouchUser(ctx context.Context, userID string, seenAt time.Time, notBefore time.Time) (reactivated bool, err error)
+
+		// FlagInactiveUsers sets the status of up to limit active users not seen since inactiveSince,
+		// users never seen are inactive since their creation. Users locked by other transactions are skipped.
+		// Returns the flagged users.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		FlagInactiveUsers(ctx context.Context, inactiveSince time.Time, status string, limit int32) ([]*InactiveUser, error)
+	}
+
+	// InactiveUser represents a user flagged by the dormant users job
+	InactiveUser struct {
+		ID         uuid.UUID
+		TenantID   string
+		LastSeenAt *time.Time
+	}
+)
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+type (
+	// AuditRepoCommands is an interface for persisting audit events
+	AuditRepoCommands interface {
+		// AddAuditEvent creates a new audit event in the database.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		AddAuditEvent(ctx context.Context, event *AuditEvent) error
+	}
+
+	// AuditRepoQueries is an interface for querying audit events
+	AuditRepoQueries interface {
+		// ListAuditEvents fetches the audit events matching the filters, the most recent first.
+		// Parameters:
+		//   cursorID: ID of the event to start listing from
+		//   cursorCreatedAt: Time the event to start listing from was created
+		//   limit: Maximum number of events to return
+		//   filters: Criteria to filter events by
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListAuditEvents(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, filters AuditEventFilters) ([]*AuditEvent, error)
+	}
+
+	// AuditEvent records who ran a command, on which user, and with which outcome
+	AuditEvent struct {
+		ID           uuid.UUID
+		Actor        string
+		Method       string
+		TargetUserID *uuid.UUID
+		RequestID    string
+		SourceIP     string
+		Outcome      string
+		// Error is the cause of failed commands
+		Error string
+		// Diff maps the changed user fields to their previous and new values
+		Diff      map[string]FieldChange
+		CreatedAt time.Time
+	}
+
+	// FieldChange represents the change of a field, empty values stand for unset fields
+	FieldChange struct {
+		Old string `json:"old"`
+		New string `json:"new"`
+	}
+
+	// AuditEventFilters represents AuditEvent's searchable fields
+	AuditEventFilters struct {
+		Actor        *string
+		TargetUserID *string
+		Method       *string
+		After        *time.Time
+		Before       *time.Time
+	}
+
+	// AuditRecord collects the audit event of the command being run.
+	// It is shared by the request context, so the commands can describe their changes while they run.
+	AuditRecord struct {
+		mu       sync.Mutex
+		event    AuditEvent
+		failure  error
+		recorded bool
+	}
+)
+
+// WithAuditRecord returns a copy of the context carrying a new audit record for the command described by the event
+func WithAuditRecord(ctx context.Context, event AuditEvent) (context.Context, *AuditRecord) {
+	record := &AuditRecord{event: event}
+	return context.WithValue(ctx, AuditKey, record), record
+}
+
+// AuditRecordFromContext returns the audit record of the context, if any
+func AuditRecordFromContext(ctx context.Context) (*AuditRecord, bool) {
+	record, ok := ctx.Value(AuditKey).(*AuditRecord)
+	return record, ok && record != nil
+}
+
+// SetAuditTarget sets the user targeted by the command of the context, if it is audited
+func SetAuditTarget(ctx context.Context, userID string) {
+	record, ok := AuditRecordFromContext(ctx)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+	record.mu.Lock()
+	defer record.mu.Unlock()
+	record.event.TargetUserID = &id
+}
+
+// AddAuditDiff adds field changes to the command of the context, if it is audited
+func AddAuditDiff(ctx context.Context, diff map[string]FieldChange) {
+	record, ok := AuditRecordFromContext(ctx)
+	if !ok || len(diff) == 0 {
+		return
+	}
+	record.mu.Lock()
+	defer record.mu.Unlock()
+	if record.event.Diff == nil {
+		record.event.Diff = make(map[string]FieldChange, len(diff))
+	}
+	for field, change := range diff {
+		record.event.Diff[field] = change
+	}
+}
+
+// SetAuditFailure flags the command of the context as failed, if it is audited.
+// It is meant for commands that commit changes and still fail, such as a wrong verification code counting an attempt.
+func SetAuditFailure(ctx context.Context, cause error) {
+	record, ok := AuditRecordFromContext(ctx)
+	if !ok {
+		return
+	}
+	record.mu.Lock()
+	defer record.mu.Unlock()
+	record.failure = cause
+}
+
+// Event returns a copy of the collected audit event with the provided outcome.
+// Commands flagged with SetAuditFailure always have a failure outcome.
+func (r *AuditRecord) Event(outcome string, cause error) *AuditEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event := r.event
+	if r.failure != nil {
+		outcome, cause = AuditFailure, r.failure
+	}
+	event.Outcome = outcome
+	if cause != nil {
+		event.Error = cause.Error()
+	}
+	return &event
+}
+
+// Recorded checks if the audit event was already persisted
+func (r *AuditRecord) Recorded() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.recorded
+}
+
+// MarkRecorded flags the audit event as persisted, so it is not persisted again
+func (r *AuditRecord) MarkRecorded() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recorded = true
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+type (
+	// BlobStore is an interface for storing binary objects, such as avatars
+	BlobStore interface {
+		// Put stores the data under the key, replacing any existing object.
+		// Returns the public URL of the stored object.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		Put(ctx context.Context, key string, contentType string, data []byte) (url string, err error)
+
+		// DeletePrefix deletes every object whose key starts with the prefix.
+		// Deleting a missing prefix is not an error.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		DeletePrefix(ctx context.Context, prefix string) error
+	}
+)
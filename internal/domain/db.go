@@ -15,6 +15,12 @@ const (
 	// TxKey is used to save the transaction into the context
 	// iota type is the recommendation here in order to avoid key collisions
 	TxKey ctxKey = iota
+	// TenantKey is used to save the tenant ID of the request into the context
+	TenantKey
+	// AllTenantsKey flags background jobs that operate across every tenant
+	AllTenantsKey
+	// AuditKey is used to save the audit record of the command into the context
+	AuditKey
 )
 
 // MonitoringRepoQueries is an interface for Ping application dependencies
@@ -7,7 +7,7 @@ var (
 	ErrInternal                = fmt.Errorf("internal error")
 	ErrInvalidPW               = fmt.Errorf("invalid password")
 	ErrFailedToProcessData     = fmt.Errorf("failed to process data")
-	ErrInvalidPaginationCursor = fmt.Errorf("cursor must be a base64 string")
+	ErrInvalidPaginationCursor = fmt.Errorf("invalid pagination cursor")
 	ErrEmptyRequest            = fmt.Errorf("empty request")
 )
 
@@ -21,4 +21,92 @@ var (
 	ErrUserNotFound      = fmt.Errorf("user not found")
 	ErrUserAlreadyExists = fmt.Errorf("user already exists")
 	ErrInvalidUserID     = fmt.Errorf("invalid userID")
+	ErrInvalidLocale     = fmt.Errorf("invalid locale")
+	ErrInvalidTimezone   = fmt.Errorf("invalid timezone")
+	ErrInvalidBirthDate  = fmt.Errorf("invalid date of birth")
+	ErrBirthDateRequired = fmt.Errorf("date of birth is required")
+	ErrUserTooYoung      = fmt.Errorf("user is under the minimum age")
+	ErrEmptySearchQuery  = fmt.Errorf("empty search query")
+	ErrInvalidOrderBy    = fmt.Errorf("invalid order_by")
+	ErrInvalidFilter     = fmt.Errorf("invalid filter")
+	ErrTooManyUserIDs    = fmt.Errorf("too many user ids")
+	ErrInvalidUserLookup = fmt.Errorf("either an email or a nickname is required")
+	ErrInvalidReadMask   = fmt.Errorf("invalid read_mask")
+	ErrInvalidStatsRange = fmt.Errorf("invalid stats range")
+)
+
+// Watch Errors
+var (
+	ErrInvalidResumeToken = fmt.Errorf("invalid resume token")
+	ErrWatchClosed        = fmt.Errorf("watch closed by the server, resume it")
+)
+
+// Phone Errors
+var (
+	ErrInvalidPhone                = fmt.Errorf("invalid phone number")
+	ErrPhoneNotSet                 = fmt.Errorf("user has no phone number")
+	ErrPhoneAlreadyVerified        = fmt.Errorf("phone number already verified")
+	ErrPhoneAlreadyInUse           = fmt.Errorf("phone number already in use")
+	ErrVerificationNotFound        = fmt.Errorf("phone verification not found")
+	ErrVerificationExpired         = fmt.Errorf("phone verification expired")
+	ErrInvalidVerificationCode     = fmt.Errorf("invalid verification code")
+	ErrTooManyVerificationAttempts = fmt.Errorf("too many verification attempts")
+)
+
+// Avatar Errors
+var (
+	ErrUnsupportedAvatarType = fmt.Errorf("unsupported avatar content type")
+	ErrAvatarTooLarge        = fmt.Errorf("avatar is too large")
+	ErrInvalidAvatar         = fmt.Errorf("invalid avatar image")
+	ErrAvatarNotSet          = fmt.Errorf("user has no avatar")
+)
+
+// Preference Errors
+var (
+	ErrUnknownPreference      = fmt.Errorf("unknown preference")
+	ErrInvalidPreferenceValue = fmt.Errorf("invalid preference value")
+)
+
+// Tenant Errors
+var (
+	ErrTenantRequired = fmt.Errorf("tenant is required")
+	ErrTenantNotFound = fmt.Errorf("tenant not found")
+)
+
+// Group Errors
+var (
+	ErrInvalidGroupID           = fmt.Errorf("invalid groupID")
+	ErrGroupNotFound            = fmt.Errorf("group not found")
+	ErrGroupAlreadyExists       = fmt.Errorf("group already exists")
+	ErrGroupMemberAlreadyExists = fmt.Errorf("user is already a member of the group")
+	ErrGroupMemberNotFound      = fmt.Errorf("user is not a member of the group")
+)
+
+// Relationship Errors
+var (
+	ErrSelfRelationship = fmt.Errorf("users cannot follow or block themselves")
+	ErrAlreadyFollowing = fmt.Errorf("user is already following the target")
+	ErrNotFollowing     = fmt.Errorf("user is not following the target")
+	ErrAlreadyBlocked   = fmt.Errorf("user already blocked the target")
+	ErrNotBlocked       = fmt.Errorf("user did not block the target")
+	ErrUserBlocked      = fmt.Errorf("user is blocked")
+)
+
+// Invitation Errors
+var (
+	ErrInvalidInvitationID     = fmt.Errorf("invalid invitationID")
+	ErrInvalidInvitationStatus = fmt.Errorf("invalid invitation status")
+	ErrInvitationNotFound      = fmt.Errorf("invitation not found")
+	ErrInvitationExpired       = fmt.Errorf("invitation expired")
+)
+
+// Merge Errors
+var (
+	ErrSelfMerge         = fmt.Errorf("a user cannot be merged into itself")
+	ErrInvalidMergeField = fmt.Errorf("invalid merge field resolution")
+)
+
+// Tag Errors
+var (
+	ErrInvalidTag = fmt.Errorf("invalid tag")
 )
@@ -0,0 +1,26 @@
+package domain
+
+// Operators of the filter expressions
+const (
+	FilterAnd            = "AND"
+	FilterOr             = "OR"
+	FilterNot            = "NOT"
+	FilterEquals         = "="
+	FilterNotEquals      = "!="
+	FilterLess           = "<"
+	FilterLessOrEqual    = "<="
+	FilterGreater        = ">"
+	FilterGreaterOrEqual = ">="
+	// FilterHas matches a substring of a text field, or an item of a repeated field
+	FilterHas = ":"
+)
+
+// FilterExpr represents a node of a parsed filter expression, in the AIP-160 syntax.
+// Logical nodes combine their Args with FilterAnd, FilterOr or FilterNot, while the leaves compare
+// a Field with a Value of its type: a string, a bool or a time.Time.
+type FilterExpr struct {
+	Op    string
+	Args  []*FilterExpr `json:",omitempty"`
+	Field string        `json:",omitempty"`
+	Value any           `json:",omitempty"`
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// GroupRepoCommands is an interface for persisting groups and their members
+	GroupRepoCommands interface {
+		// SaveGroup creates a new group in the database.
+		// Returns the created Group's ID and an error in case of failure.
+		// If a group with the same name already exists, it returns domain.ErrGroupAlreadyExists.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		SaveGroup(ctx context.Context, group *Group) (string, error)
+
+		// DeleteGroup deletes a group and its memberships.
+		// If the group does not exist, it returns domain.ErrGroupNotFound.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		DeleteGroup(ctx context.Context, groupID string) error
+
+		// AddMember adds the user to the group.
+		// If the group does not exist, it returns domain.ErrGroupNotFound.
+		// If the user does not exist, it returns domain.ErrUserNotFound.
+		// If the user is already a member, it returns domain.ErrGroupMemberAlreadyExists.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		AddMember(ctx context.Context, groupID string, userID string) error
+
+		// RemoveMember removes the user from the group.
+		// If the user is not a member of the group, it returns domain.ErrGroupMemberNotFound.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		RemoveMember(ctx context.Context, groupID string, userID string) error
+
+		// RemoveUserMemberships removes the user from every group.
+		// Returns the IDs of the groups the user was removed from.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		RemoveUserMemberships(ctx context.Context, userID string) (groupIDs []string, err error)
+	}
+
+	// GroupRepoQueries is an interface for querying groups and their members
+	GroupRepoQueries interface {
+		// GetGroup fetches a single group from the database based on the groupID.
+		// If the group does not exist, it returns domain.ErrGroupNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetGroup(ctx context.Context, groupID string) (*Group, error)
+
+		// ListGroupMembers fetches the members of a group, the most recently added first.
+		// Parameters:
+		//   cursorUserID: ID of the member to start listing from
+		//   cursorAddedAt: Time the member to start listing from was added
+		//   limit: Maximum number of members to return
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListGroupMembers(ctx context.Context, groupID string, cursorUserID string, cursorAddedAt *time.Time, limit int32) ([]*GroupMember, error)
+
+		// ListUserGroups fetches every group the user is a member of, ordered by name.
+		// If the user does not exist, it returns domain.ErrUserNotFound.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListUserGroups(ctx context.Context, userID string) ([]*Group, error)
+	}
+
+	// Group represents a named set of users in the domain model
+	Group struct {
+		ID          uuid.UUID
+		Name        string
+		Description string
+		CreatedAt   time.Time
+	}
+
+	// GroupMember represents a User as a member of a group
+	GroupMember struct {
+		User
+		AddedAt time.Time
+	}
+)
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	UserVersionCreate = "create"
+	UserVersionUpdate = "update"
+	UserVersionDelete = "delete"
+)
+
+type (
+	// UserHistoryRepoQueries is an interface for querying the recorded versions of users
+	UserHistoryRepoQueries interface {
+		// ListUserVersions fetches the versions of a user, the most recent first.
+		// The versions of deleted users are kept until they are purged.
+		// Parameters:
+		//   cursorVersion: Version to start listing from
+		//   cursorRecordedAt: Time the version to start listing from was recorded
+		//   limit: Maximum number of versions to return
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListUserVersions(ctx context.Context, userID string, cursorVersion int64, cursorRecordedAt *time.Time, limit int32) ([]*UserVersion, error)
+
+		// GetUserVersionAt fetches the version of a user that was current at the provided time.
+		// If the user did not exist yet or its history was purged, it returns domain.ErrUserNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetUserVersionAt(ctx context.Context, userID string, at time.Time) (*UserVersion, error)
+	}
+
+	// UserHistoryRepoCommands is an interface for maintaining the recorded versions of users
+	UserHistoryRepoCommands interface {
+		// PurgeDeletedUsersHistory deletes every version of the users deleted before the provided time.
+		// Returns the number of deleted versions.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		PurgeDeletedUsersHistory(ctx context.Context, deletedBefore time.Time) (int64, error)
+	}
+
+	// UserVersion represents the state of a User recorded after a change.
+	// Passwords are not recorded.
+	UserVersion struct {
+		User
+		Version    int64
+		Operation  string
+		RecordedAt time.Time
+	}
+)
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation statuses
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationRevoked  = "revoked"
+	// InvitationExpired is not stored, it is the status of pending invitations past their expiry
+	InvitationExpired = "expired"
+)
+
+type (
+	// InvitationRepoCommands is an interface for persisting invitations
+	InvitationRepoCommands interface {
+		// SaveInvitation creates a new pending invitation, revoking the pending invitations to the same email.
+		// Returns the created Invitation's ID and an error in case of failure.
+		// If a user with the same email already exists, it returns domain.ErrUserAlreadyExists.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		SaveInvitation(ctx context.Context, invitation *Invitation) (string, error)
+
+		// GetPendingInvitation fetches the pending invitation with the token hash, locking it until the transaction ends.
+		// If there is no pending invitation with the token hash, it returns domain.ErrInvitationNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetPendingInvitation(ctx context.Context, tokenHash string) (*Invitation, error)
+
+		// MarkInvitationAccepted flags the invitation as accepted by the created user.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		MarkInvitationAccepted(ctx context.Context, invitationID string, userID string) error
+
+		// RevokeInvitation flags a pending invitation as revoked.
+		// If the invitation does not exist or is no longer pending, it returns domain.ErrInvitationNotFound.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		RevokeInvitation(ctx context.Context, invitationID string) error
+	}
+
+	// InvitationRepoQueries is an interface for querying invitations
+	InvitationRepoQueries interface {
+		// ListInvitations fetches the invitations, the most recent first.
+		// Parameters:
+		//   cursorID: ID of the invitation to start listing from
+		//   cursorCreatedAt: Creation time of the invitation to start listing from
+		//   limit: Maximum number of invitations to return
+		//   status: Only list invitations with this status, empty lists every invitation
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListInvitations(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, status string) ([]*Invitation, error)
+	}
+
+	// Invitation represents an invitation to create a user in the domain model
+	Invitation struct {
+		ID             uuid.UUID
+		Email          string
+		FirstName      string
+		LastName       string
+		CountryISOCode string
+		Roles          []string
+		TokenHash      string
+		Status         string
+		// UserID is the user created when the invitation was accepted
+		UserID    *uuid.UUID
+		ExpiresAt time.Time
+		CreatedAt time.Time
+	}
+)
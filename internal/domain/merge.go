@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+type (
+	// UserMergeRepoCommands is an interface for merging duplicate users
+	UserMergeRepoCommands interface {
+		// LockUsersForMerge fetches the source and target users, with their avatar keys, locking them until the transaction ends.
+		// If any of the users does not exist or was already merged, it returns domain.ErrUserNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		LockUsersForMerge(ctx context.Context, sourceID string, targetID string) (source *User, target *User, err error)
+
+		// MergeUsers turns the source into a tombstone pointing at the target and saves the merged profile on the target.
+		// The group memberships, relationships, preferences and invitations of the source are moved to the target,
+		// keeping the target's on conflicts, and users previously merged into the source are redirected to the target.
+		// If the merged profile conflicts with another user, it returns domain.ErrUserAlreadyExists.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		MergeUsers(ctx context.Context, sourceID string, target *User) error
+	}
+)
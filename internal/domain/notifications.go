@@ -2,10 +2,16 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// SecretPayloadFields are the payload fields only meant for the consumers of the events, e.g. one-time codes
+// and invitation tokens.
+// They are never logged and are cleared from the outbox once the event is published.
+var SecretPayloadFields = []string{"code", "token"}
+
 type (
 	// NotificationService is an interface for sending events as notifications.
 	NotificationService interface {
@@ -16,8 +22,12 @@ type (
 
 	// Event represents an Event in the domain model
 	Event struct {
-		ID      uuid.UUID
-		Type    string
-		Payload []byte
+		ID       uuid.UUID
+		TenantID string
+		Type     string
+		Payload  []byte
+		// Position is the commit order of the event, 0 until it is committed
+		Position  int64
+		CreatedAt time.Time
 	}
 )
@@ -13,7 +13,7 @@ type (
 		// Returns a slice of event objects and an error if the operation fails
 		GetUnprocessed(ctx context.Context, limit int32) ([]*Event, error)
 
-		// MarkAsProcessed marks a persisted event as processed
+		// MarkAsProcessed marks a persisted event as processed, clearing the SecretPayloadFields of its payload
 		// If an internal error occurs, it logs the error and returns domain.ErrInternal
 		MarkAsProcessed(ctx context.Context, id string) error
 
@@ -22,4 +22,26 @@ type (
 		// If an internal error occurs, it logs the error and returns domain.ErrInternal
 		AddEvent(ctx context.Context, event *Event) (string, error)
 	}
+
+	// OutboxRepoQueries is an interface for reading the persisted events in the order they were committed
+	OutboxRepoQueries interface {
+		// GetCommittedEvents fetches, in commit order, up to limit events of the given types committed after the position.
+		// An event is only returned once every event committed before it is visible, so reading after the position of
+		// the last event read never skips an event.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If theres an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetCommittedEvents(ctx context.Context, after int64, types []string, limit int32) ([]*Event, error)
+
+		// GetLastCommittedPosition returns the position of the last committed event, 0 if there is none.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		GetLastCommittedPosition(ctx context.Context) (int64, error)
+	}
+
+	// OutboxListener wakes up the readers of the outbox when events are committed
+	OutboxListener interface {
+		// Subscribe returns a channel signalled after events of the tenant are committed, and a function ending the subscription.
+		// Signals are coalesced while the subscriber is busy, and also sent when notifications may have been missed.
+		// The channel is closed when the listener stops.
+		Subscribe(tenantID string) (wake <-chan struct{}, unsubscribe func())
+	}
 )
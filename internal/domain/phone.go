@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// PhoneVerificationRepoCommands is an interface for persisting phone verifications
+	PhoneVerificationRepoCommands interface {
+		// SaveVerification creates or replaces the pending verification of a user.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		SaveVerification(ctx context.Context, verification *PhoneVerification) error
+
+		// GetVerification fetches the pending verification of a user, locking it until the transaction ends.
+		// If there is no pending verification, it returns domain.ErrVerificationNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetVerification(ctx context.Context, userID string) (*PhoneVerification, error)
+
+		// IncrementAttempts increments the failed attempts counter of a pending verification.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		IncrementAttempts(ctx context.Context, userID string) error
+
+		// DeleteVerification deletes the pending verification of a user.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		DeleteVerification(ctx context.Context, userID string) error
+	}
+
+	// PhoneVerification represents a pending phone verification in the domain model
+	PhoneVerification struct {
+		UserID    uuid.UUID
+		Phone     string
+		CodeHash  string
+		Attempts  int32
+		ExpiresAt time.Time
+	}
+)
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type (
+	// PreferencesRepoCommands is an interface for persisting user preferences
+	PreferencesRepoCommands interface {
+		// SavePreferences creates or replaces the provided preferences of a user.
+		// If user does not exist, it returns domain.ErrUserNotFound.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		SavePreferences(ctx context.Context, userID string, preferences map[string]json.RawMessage) error
+
+		// DeletePreferences deletes the provided preferences of a user, or all of them if no keys are provided.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		DeletePreferences(ctx context.Context, userID string, keys []string) error
+	}
+
+	// PreferencesRepoQueries is an interface for querying user preferences
+	PreferencesRepoQueries interface {
+		// GetPreferences fetches the preferences stored for a user, as JSON values by key.
+		// If the user does not exist, it returns domain.ErrUserNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetPreferences(ctx context.Context, userID string) (map[string]json.RawMessage, error)
+	}
+)
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// RelationshipFollow is the kind of relationship of a user following another
+	RelationshipFollow = "follow"
+	// RelationshipBlock is the kind of relationship of a user blocking another
+	RelationshipBlock = "block"
+)
+
+type (
+	// RelationshipRepoCommands is an interface for persisting follows and blocks between users
+	RelationshipRepoCommands interface {
+		// Follow makes the user follow the target.
+		// It must run in a transaction, which serializes it with the other follows and blocks between the users.
+		// If any of the users does not exist, it returns domain.ErrUserNotFound.
+		// If any of the users blocked the other, it returns domain.ErrUserBlocked.
+		// If the user already follows the target, it returns domain.ErrAlreadyFollowing.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		Follow(ctx context.Context, userID string, targetID string) error
+
+		// Unfollow makes the user stop following the target.
+		// If the user does not follow the target, it returns domain.ErrNotFollowing.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		Unfollow(ctx context.Context, userID string, targetID string) error
+
+		// Block makes the user block the target, removing the follows in both directions.
+		// It must run in a transaction, which serializes it with the other follows and blocks between the users.
+		// Returns whether the user was following the target and whether the target was following the user.
+		// If any of the users does not exist, it returns domain.ErrUserNotFound.
+		// If the user already blocked the target, it returns domain.ErrAlreadyBlocked.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		Block(ctx context.Context, userID string, targetID string) (wasFollowing bool, wasFollowed bool, err error)
+
+		// Unblock removes the block of the user on the target.
+		// If the user did not block the target, it returns domain.ErrNotBlocked.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		Unblock(ctx context.Context, userID string, targetID string) error
+	}
+
+	// RelationshipRepoQueries is an interface for querying follows and blocks between users
+	RelationshipRepoQueries interface {
+		// ListFollowers fetches the users following the user, the most recent first.
+		// Parameters:
+		//   cursorUserID: ID of the user to start listing from
+		//   cursorSince: Time the relationship to start listing from was created
+		//   limit: Maximum number of users to return
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListFollowers(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*RelatedUser, error)
+
+		// ListFollowing fetches the users followed by the user, the most recent first.
+		// Takes the same pagination parameters as ListFollowers.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListFollowing(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*RelatedUser, error)
+
+		// ListBlocked fetches the users blocked by the user, the most recent first.
+		// Takes the same pagination parameters as ListFollowers.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		ListBlocked(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*RelatedUser, error)
+	}
+
+	// RelatedUser represents a User on the other side of a relationship
+	RelatedUser struct {
+		User
+		Since time.Time
+	}
+)
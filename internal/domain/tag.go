@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// TagRepoCommands is an interface for persisting the tags of the users
+	TagRepoCommands interface {
+		// TagUsers assigns the tags to every user, creating the missing tags.
+		// Returns the assignments that did not exist yet.
+		// If any of the users does not exist, it returns domain.ErrUserNotFound.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		TagUsers(ctx context.Context, userIDs []string, tags []string) ([]UserTag, error)
+
+		// UntagUsers removes the tags from every user, unknown users and tags are ignored.
+		// Returns the removed assignments.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		UntagUsers(ctx context.Context, userIDs []string, tags []string) ([]UserTag, error)
+	}
+
+	// UserTag represents the assignment of a tag to a user
+	UserTag struct {
+		UserID uuid.UUID
+		Tag    string
+	}
+)
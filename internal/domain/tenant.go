@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// TenantRepoQueries is an interface for querying tenants
+	TenantRepoQueries interface {
+		// GetTenantByID fetches a tenant by its ID.
+		// If the tenant does not exist, it returns domain.ErrTenantNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetTenantByID(ctx context.Context, tenantID string) (*Tenant, error)
+
+		// GetTenantBySlug fetches a tenant by its slug.
+		// If the tenant does not exist, it returns domain.ErrTenantNotFound.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetTenantBySlug(ctx context.Context, slug string) (*Tenant, error)
+	}
+
+	// Tenant represents a brand sharing the database in the domain model
+	Tenant struct {
+		ID        uuid.UUID
+		Slug      string
+		Name      string
+		CreatedAt time.Time
+	}
+)
+
+// WithTenant returns a copy of the context scoped to the tenant
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, TenantKey, tenantID)
+}
+
+// TenantFromContext returns the tenant ID the context is scoped to, if any
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(TenantKey).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// WithAllTenants returns a copy of the context allowed to operate across every tenant.
+// It is meant for background jobs, such as the outbox processor, and must never be derived from a request.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, AllTenantsKey, true)
+}
+
+// IsAllTenants checks if the context is allowed to operate across every tenant
+func IsAllTenants(ctx context.Context) bool {
+	allTenants, _ := ctx.Value(AllTenantsKey).(bool)
+	return allTenants
+}
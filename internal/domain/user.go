@@ -7,6 +7,45 @@ import (
 	"github.com/google/uuid"
 )
 
+// Sortable fields of the users
+const (
+	UserSortUpdatedAt = "updated_at"
+	UserSortCreatedAt = "created_at"
+	UserSortLastName  = "last_name"
+	UserSortNickName  = "nickname"
+)
+
+// Unique fields the users are looked up by
+const (
+	UserLookupEmail    = "email"
+	UserLookupNickName = "nickname"
+)
+
+// Loadable fields of the users, as named by the API, the ID is always loaded
+var (
+	UserFields = []string{"first_name", "last_name", "country_iso_code", "nick_name", "email", "phone", "phone_verified", "locale",
+		"timezone", "date_of_birth", "avatar_url", "created_at", "updated_at", "last_seen_at", "status", "tags"}
+	// UserDetailFields are only loaded along with a single user
+	UserDetailFields = []string{"followers_count", "following_count"}
+)
+
+// Periods the signups of the user statistics are grouped by
+const (
+	UserStatsDay   = "day"
+	UserStatsWeek  = "week"
+	UserStatsMonth = "month"
+)
+
+// Types of the changes of the users streamed by the watch
+const (
+	UserChangeCreate = "create"
+	UserChangeUpdate = "update"
+	UserChangeDelete = "delete"
+)
+
+// DefaultUserOrder lists the most recently updated users first
+var DefaultUserOrder = UserOrder{Field: UserSortUpdatedAt, Desc: true}
+
 type (
 	// UserRepoCommands is an interface for persisting users
 	UserRepoCommands interface {
@@ -22,29 +61,94 @@ type (
 		DeleteUser(ctx context.Context, userID string) error
 
 		// UpdateUser updates user in database.
+		// Returns the user as it was before the update.
 		// If user does not exist, it returns domain.ErrUserNotFound.
 		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
-		UpdateUser(ctx context.Context, user *User) error
+		UpdateUser(ctx context.Context, user *User) (previous *User, err error)
+
+		// MarkPhoneVerified flags the user's phone as verified, as long as it still matches the provided phone.
+		// If user does not exist or the phone was changed meanwhile, it returns domain.ErrUserNotFound.
+		// If the phone is already verified by another user, it returns domain.ErrPhoneAlreadyInUse.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		MarkPhoneVerified(ctx context.Context, userID string, phone string) error
+
+		// UpdateAvatar replaces the user's avatar, locking the user until the transaction ends.
+		// Empty key and url remove the avatar.
+		// Returns the key of the previous avatar, empty if the user had none.
+		// If user does not exist, it returns domain.ErrUserNotFound.
+		// If an internal error occurs, it logs the error and returns domain.ErrInternal.
+		UpdateAvatar(ctx context.Context, userID string, key string, url string) (previousKey string, err error)
 	}
 
 	// UserRepoQueries is an interface for query persisted users
 	UserRepoQueries interface {
-		// GetUser fetches a single user from the database based on the userID.
+		// GetUser fetches a single user from the database based on the userID, along with their follower counts.
+		// Only the ID and the UserFields or UserDetailFields listed in fields are loaded, all of them when fields is empty.
+		// Users merged into another user only have the ID and MergedInto fields set.
 		// Returns the user object and an error if the operation fails.
 		// If the user does not exist, it returns domain.ErrUserNotFound.
 		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
-		GetUser(ctx context.Context, userID string) (*User, error)
+		GetUser(ctx context.Context, userID string, fields []string) (*User, error)
 
-		// ListUsers fetches a list of users based on the provided filters and pagination options.
+		// LookupUser fetches the user, not merged, whose UserLookupEmail or UserLookupNickName field matches the value,
+		// ignoring case, along with their follower counts. Exact matches take precedence over the ones differing in case.
+		// If no user matches, it returns domain.ErrUserNotFound.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If there's an error processing the data, it returns domain.ErrFailedToProcessData.
+		LookupUser(ctx context.Context, field string, value string) (*User, error)
+
+		// BatchGetUsers fetches the users with the IDs, in no particular order, skipping the ones that do not exist.
+		// Users merged into another user only have the ID and MergedInto fields set.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If theres an error processing the data, it returns domain.ErrFailedToProcessData.
+		BatchGetUsers(ctx context.Context, userIDs []string) ([]*User, error)
+
+		// ListUsers fetches a list of users based on the provided filters and pagination options, skipping merged users.
 		// Parameters:
-		//   cursorUserID: ID of the user to start listing from
-		//   cursorUpdatedAt: Timestamp to filter users updated after this time
+		//   order: Sort of the users, the ID breaks the ties
+		//   cursor: Position of the last user of the previous page, nil for the first page. The users after it in the order are returned
 		//   limit: Maximum number of users to return
 		//   filters: Criteria to filter users by
+		//   fields: UserFields to load along with the ID, all of them when empty. The sort field must be one of them
 		// Returns a slice of user objects and an error if the operation fails.
 		// If the query fails to execute, it returns return domain.ErrInternal.
 		// If theres an error processing the data, it returns domain.ErrFailedToProcessData.
-		ListUsers(ctx context.Context, cursorUserID string, cursorUpdatedAt *time.Time, limit int32, filters UserSearchFilters) ([]*User, error)
+		ListUsers(ctx context.Context, order UserOrder, cursor *UserCursor, limit int32, filters UserSearchFilters, fields []string) ([]*User, error)
+
+		// CountUsers counts the users matching the filters, skipping merged users.
+		// When estimate is set, the count is the planner estimate instead, cheap on large tables but approximate.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If theres an error processing the data, it returns domain.ErrFailedToProcessData.
+		CountUsers(ctx context.Context, filters UserSearchFilters, estimate bool) (int64, error)
+
+		// ExportUsers calls fn with every user matching the filters, skipping merged users, oldest first.
+		// The users are read from a single snapshot through a cursor, batchSize at a time, so they are never all in memory.
+		// It must run inside a transaction. It stops at the first error of fn and returns it.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If theres an error processing the data, it returns domain.ErrFailedToProcessData.
+		ExportUsers(ctx context.Context, filters UserSearchFilters, batchSize int, fn func(user *User) error) error
+
+		// SearchUsers fetches the users matching the full-text query, skipping merged users, the most relevant first.
+		// Parameters:
+		//   query: Terms in the web search syntax, e.g. `john "van der" -smith`
+		//   cursorRank: Rank of the last user of the previous page
+		//   cursorUserID: ID of the last user of the previous page
+		//   limit: Maximum number of users to return
+		// Returns the matching users with their rank and highlights.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If theres an error processing the data, it returns domain.ErrFailedToProcessData.
+		SearchUsers(ctx context.Context, query string, cursorRank *float32, cursorUserID string, limit int32) ([]*UserSearchResult, error)
+
+		// GetUserStats aggregates the users, skipping merged users: the totals, the counts per country, status and
+		// phone verification, and the signups per period, UserStatsDay, UserStatsWeek or UserStatsMonth, between from and to.
+		// Every period of the range is returned, in order, the ones without signups with a zero count.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		// If theres an error processing the data, it returns domain.ErrFailedToProcessData.
+		GetUserStats(ctx context.Context, period string, from time.Time, to time.Time) (*UserStats, error)
+
+		// IsPhoneVerified checks if the phone is already verified by any user.
+		// If the query fails to execute, it returns domain.ErrInternal.
+		IsPhoneVerified(ctx context.Context, phone string) (bool, error)
 	}
 
 	// User represents a User in the domain model
@@ -56,8 +160,81 @@ type (
 		Email          string
 		Password       string
 		CountryISOCode string
+		Phone          string
+		PhoneVerified  bool
+		Locale         string
+		Timezone       string
+		DateOfBirth    *time.Time
+		AvatarURL      string
 		CreatedAt      time.Time
 		UpdatedAt      time.Time
+		// follower counts are only loaded when fetching a single user
+		FollowersCount int64
+		FollowingCount int64
+		// AvatarKey is the blob store prefix of the avatar, only loaded when merging users
+		AvatarKey string
+		// MergedInto is the ID of the user a merged user was merged into, the other fields of merged users are not loaded
+		MergedInto *uuid.UUID
+		// Tags are the admin labels of the user, sorted by name
+		Tags []string
+		// LastSeenAt is the last recorded activity of the user, written at most once per configured interval
+		LastSeenAt *time.Time
+		// Status is one of UserActive, UserDormant or UserDeactivated
+		Status string
+	}
+
+	// UserOrder represents the sort of a list of users by one of the UserSortFields
+	UserOrder struct {
+		Field string
+		Desc  bool
+	}
+
+	// UserCursor represents the position of a user in a sorted list: the value of the sort field and the user ID
+	UserCursor struct {
+		// Value is the sort field of the user, timestamps in the RFC 3339 format
+		Value  string
+		UserID string
+	}
+
+	// UserStats represents the aggregates of the users, merged users are not counted
+	UserStats struct {
+		Total int64
+		// ByCountry counts the users per country ISO code
+		ByCountry map[string]int64
+		// ByStatus counts the users per status, UserActive, UserDormant or UserDeactivated
+		ByStatus        map[string]int64
+		PhoneVerified   int64
+		PhoneUnverified int64
+		// Signups counts the users created per period of the requested range
+		Signups []UserSignups
+		// ComputedAt is when the aggregates were computed, they are older than the request when cached
+		ComputedAt time.Time
+	}
+
+	// UserSignups represents the number of users created in the period starting at PeriodStart, in UTC
+	UserSignups struct {
+		PeriodStart time.Time
+		Count       int64
+	}
+
+	// UserChange represents a change of a user read from the outbox, or a heartbeat when Type is empty
+	UserChange struct {
+		// Type is UserChangeCreate, UserChangeUpdate or UserChangeDelete
+		Type   string
+		UserID string
+		// Payload is the JSON payload of the event published for the change
+		Payload   []byte
+		CreatedAt time.Time
+		// ResumeToken resumes the watch right after the change
+		ResumeToken string
+	}
+
+	// UserSearchResult represents a user matching a full-text search
+	UserSearchResult struct {
+		User *User
+		Rank float32
+		// Highlights are the matching fields, keyed by field name, with the matched terms wrapped in <mark></mark>
+		Highlights map[string]string
 	}
 
 	// UserSearchFilters represents User's searchable fields
@@ -67,5 +244,20 @@ type (
 		NickName       *string
 		Email          *string
 		CountryISOCode *string
+		Locale         *string
+		Timezone       *string
+		BornAfter      *time.Time
+		BornBefore     *time.Time
+		// Tags matches users with any of the tags, or with all of them if MatchAllTags is set
+		Tags         []string
+		MatchAllTags bool
+		// FuzzyThreshold, when positive, matches the names, nickname and email by trigram similarity above it,
+		// tolerating typos, instead of by substring
+		FuzzyThreshold float64
+		// SeenAfter and SeenBefore match the last activity, users never seen are excluded
+		SeenAfter  *time.Time
+		SeenBefore *time.Time
+		// Expr is a filter expression over the fields of the users, combined with the other filters
+		Expr *FilterExpr
 	}
 )
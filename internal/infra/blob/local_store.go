@@ -0,0 +1,86 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"users/internal/domain"
+	log "users/pkg/logger"
+)
+
+type localStore struct {
+	dir     string
+	baseURL string
+	l       log.Interface
+}
+
+// NewLocalStore creates a new instance of localStore that satisfies the domain.BlobStore interface.
+// Objects are written under dir and their URLs are built by appending the key to baseURL.
+func NewLocalStore(dir string, baseURL string, logger log.Interface) domain.BlobStore {
+	return &localStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/"), l: logger}
+}
+
+// Put writes the data to a file named after the key.
+// The file is written to a temporary file first and renamed, so readers never see partial objects.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (s localStore) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	name, err := s.path(key)
+	if err != nil {
+		s.l.Error(fmt.Errorf("invalid blob key %s: %w", key, err))
+		return "", domain.ErrInternal
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		s.l.Error(fmt.Errorf("failed to create blob directory: %w", err))
+		return "", domain.ErrInternal
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		s.l.Error(fmt.Errorf("failed to create blob file: %w", err))
+		return "", domain.ErrInternal
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		s.l.Error(fmt.Errorf("failed to write blob %s: %w", key, err))
+		return "", domain.ErrInternal
+	}
+	if err := tmp.Close(); err != nil {
+		s.l.Error(fmt.Errorf("failed to write blob %s: %w", key, err))
+		return "", domain.ErrInternal
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		s.l.Error(fmt.Errorf("failed to write blob %s: %w", key, err))
+		return "", domain.ErrInternal
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		s.l.Error(fmt.Errorf("failed to write blob %s: %w", key, err))
+		return "", domain.ErrInternal
+	}
+	return s.baseURL + "/" + path.Clean(key), nil
+}
+
+// DeletePrefix removes the directory named after the prefix.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (s localStore) DeletePrefix(ctx context.Context, prefix string) error {
+	name, err := s.path(prefix)
+	if err != nil {
+		s.l.Error(fmt.Errorf("invalid blob prefix %s: %w", prefix, err))
+		return domain.ErrInternal
+	}
+	if err := os.RemoveAll(name); err != nil {
+		s.l.Error(fmt.Errorf("failed to delete blobs %s: %w", prefix, err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// path maps a key to a file inside the store directory, rejecting keys that would escape it
+func (s localStore) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("key must be a non-empty relative path")
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
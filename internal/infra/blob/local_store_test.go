@@ -0,0 +1,87 @@
+package blob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLocalStore_Put(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	dir := t.TempDir()
+	s := NewLocalStore(dir, "http://localhost:8080/avatars/", mockedLogger)
+
+	tests := []struct {
+		name          string
+		key           string
+		expectedMocks func()
+		want          string
+		wantErr       error
+	}{
+		{
+			name: "success",
+			key:  "user/v1/64.jpg",
+			want: "http://localhost:8080/avatars/user/v1/64.jpg",
+		},
+		{
+			name: "key escaping the directory",
+			key:  "../64.jpg",
+			expectedMocks: func() {
+				mockedLogger.On("Error", mock.Anything).Return().Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+		{
+			name: "absolute key",
+			key:  "/etc/64.jpg",
+			expectedMocks: func() {
+				mockedLogger.On("Error", mock.Anything).Return().Once()
+			},
+			wantErr: domain.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			got, err := s.Put(context.Background(), tt.key, "image/jpeg", []byte("data"))
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(tt.key)))
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("data"), data)
+		})
+	}
+}
+
+func TestLocalStore_DeletePrefix(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	dir := t.TempDir()
+	s := NewLocalStore(dir, "/avatars", mockedLogger)
+
+	_, err := s.Put(context.Background(), "user/v1/64.jpg", "image/jpeg", []byte("data"))
+	assert.NoError(t, err)
+	_, err = s.Put(context.Background(), "user/v2/64.jpg", "image/jpeg", []byte("data"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.DeletePrefix(context.Background(), "user/v1"))
+	_, err = os.Stat(filepath.Join(dir, "user", "v1"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "user", "v2", "64.jpg"))
+	assert.NoError(t, err)
+
+	// deleting a missing prefix is not an error
+	assert.NoError(t, s.DeletePrefix(context.Background(), "user/v1"))
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+	"users/pkg/logger"
+)
+
+// Job is a background task run periodically by the Scheduler
+type Job func(ctx context.Context) error
+
+type Scheduler interface {
+	// Start runs the job at each interval until the scheduler is stopped or the context is canceled.
+	// Runs never overlap, a run that takes longer than the interval delays the next one.
+	Start(ctx context.Context, interval time.Duration)
+	// GracefulStop stops the scheduler, waiting for the current run to finish
+	GracefulStop()
+}
+
+type scheduler struct {
+	l        logger.Interface
+	name     string
+	job      Job
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+func NewScheduler(logger logger.Interface, name string, job Job) Scheduler {
+	return &scheduler{
+		l:        logger,
+		name:     name,
+		job:      job,
+		stopChan: make(chan struct{}),
+	}
+}
+
+func (s *scheduler) Start(ctx context.Context, interval time.Duration) {
+	s.wg.Add(1)
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.job(ctx); err != nil {
+				s.l.Error("Jobs-Scheduler %s: %v", s.name, err)
+			}
+		case <-s.stopChan:
+			s.l.Info("Jobs-Scheduler %s: Stop processing", s.name)
+			return
+		case <-ctx.Done():
+			s.l.Info("Jobs-Scheduler %s: Stop processing (context canceled)", s.name)
+			return
+		}
+	}
+}
+
+func (s *scheduler) GracefulStop() {
+	s.once.Do(func() { close(s.stopChan) })
+	s.wg.Wait()
+}
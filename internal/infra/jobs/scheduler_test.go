@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScheduler(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	mockedLogger.On("Error", mock.Anything, "purge", mock.Anything).Maybe()
+	mockedLogger.On("Info", mock.Anything, "purge").Once()
+
+	var runs atomic.Int32
+	s := NewScheduler(mockedLogger, "purge", func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("failed")
+	})
+	done := make(chan struct{})
+	go func() {
+		s.Start(context.Background(), time.Millisecond)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+	s.GracefulStop()
+	<-done
+	s.GracefulStop()
+}
+
+func TestScheduler_ContextCanceled(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	mockedLogger.On("Info", mock.Anything, "purge").Once()
+
+	s := NewScheduler(mockedLogger, "purge", func(ctx context.Context) error { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Start(ctx, time.Hour)
+}
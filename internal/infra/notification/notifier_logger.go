@@ -17,6 +17,6 @@ func NewLoggerNotifierService(logger log.Interface) domain.NotificationService {
 
 // Publish uses the configured logger Interface to publish the notification.
 func (n *loggerNotifier) Publish(_ context.Context, event *domain.Event) error {
-	n.l.Info("Published: Type: %s | Payload: %s", event.Type, string(event.Payload))
+	n.l.Info("Published: Tenant: %s | Type: %s | Payload: %s", event.TenantID, event.Type, string(redactPayload(event.Payload)))
 	return nil
 }
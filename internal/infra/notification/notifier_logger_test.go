@@ -40,12 +40,31 @@ func TestNotificationService_Publish(t *testing.T) {
 			},
 			args: args{
 				event: domain.Event{
-					Type:    "as",
-					Payload: eventPayload,
+					TenantID: "00000000-0000-0000-0000-000000000001",
+					Type:     "as",
+					Payload:  eventPayload,
 				},
 			},
 			expectedMocks: func(i *loggerMocks.Interface) {
-				i.On("Info", "Published: Type: %s | Payload: %s", "as", "{\"id\":\"abc\"}").Return().Once()
+				i.On("Info", "Published: Tenant: %s | Type: %s | Payload: %s", "00000000-0000-0000-0000-000000000001", "as", "{\"id\":\"abc\"}").Return().Once()
+			},
+			wantErr: nil,
+		},
+		{
+			name: "secrets are redacted",
+			fields: fields{
+				l: mockedLogger,
+			},
+			args: args{
+				event: domain.Event{
+					TenantID: "00000000-0000-0000-0000-000000000001",
+					Type:     "PhoneVerificationRequested",
+					Payload:  []byte(`{"user_id":"abc","code":"123456"}`),
+				},
+			},
+			expectedMocks: func(i *loggerMocks.Interface) {
+				i.On("Info", "Published: Tenant: %s | Type: %s | Payload: %s", "00000000-0000-0000-0000-000000000001", "PhoneVerificationRequested",
+					`{"code":"[REDACTED]","user_id":"abc"}`).Return().Once()
 			},
 			wantErr: nil,
 		},
@@ -15,12 +15,14 @@ var attributes = map[string]string{
 }
 
 type pubsubEvent struct {
-	Type    string          `json:"type"`
-	Payload json.RawMessage `json:"payload"`
+	TenantID string          `json:"tenant_id"`
+	Type     string          `json:"type"`
+	Payload  json.RawMessage `json:"payload"`
 }
 
 type topics struct {
-	usersTopic pubsub.Topic
+	usersTopic  pubsub.Topic
+	groupsTopic pubsub.Topic
 }
 
 type gcpPubSubNotifier struct {
@@ -30,16 +32,22 @@ type gcpPubSubNotifier struct {
 }
 
 // NewPubSubNotifierService implements NotificationService using gcp pubsub
-func NewPubSubNotifierService(pubsubClient pubsub.Interface, logger log.Interface, usersTopic string) domain.NotificationService {
+func NewPubSubNotifierService(pubsubClient pubsub.Interface, logger log.Interface, usersTopic string, groupsTopic string) domain.NotificationService {
 	return &gcpPubSubNotifier{logger, pubsubClient, &topics{
-		usersTopic: pubsubClient.Topic(usersTopic),
+		usersTopic:  pubsubClient.Topic(usersTopic),
+		groupsTopic: pubsubClient.Topic(groupsTopic),
 	}}
 }
 
 func (n *gcpPubSubNotifier) getTopic(event_type string) (pubsub.Topic, error) {
 	switch event_type {
-	case "CreateUser", "UpdateUser", "DeleteUser":
+	case "CreateUser", "UpdateUser", "DeleteUser", "PhoneVerificationRequested", "AvatarUpdated", "AvatarDeleted", "PreferencesUpdated",
+		"UserFollowed", "UserUnfollowed", "UserBlocked", "UserUnblocked",
+		"InvitationCreated", "InvitationAccepted", "InvitationRevoked", "UserMerged", "UserTagged", "UserUntagged",
+		"UserDormant", "UserReactivated":
 		return n.topics.usersTopic, nil
+	case "GroupCreated", "GroupDeleted", "GroupMemberAdded", "GroupMemberRemoved":
+		return n.topics.groupsTopic, nil
 	default:
 		return nil, fmt.Errorf("unknown type: %s", event_type)
 	}
@@ -54,16 +62,23 @@ func (n *gcpPubSubNotifier) Publish(ctx context.Context, event *domain.Event) er
 	}
 
 	jsonEvent, err := json.Marshal(pubsubEvent{
-		Type:    event.Type,
-		Payload: json.RawMessage(event.Payload),
+		TenantID: event.TenantID,
+		Type:     event.Type,
+		Payload:  json.RawMessage(event.Payload),
 	})
 	if err != nil {
 		n.l.Error("PubSubNotifier Failed to marshall event: %s", err.Error())
 		return domain.ErrFailedToProcessData
 	}
+	msgAttributes := make(map[string]string, len(attributes)+1)
+	for k, v := range attributes {
+		msgAttributes[k] = v
+	}
+	// subscribers can filter the events of a single tenant
+	msgAttributes["tenant_id"] = event.TenantID
 	msg := &pubsub.Message{
 		Data:       jsonEvent,
-		Attributes: attributes,
+		Attributes: msgAttributes,
 	}
 	result := topic.Publish(ctx, msg)
 
@@ -72,6 +87,6 @@ func (n *gcpPubSubNotifier) Publish(ctx context.Context, event *domain.Event) er
 		n.l.Debug("PubSubNotifier Failed to publish message: %s", err.Error())
 		return domain.ErrNotificationNotSent
 	}
-	n.l.Debug("PubSubNotifier Published: %v", string(jsonEvent))
+	n.l.Debug("PubSubNotifier Published: Tenant: %s | Type: %s | Payload: %s", event.TenantID, event.Type, string(redactPayload(event.Payload)))
 	return nil
 }
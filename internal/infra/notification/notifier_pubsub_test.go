@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	pubsub "users/pkg/pubsub"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTopic struct {
+	id string
+}
+
+func (t *fakeTopic) Publish(_ context.Context, _ *pubsub.Message) *pubsub.PublishResult {
+	return nil
+}
+
+func TestPubSubNotifier_getTopic(t *testing.T) {
+	usersTopic, groupsTopic := &fakeTopic{"users"}, &fakeTopic{"groups"}
+	n := &gcpPubSubNotifier{topics: &topics{usersTopic: usersTopic, groupsTopic: groupsTopic}}
+
+	tests := []struct {
+		eventType string
+		want      pubsub.Topic
+		wantErr   string
+	}{
+		{eventType: "CreateUser", want: usersTopic},
+		{eventType: "UpdateUser", want: usersTopic},
+		{eventType: "DeleteUser", want: usersTopic},
+		{eventType: "PhoneVerificationRequested", want: usersTopic},
+		{eventType: "AvatarUpdated", want: usersTopic},
+		{eventType: "AvatarDeleted", want: usersTopic},
+		{eventType: "PreferencesUpdated", want: usersTopic},
+		{eventType: "UserFollowed", want: usersTopic},
+		{eventType: "UserUnfollowed", want: usersTopic},
+		{eventType: "UserBlocked", want: usersTopic},
+		{eventType: "UserUnblocked", want: usersTopic},
+		{eventType: "InvitationCreated", want: usersTopic},
+		{eventType: "InvitationAccepted", want: usersTopic},
+		{eventType: "InvitationRevoked", want: usersTopic},
+		{eventType: "UserMerged", want: usersTopic},
+		{eventType: "UserTagged", want: usersTopic},
+		{eventType: "UserUntagged", want: usersTopic},
+		{eventType: "UserDormant", want: usersTopic},
+		{eventType: "UserReactivated", want: usersTopic},
+		{eventType: "GroupCreated", want: groupsTopic},
+		{eventType: "GroupDeleted", want: groupsTopic},
+		{eventType: "GroupMemberAdded", want: groupsTopic},
+		{eventType: "GroupMemberRemoved", want: groupsTopic},
+		{eventType: "Unknown", wantErr: "unknown type: Unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			got, err := n.getTopic(tt.eventType)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Same(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package notification
+
+import (
+	"encoding/json"
+	"users/internal/domain"
+)
+
+const _redacted = "[REDACTED]"
+
+// redactPayload replaces the values of the domain.SecretPayloadFields of the payload, so that it can be logged.
+// Payloads without secrets are returned as they are.
+func redactPayload(payload []byte) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return payload
+	}
+	redacted := false
+	for _, field := range domain.SecretPayloadFields {
+		if _, ok := fields[field]; ok {
+			fields[field] = json.RawMessage(`"` + _redacted + `"`)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return payload
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return []byte(_redacted)
+	}
+	return out
+}
@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_redactPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{
+			name:    "phone verification code",
+			payload: `{"user_id":"abc","phone":"+351912345678","code":"123456","expires_at":"2024-01-01T00:00:00Z"}`,
+			want:    `{"code":"[REDACTED]","expires_at":"2024-01-01T00:00:00Z","phone":"+351912345678","user_id":"abc"}`,
+		},
+		{
+			name:    "invitation token",
+			payload: `{"invitation_id":"abc","email":"a@b.c","token":"s3cr3t"}`,
+			want:    `{"email":"a@b.c","invitation_id":"abc","token":"[REDACTED]"}`,
+		},
+		{name: "without secrets", payload: `{"id":"abc", "code_name":"x"}`, want: `{"id":"abc", "code_name":"x"}`},
+		{name: "not an object", payload: `["code"]`, want: `["code"]`},
+		{name: "empty", payload: ``, want: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(redactPayload([]byte(tt.payload))))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+	"users/internal/domain"
+	"users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+// _listenChannel is notified with the tenant of the events committed to the outbox
+const _listenChannel = "outbox_committed"
+
+type OutboxListener interface {
+	domain.OutboxListener
+	// Start listens to the commits of the outbox until the listener is stopped or the context is canceled,
+	// reconnecting after the retry delay when the connection fails.
+	// Every subscriber is woken up when the connection fails, since notifications may be missed until it reconnects.
+	Start(ctx context.Context)
+	// GracefulStop stops listening, closing the connection and the channels of the subscribers
+	GracefulStop()
+}
+
+// outboxListener shares a single listening connection between the subscribers
+type outboxListener struct {
+	l           logger.Interface
+	pg          postgresql.Interface
+	retryDelay  time.Duration
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+	stopped     bool
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+	once        sync.Once
+}
+
+func NewListener(logger logger.Interface, pg postgresql.Interface, retryDelay time.Duration) OutboxListener {
+	return &outboxListener{
+		l:           logger,
+		pg:          pg,
+		retryDelay:  retryDelay,
+		subscribers: map[string]map[chan struct{}]struct{}{},
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Subscribe returns a channel woken up after events of the tenant are committed.
+// The channel holds a single pending signal, so the subscriber is never blocking the listener.
+// It is closed once the listener is stopped.
+func (ls *outboxListener) Subscribe(tenantID string) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if ls.stopped {
+		close(wake)
+		return wake, func() {}
+	}
+	if ls.subscribers[tenantID] == nil {
+		ls.subscribers[tenantID] = map[chan struct{}]struct{}{}
+	}
+	ls.subscribers[tenantID][wake] = struct{}{}
+
+	var once sync.Once
+	return wake, func() {
+		once.Do(func() {
+			ls.mu.Lock()
+			defer ls.mu.Unlock()
+			delete(ls.subscribers[tenantID], wake)
+			if len(ls.subscribers[tenantID]) == 0 {
+				delete(ls.subscribers, tenantID)
+			}
+		})
+	}
+}
+
+// wake signals the subscribers of the tenant, or every subscriber when all is set
+func (ls *outboxListener) wake(tenantID string, all bool) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	for tenant, subscribers := range ls.subscribers {
+		if !all && tenant != tenantID {
+			continue
+		}
+		for wake := range subscribers {
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (ls *outboxListener) Start(ctx context.Context) {
+	ls.wg.Add(1)
+	defer ls.wg.Done()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-ls.stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		err := ls.pg.Listen(ctx, _listenChannel, func(tenantID string) {
+			ls.wake(tenantID, false)
+		})
+		ls.wake("", true)
+		if ctx.Err() != nil {
+			ls.l.Info("Outbox-Listener: Stop listening")
+			return
+		}
+		ls.l.Error("Outbox-Listener: %v", err)
+		select {
+		case <-time.After(ls.retryDelay):
+		case <-ctx.Done():
+			ls.l.Info("Outbox-Listener: Stop listening")
+			return
+		}
+	}
+}
+
+func (ls *outboxListener) GracefulStop() {
+	ls.once.Do(func() {
+		close(ls.stopChan)
+		ls.mu.Lock()
+		defer ls.mu.Unlock()
+		ls.stopped = true
+		for _, subscribers := range ls.subscribers {
+			for wake := range subscribers {
+				close(wake)
+			}
+		}
+		ls.subscribers = map[string]map[chan struct{}]struct{}{}
+	})
+	ls.wg.Wait()
+}
@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	loggerMocks "users/gen/mocks/users/pkg/logger"
+	pgMocks "users/gen/mocks/users/pkg/postgresql"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// woken tells whether the subscription has a pending signal, consuming it
+func woken(wake <-chan struct{}) bool {
+	select {
+	case <-wake:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestOutboxListener(t *testing.T) {
+	mockedLogger := loggerMocks.NewInterface(t)
+	mockedLogger.On("Error", mock.Anything, mock.Anything).Once()
+	mockedLogger.On("Info", mock.Anything).Once()
+	mockedPG := pgMocks.NewInterface(t)
+
+	notified, failing := make(chan struct{}), make(chan struct{})
+	mockedPG.On("Listen", mock.Anything, "outbox_committed", mock.Anything).Return(func(ctx context.Context, _ string, fn func(string)) error {
+		fn("tenant-a")
+		close(notified)
+		<-failing
+		return errors.New("connection lost")
+	}).Once()
+	mockedPG.On("Listen", mock.Anything, "outbox_committed", mock.Anything).Return(func(ctx context.Context, _ string, fn func(string)) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).Once()
+
+	ls := NewListener(mockedLogger, mockedPG, time.Millisecond)
+	wakeA, unsubscribeA := ls.Subscribe("tenant-a")
+	wakeB, unsubscribeB := ls.Subscribe("tenant-b")
+	done := make(chan struct{})
+	go func() {
+		ls.Start(context.Background())
+		close(done)
+	}()
+
+	// only the subscribers of the tenant are woken up
+	<-notified
+	assert.True(t, woken(wakeA))
+	assert.False(t, woken(wakeB))
+
+	// a failure wakes up every subscriber, notifications may be missed until the listener reconnects
+	close(failing)
+	assert.Eventually(t, func() bool { return woken(wakeB) }, time.Second, time.Millisecond)
+	assert.True(t, woken(wakeA))
+
+	unsubscribeA()
+	unsubscribeA()
+	assert.Len(t, ls.(*outboxListener).subscribers, 1)
+
+	// stopping closes the subscriptions, so that the watchers end
+	ls.GracefulStop()
+	<-done
+	ls.GracefulStop()
+	_, open := <-wakeB
+	assert.False(t, open)
+	unsubscribeB()
+	wakeC, _ := ls.Subscribe("tenant-a")
+	_, open = <-wakeC
+	assert.False(t, open)
+	assert.Empty(t, ls.(*outboxListener).subscribers)
+}
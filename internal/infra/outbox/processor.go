@@ -45,7 +45,8 @@ func NewProcessor(logger logger.Interface, txHandler domain.Transaction, repo do
 }
 
 func (p *outboxProcessor) ProcessOnce(ctx context.Context, limit int32) error {
-	err := p.txhandler.BeginTx(ctx, func(txCtx context.Context) error {
+	// the outbox is drained for every tenant at once
+	err := p.txhandler.BeginTx(domain.WithAllTenants(ctx), func(txCtx context.Context) error {
 		outboxEvents, err := p.repo.GetUnprocessed(txCtx, limit)
 		if err != nil {
 			return fmt.Errorf("failed to get events: %w", err)
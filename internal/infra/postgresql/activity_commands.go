@@ -0,0 +1,81 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type activityCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewActivityCommandsRepo creates a new instance of activityCommandsRepo that satisfies the domain.ActivityRepoCommands interface
+func NewActivityCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.ActivityRepoCommands {
+	return &activityCommandsRepo{pg: pg, l: logger}
+}
+
+func (r activityCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// TouchUser sets the last time the user was seen, unless it was already set after notBefore
+// Dormant users become active again, unknown users and the users of other tenants are ignored.
+// The tenant is checked explicitly on top of the row-level security, which connections as the table owner may bypass.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r activityCommandsRepo) TouchUser(ctx context.Context, userID string, seenAt time.Time, notBefore time.Time) (reactivated bool, err error) {
+	query := `UPDATE users u SET last_seen_at = $2, status = CASE WHEN prev.status = 'dormant' THEN 'active' ELSE prev.status END
+		FROM (SELECT id, status FROM users WHERE id = $1 AND tenant_id = NULLIF($4, '')::uuid AND merged_into IS NULL FOR UPDATE) prev
+		WHERE u.id = prev.id AND (u.last_seen_at IS NULL OR u.last_seen_at < $3)
+		RETURNING prev.status = 'dormant'`
+	tenantID, _ := domain.TenantFromContext(ctx)
+	if err := r.db(ctx).QueryRow(ctx, query, userID, seenAt, notBefore, tenantID).Scan(&reactivated); err != nil {
+		if err == postgresql.ErrNoRows {
+			// recently seen, unknown or of another tenant
+			return false, nil
+		}
+		r.l.Error(fmt.Errorf("failed to touch user: %w", err))
+		return false, domain.ErrInternal
+	}
+	return reactivated, nil
+}
+
+// FlagInactiveUsers sets the status of up to limit active users not seen since inactiveSince, skipping locked users
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r activityCommandsRepo) FlagInactiveUsers(ctx context.Context, inactiveSince time.Time, status string, limit int32) ([]*domain.InactiveUser, error) {
+	query := `UPDATE users u SET status = $2
+		FROM (SELECT id FROM users
+			WHERE status = 'active' AND merged_into IS NULL AND COALESCE(last_seen_at, created_at) < $1
+			ORDER BY COALESCE(last_seen_at, created_at) LIMIT $3 FOR UPDATE SKIP LOCKED) inactive
+		WHERE u.id = inactive.id
+		RETURNING u.id, u.tenant_id::text, u.last_seen_at`
+	rows, err := r.db(ctx).Query(ctx, query, inactiveSince, status, limit)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to flag inactive users: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var users []*domain.InactiveUser
+	for rows.Next() {
+		var user domain.InactiveUser
+		if err := rows.Scan(&user.ID, &user.TenantID, &user.LastSeenAt); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrInternal
+		}
+		users = append(users, &user)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrInternal
+	}
+	return users, nil
+}
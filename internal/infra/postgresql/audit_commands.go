@@ -0,0 +1,57 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type auditCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewAuditCommandsRepo creates a new instance of auditCommandsRepo that satisfies the domain.AuditRepoCommands interface
+func NewAuditCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.AuditRepoCommands {
+	return &auditCommandsRepo{pg: pg, l: logger}
+}
+
+func (r auditCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// AddAuditEvent creates a new audit event in the database
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r auditCommandsRepo) AddAuditEvent(ctx context.Context, event *domain.AuditEvent) error {
+	diff := event.Diff
+	if diff == nil {
+		diff = map[string]domain.FieldChange{}
+	}
+	data, err := json.Marshal(diff)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to encode audit diff: %w", err))
+		return domain.ErrInternal
+	}
+	query := `INSERT INTO audit_events (actor, method, target_user_id, request_id, source_ip, outcome, error, diff)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	if _, err := r.db(ctx).Exec(ctx, query,
+		event.Actor,
+		event.Method,
+		event.TargetUserID,
+		event.RequestID,
+		event.SourceIP,
+		event.Outcome,
+		event.Error,
+		data); err != nil {
+		r.l.Error(fmt.Errorf("failed to insert audit event: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
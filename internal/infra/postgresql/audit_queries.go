@@ -0,0 +1,100 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type auditQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewAuditQueriesRepo creates a new instance of auditQueriesRepo that satisfies the domain.AuditRepoQueries interface
+func NewAuditQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.AuditRepoQueries {
+	return &auditQueriesRepo{pg: pg, l: logger}
+}
+
+func (r auditQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// ListAuditEvents fetches the audit events from the database based on the provided cursor data, the most recent first
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r auditQueriesRepo) ListAuditEvents(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, filters domain.AuditEventFilters) ([]*domain.AuditEvent, error) {
+	whereClauses := []string{"TRUE"}
+	var args []any
+
+	// pagination
+	if cursorID != "" && cursorCreatedAt != nil {
+		whereClauses = append(whereClauses, `(created_at < $1 OR (created_at = $1 AND id < $2))`)
+		args = append(args, *cursorCreatedAt, cursorID)
+	}
+
+	// filters
+	if filters.Actor != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("actor = $%d", len(args)+1))
+		args = append(args, *filters.Actor)
+	}
+	if filters.TargetUserID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("target_user_id = $%d", len(args)+1))
+		args = append(args, *filters.TargetUserID)
+	}
+	if filters.Method != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("method = $%d", len(args)+1))
+		args = append(args, *filters.Method)
+	}
+	if filters.After != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("created_at >= $%d", len(args)+1))
+		args = append(args, *filters.After)
+	}
+	if filters.Before != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("created_at < $%d", len(args)+1))
+		args = append(args, *filters.Before)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, actor, method, target_user_id, request_id, source_ip, outcome, error, diff, created_at
+		FROM audit_events
+		WHERE %s
+		ORDER BY created_at DESC, id DESC LIMIT $%d`, strings.Join(whereClauses, " AND "), len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to list audit events: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		var event domain.AuditEvent
+		var diff []byte
+		if err := rows.Scan(&event.ID, &event.Actor, &event.Method, &event.TargetUserID, &event.RequestID, &event.SourceIP, &event.Outcome, &event.Error, &diff, &event.CreatedAt); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		if err := json.Unmarshal(diff, &event.Diff); err != nil {
+			r.l.Error(fmt.Errorf("failed to decode audit diff: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return events, nil
+}
@@ -0,0 +1,128 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type groupCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewGroupCommandsRepo creates a new instance of groupCommandsRepo that satisfies the domain.GroupRepoCommands interface
+func NewGroupCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.GroupRepoCommands {
+	return &groupCommandsRepo{pg: pg, l: logger}
+}
+
+func (r groupCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// SaveGroup creates a new group in the database.
+// If a group with the same name already exists, it returns domain.ErrGroupAlreadyExists
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r groupCommandsRepo) SaveGroup(ctx context.Context, group *domain.Group) (id string, err error) {
+	query := `INSERT INTO groups (name, description) VALUES ($1, $2) RETURNING id`
+	if err = r.db(ctx).QueryRow(ctx, query, group.Name, group.Description).Scan(&id); err != nil {
+		if postgresql.IsConflictErr(err) {
+			r.l.Debug(fmt.Errorf("group %s already exists: %w", group.Name, err))
+			return "", domain.ErrGroupAlreadyExists
+		}
+		r.l.Error(fmt.Errorf("failed to save group: %w", err))
+		return "", domain.ErrInternal
+	}
+	return id, nil
+}
+
+// DeleteGroup deletes a group, its memberships are deleted in cascade.
+// If the group does not exist, it returns domain.ErrGroupNotFound
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r groupCommandsRepo) DeleteGroup(ctx context.Context, groupID string) error {
+	commandTag, err := r.db(ctx).Exec(ctx, `DELETE FROM groups WHERE id=$1`, groupID)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to delete group: %w", err))
+		return domain.ErrInternal
+	}
+	if commandTag.RowsAffected() == 0 {
+		r.l.Debug("group with ID %s does not exist", groupID)
+		return domain.ErrGroupNotFound
+	}
+	return nil
+}
+
+// AddMember adds the user to the group in a single statement.
+// The group and the user are read through the row-level security policies, since foreign key checks bypass them.
+// If the group does not exist, it returns domain.ErrGroupNotFound
+// If the user does not exist, it returns domain.ErrUserNotFound
+// If the user is already a member, it returns domain.ErrGroupMemberAlreadyExists
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r groupCommandsRepo) AddMember(ctx context.Context, groupID string, userID string) error {
+	query := `WITH g AS (SELECT id FROM groups WHERE id = $1),
+		u AS (SELECT id FROM users WHERE id = $2 AND merged_into IS NULL),
+		ins AS (INSERT INTO group_members (group_id, user_id) SELECT g.id, u.id FROM g, u ON CONFLICT DO NOTHING RETURNING 1)
+		SELECT EXISTS (SELECT 1 FROM g), EXISTS (SELECT 1 FROM u), EXISTS (SELECT 1 FROM ins)`
+	var groupFound, userFound, added bool
+	if err := r.db(ctx).QueryRow(ctx, query, groupID, userID).Scan(&groupFound, &userFound, &added); err != nil {
+		r.l.Error(fmt.Errorf("failed to add group member: %w", err))
+		return domain.ErrInternal
+	}
+	switch {
+	case !groupFound:
+		return domain.ErrGroupNotFound
+	case !userFound:
+		return domain.ErrUserNotFound
+	case !added:
+		return domain.ErrGroupMemberAlreadyExists
+	}
+	return nil
+}
+
+// RemoveMember removes the user from the group.
+// If the user is not a member of the group, it returns domain.ErrGroupMemberNotFound
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r groupCommandsRepo) RemoveMember(ctx context.Context, groupID string, userID string) error {
+	commandTag, err := r.db(ctx).Exec(ctx, `DELETE FROM group_members WHERE group_id=$1 AND user_id=$2`, groupID, userID)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to remove group member: %w", err))
+		return domain.ErrInternal
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrGroupMemberNotFound
+	}
+	return nil
+}
+
+// RemoveUserMemberships removes the user from every group.
+// Returns the IDs of the groups the user was removed from.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r groupCommandsRepo) RemoveUserMemberships(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db(ctx).Query(ctx, `DELETE FROM group_members WHERE user_id=$1 RETURNING group_id::text`, userID)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to remove user memberships: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var groupIDs []string
+	for rows.Next() {
+		var groupID string
+		if err := rows.Scan(&groupID); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrInternal
+		}
+		groupIDs = append(groupIDs, groupID)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrInternal
+	}
+	return groupIDs, nil
+}
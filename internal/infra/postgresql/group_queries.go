@@ -0,0 +1,134 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type groupQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewGroupQueriesRepo creates a new instance of groupQueriesRepo that satisfies the domain.GroupRepoQueries interface
+func NewGroupQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.GroupRepoQueries {
+	return &groupQueriesRepo{pg: pg, l: logger}
+}
+
+func (r groupQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// GetGroup fetches a single group from the database based on the groupID
+// If the group does not exist, it returns domain.ErrGroupNotFound
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r groupQueriesRepo) GetGroup(ctx context.Context, groupID string) (*domain.Group, error) {
+	query := `SELECT id, name, description, created_at FROM groups WHERE id = $1`
+	var group domain.Group
+	if err := r.db(ctx).QueryRow(ctx, query, groupID).Scan(&group.ID, &group.Name, &group.Description, &group.CreatedAt); err != nil {
+		if err == postgresql.ErrNoRows {
+			return nil, domain.ErrGroupNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return &group, nil
+}
+
+// ListGroupMembers fetches the members of a group based on the provided cursor data, the most recently added first
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r groupQueriesRepo) ListGroupMembers(ctx context.Context, groupID string, cursorUserID string, cursorAddedAt *time.Time, limit int32) ([]*domain.GroupMember, error) {
+	args := []any{groupID, limit}
+	pagination := ""
+	if cursorUserID != "" && cursorAddedAt != nil {
+		pagination = `AND (m.created_at < $3 OR (m.created_at = $3 AND m.user_id < $4))`
+		args = append(args, *cursorAddedAt, cursorUserID)
+	}
+	query := fmt.Sprintf(`
+		SELECT u.id, u.first_name, u.last_name, u.country_iso_code, u.nickname, u.email, COALESCE(u.phone, ''), u.phone_verified, COALESCE(u.locale, ''), COALESCE(u.timezone, ''), u.date_of_birth, COALESCE(u.avatar_url, ''), u.created_at, u.updated_at, m.created_at
+		FROM group_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.group_id = $1 %s
+		ORDER BY m.created_at DESC, m.user_id DESC LIMIT $2`, pagination)
+
+	rows, err := r.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to list group members: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var members []*domain.GroupMember
+	for rows.Next() {
+		var m domain.GroupMember
+		if err := rows.Scan(&m.ID, &m.FirstName, &m.LastName, &m.CountryISOCode, &m.NickName, &m.Email, &m.Phone, &m.PhoneVerified, &m.Locale, &m.Timezone, &m.DateOfBirth, &m.AvatarURL, &m.CreatedAt, &m.UpdatedAt, &m.AddedAt); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		members = append(members, &m)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return members, nil
+}
+
+// ListUserGroups fetches every group the user is a member of, ordered by name.
+// The users table is joined so a missing user can be told apart from a user without groups.
+// If the user does not exist, it returns domain.ErrUserNotFound
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r groupQueriesRepo) ListUserGroups(ctx context.Context, userID string) ([]*domain.Group, error) {
+	query := `SELECT g.id::text, g.name, g.description, g.created_at
+		FROM users u
+		LEFT JOIN group_members m ON m.user_id = u.id
+		LEFT JOIN groups g ON g.id = m.group_id
+		WHERE u.id = $1
+		ORDER BY g.name`
+	rows, err := r.db(ctx).Query(ctx, query, userID)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to execute query: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var found bool
+	groups := []*domain.Group{}
+	for rows.Next() {
+		found = true
+		var id *string
+		var name, description *string
+		var createdAt *time.Time
+		if err := rows.Scan(&id, &name, &description, &createdAt); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		if id == nil {
+			continue
+		}
+		group := &domain.Group{Name: *name, Description: *description, CreatedAt: *createdAt}
+		if err := group.ID.Scan(*id); err != nil {
+			r.l.Error(fmt.Errorf("failed to parse group id: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	if !found {
+		return nil, domain.ErrUserNotFound
+	}
+	return groups, nil
+}
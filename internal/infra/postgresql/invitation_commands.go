@@ -0,0 +1,107 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type invitationCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewInvitationCommandsRepo creates a new instance of invitationCommandsRepo that satisfies the domain.InvitationRepoCommands interface
+func NewInvitationCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.InvitationRepoCommands {
+	return &invitationCommandsRepo{pg: pg, l: logger}
+}
+
+func (r invitationCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// SaveInvitation revokes the pending invitations to the email and creates a new one.
+// It should run in a transaction, so the previous invitations are only revoked if the new one is created.
+// If a user with the same email already exists, it returns domain.ErrUserAlreadyExists
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r invitationCommandsRepo) SaveInvitation(ctx context.Context, invitation *domain.Invitation) (id string, err error) {
+	if _, err = r.db(ctx).Exec(ctx, `UPDATE invitations SET status='revoked' WHERE email=$1 AND status='pending'`, invitation.Email); err != nil {
+		r.l.Error(fmt.Errorf("failed to revoke pending invitations: %w", err))
+		return "", domain.ErrInternal
+	}
+
+	query := `INSERT INTO invitations (email, first_name, last_name, country_iso_code, roles, token_hash, expires_at)
+		SELECT $1::varchar, $2::varchar, $3::varchar, NULLIF($4::varchar, ''), $5::text[], $6::varchar, $7::timestamptz
+		WHERE NOT EXISTS (SELECT 1 FROM users WHERE email = $1::varchar AND merged_into IS NULL)
+		RETURNING id`
+	roles := invitation.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	if err = r.db(ctx).QueryRow(ctx, query,
+		invitation.Email,
+		invitation.FirstName,
+		invitation.LastName,
+		invitation.CountryISOCode,
+		roles,
+		invitation.TokenHash,
+		invitation.ExpiresAt).Scan(&id); err != nil {
+		if err == postgresql.ErrNoRows {
+			r.l.Debug("user with email %s already exists", invitation.Email)
+			return "", domain.ErrUserAlreadyExists
+		}
+		r.l.Error(fmt.Errorf("failed to save invitation: %w", err))
+		return "", domain.ErrInternal
+	}
+	return id, nil
+}
+
+// GetPendingInvitation fetches the pending invitation with the token hash and locks it until the transaction ends.
+// If there is no pending invitation with the token hash, it returns domain.ErrInvitationNotFound
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r invitationCommandsRepo) GetPendingInvitation(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	query := `SELECT id, email, first_name, last_name, COALESCE(country_iso_code, ''), roles, token_hash, status, expires_at, created_at
+		FROM invitations WHERE token_hash = $1 AND status = 'pending' FOR UPDATE`
+	var inv domain.Invitation
+	if err := r.db(ctx).QueryRow(ctx, query, tokenHash).Scan(&inv.ID, &inv.Email, &inv.FirstName, &inv.LastName, &inv.CountryISOCode,
+		&inv.Roles, &inv.TokenHash, &inv.Status, &inv.ExpiresAt, &inv.CreatedAt); err != nil {
+		if err == postgresql.ErrNoRows {
+			return nil, domain.ErrInvitationNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return &inv, nil
+}
+
+// MarkInvitationAccepted flags the invitation as accepted by the created user.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r invitationCommandsRepo) MarkInvitationAccepted(ctx context.Context, invitationID string, userID string) error {
+	query := `UPDATE invitations SET status='accepted', user_id=$2 WHERE id=$1`
+	if _, err := r.db(ctx).Exec(ctx, query, invitationID, userID); err != nil {
+		r.l.Error(fmt.Errorf("failed to mark invitation as accepted: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// RevokeInvitation flags a pending invitation as revoked.
+// If the invitation does not exist or is no longer pending, it returns domain.ErrInvitationNotFound
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r invitationCommandsRepo) RevokeInvitation(ctx context.Context, invitationID string) error {
+	commandTag, err := r.db(ctx).Exec(ctx, `UPDATE invitations SET status='revoked' WHERE id=$1 AND status='pending'`, invitationID)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to revoke invitation: %w", err))
+		return domain.ErrInternal
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
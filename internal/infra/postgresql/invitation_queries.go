@@ -0,0 +1,87 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type invitationQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewInvitationQueriesRepo creates a new instance of invitationQueriesRepo that satisfies the domain.InvitationRepoQueries interface
+func NewInvitationQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.InvitationRepoQueries {
+	return &invitationQueriesRepo{pg: pg, l: logger}
+}
+
+func (r invitationQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// ListInvitations fetches the invitations based on the provided cursor data, the most recent first.
+// Pending invitations past their expiry are reported as expired.
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r invitationQueriesRepo) ListInvitations(ctx context.Context, cursorID string, cursorCreatedAt *time.Time, limit int32, status string) ([]*domain.Invitation, error) {
+	var whereClauses []string
+	args := []any{limit}
+
+	if cursorID != "" && cursorCreatedAt != nil {
+		whereClauses = append(whereClauses, `(created_at < $2 OR (created_at = $2 AND id < $3))`)
+		args = append(args, *cursorCreatedAt, cursorID)
+	}
+	switch status {
+	case "":
+	case domain.InvitationPending:
+		whereClauses = append(whereClauses, `status = 'pending' AND expires_at > current_timestamp`)
+	case domain.InvitationExpired:
+		whereClauses = append(whereClauses, `status = 'pending' AND expires_at <= current_timestamp`)
+	default:
+		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", len(args)+1))
+		args = append(args, status)
+	}
+
+	where := ""
+	if len(whereClauses) > 0 {
+		where = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	query := fmt.Sprintf(`
+		SELECT id, email, first_name, last_name, COALESCE(country_iso_code, ''), roles,
+			CASE WHEN status = 'pending' AND expires_at <= current_timestamp THEN 'expired' ELSE status END,
+			user_id, expires_at, created_at
+		FROM invitations %s
+		ORDER BY created_at DESC, id DESC LIMIT $1`, where)
+
+	rows, err := r.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to list invitations: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var invitations []*domain.Invitation
+	for rows.Next() {
+		var inv domain.Invitation
+		if err := rows.Scan(&inv.ID, &inv.Email, &inv.FirstName, &inv.LastName, &inv.CountryISOCode, &inv.Roles,
+			&inv.Status, &inv.UserID, &inv.ExpiresAt, &inv.CreatedAt); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		invitations = append(invitations, &inv)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return invitations, nil
+}
@@ -30,7 +30,7 @@ func (r outboxCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
 // GetUnprocessed fetches the unprocessed events from the database.
 // The amount of entries in the response is capped by the limit param
 func (r outboxCommandsRepo) GetUnprocessed(ctx context.Context, limit int32) ([]*domain.Event, error) {
-	query := `SELECT id, event_type, payload 
+	query := `SELECT id, tenant_id::text, event_type, payload 
 		FROM outbox 
 		WHERE processed_at IS NULL 
 		FOR UPDATE SKIP LOCKED 
@@ -47,7 +47,7 @@ func (r outboxCommandsRepo) GetUnprocessed(ctx context.Context, limit int32) ([]
 	// https://donchev.is/post/working-with-postgresql-in-go-using-pgx/
 	for rows.Next() {
 		var event domain.Event
-		if err := rows.Scan(&event.ID, &event.Type, &event.Payload); err != nil {
+		if err := rows.Scan(&event.ID, &event.TenantID, &event.Type, &event.Payload); err != nil {
 			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
 			return nil, domain.ErrFailedToProcessData
 		}
@@ -61,10 +61,10 @@ func (r outboxCommandsRepo) GetUnprocessed(ctx context.Context, limit int32) ([]
 	return events, nil
 }
 
-// MarkAsProcessed updates outbox table.
+// MarkAsProcessed updates outbox table, the secrets of the payload are not kept once published.
 func (r outboxCommandsRepo) MarkAsProcessed(ctx context.Context, id string) error {
-	query := `UPDATE outbox SET processed_at=NOW() WHERE id=$1;`
-	_, err := r.db(ctx).Exec(ctx, query, id)
+	query := `UPDATE outbox SET processed_at=NOW(), payload = payload - $2::text[] WHERE id=$1;`
+	_, err := r.db(ctx).Exec(ctx, query, id, domain.SecretPayloadFields)
 	if err != nil {
 		r.l.Error(fmt.Errorf("failed to update outbox: %w", err))
 		return domain.ErrInternal
@@ -72,11 +72,17 @@ func (r outboxCommandsRepo) MarkAsProcessed(ctx context.Context, id string) erro
 	return err
 }
 
-// AddEvent creates a new event in the database.
+// AddEvent creates a new event in the database, owned by the tenant of the event or, when unset, of the transaction.
 // If an internal error occurs, it logs the error and returns domain.ErrInternal
 func (r outboxCommandsRepo) AddEvent(ctx context.Context, event *domain.Event) (id string, err error) {
-	query := `INSERT INTO outbox (event_type, payload) VALUES ($1, $2) RETURNING id`
-	err = r.db(ctx).QueryRow(ctx, query, event.Type, event.Payload).Scan(&id)
+	if event.TenantID != "" {
+		// jobs running across every tenant
+		query := `INSERT INTO outbox (tenant_id, event_type, payload) VALUES ($1, $2, $3) RETURNING id`
+		err = r.db(ctx).QueryRow(ctx, query, event.TenantID, event.Type, event.Payload).Scan(&id)
+	} else {
+		query := `INSERT INTO outbox (event_type, payload) VALUES ($1, $2) RETURNING id`
+		err = r.db(ctx).QueryRow(ctx, query, event.Type, event.Payload).Scan(&id)
+	}
 	if err != nil {
 		r.l.Error(fmt.Errorf("failed to create event: %w", err))
 		return id, domain.ErrInternal
@@ -0,0 +1,69 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type outboxQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewOutboxQueriesRepo creates a new instance of outboxQueriesRepo that satisfies the domain.OutboxRepoQueries interface
+func NewOutboxQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.OutboxRepoQueries {
+	return &outboxQueriesRepo{pg: pg, l: logger}
+}
+
+func (r outboxQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// GetCommittedEvents fetches the events of the types committed after the position, in commit order.
+// The commit_seq is assigned at commit time, under a lock held until the commit completes, so no event
+// numbered before the returned ones can become visible later.
+func (r outboxQueriesRepo) GetCommittedEvents(ctx context.Context, after int64, types []string, limit int32) ([]*domain.Event, error) {
+	query := `SELECT id, tenant_id::text, event_type, payload, commit_seq, created_at
+		FROM outbox
+		WHERE commit_seq > $1 AND event_type = ANY($2)
+		ORDER BY commit_seq
+		LIMIT $3`
+	rows, err := r.db(ctx).Query(ctx, query, after, types, limit)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to fetch committed outbox events: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	events := []*domain.Event{}
+	for rows.Next() {
+		var event domain.Event
+		if err := rows.Scan(&event.ID, &event.TenantID, &event.Type, &event.Payload, &event.Position, &event.CreatedAt); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return events, nil
+}
+
+// GetLastCommittedPosition returns the highest commit_seq of the outbox, 0 if no event was committed yet
+func (r outboxQueriesRepo) GetLastCommittedPosition(ctx context.Context) (int64, error) {
+	var position int64
+	if err := r.db(ctx).QueryRow(ctx, `SELECT COALESCE(MAX(commit_seq), 0) FROM outbox`).Scan(&position); err != nil {
+		r.l.Debug(fmt.Errorf("failed to fetch the last committed outbox position: %w", err))
+		return 0, domain.ErrInternal
+	}
+	return position, nil
+}
@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type phoneVerificationCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewPhoneVerificationCommandsRepo creates a new instance of phoneVerificationCommandsRepo that satisfies the domain.PhoneVerificationRepoCommands interface
+func NewPhoneVerificationCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.PhoneVerificationRepoCommands {
+	return &phoneVerificationCommandsRepo{pg: pg, l: logger}
+}
+
+func (r phoneVerificationCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// SaveVerification creates the user's pending verification, replacing any previous one.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r phoneVerificationCommandsRepo) SaveVerification(ctx context.Context, verification *domain.PhoneVerification) error {
+	query := `INSERT INTO phone_verifications (user_id, phone, code_hash, attempts, expires_at) VALUES ($1, $2, $3, 0, $4)
+		ON CONFLICT (user_id) DO UPDATE SET phone=EXCLUDED.phone, code_hash=EXCLUDED.code_hash, attempts=0, expires_at=EXCLUDED.expires_at, created_at=current_timestamp`
+	_, err := r.db(ctx).Exec(ctx, query,
+		verification.UserID,
+		verification.Phone,
+		verification.CodeHash,
+		verification.ExpiresAt)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to save phone verification: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// GetVerification fetches the user's pending verification and locks it until the transaction ends.
+// If there is no pending verification, it returns domain.ErrVerificationNotFound
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r phoneVerificationCommandsRepo) GetVerification(ctx context.Context, userID string) (*domain.PhoneVerification, error) {
+	query := `SELECT user_id, phone, code_hash, attempts, expires_at FROM phone_verifications WHERE user_id = $1 FOR UPDATE`
+	var v domain.PhoneVerification
+	if err := r.db(ctx).QueryRow(ctx, query, userID).Scan(&v.UserID, &v.Phone, &v.CodeHash, &v.Attempts, &v.ExpiresAt); err != nil {
+		if err == postgresql.ErrNoRows {
+			return nil, domain.ErrVerificationNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return &v, nil
+}
+
+// IncrementAttempts increments the failed attempts of the user's pending verification.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r phoneVerificationCommandsRepo) IncrementAttempts(ctx context.Context, userID string) error {
+	query := `UPDATE phone_verifications SET attempts=attempts+1 WHERE user_id=$1;`
+	if _, err := r.db(ctx).Exec(ctx, query, userID); err != nil {
+		r.l.Error(fmt.Errorf("failed to increment verification attempts: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// DeleteVerification deletes the user's pending verification.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r phoneVerificationCommandsRepo) DeleteVerification(ctx context.Context, userID string) error {
+	query := `DELETE FROM phone_verifications WHERE user_id=$1`
+	if _, err := r.db(ctx).Exec(ctx, query, userID); err != nil {
+		r.l.Error(fmt.Errorf("failed to delete phone verification: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
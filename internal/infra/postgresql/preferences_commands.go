@@ -0,0 +1,65 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type preferencesCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewPreferencesCommandsRepo creates a new instance of preferencesCommandsRepo that satisfies the domain.PreferencesRepoCommands interface
+func NewPreferencesCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.PreferencesRepoCommands {
+	return &preferencesCommandsRepo{pg: pg, l: logger}
+}
+
+func (r preferencesCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// SavePreferences upserts the provided preferences of a user in a single statement.
+// If user does not exist, it returns domain.ErrUserNotFound
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r preferencesCommandsRepo) SavePreferences(ctx context.Context, userID string, preferences map[string]json.RawMessage) error {
+	keys := make([]string, 0, len(preferences))
+	values := make([]string, 0, len(preferences))
+	for key, value := range preferences {
+		keys = append(keys, key)
+		values = append(values, string(value))
+	}
+	query := `INSERT INTO user_preferences (user_id, key, value) SELECT $1, p.key, p.value::jsonb FROM unnest($2::text[], $3::text[]) AS p(key, value)
+		ON CONFLICT (user_id, key) DO UPDATE SET value=EXCLUDED.value, updated_at=current_timestamp`
+	if _, err := r.db(ctx).Exec(ctx, query, userID, keys, values); err != nil {
+		if postgresql.IsForeignKeyViolationErr(err) {
+			r.l.Debug("user with ID %s does not exist", userID)
+			return domain.ErrUserNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to save preferences: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// DeletePreferences deletes the provided preferences of a user, or all of them if no keys are provided.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r preferencesCommandsRepo) DeletePreferences(ctx context.Context, userID string, keys []string) error {
+	query := `DELETE FROM user_preferences WHERE user_id=$1 AND (cardinality($2::text[]) = 0 OR key = ANY($2))`
+	if keys == nil {
+		keys = []string{}
+	}
+	if _, err := r.db(ctx).Exec(ctx, query, userID, keys); err != nil {
+		r.l.Error(fmt.Errorf("failed to delete preferences: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
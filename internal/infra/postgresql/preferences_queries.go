@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type preferencesQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewPreferencesQueriesRepo creates a new instance of preferencesQueriesRepo that satisfies the domain.PreferencesRepoQueries interface
+func NewPreferencesQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.PreferencesRepoQueries {
+	return &preferencesQueriesRepo{pg: pg, l: logger}
+}
+
+func (r preferencesQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// GetPreferences fetches the preferences stored for a user.
+// The users table is joined so a missing user can be told apart from a user without preferences.
+// If the user does not exist, it returns domain.ErrUserNotFound
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r preferencesQueriesRepo) GetPreferences(ctx context.Context, userID string) (map[string]json.RawMessage, error) {
+	query := `SELECT p.key, p.value FROM users u LEFT JOIN user_preferences p ON p.user_id = u.id WHERE u.id = $1`
+	rows, err := r.db(ctx).Query(ctx, query, userID)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to execute query: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var found bool
+	preferences := make(map[string]json.RawMessage)
+	for rows.Next() {
+		found = true
+		var key *string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		if key != nil {
+			preferences[*key] = value
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	if !found {
+		return nil, domain.ErrUserNotFound
+	}
+	return preferences, nil
+}
@@ -0,0 +1,132 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type relationshipCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewRelationshipCommandsRepo creates a new instance of relationshipCommandsRepo that satisfies the domain.RelationshipRepoCommands interface
+func NewRelationshipCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.RelationshipRepoCommands {
+	return &relationshipCommandsRepo{pg: pg, l: logger}
+}
+
+func (r relationshipCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// Follow makes the user follow the target in a single statement, once the pair of users is locked.
+// The users are read through the row-level security policies, since foreign key checks bypass them.
+// If any of the users does not exist, it returns domain.ErrUserNotFound
+// If any of the users blocked the other, it returns domain.ErrUserBlocked
+// If the user already follows the target, it returns domain.ErrAlreadyFollowing
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r relationshipCommandsRepo) Follow(ctx context.Context, userID string, targetID string) error {
+	if err := r.lockPair(ctx, userID, targetID); err != nil {
+		return err
+	}
+	query := `WITH u AS (SELECT count(*) = 2 AS found FROM users WHERE id IN ($1::uuid, $2::uuid) AND merged_into IS NULL),
+		b AS (SELECT 1 FROM user_relationships WHERE kind = 'block'
+			AND ((user_id = $1::uuid AND target_id = $2::uuid) OR (user_id = $2::uuid AND target_id = $1::uuid))),
+		ins AS (INSERT INTO user_relationships (user_id, target_id, kind)
+			SELECT $1::uuid, $2::uuid, 'follow' FROM u WHERE u.found AND NOT EXISTS (SELECT 1 FROM b)
+			ON CONFLICT DO NOTHING RETURNING 1)
+		SELECT (SELECT found FROM u), EXISTS (SELECT 1 FROM b), EXISTS (SELECT 1 FROM ins)`
+	var usersFound, blocked, followed bool
+	if err := r.db(ctx).QueryRow(ctx, query, userID, targetID).Scan(&usersFound, &blocked, &followed); err != nil {
+		r.l.Error(fmt.Errorf("failed to follow user: %w", err))
+		return domain.ErrInternal
+	}
+	switch {
+	case !usersFound:
+		return domain.ErrUserNotFound
+	case blocked:
+		return domain.ErrUserBlocked
+	case !followed:
+		return domain.ErrAlreadyFollowing
+	}
+	return nil
+}
+
+// Unfollow makes the user stop following the target.
+// If the user does not follow the target, it returns domain.ErrNotFollowing
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r relationshipCommandsRepo) Unfollow(ctx context.Context, userID string, targetID string) error {
+	return r.remove(ctx, userID, targetID, domain.RelationshipFollow, domain.ErrNotFollowing)
+}
+
+// Block makes the user block the target and removes the follows in both directions in a single statement,
+// once the pair of users is locked.
+// If any of the users does not exist, it returns domain.ErrUserNotFound
+// If the user already blocked the target, it returns domain.ErrAlreadyBlocked
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r relationshipCommandsRepo) Block(ctx context.Context, userID string, targetID string) (wasFollowing bool, wasFollowed bool, err error) {
+	if err := r.lockPair(ctx, userID, targetID); err != nil {
+		return false, false, err
+	}
+	query := `WITH u AS (SELECT count(*) = 2 AS found FROM users WHERE id IN ($1::uuid, $2::uuid) AND merged_into IS NULL),
+		ins AS (INSERT INTO user_relationships (user_id, target_id, kind)
+			SELECT $1::uuid, $2::uuid, 'block' FROM u WHERE u.found
+			ON CONFLICT DO NOTHING RETURNING 1),
+		del AS (DELETE FROM user_relationships WHERE kind = 'follow'
+			AND ((user_id = $1::uuid AND target_id = $2::uuid) OR (user_id = $2::uuid AND target_id = $1::uuid))
+			RETURNING user_id)
+		SELECT (SELECT found FROM u), EXISTS (SELECT 1 FROM ins),
+			EXISTS (SELECT 1 FROM del WHERE user_id = $1::uuid), EXISTS (SELECT 1 FROM del WHERE user_id = $2::uuid)`
+	var usersFound, blocked bool
+	if err := r.db(ctx).QueryRow(ctx, query, userID, targetID).Scan(&usersFound, &blocked, &wasFollowing, &wasFollowed); err != nil {
+		r.l.Error(fmt.Errorf("failed to block user: %w", err))
+		return false, false, domain.ErrInternal
+	}
+	switch {
+	case !usersFound:
+		return false, false, domain.ErrUserNotFound
+	case !blocked:
+		return false, false, domain.ErrAlreadyBlocked
+	}
+	return wasFollowing, wasFollowed, nil
+}
+
+// lockPair takes a lock on the pair of users, whatever their order, held until the transaction ends.
+// A statement only sees the rows committed before it starts, so without it a follow could miss a block committed
+// meanwhile and survive it. The lock is taken in its own statement, so that the next one sees the rows committed before.
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r relationshipCommandsRepo) lockPair(ctx context.Context, userID string, targetID string) error {
+	query := `SELECT pg_advisory_xact_lock(hashtextextended('user_relationships:' || LEAST($1::text, $2::text) || ':' || GREATEST($1::text, $2::text), 0))`
+	if _, err := r.db(ctx).Exec(ctx, query, userID, targetID); err != nil {
+		r.l.Error(fmt.Errorf("failed to lock the relationship: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// Unblock removes the block of the user on the target.
+// If the user did not block the target, it returns domain.ErrNotBlocked
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r relationshipCommandsRepo) Unblock(ctx context.Context, userID string, targetID string) error {
+	return r.remove(ctx, userID, targetID, domain.RelationshipBlock, domain.ErrNotBlocked)
+}
+
+// remove deletes a relationship of the given kind, returning notFoundErr if it does not exist
+func (r relationshipCommandsRepo) remove(ctx context.Context, userID string, targetID string, kind string, notFoundErr error) error {
+	commandTag, err := r.db(ctx).Exec(ctx, `DELETE FROM user_relationships WHERE user_id=$1 AND target_id=$2 AND kind=$3`, userID, targetID, kind)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to remove %s relationship: %w", kind, err))
+		return domain.ErrInternal
+	}
+	if commandTag.RowsAffected() == 0 {
+		return notFoundErr
+	}
+	return nil
+}
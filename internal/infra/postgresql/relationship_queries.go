@@ -0,0 +1,89 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type relationshipQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewRelationshipQueriesRepo creates a new instance of relationshipQueriesRepo that satisfies the domain.RelationshipRepoQueries interface
+func NewRelationshipQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.RelationshipRepoQueries {
+	return &relationshipQueriesRepo{pg: pg, l: logger}
+}
+
+func (r relationshipQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// ListFollowers fetches the users following the user based on the provided cursor data, the most recent first
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r relationshipQueriesRepo) ListFollowers(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error) {
+	return r.list(ctx, "target_id", "user_id", domain.RelationshipFollow, userID, cursorUserID, cursorSince, limit)
+}
+
+// ListFollowing fetches the users followed by the user based on the provided cursor data, the most recent first
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r relationshipQueriesRepo) ListFollowing(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error) {
+	return r.list(ctx, "user_id", "target_id", domain.RelationshipFollow, userID, cursorUserID, cursorSince, limit)
+}
+
+// ListBlocked fetches the users blocked by the user based on the provided cursor data, the most recent first
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r relationshipQueriesRepo) ListBlocked(ctx context.Context, userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error) {
+	return r.list(ctx, "user_id", "target_id", domain.RelationshipBlock, userID, cursorUserID, cursorSince, limit)
+}
+
+// list fetches the users on the other side of the relationships of the given kind.
+// ownerColumn is the column matched against userID and relatedColumn the column holding the listed users.
+func (r relationshipQueriesRepo) list(ctx context.Context, ownerColumn string, relatedColumn string, kind string,
+	userID string, cursorUserID string, cursorSince *time.Time, limit int32) ([]*domain.RelatedUser, error) {
+	args := []any{userID, kind, limit}
+	pagination := ""
+	if cursorUserID != "" && cursorSince != nil {
+		pagination = fmt.Sprintf(`AND (r.created_at < $4 OR (r.created_at = $4 AND r.%s < $5))`, relatedColumn)
+		args = append(args, *cursorSince, cursorUserID)
+	}
+	query := fmt.Sprintf(`
+		SELECT u.id, u.first_name, u.last_name, u.country_iso_code, u.nickname, u.email, COALESCE(u.phone, ''), u.phone_verified, COALESCE(u.locale, ''), COALESCE(u.timezone, ''), u.date_of_birth, COALESCE(u.avatar_url, ''), u.created_at, u.updated_at, r.created_at
+		FROM user_relationships r
+		JOIN users u ON u.id = r.%[2]s
+		WHERE r.%[1]s = $1 AND r.kind = $2 %[3]s
+		ORDER BY r.created_at DESC, r.%[2]s DESC LIMIT $3`, ownerColumn, relatedColumn, pagination)
+
+	rows, err := r.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to list %s relationships: %w", kind, err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var users []*domain.RelatedUser
+	for rows.Next() {
+		var u domain.RelatedUser
+		if err := rows.Scan(&u.ID, &u.FirstName, &u.LastName, &u.CountryISOCode, &u.NickName, &u.Email, &u.Phone, &u.PhoneVerified, &u.Locale, &u.Timezone, &u.DateOfBirth, &u.AvatarURL, &u.CreatedAt, &u.UpdatedAt, &u.Since); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		users = append(users, &u)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return users, nil
+}
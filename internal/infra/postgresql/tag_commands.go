@@ -0,0 +1,99 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type tagCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewTagCommandsRepo creates a new instance of tagCommandsRepo that satisfies the domain.TagRepoCommands interface
+func NewTagCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.TagRepoCommands {
+	return &tagCommandsRepo{pg: pg, l: logger}
+}
+
+func (r tagCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// TagUsers assigns the tags to every user, creating the missing tags
+// If any of the users does not exist, it returns domain.ErrUserNotFound
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r tagCommandsRepo) TagUsers(ctx context.Context, userIDs []string, tags []string) ([]domain.UserTag, error) {
+	var found int
+	query := `SELECT count(*) FROM users WHERE id = ANY($1::uuid[]) AND merged_into IS NULL`
+	if err := r.db(ctx).QueryRow(ctx, query, userIDs).Scan(&found); err != nil {
+		r.l.Error(fmt.Errorf("failed to check tagged users: %w", err))
+		return nil, domain.ErrInternal
+	}
+	if found != len(userIDs) {
+		return nil, domain.ErrUserNotFound
+	}
+
+	query = `INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (tenant_id, name) DO NOTHING`
+	if _, err := r.db(ctx).Exec(ctx, query, tags); err != nil {
+		r.l.Error(fmt.Errorf("failed to create tags: %w", err))
+		return nil, domain.ErrInternal
+	}
+
+	query = `WITH added AS (
+			INSERT INTO user_tags (user_id, tag_id)
+			SELECT u.id, t.id FROM unnest($1::uuid[]) AS u(id) CROSS JOIN tags t WHERE t.name = ANY($2::text[])
+			ON CONFLICT DO NOTHING RETURNING user_id, tag_id)
+		SELECT a.user_id, t.name FROM added a JOIN tags t ON t.id = a.tag_id ORDER BY a.user_id, t.name`
+	added, err := r.assignments(ctx, query, userIDs, tags)
+	if err != nil {
+		if postgresql.IsForeignKeyViolationErr(err) {
+			// the user was deleted meanwhile
+			return nil, domain.ErrUserNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to tag users: %w", err))
+		return nil, domain.ErrInternal
+	}
+	return added, nil
+}
+
+// UntagUsers removes the tags from every user
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r tagCommandsRepo) UntagUsers(ctx context.Context, userIDs []string, tags []string) ([]domain.UserTag, error) {
+	query := `WITH removed AS (
+			DELETE FROM user_tags ut USING tags t
+			WHERE ut.tag_id = t.id AND ut.user_id = ANY($1::uuid[]) AND t.name = ANY($2::text[])
+			RETURNING ut.user_id, t.name)
+		SELECT user_id, name FROM removed ORDER BY user_id, name`
+	removed, err := r.assignments(ctx, query, userIDs, tags)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to untag users: %w", err))
+		return nil, domain.ErrInternal
+	}
+	return removed, nil
+}
+
+// assignments runs a query returning user ids and tag names
+func (r tagCommandsRepo) assignments(ctx context.Context, query string, userIDs []string, tags []string) ([]domain.UserTag, error) {
+	rows, err := r.db(ctx).Query(ctx, query, userIDs, tags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignments []domain.UserTag
+	for rows.Next() {
+		var assignment domain.UserTag
+		if err := rows.Scan(&assignment.UserID, &assignment.Tag); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
@@ -0,0 +1,53 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type tenantQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewTenantQueriesRepo creates a new instance of tenantQueriesRepo that satisfies the domain.TenantRepoQueries interface
+func NewTenantQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.TenantRepoQueries {
+	return &tenantQueriesRepo{pg: pg, l: logger}
+}
+
+func (r tenantQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// GetTenantByID fetches a single tenant from the database based on the tenantID
+// If the tenant does not exist, it returns domain.ErrTenantNotFound
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r tenantQueriesRepo) GetTenantByID(ctx context.Context, tenantID string) (*domain.Tenant, error) {
+	return r.getTenant(ctx, `SELECT id, slug, name, created_at FROM tenants WHERE id = $1`, tenantID)
+}
+
+// GetTenantBySlug fetches a single tenant from the database based on the slug
+// If the tenant does not exist, it returns domain.ErrTenantNotFound
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r tenantQueriesRepo) GetTenantBySlug(ctx context.Context, slug string) (*domain.Tenant, error) {
+	return r.getTenant(ctx, `SELECT id, slug, name, created_at FROM tenants WHERE slug = $1`, slug)
+}
+
+func (r tenantQueriesRepo) getTenant(ctx context.Context, query string, arg string) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	if err := r.db(ctx).QueryRow(ctx, query, arg).Scan(&tenant.ID, &tenant.Slug, &tenant.Name, &tenant.CreatedAt); err != nil {
+		if err == postgresql.ErrNoRows {
+			return nil, domain.ErrTenantNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return &tenant, nil
+}
@@ -19,6 +19,8 @@ func NewTransactionSupplier(db postgresql.Interface) domain.Transaction {
 }
 
 // BeginTx begins a transaction, injects it in the context and executes the fn function
+// The tenant of the context is set for the transaction, so the row-level security policies only expose its rows.
+// Without a tenant, nothing is visible unless the context is flagged with domain.WithAllTenants.
 // if fn fails, the transaction is rolledback
 // if fn succeeds, the transaction is commited
 func (u *transactionSupplier) BeginTx(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -28,7 +30,10 @@ func (u *transactionSupplier) BeginTx(ctx context.Context, fn func(ctx context.C
 	}
 	ctx = context.WithValue(ctx, domain.TxKey, tx)
 
-	if err = fn(ctx); err != nil {
+	if err = setTenant(ctx, tx); err == nil {
+		err = fn(ctx)
+	}
+	if err != nil {
 		if err2 := tx.Rollback(ctx); err2 != nil {
 			return fmt.Errorf("%w: %w", err2, err)
 		}
@@ -36,3 +41,19 @@ func (u *transactionSupplier) BeginTx(ctx context.Context, fn func(ctx context.C
 	}
 	return tx.Commit(ctx)
 }
+
+// setTenant scopes the transaction to the tenant of the context.
+// The settings are local to the transaction, so pooled connections never carry them over.
+func setTenant(ctx context.Context, tx postgresql.Tx) error {
+	if tenantID, ok := domain.TenantFromContext(ctx); ok {
+		if _, err := tx.Exec(ctx, `SELECT set_config('app.tenant_id', $1, true)`, tenantID); err != nil {
+			return fmt.Errorf("failed to set tenant: %w", err)
+		}
+	}
+	if domain.IsAllTenants(ctx) {
+		if _, err := tx.Exec(ctx, `SELECT set_config('app.all_tenants', 'on', true)`); err != nil {
+			return fmt.Errorf("failed to set all tenants: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	dbmocks "users/gen/mocks/users/pkg/postgresql"
+	"users/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransactionSupplier_BeginTx(t *testing.T) {
+	tenantID := "0f913f6a-497b-4305-b3d1-3f53657e3a25"
+
+	mockDB := dbmocks.NewInterface(t)
+	mockDBProvider := dbmocks.NewDBProvider(t)
+	mockTx := dbmocks.NewTx(t)
+	mockDB.On("GetPool").Return(mockDBProvider)
+	mockDBProvider.On("Begin", mock.Anything).Return(mockTx, nil)
+	s := NewTransactionSupplier(mockDB)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		fnErr         error
+		expectedMocks func()
+		wantErr       error
+	}{
+		{
+			name: "tenant set and committed",
+			ctx:  domain.WithTenant(context.Background(), tenantID),
+			expectedMocks: func() {
+				mockTx.On("Exec", mock.Anything, `SELECT set_config('app.tenant_id', $1, true)`, tenantID).Return(pgconn.CommandTag{}, nil).Once()
+				mockTx.On("Commit", mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "all tenants set and committed",
+			ctx:  domain.WithAllTenants(context.Background()),
+			expectedMocks: func() {
+				mockTx.On("Exec", mock.Anything, `SELECT set_config('app.all_tenants', 'on', true)`).Return(pgconn.CommandTag{}, nil).Once()
+				mockTx.On("Commit", mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "no tenant",
+			ctx:  context.Background(),
+			expectedMocks: func() {
+				mockTx.On("Commit", mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "failed to set tenant",
+			ctx:  domain.WithTenant(context.Background(), tenantID),
+			expectedMocks: func() {
+				mockTx.On("Exec", mock.Anything, `SELECT set_config('app.tenant_id', $1, true)`, tenantID).Return(pgconn.CommandTag{}, fmt.Errorf("conn closed")).Once()
+				mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+			},
+			wantErr: fmt.Errorf("failed to set tenant: conn closed"),
+		},
+		{
+			name:  "fn failed",
+			ctx:   domain.WithTenant(context.Background(), tenantID),
+			fnErr: domain.ErrUserNotFound,
+			expectedMocks: func() {
+				mockTx.On("Exec", mock.Anything, `SELECT set_config('app.tenant_id', $1, true)`, tenantID).Return(pgconn.CommandTag{}, nil).Once()
+				mockTx.On("Rollback", mock.Anything).Return(nil).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectedMocks != nil {
+				tt.expectedMocks()
+			}
+			var called bool
+			err := s.BeginTx(tt.ctx, func(ctx context.Context) error {
+				called = true
+				assert.Equal(t, mockTx, ctx.Value(domain.TxKey))
+				return tt.fnErr
+			})
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.True(t, called)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
@@ -31,14 +31,18 @@ func (r userCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
 // If user already exists or a conflict is found, it returns domain.ErrUserAlreadyExists
 // If an internal error occurs, it logs the error and returns domain.ErrInternal
 func (r userCommandsRepo) SaveUser(ctx context.Context, user *domain.User) (id string, err error) {
-	query := `INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	query := `INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw, phone, locale, timezone, date_of_birth) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10) RETURNING id`
 	err = r.db(ctx).QueryRow(ctx, query,
 		user.FirstName,
 		user.LastName,
 		user.CountryISOCode,
 		user.NickName,
 		user.Email,
-		user.Password).Scan(&id)
+		user.Password,
+		user.Phone,
+		user.Locale,
+		user.Timezone,
+		user.DateOfBirth).Scan(&id)
 	if err != nil {
 		if postgresql.IsConflictErr(err) {
 			r.l.Debug(fmt.Errorf("user %s already exists: %w", user.Email, err))
@@ -68,25 +72,78 @@ func (r userCommandsRepo) DeleteUser(ctx context.Context, userID string) error {
 	return err
 }
 
-// UpdateUser updates user in database.
+// UpdateUser updates user in database and returns the user as it was before the update.
+// Changing the phone number resets its verification.
 // If user does not exist, it returns domain.ErrUserNotFound
 // If an internal error occurs, it logs the error and returns domain.ErrInternal
-func (r userCommandsRepo) UpdateUser(ctx context.Context, user *domain.User) error {
-	query := `UPDATE users SET first_name=$2, last_name=$3, country_iso_code=$4, nickname=$5, email=$6 WHERE id=$1;`
-	commandTag, err := r.db(ctx).Exec(ctx, query,
+func (r userCommandsRepo) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	// the previous values are read from the locked row, as RETURNING only sees the new ones
+	query := `UPDATE users u SET first_name=$2, last_name=$3, country_iso_code=$4, nickname=$5, email=$6,
+		phone=NULLIF($7, ''), phone_verified=(u.phone_verified AND u.phone IS NOT DISTINCT FROM NULLIF($7, '')),
+		locale=NULLIF($8, ''), timezone=NULLIF($9, ''), date_of_birth=$10
+		FROM (SELECT * FROM users WHERE id=$1 AND merged_into IS NULL FOR UPDATE) prev
+		WHERE u.id = prev.id
+		RETURNING prev.id, prev.first_name, prev.last_name, COALESCE(prev.country_iso_code, ''), prev.nickname, prev.email, COALESCE(prev.phone, ''), prev.phone_verified,
+			COALESCE(prev.locale, ''), COALESCE(prev.timezone, ''), prev.date_of_birth, COALESCE(prev.avatar_url, ''), prev.created_at, prev.updated_at;`
+	var prev domain.User
+	err := r.db(ctx).QueryRow(ctx, query,
 		user.ID,
 		user.FirstName,
 		user.LastName,
 		user.CountryISOCode,
 		user.NickName,
-		user.Email)
+		user.Email,
+		user.Phone,
+		user.Locale,
+		user.Timezone,
+		user.DateOfBirth).Scan(&prev.ID, &prev.FirstName, &prev.LastName, &prev.CountryISOCode, &prev.NickName, &prev.Email, &prev.Phone, &prev.PhoneVerified,
+		&prev.Locale, &prev.Timezone, &prev.DateOfBirth, &prev.AvatarURL, &prev.CreatedAt, &prev.UpdatedAt)
 	if err != nil {
+		if err == postgresql.ErrNoRows {
+			r.l.Debug("user with ID %s does not exist", user.ID)
+			return nil, domain.ErrUserNotFound
+		}
 		r.l.Error(fmt.Errorf("failed to update user: %w", err))
+		return nil, domain.ErrInternal
+	}
+	return &prev, nil
+}
+
+// MarkPhoneVerified flags the user's phone as verified if it still matches the provided phone.
+// If user does not exist or the phone changed, it returns domain.ErrUserNotFound
+// If the phone is already verified by another user, it returns domain.ErrPhoneAlreadyInUse
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r userCommandsRepo) MarkPhoneVerified(ctx context.Context, userID string, phone string) error {
+	query := `UPDATE users SET phone_verified=TRUE WHERE id=$1 AND phone=$2 AND merged_into IS NULL;`
+	commandTag, err := r.db(ctx).Exec(ctx, query, userID, phone)
+	if err != nil {
+		if postgresql.IsConflictErr(err) {
+			r.l.Debug(fmt.Errorf("phone %s already verified by another user: %w", phone, err))
+			return domain.ErrPhoneAlreadyInUse
+		}
+		r.l.Error(fmt.Errorf("failed to mark phone as verified: %w", err))
 		return domain.ErrInternal
 	}
 	if commandTag.RowsAffected() == 0 {
-		r.l.Debug("user with ID %s does not exist", user.ID)
+		r.l.Debug("user with ID %s and phone %s does not exist", userID, phone)
 		return domain.ErrUserNotFound
 	}
-	return err
+	return nil
+}
+
+// UpdateAvatar replaces the user's avatar and returns the key of the previous one.
+// If user does not exist, it returns domain.ErrUserNotFound
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r userCommandsRepo) UpdateAvatar(ctx context.Context, userID string, key string, url string) (previousKey string, err error) {
+	query := `UPDATE users u SET avatar_key=NULLIF($2, ''), avatar_url=NULLIF($3, '') FROM (SELECT id, avatar_key FROM users WHERE id=$1 AND merged_into IS NULL FOR UPDATE) prev WHERE u.id=prev.id RETURNING COALESCE(prev.avatar_key, '')`
+	err = r.db(ctx).QueryRow(ctx, query, userID, key, url).Scan(&previousKey)
+	if err != nil {
+		if err == postgresql.ErrNoRows {
+			r.l.Debug("user with ID %s does not exist", userID)
+			return "", domain.ErrUserNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to update avatar: %w", err))
+		return "", domain.ErrInternal
+	}
+	return previousKey, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 	loggermocks "users/gen/mocks/users/pkg/logger"
 	dbmocks "users/gen/mocks/users/pkg/postgresql"
 	"users/internal/domain"
@@ -63,8 +64,8 @@ func TestUserCommandsRepo_SaveUser(t *testing.T) {
 			},
 			expectedMocks: func() {
 				mockDBProvider.On("QueryRow", mock.Anything,
-					"INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
-					"first", "last", "UK", "nick", "first@test.pt", "someHashHere").Return(mockRow).Once()
+					"INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw, phone, locale, timezone, date_of_birth) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10) RETURNING id",
+					"first", "last", "UK", "nick", "first@test.pt", "someHashHere", "", "", "", (*time.Time)(nil)).Return(mockRow).Once()
 				mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
 					// change the value of the scan argument
 					arg := args.Get(0).([]interface{})
@@ -89,8 +90,8 @@ func TestUserCommandsRepo_SaveUser(t *testing.T) {
 			},
 			expectedMocks: func() {
 				mockDBProvider.On("QueryRow", mock.Anything,
-					"INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
-					"first", "last", "UK", "nick", "first@test.pt", "someHashHere").Return(mockRow).Once()
+					"INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw, phone, locale, timezone, date_of_birth) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10) RETURNING id",
+					"first", "last", "UK", "nick", "first@test.pt", "someHashHere", "", "", "", (*time.Time)(nil)).Return(mockRow).Once()
 				mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
 					// change the value of the scan argument
 					arg := args.Get(0).([]interface{})
@@ -115,8 +116,8 @@ func TestUserCommandsRepo_SaveUser(t *testing.T) {
 			},
 			expectedMocks: func() {
 				mockDBProvider.On("QueryRow", mock.Anything,
-					"INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
-					"first", "last", "UK", "nick", "first@test.pt", "someHashHere").Return(mockRow).Once()
+					"INSERT INTO users (first_name, last_name, country_iso_code, nickname, email, pw, phone, locale, timezone, date_of_birth) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10) RETURNING id",
+					"first", "last", "UK", "nick", "first@test.pt", "someHashHere", "", "", "", (*time.Time)(nil)).Return(mockRow).Once()
 				mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
 					// change the value of the scan argument
 					arg := args.Get(0).([]interface{})
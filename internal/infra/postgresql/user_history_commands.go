@@ -0,0 +1,42 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type userHistoryCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewUserHistoryCommandsRepo creates a new instance of userHistoryCommandsRepo that satisfies the domain.UserHistoryRepoCommands interface
+func NewUserHistoryCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.UserHistoryRepoCommands {
+	return &userHistoryCommandsRepo{pg: pg, l: logger}
+}
+
+func (r userHistoryCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// PurgeDeletedUsersHistory deletes the versions of the users deleted before the provided time
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r userHistoryCommandsRepo) PurgeDeletedUsersHistory(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	// a user id is never reused, so every version of a deleted user is expired
+	query := `DELETE FROM users_history WHERE user_id IN (
+		SELECT user_id FROM users_history WHERE operation = 'delete' AND recorded_at < $1)`
+	tag, err := r.db(ctx).Exec(ctx, query, deletedBefore)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to purge users history: %w", err))
+		return 0, domain.ErrInternal
+	}
+	return tag.RowsAffected(), nil
+}
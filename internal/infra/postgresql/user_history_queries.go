@@ -0,0 +1,93 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+const _userVersionColumns = `version, operation, recorded_at, user_id, first_name, last_name, COALESCE(country_iso_code, ''), nickname, email,
+	COALESCE(phone, ''), phone_verified, COALESCE(locale, ''), COALESCE(timezone, ''), date_of_birth, COALESCE(avatar_url, ''), merged_into, created_at, updated_at`
+
+type userHistoryQueriesRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewUserHistoryQueriesRepo creates a new instance of userHistoryQueriesRepo that satisfies the domain.UserHistoryRepoQueries interface
+func NewUserHistoryQueriesRepo(pg postgresql.Interface, logger log.Interface) domain.UserHistoryRepoQueries {
+	return &userHistoryQueriesRepo{pg: pg, l: logger}
+}
+
+func (r userHistoryQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// ListUserVersions fetches the versions of the user based on the provided cursor data, the most recent first
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userHistoryQueriesRepo) ListUserVersions(ctx context.Context, userID string, cursorVersion int64, cursorRecordedAt *time.Time, limit int32) ([]*domain.UserVersion, error) {
+	args := []any{userID, limit}
+	pagination := ""
+	if cursorRecordedAt != nil {
+		pagination = `AND (recorded_at < $3 OR (recorded_at = $3 AND version < $4))`
+		args = append(args, *cursorRecordedAt, cursorVersion)
+	}
+	query := fmt.Sprintf(`SELECT %s FROM users_history WHERE user_id = $1 %s ORDER BY recorded_at DESC, version DESC LIMIT $2`, _userVersionColumns, pagination)
+
+	rows, err := r.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to list user versions: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var versions []*domain.UserVersion
+	for rows.Next() {
+		v, err := scanUserVersion(rows)
+		if err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return versions, nil
+}
+
+// GetUserVersionAt fetches the last version of the user recorded up to the provided time
+// If there is no such version, it returns domain.ErrUserNotFound
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userHistoryQueriesRepo) GetUserVersionAt(ctx context.Context, userID string, at time.Time) (*domain.UserVersion, error) {
+	query := fmt.Sprintf(`SELECT %s FROM users_history WHERE user_id = $1 AND recorded_at <= $2 ORDER BY recorded_at DESC, version DESC LIMIT 1`, _userVersionColumns)
+	v, err := scanUserVersion(r.db(ctx).QueryRow(ctx, query, userID, at))
+	if err != nil {
+		if err == postgresql.ErrNoRows {
+			return nil, domain.ErrUserNotFound
+		}
+		r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return v, nil
+}
+
+// scanUserVersion scans a row selected with _userVersionColumns
+func scanUserVersion(row interface{ Scan(dest ...any) error }) (*domain.UserVersion, error) {
+	var v domain.UserVersion
+	err := row.Scan(&v.Version, &v.Operation, &v.RecordedAt, &v.ID, &v.FirstName, &v.LastName, &v.CountryISOCode, &v.NickName, &v.Email,
+		&v.Phone, &v.PhoneVerified, &v.Locale, &v.Timezone, &v.DateOfBirth, &v.AvatarURL, &v.MergedInto, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
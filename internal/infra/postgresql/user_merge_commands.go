@@ -0,0 +1,137 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"users/internal/domain"
+	log "users/pkg/logger"
+	"users/pkg/postgresql"
+)
+
+type userMergeCommandsRepo struct {
+	pg postgresql.Interface
+	l  log.Interface
+}
+
+// NewUserMergeCommandsRepo creates a new instance of userMergeCommandsRepo that satisfies the domain.UserMergeRepoCommands interface
+func NewUserMergeCommandsRepo(pg postgresql.Interface, logger log.Interface) domain.UserMergeRepoCommands {
+	return &userMergeCommandsRepo{pg: pg, l: logger}
+}
+
+func (r userMergeCommandsRepo) db(ctx context.Context) postgresql.DBProvider {
+	tx, ok := ctx.Value(domain.TxKey).(postgresql.Tx)
+	if ok {
+		return tx
+	}
+	return r.pg.GetPool()
+}
+
+// LockUsersForMerge fetches the source and target users and locks them, ordered by id so concurrent merges do not deadlock.
+// If any of the users does not exist or was already merged, it returns domain.ErrUserNotFound
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userMergeCommandsRepo) LockUsersForMerge(ctx context.Context, sourceID string, targetID string) (source *domain.User, target *domain.User, err error) {
+	query := `SELECT id, first_name, last_name, COALESCE(country_iso_code, ''), nickname, email, COALESCE(phone, ''), phone_verified, COALESCE(locale, ''), COALESCE(timezone, ''), date_of_birth, COALESCE(avatar_key, ''), COALESCE(avatar_url, ''), created_at, updated_at
+		FROM users WHERE id IN ($1, $2) AND merged_into IS NULL ORDER BY id FOR UPDATE`
+	rows, err := r.db(ctx).Query(ctx, query, sourceID, targetID)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to lock users: %w", err))
+		return nil, nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.FirstName, &u.LastName, &u.CountryISOCode, &u.NickName, &u.Email, &u.Phone, &u.PhoneVerified, &u.Locale, &u.Timezone, &u.DateOfBirth, &u.AvatarKey, &u.AvatarURL, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, nil, domain.ErrFailedToProcessData
+		}
+		if u.ID.String() == sourceID {
+			source = &u
+		} else {
+			target = &u
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, nil, domain.ErrFailedToProcessData
+	}
+	if source == nil || target == nil {
+		return nil, nil, domain.ErrUserNotFound
+	}
+	return source, target, nil
+}
+
+// MergeUsers tombstones the source, saves the merged target and moves the data owned by the source to the target.
+// It must run in a transaction, after locking both users with LockUsersForMerge.
+// If the merged profile conflicts with another user, it returns domain.ErrUserAlreadyExists
+// If an internal error occurs, it logs the error and returns domain.ErrInternal
+func (r userMergeCommandsRepo) MergeUsers(ctx context.Context, sourceID string, target *domain.User) error {
+	targetID := target.ID.String()
+
+	// the tombstone releases its unique fields and its avatar, which now belongs to the target or is deleted
+	tombstone := `UPDATE users SET merged_into=$2, avatar_key=NULL, avatar_url=NULL WHERE id=$1 OR merged_into=$1`
+	if _, err := r.db(ctx).Exec(ctx, tombstone, sourceID, targetID); err != nil {
+		r.l.Error(fmt.Errorf("failed to tombstone merged user: %w", err))
+		return domain.ErrInternal
+	}
+
+	profile := `UPDATE users SET first_name=$2, last_name=$3, country_iso_code=$4, nickname=$5, email=$6,
+		phone=NULLIF($7, ''), phone_verified=$8, locale=NULLIF($9, ''), timezone=NULLIF($10, ''), date_of_birth=$11,
+		avatar_key=NULLIF($12, ''), avatar_url=NULLIF($13, '') WHERE id=$1`
+	if _, err := r.db(ctx).Exec(ctx, profile,
+		targetID,
+		target.FirstName,
+		target.LastName,
+		target.CountryISOCode,
+		target.NickName,
+		target.Email,
+		target.Phone,
+		target.PhoneVerified,
+		target.Locale,
+		target.Timezone,
+		target.DateOfBirth,
+		target.AvatarKey,
+		target.AvatarURL); err != nil {
+		if postgresql.IsConflictErr(err) {
+			r.l.Debug(fmt.Errorf("merged user %s conflicts with another user: %w", targetID, err))
+			return domain.ErrUserAlreadyExists
+		}
+		r.l.Error(fmt.Errorf("failed to update merged user: %w", err))
+		return domain.ErrInternal
+	}
+
+	statements := []struct {
+		name  string
+		query string
+	}{
+		{"group memberships", `INSERT INTO group_members (group_id, user_id, created_at)
+			SELECT group_id, $2, created_at FROM group_members WHERE user_id = $1 ON CONFLICT DO NOTHING`},
+		{"group memberships", `DELETE FROM group_members WHERE user_id = $1`},
+		// relationships between the source and the target are dropped
+		{"relationships", `INSERT INTO user_relationships (user_id, target_id, kind, created_at)
+			SELECT CASE WHEN user_id = $1 THEN $2 ELSE user_id END, CASE WHEN target_id = $1 THEN $2 ELSE target_id END, kind, created_at
+			FROM user_relationships
+			WHERE (user_id = $1 OR target_id = $1) AND NOT (user_id IN ($1, $2) AND target_id IN ($1, $2))
+			ON CONFLICT DO NOTHING`},
+		{"relationships", `DELETE FROM user_relationships WHERE user_id = $1 OR target_id = $1`},
+		// a block moved from either user removes the follows between the blocked pair
+		{"relationships", `DELETE FROM user_relationships f WHERE f.kind = 'follow' AND (f.user_id = $2 OR f.target_id = $2)
+			AND EXISTS (SELECT 1 FROM user_relationships b WHERE b.kind = 'block'
+				AND ((b.user_id = f.user_id AND b.target_id = f.target_id) OR (b.user_id = f.target_id AND b.target_id = f.user_id)))`},
+		{"preferences", `INSERT INTO user_preferences (user_id, key, value, updated_at)
+			SELECT $2, key, value, updated_at FROM user_preferences WHERE user_id = $1 ON CONFLICT DO NOTHING`},
+		{"preferences", `DELETE FROM user_preferences WHERE user_id = $1`},
+		{"tags", `INSERT INTO user_tags (user_id, tag_id, created_at)
+			SELECT $2, tag_id, created_at FROM user_tags WHERE user_id = $1 ON CONFLICT DO NOTHING`},
+		{"tags", `DELETE FROM user_tags WHERE user_id = $1`},
+		{"invitations", `UPDATE invitations SET user_id = $2 WHERE user_id = $1`},
+		{"phone verifications", `DELETE FROM phone_verifications WHERE user_id = $1`},
+	}
+	for _, stmt := range statements {
+		if _, err := r.db(ctx).Exec(ctx, stmt.query, sourceID, targetID); err != nil {
+			r.l.Error(fmt.Errorf("failed to merge %s: %w", stmt.name, err))
+			return domain.ErrInternal
+		}
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package postgresql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 	"users/internal/domain"
@@ -10,6 +12,103 @@ import (
 	"users/pkg/postgresql"
 )
 
+// _userSortColumns maps the sortable fields of the users to the type of their cursor values
+var _userSortColumns = map[string]string{
+	domain.UserSortUpdatedAt: "timestamptz",
+	domain.UserSortCreatedAt: "timestamptz",
+	domain.UserSortLastName:  "text",
+	domain.UserSortNickName:  "text",
+}
+
+// _userFilterColumns maps the fields of the filter expressions to their columns
+var _userFilterColumns = map[string]string{
+	"first_name":       "first_name",
+	"last_name":        "last_name",
+	"nick_name":        "nickname",
+	"email":            "email",
+	"country_iso_code": "country_iso_code",
+	"locale":           "locale",
+	"timezone":         "timezone",
+	"status":           "status",
+	"phone_verified":   "phone_verified",
+	"date_of_birth":    "date_of_birth",
+	"created_at":       "created_at",
+	"updated_at":       "updated_at",
+	"last_seen_at":     "last_seen_at",
+}
+
+// _userFilterOperators maps the operators of the filter expressions to SQL, != matches the NULL columns as well
+var _userFilterOperators = map[string]string{
+	domain.FilterEquals:         "=",
+	domain.FilterNotEquals:      "IS DISTINCT FROM",
+	domain.FilterLess:           "<",
+	domain.FilterLessOrEqual:    "<=",
+	domain.FilterGreater:        ">",
+	domain.FilterGreaterOrEqual: ">=",
+	domain.FilterHas:            "ILIKE",
+}
+
+// _userTagsColumn selects the sorted tag names of the user
+const _userTagsColumn = `ARRAY(SELECT t.name FROM user_tags ut JOIN tags t ON t.id = ut.tag_id WHERE ut.user_id = users.id ORDER BY t.name)`
+
+// _userColumns maps the loadable fields of the users, and merged_into, to their select expressions and scan destinations
+var _userColumns = map[string]userColumn{
+	"first_name":       {"first_name", func(u *domain.User) any { return &u.FirstName }},
+	"last_name":        {"last_name", func(u *domain.User) any { return &u.LastName }},
+	"country_iso_code": {"country_iso_code", func(u *domain.User) any { return &u.CountryISOCode }},
+	"nick_name":        {"nickname", func(u *domain.User) any { return &u.NickName }},
+	"email":            {"email", func(u *domain.User) any { return &u.Email }},
+	"phone":            {"COALESCE(phone, '')", func(u *domain.User) any { return &u.Phone }},
+	"phone_verified":   {"phone_verified", func(u *domain.User) any { return &u.PhoneVerified }},
+	"locale":           {"COALESCE(locale, '')", func(u *domain.User) any { return &u.Locale }},
+	"timezone":         {"COALESCE(timezone, '')", func(u *domain.User) any { return &u.Timezone }},
+	"date_of_birth":    {"date_of_birth", func(u *domain.User) any { return &u.DateOfBirth }},
+	"avatar_url":       {"COALESCE(avatar_url, '')", func(u *domain.User) any { return &u.AvatarURL }},
+	"created_at":       {"created_at", func(u *domain.User) any { return &u.CreatedAt }},
+	"updated_at":       {"updated_at", func(u *domain.User) any { return &u.UpdatedAt }},
+	"last_seen_at":     {"last_seen_at", func(u *domain.User) any { return &u.LastSeenAt }},
+	"status":           {"status", func(u *domain.User) any { return &u.Status }},
+	"tags":             {_userTagsColumn, func(u *domain.User) any { return &u.Tags }},
+	"followers_count": {"(SELECT count(*) FROM user_relationships r WHERE r.target_id = users.id AND r.kind = 'follow')",
+		func(u *domain.User) any { return &u.FollowersCount }},
+	"following_count": {"(SELECT count(*) FROM user_relationships r WHERE r.user_id = users.id AND r.kind = 'follow')",
+		func(u *domain.User) any { return &u.FollowingCount }},
+	"merged_into": {"merged_into", func(u *domain.User) any { return &u.MergedInto }},
+}
+
+type userColumn struct {
+	expr string
+	dest func(user *domain.User) any
+}
+
+// selectUserFields returns the select list of the id and the fields of the users
+func selectUserFields(fields []string) (string, error) {
+	exprs := []string{"id"}
+	for _, field := range fields {
+		column, ok := _userColumns[field]
+		if !ok {
+			return "", fmt.Errorf("unknown users field: %s", field)
+		}
+		exprs = append(exprs, column.expr)
+	}
+	return strings.Join(exprs, ", "), nil
+}
+
+// userFieldsDests returns the scan destinations of the id and the fields of the user, as selected by selectUserFields
+func userFieldsDests(user *domain.User, fields []string) []any {
+	dests := []any{&user.ID}
+	for _, field := range fields {
+		dests = append(dests, _userColumns[field].dest(user))
+	}
+	return dests
+}
+
+// _userLookupColumns maps the lookup fields of the users to their columns
+var _userLookupColumns = map[string]string{
+	domain.UserLookupEmail:    "email",
+	domain.UserLookupNickName: "nickname",
+}
+
 type userQueriesRepo struct {
 	pg postgresql.Interface
 	l  log.Interface
@@ -29,13 +128,53 @@ func (r userQueriesRepo) db(ctx context.Context) postgresql.DBProvider {
 	return r.pg.GetPool()
 }
 
-// GetUser fetches a single user from the database based on the userID
+// GetUser fetches a single user from the database based on the userID, with the fields, all of them when empty
+// Merged users only have the ID and MergedInto fields set
+// If the field is unknown or the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userQueriesRepo) GetUser(ctx context.Context, userID string, fields []string) (*domain.User, error) {
+	if len(fields) == 0 {
+		fields = append(append([]string{}, domain.UserFields...), domain.UserDetailFields...)
+	}
+	fields = append(fields[:len(fields):len(fields)], "merged_into")
+	user, err := r.getUser(ctx, `WHERE id = $1`, fields, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MergedInto != nil {
+		// tombstones only redirect to the user they were merged into
+		return &domain.User{ID: user.ID, MergedInto: user.MergedInto}, nil
+	}
+	return user, nil
+}
+
+// LookupUser fetches the user, skipping merged users, whose email or nickname matches the value regardless of case
+// The lookup is served by the lower() indexes of the fields, an exact match wins over the ones differing in case
+// If the field is not a lookup field or the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userQueriesRepo) LookupUser(ctx context.Context, field string, value string) (*domain.User, error) {
+	// the column is never taken from the input as is
+	column, ok := _userLookupColumns[field]
+	if !ok {
+		r.l.Error(fmt.Errorf("unknown users lookup field: %s", field))
+		return nil, domain.ErrInternal
+	}
+	where := fmt.Sprintf(`WHERE lower(%[1]s) = lower($1) AND merged_into IS NULL ORDER BY %[1]s = $1 DESC LIMIT 1`, column)
+	return r.getUser(ctx, where, append(append([]string{}, domain.UserFields...), domain.UserDetailFields...), value)
+}
+
+// getUser fetches the fields of the first user matching the where clause
+// If no user matches, it returns domain.ErrUserNotFound
+// If the field is unknown, it returns domain.ErrInternal
 // If theres an error processing the data, it returns domain.ErrFailedToProcessData
-func (r userQueriesRepo) GetUser(ctx context.Context, userID string) (*domain.User, error) {
-	query := `SELECT id, first_name, last_name, country_iso_code, nickname, email, created_at, updated_at FROM users WHERE id = $1`
-	row := r.db(ctx).QueryRow(ctx, query, userID)
+func (r userQueriesRepo) getUser(ctx context.Context, where string, fields []string, args ...any) (*domain.User, error) {
+	columns, err := selectUserFields(fields)
+	if err != nil {
+		r.l.Error(err)
+		return nil, domain.ErrInternal
+	}
 	var user domain.User
-	if err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.CountryISOCode, &user.NickName, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+	if err := r.db(ctx).QueryRow(ctx, "SELECT "+columns+" FROM users "+where, args...).Scan(userFieldsDests(&user, fields)...); err != nil {
 		if err == postgresql.ErrNoRows {
 			return nil, domain.ErrUserNotFound
 		}
@@ -45,53 +184,90 @@ func (r userQueriesRepo) GetUser(ctx context.Context, userID string) (*domain.Us
 	return &user, nil
 }
 
-// ListUsers fetches the users from the database based on the provided cursor data
-// If the query fails to execute, it returns return domain.ErrInternal
+// BatchGetUsers fetches the users with the IDs from the database in a single query, the missing ones are skipped
+// Merged users only have the ID and MergedInto fields set
+// If the query fails to execute, it returns domain.ErrInternal
 // If theres an error processing the data, it returns domain.ErrFailedToProcessData
-func (r userQueriesRepo) ListUsers(ctx context.Context, cursorUserID string, cursorUpdatedAt *time.Time, limit int32, filters domain.UserSearchFilters) ([]*domain.User, error) {
-	var whereClauses []string
-	var args []any
+func (r userQueriesRepo) BatchGetUsers(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	query := `SELECT id, first_name, last_name, country_iso_code, nickname, email, COALESCE(phone, ''), phone_verified, COALESCE(locale, ''), COALESCE(timezone, ''), date_of_birth, COALESCE(avatar_url, ''), created_at, updated_at, last_seen_at, status, merged_into, ` + _userTagsColumn + `
+		FROM users WHERE id = ANY($1::uuid[])`
+	rows, err := r.db(ctx).Query(ctx, query, userIDs)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to batch get users: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.CountryISOCode, &user.NickName, &user.Email, &user.Phone, &user.PhoneVerified, &user.Locale, &user.Timezone, &user.DateOfBirth, &user.AvatarURL, &user.CreatedAt, &user.UpdatedAt, &user.LastSeenAt, &user.Status, &user.MergedInto, &user.Tags); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		if user.MergedInto != nil {
+			// tombstones only redirect to the user they were merged into
+			user = domain.User{ID: user.ID, MergedInto: user.MergedInto}
+		}
+		users = append(users, &user)
+	}
 
-	// pagination
-	if cursorUserID != "" && cursorUpdatedAt != nil {
-		whereClauses = append(whereClauses, `(updated_at < $1 OR (updated_at = $1 AND id < $2))`)
-		args = append(args, *cursorUpdatedAt, cursorUserID)
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
 	}
+	return users, nil
+}
 
-	// filters
-	if filters.FirstName != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("first_name ILIKE $%d", len(args)+1))
-		args = append(args, "%"+*filters.FirstName+"%")
+// ListUsers fetches the users from the database in the provided order, after the cursor, skipping merged users
+// If the order is not sortable or the query fails to execute, it returns return domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userQueriesRepo) ListUsers(ctx context.Context, order domain.UserOrder, cursor *domain.UserCursor, limit int32, filters domain.UserSearchFilters, fields []string) ([]*domain.User, error) {
+	whereClauses := []string{"merged_into IS NULL"}
+	var args []any
+	if len(fields) == 0 {
+		fields = domain.UserFields
 	}
-	if filters.LastName != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("last_name ILIKE $%d", len(args)+1))
-		args = append(args, "%"+*filters.LastName+"%")
+	columns, err := selectUserFields(fields)
+	if err != nil {
+		r.l.Error(err)
+		return nil, domain.ErrInternal
 	}
-	if filters.NickName != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("nickname ILIKE $%d", len(args)+1))
-		args = append(args, "%"+*filters.NickName+"%")
+
+	// pagination, the column is never taken from the input as is
+	sortType, ok := _userSortColumns[order.Field]
+	if !ok {
+		r.l.Error(fmt.Errorf("unsortable users field: %s", order.Field))
+		return nil, domain.ErrInternal
 	}
-	if filters.Email != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("email ILIKE $%d", len(args)+1))
-		args = append(args, "%"+*filters.Email+"%")
+	direction, after := "ASC", ">"
+	if order.Desc {
+		direction, after = "DESC", "<"
 	}
-	if filters.CountryISOCode != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("country_iso_code ILIKE $%d", len(args)+1))
-		args = append(args, *filters.CountryISOCode)
+	if cursor != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf(`(%[1]s %[2]s $1::%[3]s OR (%[1]s = $1::%[3]s AND id %[2]s $2))`, order.Field, after, sortType))
+		args = append(args, cursor.Value, cursor.UserID)
 	}
+	whereClauses, args = tenantClause(ctx, whereClauses, args)
 
-	// compose query statement
-	where := ""
-	if len(whereClauses) > 0 {
-		where = "WHERE " + strings.Join(whereClauses, " AND ")
+	whereClauses, args, err = userFilterClauses(filters, whereClauses, args)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to compile the users filter: %w", err))
+		return nil, domain.ErrInternal
 	}
+
+	// compose query statement
+	where := "WHERE " + strings.Join(whereClauses, " AND ")
 	query := fmt.Sprintf(`
-		SELECT id, first_name, last_name, country_iso_code, nickname, email, created_at, updated_at 
+		SELECT %s
 		FROM users 
 		%s 
-		ORDER BY updated_at DESC, id DESC LIMIT $%d`, where, len(args)+1)
+		ORDER BY %[3]s %[4]s, id %[4]s LIMIT $%[5]d`, columns, where, order.Field, direction, len(args)+1)
 	args = append(args, limit)
 
+	if err := r.setSimilarityThreshold(ctx, filters); err != nil {
+		return nil, err
+	}
 	rows, err := r.db(ctx).Query(ctx, query, args...)
 	if err != nil {
 		r.l.Debug(fmt.Errorf("failed to list users: %w", err))
@@ -104,7 +280,7 @@ func (r userQueriesRepo) ListUsers(ctx context.Context, cursorUserID string, cur
 	// https://donchev.is/post/working-with-postgresql-in-go-using-pgx/
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.CountryISOCode, &user.NickName, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err := rows.Scan(userFieldsDests(&user, fields)...); err != nil {
 			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
 			return nil, domain.ErrFailedToProcessData
 		}
@@ -117,3 +293,389 @@ func (r userQueriesRepo) ListUsers(ctx context.Context, cursorUserID string, cur
 	}
 	return users, nil
 }
+
+// CountUsers counts the users matching the filters in the database, skipping merged users
+// The estimate is the number of rows the planner expects the query to return, read from EXPLAIN, so it relies on
+// the table statistics kept by ANALYZE and honours the filters and the row-level security of the tenant
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userQueriesRepo) CountUsers(ctx context.Context, filters domain.UserSearchFilters, estimate bool) (int64, error) {
+	whereClauses, args := tenantClause(ctx, []string{"merged_into IS NULL"}, nil)
+	whereClauses, args, err := userFilterClauses(filters, whereClauses, args)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to compile the users filter: %w", err))
+		return 0, domain.ErrInternal
+	}
+	where := "WHERE " + strings.Join(whereClauses, " AND ")
+	if err := r.setSimilarityThreshold(ctx, filters); err != nil {
+		return 0, err
+	}
+
+	if !estimate {
+		var count int64
+		if err := r.db(ctx).QueryRow(ctx, "SELECT count(*) FROM users "+where, args...).Scan(&count); err != nil {
+			r.l.Debug(fmt.Errorf("failed to count users: %w", err))
+			return 0, domain.ErrInternal
+		}
+		return count, nil
+	}
+
+	var plan string
+	if err := r.db(ctx).QueryRow(ctx, "EXPLAIN (FORMAT JSON) SELECT 1 FROM users "+where, args...).Scan(&plan); err != nil {
+		r.l.Debug(fmt.Errorf("failed to estimate users: %w", err))
+		return 0, domain.ErrInternal
+	}
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		}
+	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil || len(explained) == 0 {
+		r.l.Error(fmt.Errorf("failed to parse the users plan: %v", err))
+		return 0, domain.ErrFailedToProcessData
+	}
+	return int64(explained[0].Plan.Rows), nil
+}
+
+// ExportUsers reads the users matching the filters, skipping merged users, through a server-side cursor
+// The cursor sees the snapshot taken when it is declared, so the users updated during the export are read as they were,
+// and it is closed along with the transaction
+// If the query fails to execute or the context is cancelled, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userQueriesRepo) ExportUsers(ctx context.Context, filters domain.UserSearchFilters, batchSize int, fn func(user *domain.User) error) error {
+	whereClauses, args := tenantClause(ctx, []string{"merged_into IS NULL"}, nil)
+	whereClauses, args, err := userFilterClauses(filters, whereClauses, args)
+	if err != nil {
+		r.l.Error(fmt.Errorf("failed to compile the users filter: %w", err))
+		return domain.ErrInternal
+	}
+	columns, err := selectUserFields(domain.UserFields)
+	if err != nil {
+		r.l.Error(err)
+		return domain.ErrInternal
+	}
+	if err := r.setSimilarityThreshold(ctx, filters); err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`DECLARE export_users NO SCROLL CURSOR FOR
+		SELECT %s FROM users WHERE %s ORDER BY created_at, id`, columns, strings.Join(whereClauses, " AND "))
+	if _, err := r.db(ctx).Exec(ctx, query, args...); err != nil {
+		r.l.Debug(fmt.Errorf("failed to declare the export cursor: %w", err))
+		return domain.ErrInternal
+	}
+
+	for {
+		rows, err := r.db(ctx).Query(ctx, fmt.Sprintf(`FETCH FORWARD %d FROM export_users`, batchSize))
+		if err != nil {
+			r.l.Debug(fmt.Errorf("failed to fetch users: %w", err))
+			return domain.ErrInternal
+		}
+		var users []*domain.User
+		for rows.Next() {
+			var user domain.User
+			if err := rows.Scan(userFieldsDests(&user, domain.UserFields)...); err != nil {
+				rows.Close()
+				r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+				return domain.ErrFailedToProcessData
+			}
+			users = append(users, &user)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			r.l.Debug(fmt.Errorf("row iteration error: %w", err))
+			return domain.ErrInternal
+		}
+
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		if len(users) < batchSize {
+			return nil
+		}
+	}
+}
+
+// tenantClause appends the condition on the tenant of the context, and its argument, to the where clauses.
+// The row-level security policy already isolates the tenant, but the planner can't use it as the prefix of the
+// tenant indexes. Contexts across every tenant get no condition.
+func tenantClause(ctx context.Context, whereClauses []string, args []any) ([]string, []any) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return whereClauses, args
+	}
+	return append(whereClauses, fmt.Sprintf("tenant_id = $%d", len(args)+1)), append(args, tenantID)
+}
+
+// userFilterClauses appends the conditions of the filters, and their arguments, to the where clauses
+func userFilterClauses(filters domain.UserSearchFilters, whereClauses []string, args []any) ([]string, []any, error) {
+	// the text ones are served by the trigram indexes
+	textFilters := []struct {
+		column string
+		value  *string
+	}{{"first_name", filters.FirstName}, {"last_name", filters.LastName}, {"nickname", filters.NickName}, {"email", filters.Email}}
+	for _, f := range textFilters {
+		if f.value == nil {
+			continue
+		}
+		if filters.FuzzyThreshold > 0 {
+			// the % operator compares the similarity with pg_trgm.similarity_threshold, unlike similarity() it uses the index
+			whereClauses = append(whereClauses, fmt.Sprintf("%s %% $%d", f.column, len(args)+1))
+			args = append(args, *f.value)
+		} else {
+			whereClauses = append(whereClauses, fmt.Sprintf("%s ILIKE $%d", f.column, len(args)+1))
+			args = append(args, "%"+*f.value+"%")
+		}
+	}
+	if filters.CountryISOCode != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("country_iso_code ILIKE $%d", len(args)+1))
+		args = append(args, *filters.CountryISOCode)
+	}
+	if filters.Locale != nil {
+		// the language tag matches its regional variants as well, ex: "pt" matches "pt-PT"
+		whereClauses = append(whereClauses, fmt.Sprintf("(locale ILIKE $%[1]d OR locale ILIKE ($%[1]d::text || '-%%'))", len(args)+1))
+		args = append(args, *filters.Locale)
+	}
+	if filters.Timezone != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("timezone = $%d", len(args)+1))
+		args = append(args, *filters.Timezone)
+	}
+	if filters.BornAfter != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("date_of_birth >= $%d", len(args)+1))
+		args = append(args, *filters.BornAfter)
+	}
+	if filters.BornBefore != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("date_of_birth <= $%d", len(args)+1))
+		args = append(args, *filters.BornBefore)
+	}
+	if filters.SeenAfter != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("last_seen_at >= $%d", len(args)+1))
+		args = append(args, *filters.SeenAfter)
+	}
+	if filters.SeenBefore != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("last_seen_at <= $%d", len(args)+1))
+		args = append(args, *filters.SeenBefore)
+	}
+	if len(filters.Tags) > 0 {
+		tagged := fmt.Sprintf("FROM user_tags ut JOIN tags t ON t.id = ut.tag_id WHERE ut.user_id = users.id AND t.name = ANY($%d::text[])", len(args)+1)
+		if filters.MatchAllTags {
+			whereClauses = append(whereClauses, fmt.Sprintf("(SELECT count(*) %s) = (SELECT count(DISTINCT tag) FROM unnest($%d::text[]) tag)", tagged, len(args)+1))
+		} else {
+			whereClauses = append(whereClauses, fmt.Sprintf("EXISTS (SELECT 1 %s)", tagged))
+		}
+		args = append(args, filters.Tags)
+	}
+	if filters.Expr != nil {
+		clause, exprArgs, err := compileUserFilter(filters.Expr, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		whereClauses, args = append(whereClauses, clause), exprArgs
+	}
+	return whereClauses, args, nil
+}
+
+// compileUserFilter compiles the filter expression into a SQL condition, with the values as arguments after args.
+// Neither the fields nor the operators are taken from the input as is.
+func compileUserFilter(expr *domain.FilterExpr, args []any) (string, []any, error) {
+	switch expr.Op {
+	case domain.FilterAnd, domain.FilterOr:
+		clauses := make([]string, 0, len(expr.Args))
+		for _, arg := range expr.Args {
+			clause, argsWithArg, err := compileUserFilter(arg, args)
+			if err != nil {
+				return "", nil, err
+			}
+			clauses, args = append(clauses, clause), argsWithArg
+		}
+		return "(" + strings.Join(clauses, " "+expr.Op+" ") + ")", args, nil
+	case domain.FilterNot:
+		if len(expr.Args) != 1 {
+			return "", nil, fmt.Errorf("NOT takes one expression, got %d", len(expr.Args))
+		}
+		clause, args, err := compileUserFilter(expr.Args[0], args)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + clause, args, nil
+	}
+
+	if expr.Field == "tags" && expr.Op == domain.FilterHas {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM user_tags ut JOIN tags t ON t.id = ut.tag_id WHERE ut.user_id = users.id AND t.name = $%d)", len(args)+1),
+			append(args, expr.Value), nil
+	}
+	column, ok := _userFilterColumns[expr.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown field %s", expr.Field)
+	}
+	operator, ok := _userFilterOperators[expr.Op]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown operator %s", expr.Op)
+	}
+	if expr.Op == domain.FilterHas {
+		return fmt.Sprintf("(%s ILIKE '%%' || $%d || '%%')", column, len(args)+1), append(args, expr.Value), nil
+	}
+	return fmt.Sprintf("(%s %s $%d)", column, operator, len(args)+1), append(args, expr.Value), nil
+}
+
+// setSimilarityThreshold sets the similarity of the fuzzy filters, scoped to the transaction
+func (r userQueriesRepo) setSimilarityThreshold(ctx context.Context, filters domain.UserSearchFilters) error {
+	if filters.FuzzyThreshold <= 0 {
+		return nil
+	}
+	if _, err := r.db(ctx).Exec(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`, strconv.FormatFloat(filters.FuzzyThreshold, 'f', -1, 64)); err != nil {
+		r.l.Debug(fmt.Errorf("failed to set the similarity threshold: %w", err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// SearchUsers fetches the users matching the full-text query from the database, ranked by ts_rank, skipping merged users
+// The highlights are only computed for the fields matching the query
+// If the query fails to execute, it returns return domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userQueriesRepo) SearchUsers(ctx context.Context, query string, cursorRank *float32, cursorUserID string, limit int32) ([]*domain.UserSearchResult, error) {
+	args := []any{query}
+	where := "search_vector @@ q.query AND merged_into IS NULL"
+	if cursorRank != nil && cursorUserID != "" {
+		where += " AND (ts_rank(search_vector, q.query) < $2::real OR (ts_rank(search_vector, q.query) = $2::real AND id < $3))"
+		args = append(args, *cursorRank, cursorUserID)
+	}
+	sql := fmt.Sprintf(`
+		SELECT id, first_name, last_name, country_iso_code, nickname, email, COALESCE(phone, ''), phone_verified, COALESCE(locale, ''), COALESCE(timezone, ''), date_of_birth, COALESCE(avatar_url, ''), created_at, updated_at, last_seen_at, status, %s,
+			ts_rank(search_vector, q.query) AS rank,
+			%s, %s, %s, %s
+		FROM users, websearch_to_tsquery('simple', $1) AS q(query)
+		WHERE %s
+		ORDER BY rank DESC, id DESC LIMIT $%d`, _userTagsColumn,
+		searchHeadline("first_name"), searchHeadline("last_name"), searchHeadline("nickname"), searchHeadline("email"),
+		where, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to search users: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+
+	var results []*domain.UserSearchResult
+	for rows.Next() {
+		var user domain.User
+		var result domain.UserSearchResult
+		var firstName, lastName, nickName, email *string
+		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.CountryISOCode, &user.NickName, &user.Email, &user.Phone, &user.PhoneVerified, &user.Locale, &user.Timezone, &user.DateOfBirth, &user.AvatarURL, &user.CreatedAt, &user.UpdatedAt, &user.LastSeenAt, &user.Status, &user.Tags,
+			&result.Rank, &firstName, &lastName, &nickName, &email); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		result.User = &user
+		result.Highlights = map[string]string{}
+		for field, highlight := range map[string]*string{"first_name": firstName, "last_name": lastName, "nick_name": nickName, "email": email} {
+			if highlight != nil {
+				result.Highlights[field] = *highlight
+			}
+		}
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return results, nil
+}
+
+// searchHeadline highlights the terms of the search query in the column, NULL if the column does not match
+func searchHeadline(column string) string {
+	return fmt.Sprintf(`CASE WHEN to_tsvector('simple', %[1]s) @@ q.query
+		THEN ts_headline('simple', %[1]s, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') END`, column)
+}
+
+// GetUserStats aggregates the users in the database, skipping merged users
+// The counts per country, status and phone verification are computed by a single scan, through grouping sets.
+// The country is nullable, the users without one are counted under the empty country.
+// The signups are grouped by the period the users were created in, in UTC, weeks starting on Monday, and the periods
+// without signups are filled in by generate_series
+// If the query fails to execute, it returns domain.ErrInternal
+// If theres an error processing the data, it returns domain.ErrFailedToProcessData
+func (r userQueriesRepo) GetUserStats(ctx context.Context, period string, from time.Time, to time.Time) (*domain.UserStats, error) {
+	stats := &domain.UserStats{ByCountry: map[string]int64{}, ByStatus: map[string]int64{}, Signups: []domain.UserSignups{}}
+	rows, err := r.db(ctx).Query(ctx, `
+		SELECT GROUPING(COALESCE(country_iso_code, ''), status, phone_verified), COALESCE(country_iso_code, ''), status, phone_verified, count(*)
+		FROM users
+		WHERE merged_into IS NULL
+		GROUP BY GROUPING SETS ((COALESCE(country_iso_code, '')), (status), (phone_verified), ())`)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to aggregate users: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var grouping int
+		var country, status *string
+		var phoneVerified *bool
+		var count int64
+		if err := rows.Scan(&grouping, &country, &status, &phoneVerified, &count); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		// the bits of the grouping are set for the columns aggregated over, the country being the most significant
+		switch grouping {
+		case 0b011:
+			stats.ByCountry[*country] = count
+		case 0b101:
+			stats.ByStatus[*status] = count
+		case 0b110:
+			if *phoneVerified {
+				stats.PhoneVerified = count
+			} else {
+				stats.PhoneUnverified = count
+			}
+		case 0b111:
+			stats.Total = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+
+	rows, err = r.db(ctx).Query(ctx, `
+		SELECT p.period_start, count(u.id)
+		FROM generate_series(date_trunc($1, $2::timestamptz AT TIME ZONE 'UTC'), ($3::timestamptz - interval '1 microsecond') AT TIME ZONE 'UTC', ('1 ' || $1)::interval) AS p(period_start)
+		LEFT JOIN users u ON date_trunc($1, u.created_at AT TIME ZONE 'UTC') = p.period_start
+			AND u.created_at >= $2 AND u.created_at < $3 AND u.merged_into IS NULL
+		GROUP BY p.period_start
+		ORDER BY p.period_start`, period, from, to)
+	if err != nil {
+		r.l.Debug(fmt.Errorf("failed to aggregate signups: %w", err))
+		return nil, domain.ErrInternal
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var signups domain.UserSignups
+		if err := rows.Scan(&signups.PeriodStart, &signups.Count); err != nil {
+			r.l.Error(fmt.Errorf("failed to scan row: %w", err))
+			return nil, domain.ErrFailedToProcessData
+		}
+		stats.Signups = append(stats.Signups, signups)
+	}
+	if err := rows.Err(); err != nil {
+		r.l.Error(fmt.Errorf("row iteration error: %w", err))
+		return nil, domain.ErrFailedToProcessData
+	}
+	return stats, nil
+}
+
+// IsPhoneVerified checks if any user has the phone verified
+// If the query fails to execute, it returns domain.ErrInternal
+func (r userQueriesRepo) IsPhoneVerified(ctx context.Context, phone string) (exists bool, err error) {
+	query := `SELECT EXISTS(SELECT 1 FROM users WHERE phone = $1 AND phone_verified)`
+	if err := r.db(ctx).QueryRow(ctx, query, phone).Scan(&exists); err != nil {
+		r.l.Error(fmt.Errorf("failed to check verified phone: %w", err))
+		return false, domain.ErrInternal
+	}
+	return exists, nil
+}
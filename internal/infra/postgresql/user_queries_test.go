@@ -0,0 +1,93 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+	"users/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compileUserFilter(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		expr     *domain.FilterExpr
+		args     []any
+		want     string
+		wantArgs []any
+		wantErr  string
+	}{
+		{
+			name: "logical operators",
+			expr: &domain.FilterExpr{Op: domain.FilterAnd, Args: []*domain.FilterExpr{
+				{Op: domain.FilterEquals, Field: "country_iso_code", Value: "PT"},
+				{Op: domain.FilterGreater, Field: "created_at", Value: since},
+				{Op: domain.FilterOr, Args: []*domain.FilterExpr{
+					{Op: domain.FilterHas, Field: "first_name", Value: "ann"},
+					{Op: domain.FilterNot, Args: []*domain.FilterExpr{{Op: domain.FilterNotEquals, Field: "nick_name", Value: "ann"}}},
+				}},
+			}},
+			args:     []any{"cursor", "user"},
+			want:     `((country_iso_code = $3) AND (created_at > $4) AND ((first_name ILIKE '%' || $5 || '%') OR NOT (nickname IS DISTINCT FROM $6)))`,
+			wantArgs: []any{"cursor", "user", "PT", since, "ann", "ann"},
+		},
+		{
+			name:     "tags",
+			expr:     &domain.FilterExpr{Op: domain.FilterHas, Field: "tags", Value: "vip"},
+			want:     `EXISTS (SELECT 1 FROM user_tags ut JOIN tags t ON t.id = ut.tag_id WHERE ut.user_id = users.id AND t.name = $1)`,
+			wantArgs: []any{"vip"},
+		},
+		{
+			name:    "unknown field",
+			expr:    &domain.FilterExpr{Op: domain.FilterEquals, Field: "password", Value: "x"},
+			wantErr: "unknown field password",
+		},
+		{
+			name:    "unknown operator",
+			expr:    &domain.FilterExpr{Op: "; DROP TABLE users", Field: "email", Value: "x"},
+			wantErr: "unknown operator ; DROP TABLE users",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotArgs, err := compileUserFilter(tt.expr, tt.args)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func Test_selectUserFields(t *testing.T) {
+	// every loadable field has a column
+	fields := append(append([]string{}, domain.UserFields...), domain.UserDetailFields...)
+	_, err := selectUserFields(fields)
+	assert.NoError(t, err)
+	assert.Len(t, userFieldsDests(&domain.User{}, fields), len(fields)+1)
+
+	columns, err := selectUserFields([]string{"nick_name", "phone", "tags"})
+	assert.NoError(t, err)
+	assert.Equal(t, "id, nickname, COALESCE(phone, ''), "+_userTagsColumn, columns)
+
+	_, err = selectUserFields([]string{"nick_name", "password"})
+	assert.EqualError(t, err, "unknown users field: password")
+}
+
+func Test_tenantClause(t *testing.T) {
+	ctx := domain.WithTenant(context.Background(), "00000000-0000-0000-0000-000000000001")
+	clauses, args := tenantClause(ctx, []string{"merged_into IS NULL", "(updated_at < $1::timestamptz OR (updated_at = $1::timestamptz AND id < $2))"},
+		[]any{"2024-01-01T00:00:00Z", "0f913f6a-497b-4305-b3d1-3f53657e3a25"})
+	assert.Equal(t, "tenant_id = $3", clauses[2])
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", args[2])
+
+	// jobs across every tenant rely on the policy alone
+	clauses, args = tenantClause(domain.WithAllTenants(context.Background()), []string{"merged_into IS NULL"}, nil)
+	assert.Equal(t, []string{"merged_into IS NULL"}, clauses)
+	assert.Empty(t, args)
+}
@@ -0,0 +1,15 @@
+ALTER TABLE users
+   ADD COLUMN phone VARCHAR(16),
+   ADD COLUMN phone_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- a phone number can be claimed by several accounts, but only verified once
+CREATE UNIQUE INDEX idx_users_verified_phone ON users (phone) WHERE phone_verified;
+
+CREATE TABLE IF NOT EXISTS phone_verifications(
+   user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+   phone VARCHAR(16) NOT NULL,
+   code_hash VARCHAR(300) NOT NULL,
+   attempts INT NOT NULL DEFAULT 0,
+   expires_at TIMESTAMPTZ NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp
+);
@@ -0,0 +1,4 @@
+ALTER TABLE users
+   DROP COLUMN IF EXISTS date_of_birth,
+   DROP COLUMN IF EXISTS timezone,
+   DROP COLUMN IF EXISTS locale;
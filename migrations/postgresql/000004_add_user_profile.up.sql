@@ -0,0 +1,4 @@
+ALTER TABLE users
+   ADD COLUMN locale VARCHAR(35),
+   ADD COLUMN timezone VARCHAR(64),
+   ADD COLUMN date_of_birth DATE;
@@ -0,0 +1,3 @@
+ALTER TABLE users
+   ADD COLUMN avatar_key VARCHAR(255),
+   ADD COLUMN avatar_url VARCHAR(2048);
@@ -0,0 +1,8 @@
+-- only the values set by the user are stored, the defaults live in the config file
+CREATE TABLE IF NOT EXISTS user_preferences(
+   user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+   key VARCHAR(64) NOT NULL,
+   value JSONB NOT NULL,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp,
+   PRIMARY KEY (user_id, key)
+);
@@ -0,0 +1,23 @@
+DROP POLICY IF EXISTS tenant_isolation ON user_preferences;
+ALTER TABLE user_preferences NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE user_preferences DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON phone_verifications;
+ALTER TABLE phone_verifications NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE phone_verifications DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON outbox;
+ALTER TABLE outbox NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE outbox DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS idx_users_verified_phone;
+CREATE UNIQUE INDEX idx_users_verified_phone ON users (phone) WHERE phone_verified;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_id_nickname_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_id_email_key;
+ALTER TABLE users ADD CONSTRAINT users_nickname_key UNIQUE (nickname);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+DROP TABLE IF EXISTS tenants;
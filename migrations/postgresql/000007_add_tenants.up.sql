@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS tenants(
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   slug VARCHAR(63) UNIQUE NOT NULL,
+   name VARCHAR(255) NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp
+);
+
+-- existing rows belong to the default tenant
+INSERT INTO tenants (id, slug, name) VALUES ('00000000-0000-0000-0000-000000000001', 'default', 'Default');
+
+-- new rows take the tenant of the transaction, set by the application in app.tenant_id
+ALTER TABLE users ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants (id);
+ALTER TABLE users ALTER COLUMN tenant_id SET DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid;
+ALTER TABLE outbox ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants (id);
+ALTER TABLE outbox ALTER COLUMN tenant_id SET DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid;
+
+-- email, nickname and verified phone numbers are unique per tenant
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+ALTER TABLE users DROP CONSTRAINT users_nickname_key;
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_email_key UNIQUE (tenant_id, email);
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_nickname_key UNIQUE (tenant_id, nickname);
+DROP INDEX idx_users_verified_phone;
+CREATE UNIQUE INDEX idx_users_verified_phone ON users (tenant_id, phone) WHERE phone_verified;
+
+-- rows are only visible to the tenant of the transaction, or to background jobs flagged with app.all_tenants.
+-- FORCE applies the policies to the table owner as well, superusers still bypass them.
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
+
+ALTER TABLE outbox ENABLE ROW LEVEL SECURITY;
+ALTER TABLE outbox FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON outbox
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
+
+-- tables owned by a user follow the visibility of the user
+ALTER TABLE phone_verifications ENABLE ROW LEVEL SECURITY;
+ALTER TABLE phone_verifications FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON phone_verifications
+   USING (EXISTS (SELECT 1 FROM users u WHERE u.id = user_id));
+
+ALTER TABLE user_preferences ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_preferences FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_preferences
+   USING (EXISTS (SELECT 1 FROM users u WHERE u.id = user_id));
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS groups(
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants (id),
+   name VARCHAR(100) NOT NULL,
+   description VARCHAR(500) NOT NULL DEFAULT '',
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp,
+   UNIQUE (tenant_id, name)
+);
+
+-- users are not deleted in cascade, their memberships are removed by the application so the changes are published
+CREATE TABLE IF NOT EXISTS group_members(
+   group_id UUID NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+   user_id UUID NOT NULL REFERENCES users (id),
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp,
+   PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX idx_group_members_group_created_at_user ON group_members (group_id, created_at DESC, user_id DESC);
+CREATE INDEX idx_group_members_user ON group_members (user_id);
+
+ALTER TABLE groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE groups FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON groups
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
+
+ALTER TABLE group_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE group_members FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON group_members
+   USING (EXISTS (SELECT 1 FROM groups g WHERE g.id = group_id));
@@ -0,0 +1,19 @@
+-- relationships are deleted in cascade with either user, the UserDeleted event covers them
+CREATE TABLE IF NOT EXISTS user_relationships(
+   user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+   target_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+   kind VARCHAR(10) NOT NULL CHECK (kind IN ('follow', 'block')),
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp,
+   PRIMARY KEY (user_id, target_id, kind),
+   CHECK (user_id <> target_id)
+);
+
+-- following and blocked lists, by user
+CREATE INDEX idx_user_relationships_user_kind_created_at ON user_relationships (user_id, kind, created_at DESC, target_id DESC);
+-- followers lists and counts, by target
+CREATE INDEX idx_user_relationships_target_kind_created_at ON user_relationships (target_id, kind, created_at DESC, user_id DESC);
+
+ALTER TABLE user_relationships ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_relationships FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_relationships
+   USING (EXISTS (SELECT 1 FROM users u WHERE u.id = user_id));
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS invitations(
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants (id),
+   email VARCHAR(320) NOT NULL,
+   first_name VARCHAR(25) NOT NULL,
+   last_name VARCHAR(25) NOT NULL,
+   country_iso_code VARCHAR(2),
+   roles TEXT[] NOT NULL DEFAULT '{}',
+   -- sha256 of the token, the token itself is only sent in the InvitationCreated event
+   token_hash VARCHAR(64) UNIQUE NOT NULL,
+   status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+   user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+   expires_at TIMESTAMPTZ NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp
+);
+
+-- an email has at most one pending invitation, inviting again revokes the previous one
+CREATE UNIQUE INDEX idx_invitations_pending_email ON invitations (tenant_id, email) WHERE status = 'pending';
+CREATE INDEX idx_invitations_created_at_id ON invitations (created_at DESC, id DESC);
+
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON invitations
+FOR EACH ROW
+EXECUTE FUNCTION updated_at_refresh();
+
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON invitations
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
@@ -0,0 +1,11 @@
+DELETE FROM users WHERE merged_into IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_verified_phone;
+CREATE UNIQUE INDEX idx_users_verified_phone ON users (tenant_id, phone) WHERE phone_verified;
+DROP INDEX IF EXISTS users_tenant_id_nickname_key;
+DROP INDEX IF EXISTS users_tenant_id_email_key;
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_nickname_key UNIQUE (tenant_id, nickname);
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_email_key UNIQUE (tenant_id, email);
+
+DROP INDEX IF EXISTS idx_users_merged_into;
+ALTER TABLE users DROP COLUMN IF EXISTS merged_into;
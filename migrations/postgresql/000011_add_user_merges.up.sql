@@ -0,0 +1,11 @@
+-- merged users are kept as tombstones pointing at the user they were merged into
+ALTER TABLE users ADD COLUMN merged_into UUID REFERENCES users (id) ON DELETE CASCADE;
+CREATE INDEX idx_users_merged_into ON users (merged_into) WHERE merged_into IS NOT NULL;
+
+-- tombstones release the unique fields, so the target can take them
+ALTER TABLE users DROP CONSTRAINT users_tenant_id_email_key;
+ALTER TABLE users DROP CONSTRAINT users_tenant_id_nickname_key;
+CREATE UNIQUE INDEX users_tenant_id_email_key ON users (tenant_id, email) WHERE merged_into IS NULL;
+CREATE UNIQUE INDEX users_tenant_id_nickname_key ON users (tenant_id, nickname) WHERE merged_into IS NULL;
+DROP INDEX idx_users_verified_phone;
+CREATE UNIQUE INDEX idx_users_verified_phone ON users (tenant_id, phone) WHERE phone_verified AND merged_into IS NULL;
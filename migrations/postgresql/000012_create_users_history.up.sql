@@ -0,0 +1,65 @@
+-- every change to a user is recorded as a new version, passwords excluded.
+-- There is no foreign key to users, so the history survives the deletion of the user until it is purged.
+CREATE TABLE IF NOT EXISTS users_history(
+   version BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+   user_id UUID NOT NULL,
+   tenant_id UUID NOT NULL REFERENCES tenants (id),
+   operation VARCHAR(6) NOT NULL CHECK (operation IN ('create', 'update', 'delete')),
+   first_name VARCHAR(25) NOT NULL,
+   last_name VARCHAR(25) NOT NULL,
+   country_iso_code VARCHAR(2),
+   nickname VARCHAR(25) NOT NULL,
+   email VARCHAR(320) NOT NULL,
+   phone VARCHAR(16),
+   phone_verified BOOLEAN NOT NULL,
+   locale VARCHAR(35),
+   timezone VARCHAR(64),
+   date_of_birth DATE,
+   avatar_url VARCHAR(2048),
+   merged_into UUID,
+   created_at TIMESTAMPTZ NOT NULL,
+   updated_at TIMESTAMPTZ NOT NULL,
+   recorded_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX idx_users_history_user_recorded_at ON users_history (user_id, recorded_at DESC, version DESC);
+CREATE INDEX idx_users_history_deleted ON users_history (recorded_at) WHERE operation = 'delete';
+
+CREATE OR REPLACE FUNCTION users_history_record()
+RETURNS TRIGGER AS $$
+DECLARE
+    u users%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        u := OLD;
+    ELSE
+        u := NEW;
+    END IF;
+    INSERT INTO users_history (user_id, tenant_id, operation, first_name, last_name, country_iso_code, nickname, email,
+        phone, phone_verified, locale, timezone, date_of_birth, avatar_url, merged_into, created_at, updated_at)
+    VALUES (u.id, u.tenant_id, CASE TG_OP WHEN 'INSERT' THEN 'create' WHEN 'UPDATE' THEN 'update' ELSE 'delete' END,
+        u.first_name, u.last_name, u.country_iso_code, u.nickname, u.email,
+        u.phone, u.phone_verified, u.locale, u.timezone, u.date_of_birth, u.avatar_url, u.merged_into, u.created_at, u.updated_at);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER record_history
+AFTER INSERT OR UPDATE OR DELETE ON users
+FOR EACH ROW
+EXECUTE FUNCTION users_history_record();
+
+-- existing users start with their current state, recorded at their last update.
+-- The history policy is created afterwards, the users policy still applies to non superusers.
+SELECT set_config('app.all_tenants', 'on', true);
+INSERT INTO users_history (user_id, tenant_id, operation, first_name, last_name, country_iso_code, nickname, email,
+    phone, phone_verified, locale, timezone, date_of_birth, avatar_url, merged_into, created_at, updated_at, recorded_at)
+SELECT id, tenant_id, 'create', first_name, last_name, country_iso_code, nickname, email,
+    phone, phone_verified, locale, timezone, date_of_birth, avatar_url, merged_into, created_at, updated_at, updated_at
+FROM users;
+
+ALTER TABLE users_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users_history
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
@@ -0,0 +1,24 @@
+-- audit events outlive the users they target, so there is no foreign key to users
+CREATE TABLE IF NOT EXISTS audit_events(
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants (id),
+   actor VARCHAR(255) NOT NULL DEFAULT '',
+   method VARCHAR(255) NOT NULL,
+   target_user_id UUID,
+   request_id VARCHAR(255) NOT NULL DEFAULT '',
+   source_ip VARCHAR(45) NOT NULL DEFAULT '',
+   outcome VARCHAR(7) NOT NULL CHECK (outcome IN ('success', 'failure')),
+   error TEXT NOT NULL DEFAULT '',
+   diff JSONB NOT NULL DEFAULT '{}',
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX idx_audit_events_created_at_id ON audit_events (created_at DESC, id DESC);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor, created_at DESC, id DESC);
+CREATE INDEX idx_audit_events_target_user ON audit_events (target_user_id, created_at DESC, id DESC) WHERE target_user_id IS NOT NULL;
+
+ALTER TABLE audit_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE audit_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON audit_events
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
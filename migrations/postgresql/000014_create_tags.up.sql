@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS tags(
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   tenant_id UUID NOT NULL DEFAULT NULLIF(current_setting('app.tenant_id', true), '')::uuid REFERENCES tenants (id),
+   name VARCHAR(64) NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp,
+   UNIQUE (tenant_id, name)
+);
+
+-- assignments are deleted in cascade with the user, the DeleteUser event covers them
+CREATE TABLE IF NOT EXISTS user_tags(
+   user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+   tag_id UUID NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT current_timestamp,
+   PRIMARY KEY (user_id, tag_id)
+);
+
+-- tag filters, by tag
+CREATE INDEX idx_user_tags_tag_user ON user_tags (tag_id, user_id);
+
+ALTER TABLE tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tags FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON tags
+   USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on')
+   WITH CHECK (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid OR current_setting('app.all_tenants', true) = 'on');
+
+ALTER TABLE user_tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_tags FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_tags
+   USING (EXISTS (SELECT 1 FROM tags t WHERE t.id = tag_id));
@@ -0,0 +1,17 @@
+DROP TRIGGER IF EXISTS record_history_update ON users;
+DROP TRIGGER IF EXISTS record_history ON users;
+CREATE TRIGGER record_history
+AFTER INSERT OR UPDATE OR DELETE ON users
+FOR EACH ROW
+EXECUTE FUNCTION users_history_record();
+
+DROP TRIGGER IF EXISTS set_updated_at ON users;
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON users
+FOR EACH ROW
+EXECUTE FUNCTION updated_at_refresh();
+
+DROP INDEX IF EXISTS idx_users_active_inactivity;
+DROP INDEX IF EXISTS idx_users_last_seen_at;
+ALTER TABLE users DROP COLUMN IF EXISTS status;
+ALTER TABLE users DROP COLUMN IF EXISTS last_seen_at;
@@ -0,0 +1,30 @@
+-- last_seen_at is written at most once per user per configured interval, status is set by the dormant users job.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(11) NOT NULL DEFAULT 'active'
+   CHECK (status IN ('active', 'dormant', 'deactivated'));
+
+-- last seen filters
+CREATE INDEX idx_users_last_seen_at ON users (last_seen_at);
+-- dormant users lookup, users never seen are inactive since their creation
+CREATE INDEX idx_users_active_inactivity ON users ((COALESCE(last_seen_at, created_at))) WHERE status = 'active' AND merged_into IS NULL;
+
+-- activity is not a change of the user: it neither refreshes updated_at, keeping the listing order stable,
+-- nor records a new version
+DROP TRIGGER IF EXISTS set_updated_at ON users;
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON users
+FOR EACH ROW
+WHEN (to_jsonb(OLD) - ARRAY['last_seen_at', 'status'] IS DISTINCT FROM to_jsonb(NEW) - ARRAY['last_seen_at', 'status'])
+EXECUTE FUNCTION updated_at_refresh();
+
+DROP TRIGGER IF EXISTS record_history ON users;
+CREATE TRIGGER record_history
+AFTER INSERT OR DELETE ON users
+FOR EACH ROW
+EXECUTE FUNCTION users_history_record();
+
+CREATE TRIGGER record_history_update
+AFTER UPDATE ON users
+FOR EACH ROW
+WHEN (to_jsonb(OLD) - ARRAY['last_seen_at', 'status'] IS DISTINCT FROM to_jsonb(NEW) - ARRAY['last_seen_at', 'status'])
+EXECUTE FUNCTION users_history_record();
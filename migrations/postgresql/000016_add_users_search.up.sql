@@ -0,0 +1,10 @@
+-- full-text search over the names, nickname and email of the users. The simple configuration neither stems
+-- nor drops stop words, names are matched as written. Names weigh more than the nickname, and the nickname more than the email.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+   setweight(to_tsvector('simple', first_name), 'A') ||
+   setweight(to_tsvector('simple', last_name), 'A') ||
+   setweight(to_tsvector('simple', nickname), 'B') ||
+   setweight(to_tsvector('simple', email), 'C')
+) STORED;
+
+CREATE INDEX idx_users_search_vector ON users USING GIN (search_vector);
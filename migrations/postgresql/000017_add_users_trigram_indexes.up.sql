@@ -0,0 +1,8 @@
+-- the text filters of ListUsers match substrings (ILIKE '%value%') or, in fuzzy mode, similar values (value % column),
+-- both can only use trigram indexes. The country, locale and timezone filters match whole values.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_users_first_name_trgm ON users USING GIN (first_name gin_trgm_ops);
+CREATE INDEX idx_users_last_name_trgm ON users USING GIN (last_name gin_trgm_ops);
+CREATE INDEX idx_users_nickname_trgm ON users USING GIN (nickname gin_trgm_ops);
+CREATE INDEX idx_users_email_trgm ON users USING GIN (email gin_trgm_ops);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_nickname_id;
+DROP INDEX IF EXISTS idx_users_last_name_id;
+DROP INDEX IF EXISTS idx_users_created_at_id;
@@ -0,0 +1,4 @@
+-- keyset pagination of ListUsers for every sortable field, the id breaks the ties. Descending orders scan them backwards.
+CREATE INDEX idx_users_created_at_id ON users (created_at, id);
+CREATE INDEX idx_users_last_name_id ON users (last_name, id);
+CREATE INDEX idx_users_nickname_id ON users (nickname, id);
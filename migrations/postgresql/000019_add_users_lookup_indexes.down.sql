@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_lookup_nickname;
+DROP INDEX IF EXISTS idx_users_lookup_email;
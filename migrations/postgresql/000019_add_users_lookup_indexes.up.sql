@@ -0,0 +1,3 @@
+-- exact, case-insensitive lookups of the users by email or nickname, within their tenant
+CREATE INDEX idx_users_lookup_email ON users (tenant_id, lower(email)) WHERE merged_into IS NULL;
+CREATE INDEX idx_users_lookup_nickname ON users (tenant_id, lower(nickname)) WHERE merged_into IS NULL;
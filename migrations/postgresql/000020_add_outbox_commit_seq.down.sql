@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS assign_commit_seq ON outbox;
+DROP FUNCTION IF EXISTS outbox_assign_commit_seq;
+DROP INDEX IF EXISTS idx_outbox_commit_seq;
+ALTER TABLE outbox DROP COLUMN IF EXISTS commit_seq;
+DROP SEQUENCE IF EXISTS outbox_commit_seq;
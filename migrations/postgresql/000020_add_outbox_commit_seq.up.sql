@@ -0,0 +1,25 @@
+-- events are numbered in the order their transactions commit, so readers of the outbox can resume after a position
+-- without missing events committed later by transactions that started earlier.
+-- The number is assigned by a deferred trigger, under a lock held until the commit completes: the commits writing
+-- events are serialized and an event is only visible once every event numbered before it is.
+-- Events written before this migration are not numbered.
+CREATE SEQUENCE outbox_commit_seq;
+ALTER TABLE outbox ADD COLUMN commit_seq BIGINT;
+CREATE UNIQUE INDEX idx_outbox_commit_seq ON outbox (commit_seq);
+
+-- listeners of outbox_committed are woken up with the tenant of the events once they are committed
+CREATE OR REPLACE FUNCTION outbox_assign_commit_seq()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('outbox_commit_seq'));
+    UPDATE outbox SET commit_seq = nextval('outbox_commit_seq') WHERE id = NEW.id;
+    PERFORM pg_notify('outbox_committed', NEW.tenant_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER assign_commit_seq
+AFTER INSERT ON outbox
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW
+EXECUTE FUNCTION outbox_assign_commit_seq();
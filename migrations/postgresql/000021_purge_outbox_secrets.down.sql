@@ -0,0 +1,2 @@
+-- the purged secrets can not be restored
+SELECT 1;
@@ -0,0 +1,4 @@
+-- the secrets of the published events are cleared by the outbox processor, the events published before are purged here
+SELECT set_config('app.all_tenants', 'on', true);
+UPDATE outbox SET payload = payload - 'code'
+WHERE event_type = 'PhoneVerificationRequested' AND processed_at IS NOT NULL;
@@ -0,0 +1,2 @@
+-- the purged tokens can not be restored
+SELECT 1;
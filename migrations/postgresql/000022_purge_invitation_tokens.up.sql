@@ -0,0 +1,4 @@
+-- the tokens of the invitations published before the outbox processor cleared them
+SELECT set_config('app.all_tenants', 'on', true);
+UPDATE outbox SET payload = payload - 'token'
+WHERE event_type = 'InvitationCreated' AND processed_at IS NOT NULL;
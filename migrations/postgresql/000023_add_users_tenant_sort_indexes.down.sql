@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_users_last_name_id ON users (last_name, id);
+CREATE INDEX IF NOT EXISTS idx_users_nickname_id ON users (nickname, id);
+DROP INDEX IF EXISTS idx_users_tenant_nickname_id;
+DROP INDEX IF EXISTS idx_users_tenant_last_name_id;
+DROP INDEX IF EXISTS idx_users_tenant_created_at_id;
+DROP INDEX IF EXISTS idx_users_tenant_updated_at_id;
@@ -0,0 +1,8 @@
+-- ListUsers filters on the tenant, so the keyset pagination indexes of every sortable field start with it
+CREATE INDEX idx_users_tenant_updated_at_id ON users (tenant_id, updated_at, id);
+CREATE INDEX idx_users_tenant_created_at_id ON users (tenant_id, created_at, id);
+CREATE INDEX idx_users_tenant_last_name_id ON users (tenant_id, last_name, id);
+CREATE INDEX idx_users_tenant_nickname_id ON users (tenant_id, nickname, id);
+DROP INDEX IF EXISTS idx_users_created_at_id;
+DROP INDEX IF EXISTS idx_users_last_name_id;
+DROP INDEX IF EXISTS idx_users_nickname_id;
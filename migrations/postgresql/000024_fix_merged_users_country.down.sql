@@ -0,0 +1,2 @@
+-- the empty countries written by merges can not be told apart from the others
+SELECT 1;
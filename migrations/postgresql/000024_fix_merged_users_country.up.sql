@@ -0,0 +1,3 @@
+-- merges stored a missing country as NULL, the other writes store an empty one
+SELECT set_config('app.all_tenants', 'on', true);
+UPDATE users SET country_iso_code = '' WHERE country_iso_code IS NULL;
@@ -13,3 +13,10 @@ func IsConflictErr(err error) bool {
 }
 
 var ErrNoRows = pgx.ErrNoRows
+
+func IsForeignKeyViolationErr(err error) bool {
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+		return true
+	}
+	return false
+}
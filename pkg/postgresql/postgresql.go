@@ -11,6 +11,7 @@ import (
 	mpg "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 )
@@ -33,6 +34,10 @@ type Interface interface {
 	// Ping returns true if pool's ping does not return an error
 	Ping(ctx context.Context) bool
 
+	// Listen listens to the channel on a dedicated connection, outside of the pool, calling fn with the payload of
+	// every notification. It returns when the context is done or the connection fails, closing the connection.
+	Listen(ctx context.Context, channel string, fn func(payload string)) error
+
 	// IsEnabled returns true if postgres instance is enabled
 	// will return true in this version
 	IsEnabled() bool
@@ -143,6 +148,27 @@ func (p *postgres) GetPool() DBProvider {
 	return p.Pool
 }
 
+// Listen listens to the channel on a dedicated connection, so that the notifications are not lost to the pool.
+// The connection is closed on return, which also stops listening.
+func (p *postgres) Listen(ctx context.Context, channel string, fn func(payload string)) error {
+	conn, err := pgx.ConnectConfig(ctx, p.Pool.Config().ConnConfig.Copy())
+	if err != nil {
+		return fmt.Errorf("postgresql-listen connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("postgresql-listen: %w", err)
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("postgresql-listen wait: %w", err)
+		}
+		fn(notification.Payload)
+	}
+}
+
 // IsEnabled returns true if the postgres instance is enabled
 // in this version, it returns always true
 func (p *postgres) IsEnabled() bool {